@@ -0,0 +1,141 @@
+// Command ioc233 ioc233 容器的命令行工具
+//
+// 用法：
+//
+//	ioc233 diff --remote=prod-service:9000 --local=./dump.json
+//
+// diff 从运行中服务的管理端点（ioc233.SnapshotHandler）拉取装配快照，
+// 与本地快照文件（Container.WriteSnapshot 生成）比较 bean、版本、接口绑定与依赖关系。
+// 退出码：0 无差异，1 存在差异，2 执行出错
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run 执行命令并返回退出码
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		usage(stderr)
+		return 2
+	}
+	switch args[0] {
+	case "diff":
+		return runDiff(args[1:], stdout, stderr)
+	case "-h", "--help", "help":
+		usage(stdout)
+		return 0
+	}
+	fmt.Fprintf(stderr, "未知命令: %s\n", args[0])
+	usage(stderr)
+	return 2
+}
+
+// usage 输出帮助
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "用法: ioc233 <command> [flags]")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "命令:")
+	fmt.Fprintln(w, "  diff   比较运行中容器与本地快照的装配差异")
+}
+
+// runDiff 执行 diff 子命令
+func runDiff(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	remote := fs.String("remote", "", "运行中服务的地址（host:port 或完整 URL）")
+	local := fs.String("local", "", "本地快照文件（Container.WriteSnapshot 生成）")
+	asJSON := fs.Bool("json", false, "以 JSON 输出差异")
+	timeout := fs.Duration("timeout", 10*time.Second, "拉取远端快照的超时时间")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *remote == "" || *local == "" {
+		fmt.Fprintln(stderr, "diff 需要同时指定 --remote 与 --local")
+		fs.Usage()
+		return 2
+	}
+
+	localSnap, err := readLocal(*local)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	remoteSnap, err := fetchRemote(*remote, *timeout)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	diffs := ioc233.DiffSnapshots(localSnap, remoteSnap)
+	if *asJSON {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(diffs); err != nil {
+			fmt.Fprintln(stderr, err)
+			return 2
+		}
+	} else {
+		fmt.Fprintf(stdout, "--- local  %s\n+++ remote %s\n", *local, *remote)
+		for _, d := range diffs {
+			fmt.Fprintln(stdout, d.String())
+		}
+		if len(diffs) == 0 {
+			fmt.Fprintln(stdout, "装配一致")
+		}
+	}
+	if len(diffs) > 0 {
+		return 1
+	}
+	return 0
+}
+
+// readLocal 读取本地快照文件
+func readLocal(path string) (*ioc233.Snapshot, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("打开本地快照失败: %w", err)
+	}
+	defer f.Close()
+	return ioc233.ReadSnapshot(f)
+}
+
+// fetchRemote 从管理端点拉取快照
+func fetchRemote(remote string, timeout time.Duration) (*ioc233.Snapshot, error) {
+	url := snapshotURL(remote)
+	client := &http.Client{Timeout: timeout}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("拉取远端快照失败: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("拉取远端快照失败: %s 返回 %s", url, resp.Status)
+	}
+	return ioc233.ReadSnapshot(resp.Body)
+}
+
+// snapshotURL 将 host:port 补全为快照端点 URL（已包含路径的 URL 原样使用）
+func snapshotURL(remote string) string {
+	if !strings.Contains(remote, "://") {
+		remote = "http://" + remote
+	}
+	rest := remote[strings.Index(remote, "://")+3:]
+	if !strings.Contains(rest, "/") {
+		remote += ioc233.SnapshotPath
+	}
+	return remote
+}
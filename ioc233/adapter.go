@@ -0,0 +1,232 @@
+package ioc233
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ContainerAdapter 供外部框架（gin 脚手架、go-kit、自研游戏引擎等）使用的最小容器接口
+// 框架只依赖此接口即可把 ioc233 作为 DI 后端，不需要了解容器内部结构：
+//
+//	func Mount(router *gin.Engine, di ioc233.ContainerAdapter) {
+//	    di.Range(func(name string, bean any) bool {
+//	        if r, ok := bean.(interface{ Routes(*gin.Engine) }); ok {
+//	            r.Routes(router)
+//	        }
+//	        return true
+//	    })
+//	    di.OnStopping(func() { _ = server.Shutdown(context.Background()) })
+//	}
+type ContainerAdapter interface {
+	// Resolve 按类型解析 bean（接口取首个实现，具体类型精确匹配，未命中时回退父容器）
+	Resolve(t reflect.Type) (any, bool)
+	// ResolveByName 按 bean 名解析（未命中时回退父容器）
+	ResolveByName(name string) (any, bool)
+	// Range 按注册顺序遍历 bean，fn 返回 false 时停止
+	Range(fn func(name string, instance any) bool)
+	// Inject 对框架自行创建的对象（例如 handler、实体）执行字段注入，不注册到容器
+	Inject(target any) error
+	// OnStarted 注册启动完成钩子（容器已启动时立即执行）
+	OnStarted(hook func())
+	// OnStopping 注册关闭钩子（Close 时在 IDestroy 回调之前逆序执行）
+	OnStopping(hook func())
+}
+
+// containerAdapter ContainerAdapter 的默认实现
+type containerAdapter struct {
+	c *Container
+	// locked 调用方已持有容器锁（见 lockedAdapter），方法直接访问容器，不再加锁
+	locked bool
+}
+
+// Adapter 返回容器的 ContainerAdapter 视图
+// 方法会获取容器锁，不能在持有容器锁的生命周期回调中调用（标签处理器使用 TagField.Container）
+func (c *Container) Adapter() ContainerAdapter {
+	return &containerAdapter{c: c}
+}
+
+// lockedAdapter 返回供已持有容器锁的调用方使用的适配器视图（例如注入流程中的标签处理器），只在持锁期间有效
+func (c *Container) lockedAdapter() ContainerAdapter {
+	return &containerAdapter{c: c, locked: true}
+}
+
+// withReadLock 在读锁保护下执行 fn（调用方已持有锁时直接执行）
+func (a *containerAdapter) withReadLock(fn func()) {
+	if a.locked {
+		fn()
+		return
+	}
+	a.c.withReadLock(fn)
+}
+
+// withWriteLock 在写锁保护下执行 fn（调用方已持有锁时直接执行）
+func (a *containerAdapter) withWriteLock(fn func()) {
+	if a.locked {
+		fn()
+		return
+	}
+	a.c.withWriteLock(fn)
+}
+
+// Resolve 实现 ContainerAdapter
+func (a *containerAdapter) Resolve(t reflect.Type) (any, bool) {
+	if view := a.c.view.Load(); view != nil {
+		if obj, ok := view.lookupType(t); ok {
+			a.c.markUsed(reflect.ValueOf(obj))
+			return obj, true
+		}
+	}
+	var (
+		v  reflect.Value
+		ok bool
+	)
+	a.withReadLock(func() {
+		v, ok = a.c.resolveByType(t)
+	})
+	if !ok || !v.IsValid() {
+		return nil, false
+	}
+	a.c.markUsed(v)
+	return v.Interface(), true
+}
+
+// ResolveByName 实现 ContainerAdapter
+func (a *containerAdapter) ResolveByName(name string) (any, bool) {
+	if view := a.c.view.Load(); view != nil {
+		if obj, ok := view.lookupName(name); ok {
+			a.c.markUsed(reflect.ValueOf(obj))
+			return obj, true
+		}
+	}
+	var (
+		obj any
+		ok  bool
+	)
+	a.withReadLock(func() {
+		obj, ok = a.c.nameToObjMap.load(name)
+	})
+	if ok && obj != nil {
+		a.c.markUsed(reflect.ValueOf(obj))
+		return obj, true
+	}
+	if a.c.parent != nil {
+		return a.c.parent.Adapter().ResolveByName(name)
+	}
+	return nil, false
+}
+
+// Range 实现 ContainerAdapter
+// 遍历的是调用时的快照，fn 中可以安全地调用容器的其他方法
+func (a *containerAdapter) Range(fn func(name string, instance any) bool) {
+	var beans []*beanDefinition
+	a.withReadLock(func() {
+		beans = append(beans, a.c.beans...)
+	})
+	for _, def := range beans {
+		if def.instance == nil {
+			continue
+		}
+		if !fn(def.name, def.instance) {
+			return
+		}
+	}
+}
+
+// Inject 实现 ContainerAdapter
+// 必须注入/名称注入失败时返回汇总错误，且不修改目标对象
+func (a *containerAdapter) Inject(target any) error {
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("[ioc233] Inject 只支持非 nil 的结构体指针: %T", target)
+	}
+	var err error
+	a.withReadLock(func() {
+		if errs := a.c.validateInstance(target); len(errs) > 0 {
+			err = errors.Join(errs...)
+			return
+		}
+		err = a.c.injectFields(a.c.Context(), target, resolveRead)
+	})
+	return err
+}
+
+// OnStarted 实现 ContainerAdapter
+// 启动完成钩子已经执行过（容器已启动，或 StartUp 取出钩子之后才注册）时立即在调用方协程执行
+func (a *containerAdapter) OnStarted(hook func()) {
+	if !a.c.addStartedHook(hook) {
+		hook()
+	}
+}
+
+// OnStopping 实现 ContainerAdapter
+// 关闭钩子已经执行过（Close 取出钩子之后才注册）时立即在调用方协程执行
+func (a *containerAdapter) OnStopping(hook func()) {
+	if !a.c.addStoppingHook(hook) {
+		hook()
+	}
+}
+
+// addStartedHook 登记启动完成钩子；钩子已经执行过时不登记并返回 false
+func (c *Container) addStartedHook(hook func()) bool {
+	c.hooksMu.Lock()
+	defer c.hooksMu.Unlock()
+	if c.startedHooksFired {
+		return false
+	}
+	c.startedHooks = append(c.startedHooks, hook)
+	return true
+}
+
+// addStoppingHook 登记关闭钩子；钩子已经执行过时不登记并返回 false
+func (c *Container) addStoppingHook(hook func()) bool {
+	c.hooksMu.Lock()
+	defer c.hooksMu.Unlock()
+	if c.stoppingHooksFired {
+		return false
+	}
+	c.stoppingHooks = append(c.stoppingHooks, hook)
+	return true
+}
+
+// takeStartedHooks 取出已登记的启动完成钩子并标记为已执行（StartUp 完成时调用）
+func (c *Container) takeStartedHooks() []func() {
+	c.hooksMu.Lock()
+	defer c.hooksMu.Unlock()
+	c.startedHooksFired = true
+	hooks := c.startedHooks
+	c.startedHooks = nil
+	return hooks
+}
+
+// takeStoppingHooks 取出已登记的关闭钩子并标记为已执行（Close 时调用）
+func (c *Container) takeStoppingHooks() []func() {
+	c.hooksMu.Lock()
+	defer c.hooksMu.Unlock()
+	c.stoppingHooksFired = true
+	hooks := c.stoppingHooks
+	c.stoppingHooks = nil
+	return hooks
+}
+
+// ResolveAs 按类型 T 从适配器解析 bean（泛型便捷函数）
+func ResolveAs[T any](a ContainerAdapter) (T, bool) {
+	var zero T
+	obj, ok := a.Resolve(reflect.TypeOf((*T)(nil)).Elem())
+	if !ok {
+		return zero, false
+	}
+	typed, ok := obj.(T)
+	return typed, ok
+}
+
+// ResolveByNameAs 按名称从适配器解析 bean，并断言为 T
+func ResolveByNameAs[T any](a ContainerAdapter, name string) (T, bool) {
+	var zero T
+	obj, ok := a.ResolveByName(name)
+	if !ok {
+		return zero, false
+	}
+	typed, ok := obj.(T)
+	return typed, ok
+}
@@ -0,0 +1,157 @@
+package ioc233
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// InjectionRecord 一次成功的字段注入（见 Container.InjectionAudit）
+type InjectionRecord struct {
+	// Owner 消费方结构体类型
+	Owner string
+	// OwnerBean 消费方 bean 名（通过 Inject 注入的非托管对象为空）
+	OwnerBean string
+	// Field 字段名
+	Field string
+	// FieldType 字段期望的类型（Lazy[T] 取 T）
+	FieldType string
+	// Sources 注入来源的 bean 名（切片、分组与负载均衡注入为全部来源；不是 bean 的实例取类型名，例如原型实例）
+	Sources []string
+	// Mode 解析方式（EdgeByType/EdgeByInterface/EdgeByName/EdgeSlice/EdgeBalance/EdgeGroup）
+	Mode string
+	// Lazy 是否为懒加载注入（首次使用时解析并记录）
+	Lazy bool
+	// At 注入时间
+	At time.Time
+}
+
+// String 返回 "Owner.Field <- sources (mode)" 形式的描述
+func (r InjectionRecord) String() string {
+	mode := r.Mode
+	if r.Lazy {
+		mode += ", lazy"
+	}
+	return r.Owner + "." + r.Field + " <- " + strings.Join(r.Sources, ", ") + " (" + mode + ")"
+}
+
+// injectionAudit 注入记录（并行注入时多个协程同时写入）
+// 记录只保存名称与类型字符串，不引用消费方与来源实例；limit 为 0 时不记录（默认），见 SetInjectionAudit
+type injectionAudit struct {
+	limit   atomic.Int64
+	mutex   sync.Mutex
+	records []InjectionRecord
+}
+
+// enabled 是否开启记录
+func (a *injectionAudit) enabled() bool {
+	return a.limit.Load() > 0
+}
+
+// add 追加记录；累积到上限两倍时一次性丢弃较早的记录
+func (a *injectionAudit) add(r InjectionRecord) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	limit := int(a.limit.Load())
+	if limit <= 0 {
+		return
+	}
+	if len(a.records) >= 2*limit {
+		a.records = append(a.records[:0:0], a.records[len(a.records)-limit:]...)
+	}
+	a.records = append(a.records, r)
+}
+
+// snapshot 返回最近 limit 条记录的副本
+func (a *injectionAudit) snapshot() []InjectionRecord {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	records := a.records
+	if limit := int(a.limit.Load()); len(records) > limit {
+		records = records[len(records)-limit:]
+	}
+	return append([]InjectionRecord(nil), records...)
+}
+
+// SetInjectionAudit 开启注入审计，最多保留最近 limit 条记录（limit <= 0 时关闭并清空记录，默认关闭）
+// 开启后每次成功的字段注入都会查找消费方与来源的 bean 名，建议只在排查问题或测试时开启
+func (c *Container) SetInjectionAudit(limit int) {
+	c.audit.mutex.Lock()
+	defer c.audit.mutex.Unlock()
+	if limit <= 0 {
+		c.audit.limit.Store(0)
+		c.audit.records = nil
+		return
+	}
+	c.audit.limit.Store(int64(limit))
+}
+
+// injectionMode 返回普通 autowire 字段的解析方式
+func injectionMode(field reflect.StructField, tag string) string {
+	switch {
+	case (tag == "true" || tag == "false") && field.Type.Kind() == reflect.Slice:
+		return EdgeSlice
+	case strings.HasPrefix(tag, groupTagPrefix):
+		return EdgeGroup
+	case isNameTag(tag):
+		return EdgeByName
+	case field.Type.Kind() == reflect.Interface:
+		return EdgeByInterface
+	}
+	return EdgeByType
+}
+
+// recordInjection 记录一次成功的字段注入（未开启审计时直接返回）；切片与分组注入按元素记录来源
+// 调用方需持有本容器的锁（查找 bean 名）
+func (c *Container) recordInjection(owner any, field reflect.StructField, mode string, lazy bool, resolved ...reflect.Value) {
+	if !c.audit.enabled() {
+		return
+	}
+	sources := resolved
+	if (mode == EdgeSlice || mode == EdgeGroup) && len(resolved) == 1 && resolved[0].Kind() == reflect.Slice {
+		sources = make([]reflect.Value, 0, resolved[0].Len())
+		for i := 0; i < resolved[0].Len(); i++ {
+			sources = append(sources, resolved[0].Index(i))
+		}
+	}
+	r := InjectionRecord{
+		Owner:     displayTypeName(reflect.TypeOf(owner)),
+		Field:     field.Name,
+		FieldType: field.Type.String(),
+		Mode:      mode,
+		Lazy:      lazy,
+		At:        time.Now(),
+	}
+	if name, ok := c.auditBeanName(reflect.ValueOf(owner)); ok {
+		r.OwnerBean = name
+	}
+	for _, src := range sources {
+		name, _ := c.auditBeanName(src)
+		r.Sources = append(r.Sources, name)
+	}
+	c.audit.add(r)
+}
+
+// auditBeanName 返回实例的 bean 名（本容器优先于父容器）；不是 bean 时返回类型名与 false（调用方需持有本容器的锁）
+func (c *Container) auditBeanName(v reflect.Value) (string, bool) {
+	for v.Kind() == reflect.Interface && !v.IsNil() {
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return "", false
+	}
+	if def := c.definitionOf(v); def != nil {
+		return def.name, true
+	}
+	return displayTypeName(v.Type()), false
+}
+
+// InjectionAudit 按发生顺序返回每一次成功的字段注入：消费方、字段、来源 bean 与解析方式，
+// 用于排查"这个实例是从哪里注入的"。覆盖 StartUp 注入、Inject、启动后注册、待定依赖补齐、
+// Swap 重新注入，以及懒加载字段的首次解析；来源在父容器中的 bean 同样给出 bean 名。
+// 需先通过 SetInjectionAudit 开启，最多保留最近 limit 条记录
+func (c *Container) InjectionAudit() []InjectionRecord {
+	return c.audit.snapshot()
+}
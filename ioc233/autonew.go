@@ -0,0 +1,40 @@
+package ioc233
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// autowireNew autowire:"new" 标签值：按类型注入，容器中没有匹配的 bean 时自动创建
+const autowireNew = "new"
+
+// isNameTag 判断注入标签是否为名称注入（true/false/new 与 group: 分组以外的值）
+func isNameTag(tag string) bool {
+	return tag != "true" && tag != "false" && tag != autowireNew && !strings.HasPrefix(tag, groupTagPrefix)
+}
+
+// resolveOrNew 解析 autowire:"new" 字段
+// 先按类型查找已有 bean（含父容器）；未找到时用 new(T) 创建实例并注册到本容器，
+// 新实例排在注入队列末尾，由 StartUp 继续完成其自身的注入与生命周期回调
+// 自动创建需要写锁，只在 StartUp 或启动后注册的注入期间（resolveWrite）进行；其他时机未找到时返回错误
+func (c *Container) resolveOrNew(structName string, field reflect.StructField, mode resolveMode) (reflect.Value, error) {
+	t := field.Type
+	if t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("[ioc233] autowire:\"new\" 只支持结构体指针字段: struct=%s field=%s type=%v", structName, field.Name, t)
+	}
+	v, err := c.resolveField(structName, field, "false", mode)
+	if err != nil || v.IsValid() || mode == resolveDryRun {
+		return v, err
+	}
+	if mode != resolveWrite {
+		return reflect.Value{}, fmt.Errorf("[ioc233] 自动创建依赖失败: struct=%s field=%s (未找到 %v 的实例，且只能在 StartUp 或启动后注册时自动创建)", structName, field.Name, t)
+	}
+	c.provideLocked(reflect.New(t.Elem()).Interface())
+	obj, ok := c.typeToObjectMap.load(t)
+	if !ok || obj == nil {
+		return reflect.Value{}, fmt.Errorf("[ioc233] 自动创建依赖失败: struct=%s field=%s (%v 未能注册到容器)", structName, field.Name, t)
+	}
+	logInfo("[ioc233] 自动创建依赖: struct=%s field=%s type=%v", structName, field.Name, t)
+	return reflect.ValueOf(obj), nil
+}
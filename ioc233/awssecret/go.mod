@@ -0,0 +1,15 @@
+module github.com/neko233-com/ioc233-go/ioc233/awssecret
+
+go 1.25
+
+require (
+	github.com/aws/aws-sdk-go-v2 v1.39.2
+	github.com/aws/aws-sdk-go-v2/service/secretsmanager v1.39.6
+	github.com/neko233-com/ioc233-go v0.0.1
+)
+
+require (
+	github.com/aws/aws-sdk-go-v2/internal/configsources v1.4.9 // indirect
+	github.com/aws/aws-sdk-go-v2/internal/endpoints/v2 v2.7.9 // indirect
+	github.com/aws/smithy-go v1.23.0 // indirect
+)
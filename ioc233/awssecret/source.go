@@ -0,0 +1,69 @@
+// Package awssecret 基于 AWS Secrets Manager 的 ioc233 密钥源
+// 独立模块，只有导入本包时才引入 AWS SDK 依赖：
+//
+//	cfg, _ := config.LoadDefaultConfig(ctx)
+//	container.AddSecretSource(awssecret.New(secretsmanager.NewFromConfig(cfg)))
+//
+// secret 标签的路径格式为 "<secret id>[#<JSON 字段>]"：secret:"prod/db" 读取整个 SecretString，
+// secret:"prod/db#password" 将 SecretString 解析为 JSON 对象后读取 password 字段
+package awssecret
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// Client Secrets Manager 客户端的最小接口（*secretsmanager.Client 实现了该接口）
+type Client interface {
+	GetSecretValue(ctx context.Context, params *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error)
+}
+
+// Source AWS Secrets Manager 密钥源（实现 ioc233.SecretSource）
+type Source struct {
+	client Client
+}
+
+// New 创建 AWS Secrets Manager 密钥源
+func New(client Client) *Source {
+	return &Source{client: client}
+}
+
+// GetSecret 实现 ioc233.SecretSource
+func (s *Source) GetSecret(ctx context.Context, path string) (string, error) {
+	id, key, hasKey := strings.Cut(path, "#")
+	out, err := s.client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{SecretId: aws.String(id)})
+	var notFound *types.ResourceNotFoundException
+	if errors.As(err, &notFound) {
+		return "", ioc233.ErrSecretNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("[ioc233] 读取 AWS 密钥失败: id=%s: %w", id, err)
+	}
+	value := aws.ToString(out.SecretString)
+	if out.SecretString == nil {
+		value = string(out.SecretBinary)
+	}
+	if !hasKey {
+		return value, nil
+	}
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(value), &fields); err != nil {
+		return "", fmt.Errorf("[ioc233] AWS 密钥不是 JSON 对象: id=%s", id)
+	}
+	v, ok := fields[key]
+	if !ok || v == nil {
+		return "", fmt.Errorf("%w: id=%s field=%s", ioc233.ErrSecretNotFound, id, key)
+	}
+	if str, ok := v.(string); ok {
+		return str, nil
+	}
+	return fmt.Sprint(v), nil
+}
@@ -0,0 +1,57 @@
+package awssecret_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+	"github.com/neko233-com/ioc233-go/ioc233"
+	"github.com/neko233-com/ioc233-go/ioc233/awssecret"
+)
+
+// fakeClient 内存中的 Secrets Manager
+type fakeClient map[string]string
+
+func (f fakeClient) GetSecretValue(_ context.Context, in *secretsmanager.GetSecretValueInput, _ ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error) {
+	v, ok := f[aws.ToString(in.SecretId)]
+	if !ok {
+		return nil, &types.ResourceNotFoundException{Message: aws.String("not found")}
+	}
+	return &secretsmanager.GetSecretValueOutput{SecretString: aws.String(v)}, nil
+}
+
+type Repo struct {
+	Password string `secret:"prod/db#password"`
+	Port     int    `secret:"prod/db#port"`
+	APIKey   string `secret:"prod/api-key"`
+}
+
+func TestSource_InjectsSecrets(t *testing.T) {
+	client := fakeClient{
+		"prod/db":      `{"password": "s3cret", "port": 5432}`,
+		"prod/api-key": "k3y",
+	}
+	c := ioc233.NewContainer()
+	c.AddSecretSource(awssecret.New(client))
+	repo := &Repo{}
+	c.Provide(repo)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	if repo.Password != "s3cret" || repo.Port != 5432 || repo.APIKey != "k3y" {
+		t.Errorf("应该注入 AWS Secrets Manager 中的密钥, 实际: %+v", repo)
+	}
+}
+
+func TestSource_NotFound(t *testing.T) {
+	src := awssecret.New(fakeClient{"prod/db": `{"user": "svc"}`})
+	if _, err := src.GetSecret(context.Background(), "prod/missing"); !errors.Is(err, ioc233.ErrSecretNotFound) {
+		t.Errorf("secret 不存在时应该返回 ErrSecretNotFound, 实际: %v", err)
+	}
+	if _, err := src.GetSecret(context.Background(), "prod/db#password"); !errors.Is(err, ioc233.ErrSecretNotFound) {
+		t.Errorf("JSON 字段不存在时应该返回 ErrSecretNotFound, 实际: %v", err)
+	}
+}
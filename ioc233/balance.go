@@ -0,0 +1,140 @@
+package ioc233
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// 负载均衡模式（balance 标签取值）
+const (
+	BalanceRoundRobin = "round-robin"
+	BalanceWeighted   = "weighted"
+)
+
+// SetBalanceWeight 设置 bean 在加权负载均衡中的权重（通常来自配置）
+// 优先级：SetBalanceWeight > IWeighted.Weight() > 默认 1；权重 <= 0 的实例不参与分发
+func (c *Container) SetBalanceWeight(beanName string, weight int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.balanceWeights == nil {
+		c.balanceWeights = make(map[string]int)
+	}
+	c.balanceWeights[beanName] = weight
+}
+
+// balanceTarget 参与负载均衡的单个实例
+type balanceTarget struct {
+	name     string
+	instance any
+	weight   int
+	current  int // 平滑加权轮询的当前权重
+}
+
+// balancer 负载均衡分发器：每次调用选出一个可用实例
+type balancer struct {
+	mode    string
+	targets []*balanceTarget
+	counter atomic.Uint64
+	mutex   sync.Mutex
+}
+
+// available 判断实例当前是否可用（实现 IAvailable 且返回 false 的实例被排除）
+func (t *balanceTarget) available() bool {
+	if a, ok := t.instance.(IAvailable); ok {
+		return a.Available()
+	}
+	return true
+}
+
+// next 选出下一个实例；所有实例均不可用时退化为在全部实例中分发
+func (b *balancer) next() any {
+	healthy := make([]*balanceTarget, 0, len(b.targets))
+	for _, t := range b.targets {
+		if t.weight > 0 && t.available() {
+			healthy = append(healthy, t)
+		}
+	}
+	if len(healthy) == 0 {
+		logWarn("[ioc233] 负载均衡: 所有实例均不可用，退化为全量分发")
+		healthy = b.targets
+	}
+	if b.mode != BalanceWeighted {
+		n := b.counter.Add(1) - 1
+		return healthy[n%uint64(len(healthy))].instance
+	}
+
+	// 平滑加权轮询（nginx smooth weighted round-robin）
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	total := 0
+	var best *balanceTarget
+	for _, t := range healthy {
+		w := t.weight
+		if w <= 0 {
+			w = 1
+		}
+		t.current += w
+		total += w
+		if best == nil || t.current > best.current {
+			best = t
+		}
+	}
+	best.current -= total
+	return best.instance
+}
+
+// injectBalanced 处理带 balance 标签的接口字段；handled 为 true 表示字段已按负载均衡处理，
+// 没有任何实现时返回注入错误
+// 门面由 RegisterProxy 注册的代理实现，每次方法调用通过 target() 选择一个实现
+func (c *Container) injectBalanced(owner any, structName string, field reflect.StructField, fv reflect.Value) (handled bool, err error) {
+	mode := strings.TrimSpace(field.Tag.Get("balance"))
+	if mode == "" {
+		return false, nil
+	}
+	if mode != BalanceRoundRobin && mode != BalanceWeighted {
+		logError("[ioc233] 未知的 balance 模式 %q，回退为普通注入: %s.%s", mode, structName, field.Name)
+		return false, nil
+	}
+	if field.Type.Kind() != reflect.Interface {
+		logWarn("[ioc233] balance 标签仅支持接口字段，回退为普通注入: %s.%s", structName, field.Name)
+		return false, nil
+	}
+	factory, ok := proxyFactoryFor(field.Type)
+	if !ok {
+		logWarn("[ioc233] 接口 %v 未注册代理（RegisterProxy），回退为普通注入: %s.%s", field.Type, structName, field.Name)
+		return false, nil
+	}
+
+	b := &balancer{mode: mode}
+	for _, def := range c.beans {
+		if def.instance == nil || !implementsInterface(def.typ, field.Type) {
+			continue
+		}
+		weight := 1
+		if w, ok := def.instance.(IWeighted); ok {
+			weight = w.Weight()
+		}
+		if w, ok := c.balanceWeights[def.name]; ok {
+			weight = w
+		}
+		b.targets = append(b.targets, &balanceTarget{name: def.name, instance: def.instance, weight: weight})
+	}
+	if len(b.targets) == 0 {
+		return true, fmt.Errorf("[ioc233] 负载均衡注入失败: struct=%s field=%s (未找到实现 iface=%v)", structName, field.Name, field.Type)
+	}
+
+	fv.Set(reflect.ValueOf(factory(b.next)))
+	names := make([]string, 0, len(b.targets))
+	sources := make([]reflect.Value, 0, len(b.targets))
+	for _, t := range b.targets {
+		names = append(names, t.name)
+		sources = append(sources, reflect.ValueOf(t.instance))
+		c.markUsed(reflect.ValueOf(t.instance))
+	}
+	c.recordInjection(owner, field, EdgeBalance, false, sources...)
+	logInfo("[ioc233] 负载均衡门面注入: struct=%s field=%s mode=%s impls=%v", structName, field.Name, mode, names)
+	return true, nil
+}
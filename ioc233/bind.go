@@ -0,0 +1,60 @@
+package ioc233
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Bind 在默认容器中显式声明接口 I 由 Impl 实现
+// 声明后按接口解析（注入、GetObjectByType）直接 O(1) 查找 Impl 类型的 bean，不再扫描全部 bean，
+// 存在多个实现时也不再依赖注册顺序：
+//
+//	ioc233.Bind[UserService, *UserServiceImpl]()
+//
+// 切片注入与 GetObjectsByType 仍返回全部实现
+func Bind[I, Impl any]() error {
+	return BindIn[I, Impl](Default())
+}
+
+// BindIn 在指定容器中显式声明接口 I 由 Impl 实现（规则同 Bind）
+// 重复绑定同一接口时以最后一次为准
+func BindIn[I, Impl any](c *Container) error {
+	iface := reflect.TypeOf((*I)(nil)).Elem()
+	impl := reflect.TypeOf((*Impl)(nil)).Elem()
+	if iface.Kind() != reflect.Interface {
+		return fmt.Errorf("[ioc233] Bind 的第一个类型参数必须是接口: %v", iface)
+	}
+	if !impl.Implements(iface) {
+		return fmt.Errorf("[ioc233] Bind 的实现类型 %v 未实现接口 %v", impl, iface)
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.bindings == nil {
+		c.bindings = make(map[reflect.Type]reflect.Type)
+	}
+	if prev, ok := c.bindings[iface]; ok && prev != impl {
+		logWarn("[ioc233] 接口绑定被覆盖: iface=%v %v -> %v", iface, prev, impl)
+	}
+	c.bindings[iface] = impl
+	c.invalidateReadViewLocked()
+	logInfo("[ioc233] 接口绑定 | iface = %v -> impl = %v", iface, impl)
+	return nil
+}
+
+// resolveBound 按显式绑定解析接口（调用方需持有锁）
+// 返回 bound=false 表示接口未绑定，调用方应回退到扫描实现；
+// 已绑定但实现未注册时返回无效值与错误
+func (c *Container) resolveBound(iface reflect.Type, create bool) (v reflect.Value, bound bool, err error) {
+	impl, ok := c.bindings[iface]
+	if !ok {
+		return reflect.Value{}, false, nil
+	}
+	if obj, ok := c.typeToObjectMap.load(impl); ok && obj != nil {
+		return reflect.ValueOf(obj), true, nil
+	}
+	if proto := c.findPrototype(impl); proto != nil {
+		v, err := c.injectablePrototype(proto, create)
+		return v, true, err
+	}
+	return reflect.Value{}, true, fmt.Errorf("[ioc233] 接口 %v 绑定的实现 %v 未注册", iface, impl)
+}
@@ -0,0 +1,136 @@
+package ioc233
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+)
+
+// CallbackTimeoutPolicy 生命周期回调超时后的处理策略
+type CallbackTimeoutPolicy int
+
+const (
+	// CallbackTimeoutContinue 记录卡住的 bean 与回调后继续启动（回调仍在后台执行）
+	// 超时的回调与后续启动并发执行且不受容器锁保护：回调在超时之后不能再访问容器、其他 bean 或自身已被注入的依赖，
+	// 只适用于可以安全地在后台完成的工作（例如预热本地缓存）；不能保证这一点时使用 CallbackTimeoutFail
+	CallbackTimeoutContinue CallbackTimeoutPolicy = iota
+	// CallbackTimeoutFail 中止启动，StartUp 返回包装了 *CallbackTimeoutError 的错误
+	CallbackTimeoutFail
+)
+
+// CallbackTimeoutError 生命周期回调超过 SetCallbackTimeout 设置的时长
+type CallbackTimeoutError struct {
+	// Bean 回调所属的 bean 名
+	Bean string
+	// Callback 回调名，例如 OnInjectComplete
+	Callback string
+	// Timeout 超时时长
+	Timeout time.Duration
+}
+
+// Error 实现 error 接口
+func (e *CallbackTimeoutError) Error() string {
+	return fmt.Sprintf("[ioc233] 生命周期回调超时: bean=%s callback=%s timeout=%v", e.Bean, e.Callback, e.Timeout)
+}
+
+// CallbackPanicError 在独立协程中执行的生命周期回调（设置了回调超时或启动截止时间）发生 panic 时，
+// StartUp 在调用方协程中重新抛出的值；Stack 为回调协程中捕获的调用栈
+type CallbackPanicError struct {
+	// Bean 回调所属的 bean 名
+	Bean string
+	// Callback 回调名，例如 OnInjectComplete
+	Callback string
+	// Value 回调 panic 的原始值
+	Value any
+	// Stack 回调协程 panic 时的调用栈
+	Stack []byte
+}
+
+// Error 实现 error 接口
+func (e *CallbackPanicError) Error() string {
+	return fmt.Sprintf("[ioc233] 生命周期回调 panic: bean=%s callback=%s: %v\n%s", e.Bean, e.Callback, e.Value, e.Stack)
+}
+
+// Unwrap 原始值是 error 时返回该 error
+func (e *CallbackPanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// SetCallbackTimeout 设置单个生命周期回调（OnInjectBefore / OnInjectAfter / OnInjectComplete）的最长执行时间（d <= 0 表示不限制）
+// 超时后记录卡住的 bean 与回调，并按 policy 继续启动或中止启动；超时的回调无法被强制结束，会在后台继续执行
+// （CallbackTimeoutContinue 下超时的回调不能再访问容器与其他 bean，见 CallbackTimeoutContinue）
+//
+//	container.SetCallbackTimeout(10*time.Second, ioc233.CallbackTimeoutFail)
+func (c *Container) SetCallbackTimeout(d time.Duration, policy CallbackTimeoutPolicy) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.callbackTimeout, c.callbackTimeoutPolicy = d, policy
+}
+
+// runCallbackLocked 执行生命周期回调；设置了超时或 ctx 带截止时间时在独立协程中执行并等待（调用方需持有锁）
+// 回调中的 panic 包装为 *CallbackPanicError（带回调协程的调用栈）在调用方协程中重新抛出；截止时间到达时返回包装了 ctx.Err() 的错误，
+// 按 CallbackTimeoutFail 策略超时时返回 *CallbackTimeoutError
+// 不带截止时间的 ctx 被取消时不打断回调，由调用方在回调之间检查
+func (c *Container) runCallbackLocked(ctx context.Context, def *beanDefinition, callback string, fn func()) error {
+	_, hasDeadline := ctx.Deadline()
+	if c.callbackTimeout <= 0 && !hasDeadline {
+		fn()
+		return nil
+	}
+	var deadline <-chan struct{}
+	if hasDeadline {
+		deadline = ctx.Done()
+	}
+	done := make(chan *CallbackPanicError, 1)
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				done <- &CallbackPanicError{Bean: def.name, Callback: callback, Value: p, Stack: debug.Stack()}
+				return
+			}
+			done <- nil
+		}()
+		fn()
+	}()
+	var expired <-chan time.Time
+	if c.callbackTimeout > 0 {
+		timer := time.NewTimer(c.callbackTimeout)
+		defer timer.Stop()
+		expired = timer.C
+	}
+	finished := func(p *CallbackPanicError) error {
+		if p != nil {
+			panic(p)
+		}
+		return nil
+	}
+	var err error
+	select {
+	case p := <-done:
+		return finished(p)
+	case <-deadline:
+		err = fmt.Errorf("%w: 生命周期回调未完成: bean=%s callback=%s", ctx.Err(), def.name, callback)
+	case <-expired:
+		err = &CallbackTimeoutError{Bean: def.name, Callback: callback, Timeout: c.callbackTimeout}
+	}
+	// 回调已经返回（例如回调内取消了 ctx）时以回调结果为准
+	select {
+	case p := <-done:
+		return finished(p)
+	default:
+	}
+
+	go func() {
+		if p := <-done; p != nil {
+			logError("[ioc233] 超时的生命周期回调发生 panic: bean=%s callback=%s: %v\n%s", def.name, callback, p.Value, p.Stack)
+		}
+	}()
+	if _, timedOut := err.(*CallbackTimeoutError); !timedOut || c.callbackTimeoutPolicy == CallbackTimeoutFail {
+		logError("%s", err.Error())
+		return err
+	}
+	logWarn("%s，继续启动（回调仍在后台执行）", err.Error())
+	return nil
+}
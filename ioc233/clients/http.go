@@ -0,0 +1,175 @@
+// Package clients 常用第三方客户端的 ioc233 提供器模块
+// 每个模块负责按配置构造客户端并注册到容器，扩展钩子（中间件）从容器中解析，
+// 减少各服务重复编写的客户端装配代码
+package clients
+
+import (
+	"net"
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// DefaultHTTPClientName HTTP 客户端默认 bean 名
+const DefaultHTTPClientName = "httpClient"
+
+// HTTPClientConfig HTTP 客户端配置（零值字段使用默认值）
+type HTTPClientConfig struct {
+	// Timeout 整体请求超时（默认 30s）
+	Timeout time.Duration
+	// DialTimeout 建连超时（默认 5s）
+	DialTimeout time.Duration
+	// MaxIdleConns 最大空闲连接数（默认 100）
+	MaxIdleConns int
+	// MaxIdleConnsPerHost 每个 host 的最大空闲连接数（默认 32）
+	MaxIdleConnsPerHost int
+	// IdleConnTimeout 空闲连接超时（默认 90s）
+	IdleConnTimeout time.Duration
+	// TLSHandshakeTimeout TLS 握手超时（默认 5s）
+	TLSHandshakeTimeout time.Duration
+	// DisableKeepAlives 禁用长连接
+	DisableKeepAlives bool
+}
+
+// withDefaults 填充默认值
+func (c HTTPClientConfig) withDefaults() HTTPClientConfig {
+	if c.Timeout <= 0 {
+		c.Timeout = 30 * time.Second
+	}
+	if c.DialTimeout <= 0 {
+		c.DialTimeout = 5 * time.Second
+	}
+	if c.MaxIdleConns <= 0 {
+		c.MaxIdleConns = 100
+	}
+	if c.MaxIdleConnsPerHost <= 0 {
+		c.MaxIdleConnsPerHost = 32
+	}
+	if c.IdleConnTimeout <= 0 {
+		c.IdleConnTimeout = 90 * time.Second
+	}
+	if c.TLSHandshakeTimeout <= 0 {
+		c.TLSHandshakeTimeout = 5 * time.Second
+	}
+	return c
+}
+
+// HTTPMiddleware HTTP 客户端中间件（注册为 bean 即生效，按 IOrdered 排序，Order 小的在外层）
+// client 为客户端 bean 名，可据此只包装特定客户端
+type HTTPMiddleware interface {
+	WrapHTTP(client string, next http.RoundTripper) http.RoundTripper
+}
+
+// HTTPClientModule HTTP 客户端提供器模块
+//
+//	c.Install(clients.HTTPClientModule{Name: "paymentHTTP", ConfigBean: "paymentHTTPConfig"})
+//
+//	type PaymentGateway struct {
+//	    HTTP *clients.HTTPClient `autowire:"paymentHTTP"`
+//	}
+type HTTPClientModule struct {
+	// Name bean 名（默认 "httpClient"）
+	Name string
+	// Config 默认配置
+	Config HTTPClientConfig
+	// ConfigBean 可选：启动时从容器按名称读取 HTTPClientConfig 值 bean，覆盖 Config
+	ConfigBean string
+}
+
+// Configure 实现 ioc233.Module
+func (m HTTPClientModule) Configure(c *ioc233.Container) error {
+	name := m.Name
+	if name == "" {
+		name = DefaultHTTPClientName
+	}
+	registerConfigTag(c)
+	return c.ProvideByName(name, &HTTPClient{
+		Config:     m.Config,
+		name:       name,
+		configBean: m.ConfigBean,
+	})
+}
+
+// HTTPClient 由容器装配的 HTTP 客户端（嵌入 *http.Client，注入完成后可直接使用）
+type HTTPClient struct {
+	*http.Client
+
+	// Middlewares 容器中的全部 HTTPMiddleware
+	Middlewares []HTTPMiddleware `autowire:"false"`
+	// Config 生效的配置：模块的 Config，注入阶段被 ConfigBean 指定的值 bean 覆盖
+	Config HTTPClientConfig `clientconfig:""`
+
+	name       string
+	configBean string
+}
+
+// Name 返回客户端 bean 名
+func (h *HTTPClient) Name() string {
+	return h.name
+}
+
+// OnInjectAfter 注入完成后按配置构造客户端并应用中间件
+func (h *HTTPClient) OnInjectAfter() {
+	cfg := h.Config.withDefaults()
+	dialer := &net.Dialer{Timeout: cfg.DialTimeout, KeepAlive: 30 * time.Second}
+	var rt http.RoundTripper = &http.Transport{
+		Proxy:               http.ProxyFromEnvironment,
+		DialContext:         dialer.DialContext,
+		MaxIdleConns:        cfg.MaxIdleConns,
+		MaxIdleConnsPerHost: cfg.MaxIdleConnsPerHost,
+		IdleConnTimeout:     cfg.IdleConnTimeout,
+		TLSHandshakeTimeout: cfg.TLSHandshakeTimeout,
+		DisableKeepAlives:   cfg.DisableKeepAlives,
+		ForceAttemptHTTP2:   true,
+	}
+	// 逆序包装，使排在前面的中间件位于最外层
+	for i := len(h.Middlewares) - 1; i >= 0; i-- {
+		rt = h.Middlewares[i].WrapHTTP(h.name, rt)
+	}
+	h.Client = &http.Client{Transport: rt, Timeout: cfg.Timeout}
+}
+
+// OnDestroy 关闭空闲连接
+func (h *HTTPClient) OnDestroy() {
+	if h.Client != nil {
+		h.Client.CloseIdleConnections()
+	}
+}
+
+// configTag 客户端配置标签：注入阶段由标签处理器按模块的 ConfigBean 从容器读取配置值 bean 覆盖字段
+// 标签处理器通过 TagField.Container 在注入流程持有的容器锁内解析，不需要在生命周期回调中访问容器
+const configTag = "clientconfig"
+
+// configBeanOwner 带有配置标签的客户端 bean
+type configBeanOwner interface {
+	configBeanName() string
+}
+
+func (h *HTTPClient) configBeanName() string { return h.configBean }
+
+// registerConfigTag 注册配置标签处理器（多个客户端模块共用一个处理器，重复注册返回的错误可以忽略）
+func registerConfigTag(c *ioc233.Container) {
+	_ = c.RegisterTagHandler(configTag, ioc233.TagHandlerFunc(resolveConfig))
+}
+
+// resolveConfig 从容器按名称读取配置值 bean（支持值与指针）写入字段，未配置或未找到时保持模块的默认配置
+func resolveConfig(f ioc233.TagField) error {
+	owner, ok := f.Owner.(configBeanOwner)
+	if !ok || owner.configBeanName() == "" {
+		return nil
+	}
+	obj, ok := f.Container.ResolveByName(owner.configBeanName())
+	if !ok {
+		return nil
+	}
+	v := reflect.ValueOf(obj)
+	switch {
+	case v.Type() == f.Value.Type():
+		f.Value.Set(v)
+	case v.Kind() == reflect.Ptr && !v.IsNil() && v.Elem().Type() == f.Value.Type():
+		f.Value.Set(v.Elem())
+	}
+	return nil
+}
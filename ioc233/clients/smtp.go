@@ -0,0 +1,138 @@
+package clients
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// DefaultSMTPSenderName SMTP 发送器默认 bean 名
+const DefaultSMTPSenderName = "smtpSender"
+
+// SMTPConfig SMTP 配置
+type SMTPConfig struct {
+	Host     string
+	Port     int // 默认 587
+	Username string
+	Password string
+	// From 默认发件人（Mail.From 为空时使用）
+	From string
+}
+
+// Mail 邮件
+type Mail struct {
+	From    string
+	To      []string
+	Subject string
+	Body    string
+}
+
+// SendFunc 邮件发送函数
+type SendFunc func(mail *Mail) error
+
+// SMTPMiddleware SMTP 发送中间件（注册为 bean 即生效，按 IOrdered 排序，Order 小的在外层）
+// sender 为发送器 bean 名，可据此只包装特定发送器
+type SMTPMiddleware interface {
+	WrapSMTP(sender string, next SendFunc) SendFunc
+}
+
+// SMTPSenderModule SMTP 发送器提供器模块
+//
+//	c.Install(clients.SMTPSenderModule{ConfigBean: "smtpConfig"})
+//
+//	type Notifier struct {
+//	    Mail *clients.SMTPSender `autowire:"true"`
+//	}
+type SMTPSenderModule struct {
+	// Name bean 名（默认 "smtpSender"）
+	Name string
+	// Config 默认配置
+	Config SMTPConfig
+	// ConfigBean 可选：启动时从容器按名称读取 SMTPConfig 值 bean，覆盖 Config
+	ConfigBean string
+}
+
+// Configure 实现 ioc233.Module
+func (m SMTPSenderModule) Configure(c *ioc233.Container) error {
+	name := m.Name
+	if name == "" {
+		name = DefaultSMTPSenderName
+	}
+	registerConfigTag(c)
+	return c.ProvideByName(name, &SMTPSender{
+		Config:     m.Config,
+		name:       name,
+		configBean: m.ConfigBean,
+	})
+}
+
+// SMTPSender 由容器装配的 SMTP 发送器
+type SMTPSender struct {
+	// Middlewares 容器中的全部 SMTPMiddleware
+	Middlewares []SMTPMiddleware `autowire:"false"`
+	// Config 生效的配置：模块的 Config，注入阶段被 ConfigBean 指定的值 bean 覆盖
+	Config SMTPConfig `clientconfig:""`
+
+	name       string
+	configBean string
+	send       SendFunc
+}
+
+func (s *SMTPSender) configBeanName() string { return s.configBean }
+
+// OnInjectAfter 注入完成后按配置构造发送链并应用中间件
+func (s *SMTPSender) OnInjectAfter() {
+	send := s.sendSMTP
+	for i := len(s.Middlewares) - 1; i >= 0; i-- {
+		send = s.Middlewares[i].WrapSMTP(s.name, send)
+	}
+	s.send = send
+}
+
+// Send 发送邮件
+func (s *SMTPSender) Send(mail *Mail) error {
+	if mail == nil || len(mail.To) == 0 {
+		return errors.New("[ioc233] SMTPSender 收件人不能为空")
+	}
+	if mail.From == "" {
+		mail.From = s.Config.From
+	}
+	if s.send == nil {
+		return fmt.Errorf("[ioc233] SMTPSender %s 尚未完成注入", s.name)
+	}
+	return s.send(mail)
+}
+
+// sendSMTP 通过 net/smtp 发送
+func (s *SMTPSender) sendSMTP(mail *Mail) error {
+	if s.Config.Host == "" {
+		return fmt.Errorf("[ioc233] SMTPSender %s 未配置 Host", s.name)
+	}
+	port := s.Config.Port
+	if port == 0 {
+		port = 587
+	}
+	var auth smtp.Auth
+	if s.Config.Username != "" {
+		auth = smtp.PlainAuth("", s.Config.Username, s.Config.Password, s.Config.Host)
+	}
+	addr := net.JoinHostPort(s.Config.Host, strconv.Itoa(port))
+	return smtp.SendMail(addr, auth, mail.From, mail.To, buildMessage(mail))
+}
+
+// buildMessage 构造简单的纯文本邮件报文
+func buildMessage(mail *Mail) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + mail.From + "\r\n")
+	b.WriteString("To: " + strings.Join(mail.To, ", ") + "\r\n")
+	b.WriteString("Subject: " + mail.Subject + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	b.WriteString(mail.Body)
+	return []byte(b.String())
+}
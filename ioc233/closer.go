@@ -0,0 +1,83 @@
+package ioc233
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// WithoutAutoClose 容器关闭时不调用该 bean 的 Close（bean 实现了 io.Closer 但由外部管理生命周期时使用，例如共享的连接池）
+func WithoutAutoClose() BeanOption {
+	return func(o *beanOptions) {
+		o.noAutoClose = true
+	}
+}
+
+// closeBeansLocked 按依赖逆序调用实现 io.Closer 的 bean 的 Close：依赖方先于被依赖方关闭，
+// 单个 bean 的错误与 panic 不影响其余 bean，全部错误合并返回（调用方需持有写锁）
+func (c *Container) closeBeansLocked(beans []*beanDefinition) error {
+	order := c.dependencyOrderLocked(beans)
+	var errs []error
+	for i := len(order) - 1; i >= 0; i-- {
+		def := order[i]
+		closer, ok := def.instance.(io.Closer)
+		if !ok || def.noAutoClose || sameInstance(def.instance, c) {
+			continue
+		}
+		logInfo("[ioc233] 关闭资源: %s", def.name)
+		if err := closeSafely(closer); err != nil {
+			logError("[ioc233] 关闭资源失败: name=%s: %v", def.name, err)
+			errs = append(errs, fmt.Errorf("%s: %w", def.name, err))
+			c.closeFailures = append(c.closeFailures, Leak{Bean: def.name, Kind: LeakCloser, Detail: err.Error()})
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("[ioc233] 关闭资源失败: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// closeSafely 调用 Close，panic 转换为错误
+func closeSafely(closer io.Closer) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("panic: %v", p)
+		}
+	}()
+	return closer.Close()
+}
+
+// dependencyOrderLocked 返回 beans 的依赖顺序：被依赖的 bean（autowire 字段、dependsOn）排在依赖方之前，
+// 没有依赖关系的 bean 保持注册顺序，循环依赖按注册顺序打破（调用方需持有锁）
+func (c *Container) dependencyOrderLocked(beans []*beanDefinition) []*beanDefinition {
+	byName := make(map[string]*beanDefinition, len(beans))
+	for _, def := range beans {
+		byName[def.name] = def
+	}
+	visited := make(map[*beanDefinition]bool, len(beans))
+	order := make([]*beanDefinition, 0, len(beans))
+	var visit func(def *beanDefinition)
+	visit = func(def *beanDefinition) {
+		if visited[def] {
+			return
+		}
+		visited[def] = true
+		for _, edge := range c.edgesOf(def) {
+			for _, name := range edge.To {
+				if dep, ok := byName[name]; ok {
+					visit(dep)
+				}
+			}
+		}
+		for _, name := range dependsOnOf(def) {
+			if dep, ok := byName[name]; ok {
+				visit(dep)
+			}
+		}
+		order = append(order, def)
+	}
+	for _, def := range beans {
+		visit(def)
+	}
+	return order
+}
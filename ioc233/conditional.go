@@ -0,0 +1,238 @@
+package ioc233
+
+import (
+	"os"
+	"reflect"
+	"strings"
+)
+
+// ProfilesEnv 未调用 SetActiveProfiles 时读取激活 profile 的环境变量（逗号分隔）
+const ProfilesEnv = "IOC233_PROFILES"
+
+// DefaultProfile 没有任何激活 profile 时生效的默认 profile
+const DefaultProfile = "default"
+
+// conditionalDefinition 条件注册定义（在 StartUp 时求值）
+type conditionalDefinition struct {
+	// name 为空表示按类型注册（Provide），否则按名称注册（ProvideByName）
+	name     string
+	instance any
+	// cond 条件（持有容器写锁时调用）
+	cond func() bool
+	// desc 条件描述（日志用）
+	desc string
+	// opts 注册时的附加选项（条件满足后应用）
+	opts []BeanOption
+}
+
+// SetActiveProfiles 设置激活的 profile（例如 "dev"、"prod"）
+// 未设置时依次回退：父容器的激活 profile -> 环境变量 IOC233_PROFILES -> "default"
+func (c *Container) SetActiveProfiles(profiles ...string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.profiles = normalizeProfiles(profiles)
+}
+
+// ActiveProfiles 返回当前激活的 profile
+func (c *Container) ActiveProfiles() []string {
+	var profiles []string
+	c.withReadLock(func() {
+		profiles = c.activeProfilesLocked()
+	})
+	return profiles
+}
+
+// activeProfilesLocked 返回当前激活的 profile（调用方需持有锁）
+func (c *Container) activeProfilesLocked() []string {
+	if c.profiles != nil {
+		return append([]string(nil), c.profiles...)
+	}
+	if c.parent != nil {
+		return c.parent.ActiveProfiles()
+	}
+	if env := normalizeProfiles(strings.Split(os.Getenv(ProfilesEnv), ",")); len(env) > 0 {
+		return env
+	}
+	return []string{DefaultProfile}
+}
+
+// normalizeProfiles 去除空白与空项
+func normalizeProfiles(profiles []string) []string {
+	result := make([]string, 0, len(profiles))
+	for _, p := range profiles {
+		if p = strings.TrimSpace(p); p != "" {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// matchProfiles 判断 profile 表达式是否匹配激活的 profile
+// 表达式为逗号分隔的多项（任一项匹配即可），"!prod" 表示 prod 未激活
+func matchProfiles(expr string, active []string) bool {
+	isActive := make(map[string]bool, len(active))
+	for _, p := range active {
+		isActive[p] = true
+	}
+	for _, term := range normalizeProfiles(strings.Split(expr, ",")) {
+		if strings.HasPrefix(term, "!") {
+			if !isActive[strings.TrimSpace(term[1:])] {
+				return true
+			}
+		} else if isActive[term] {
+			return true
+		}
+	}
+	return false
+}
+
+// ProvideForProfile 仅当 profile 表达式匹配激活的 profile 时注册对象（在 StartUp 时求值）
+// 表达式支持逗号分隔的多项与 "!" 取反，例如 "dev,test"、"!prod"：
+//
+//	c.ProvideForProfile("dev", &MockMailer{})
+//	c.ProvideForProfile("prod", &SMTPMailer{})
+//
+// 也可以在结构体中用标签声明 profile，直接 Provide 即可：
+//
+//	type MockMailer struct {
+//	    _ struct{} `profile:"dev,test"`
+//	}
+func (c *Container) ProvideForProfile(profile string, instance any) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.addProfileConditionalLocked(profile, "", instance)
+}
+
+// ProvideForProfileByName 按名称注册，仅当 profile 表达式匹配时生效
+func (c *Container) ProvideForProfileByName(profile, name string, instance any) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.addProfileConditionalLocked(profile, name, instance)
+}
+
+// addProfileConditionalLocked 记录 profile 条件注册（调用方需持有写锁）
+func (c *Container) addProfileConditionalLocked(profile, name string, instance any) {
+	c.addConditionalLocked(func() bool {
+		return matchProfiles(profile, c.activeProfilesLocked())
+	}, name, instance, "profile="+profile)
+}
+
+// ProvideIf 仅当 cond 返回 true 时注册对象（在 StartUp 时求值，而非调用时）
+// 适用于特性开关、配置值、操作系统等条件；被跳过的对象不会注册，
+// 依赖它的 autowire:"false" 可选字段保持 nil 而不会报错：
+//
+//	c.ProvideIf(func() bool { return runtime.GOOS == "linux" }, &EpollPoller{})
+//	c.ProvideIf(func() bool { return cfg.NewMatchmaker }, &Matchmaker{})
+func (c *Container) ProvideIf(cond func() bool, instance any) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.addConditionalLocked(cond, "", instance, "ProvideIf")
+}
+
+// ProvideIfByName 按名称注册，仅当 cond 返回 true 时生效（在 StartUp 时求值）
+func (c *Container) ProvideIfByName(cond func() bool, name string, instance any) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.addConditionalLocked(cond, name, instance, "ProvideIf")
+}
+
+// addConditionalLocked 记录条件注册（调用方需持有写锁）
+func (c *Container) addConditionalLocked(cond func() bool, name string, instance any, desc string) {
+	if instance == nil || cond == nil || c.checkFrozenLocked("ProvideIf", instance) != nil {
+		return
+	}
+	c.conditionals = append(c.conditionals, &conditionalDefinition{
+		name:     name,
+		instance: instance,
+		cond:     cond,
+		desc:     desc,
+	})
+}
+
+// ProvideIfMissing 在默认容器中注册 T 的默认实现，仅当 StartUp 时没有其他 T 类型（或实现 T 接口）的 bean
+// 适用于库提供合理的兜底实现，应用注册自己的实现即可覆盖：
+//
+//	ioc233.ProvideIfMissing[Cache](&MemoryCache{}) // 应用注册了 RedisCache 时不生效
+func ProvideIfMissing[T any](instance T) {
+	ProvideIfMissingIn[T](Default(), instance)
+}
+
+// ProvideIfMissingIn 在指定容器中注册 T 的默认实现（规则同 ProvideIfMissing）
+// 说明：
+// - 在所有普通注册与其他条件注册之后求值，与调用顺序无关
+// - 本容器（含原型 bean）与父容器中已存在 T 时跳过
+// - 同一个 T 的多个兜底实现，先声明的生效
+func ProvideIfMissingIn[T any](c *Container, instance T) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if any(instance) == nil || c.checkFrozenLocked("ProvideIfMissing", instance) != nil {
+		return
+	}
+	c.missingDefaults = append(c.missingDefaults, &conditionalDefinition{
+		instance: instance,
+		cond:     func() bool { return !c.hasBeanOfLocked(t) },
+		desc:     "ProvideIfMissing[" + t.String() + "]",
+	})
+}
+
+// hasBeanOfLocked 判断容器（含原型与父容器）中是否存在 t 类型或实现 t 接口的 bean（调用方需持有锁）
+func (c *Container) hasBeanOfLocked(t reflect.Type) bool {
+	if t.Kind() == reflect.Interface {
+		if len(c.findImplementations(t)) > 0 {
+			return true
+		}
+	} else if obj, ok := c.typeToObjectMap.load(t); ok && obj != nil {
+		return true
+	}
+	if c.findPrototype(t) != nil {
+		return true
+	}
+	if c.parent != nil {
+		found := false
+		c.parent.withReadLock(func() {
+			found = c.parent.hasBeanOfLocked(t)
+		})
+		return found
+	}
+	return false
+}
+
+// profileTagOf 读取结构体中 profile 标签声明的表达式（未声明返回空）
+func profileTagOf(instance any) string {
+	t := reflect.TypeOf(instance)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return ""
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if expr, ok := t.Field(i).Tag.Lookup("profile"); ok {
+			return expr
+		}
+	}
+	return ""
+}
+
+// applyConditionalsLocked 求值条件注册，满足条件的对象注册到容器（调用方需持有写锁）
+// 兜底实现（ProvideIfMissing）在其他条件注册之后求值
+func (c *Container) applyConditionalsLocked() {
+	pending := append(c.conditionals, c.missingDefaults...)
+	c.conditionals, c.missingDefaults = nil, nil
+	c.applyingConditionals = true
+	defer func() { c.applyingConditionals = false }()
+	for _, def := range pending {
+		if !def.cond() {
+			logInfo("[ioc233] 条件不满足，跳过注册: %v (%s)", reflect.TypeOf(def.instance), def.desc)
+			continue
+		}
+		logInfo("[ioc233] 条件满足，注册: %v (%s)", reflect.TypeOf(def.instance), def.desc)
+		if def.name == "" {
+			c.provideLocked(def.instance)
+		} else {
+			_ = c.provideByNameLocked(def.name, def.instance)
+		}
+		c.applyBeanOptionsLocked(def.instance, def.opts)
+	}
+}
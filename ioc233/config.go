@@ -0,0 +1,137 @@
+package ioc233
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+)
+
+// ConfigSource 配置源，value 标签中的占位符按 key 从配置源查找
+type ConfigSource interface {
+	// Lookup 按 key 查找配置值（key 形如 db.host），不存在时返回 false
+	Lookup(key string) (string, bool)
+}
+
+// MapConfigSource 基于 map 的配置源（测试或代码内置配置）
+type MapConfigSource map[string]string
+
+// Lookup 实现 ConfigSource
+func (m MapConfigSource) Lookup(key string) (string, bool) {
+	v, ok := m[key]
+	return v, ok
+}
+
+// EnvConfigSource 环境变量配置源：key 中的 . 与 - 替换为 _ 并转为大写后查找（db.host -> DB_HOST）
+type EnvConfigSource struct {
+	// Prefix 变量名前缀，例如 "APP_"（db.host -> APP_DB_HOST）
+	Prefix string
+}
+
+// Lookup 实现 ConfigSource
+func (e EnvConfigSource) Lookup(key string) (string, bool) {
+	name := strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(key))
+	return os.LookupEnv(e.Prefix + name)
+}
+
+// AddConfigSource 添加配置源（先添加的优先级更高；子容器未命中时回退父容器的配置源）
+// 实现 WatchableConfigSource 的配置源立即开始监听，变化时调用 NotifyConfigChanged
+func (c *Container) AddConfigSource(src ConfigSource) {
+	if src == nil {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.configSources = append(c.configSources, src)
+	if w, ok := src.(WatchableConfigSource); ok {
+		c.configWatchStops = append(c.configWatchStops, w.Watch(func(keys []string) {
+			c.NotifyConfigChanged(keys...)
+		}))
+	}
+}
+
+// Property 按 key 从配置源查找配置值
+func (c *Container) Property(key string) (string, bool) {
+	var (
+		v  string
+		ok bool
+	)
+	c.withReadLock(func() {
+		v, ok = c.lookupProperty(key)
+	})
+	return v, ok
+}
+
+// ResolvePlaceholders 替换字符串中的 ${key} / ${key:default} 占位符
+func (c *Container) ResolvePlaceholders(s string) (string, error) {
+	var (
+		v   string
+		err error
+	)
+	c.withReadLock(func() {
+		v, err = resolvePlaceholders(s, c.lookupProperty)
+	})
+	return v, err
+}
+
+// lookupProperty 按配置源顺序查找 key（调用方需持有读锁）
+func (c *Container) lookupProperty(key string) (string, bool) {
+	for _, src := range c.configSources {
+		if v, ok := src.Lookup(key); ok {
+			return v, true
+		}
+	}
+	if c.parent != nil {
+		var (
+			v  string
+			ok bool
+		)
+		c.parent.withReadLock(func() {
+			v, ok = c.parent.lookupProperty(key)
+		})
+		return v, ok
+	}
+	return "", false
+}
+
+// resolvePlaceholders 替换 s 中的全部 ${key[:default]} 占位符；未找到且没有默认值的 key 返回错误
+func resolvePlaceholders(s string, lookup func(key string) (string, bool)) (string, error) {
+	var b strings.Builder
+	for {
+		start := strings.Index(s, "${")
+		if start < 0 {
+			b.WriteString(s)
+			return b.String(), nil
+		}
+		end := strings.IndexByte(s[start:], '}')
+		if end < 0 {
+			return "", fmt.Errorf("占位符缺少 '}': %q", s[start:])
+		}
+		end += start
+		b.WriteString(s[:start])
+
+		key, def, hasDefault := strings.Cut(s[start+2:end], ":")
+		key = strings.TrimSpace(key)
+		if v, ok := lookup(key); ok {
+			b.WriteString(v)
+		} else if hasDefault {
+			b.WriteString(def)
+		} else {
+			return "", fmt.Errorf("配置项未找到: %s", key)
+		}
+		s = s[end+1:]
+	}
+}
+
+// resolveValueTag 解析 value 标签并转换为字段类型（供注入与校验共用）
+func (c *Container) resolveValueTag(structName string, field reflect.StructField, expr string) (reflect.Value, error) {
+	raw, err := resolvePlaceholders(expr, c.lookupProperty)
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("[ioc233] 配置注入失败: struct=%s field=%s value=%q: %w", structName, field.Name, expr, err)
+	}
+	v, err := parseLiteral(field.Type, raw)
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("[ioc233] 配置值类型不匹配: struct=%s field=%s value=%q: %w", structName, field.Name, raw, err)
+	}
+	return v, nil
+}
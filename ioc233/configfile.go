@@ -0,0 +1,332 @@
+package ioc233
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ConfigDecoder 将配置文件内容解码为嵌套 map（对象为 map[string]any，数组为切片）
+type ConfigDecoder func(data []byte) (map[string]any, error)
+
+var (
+	// configDecoders 文件扩展名 -> 解码器（YAML/TOML 见 ioc233/configfile 模块）
+	configDecoders = map[string]ConfigDecoder{
+		".json": decodeJSONConfig,
+		".env":  decodeDotEnvConfig,
+	}
+	configDecodersLock sync.RWMutex
+)
+
+// RegisterConfigDecoder 按文件扩展名（例如 ".yaml"）注册配置解码器，重复注册会覆盖之前的解码器
+// 核心库内置 .json 与 .env，YAML/TOML 由独立模块 ioc233/configfile 注册，避免引入第三方依赖
+func RegisterConfigDecoder(ext string, decode ConfigDecoder) {
+	if ext == "" || decode == nil {
+		logError("[ioc233] RegisterConfigDecoder 参数非法: ext=%q", ext)
+		return
+	}
+	configDecodersLock.Lock()
+	defer configDecodersLock.Unlock()
+	configDecoders[strings.ToLower(ext)] = decode
+}
+
+// FileConfigSource 基于配置文件的配置源，嵌套对象展开为点分 key（db.host），数组元素按下标展开（servers.0）
+// 实现 WatchableConfigSource：添加到容器后按 PollInterval 检查文件修改时间，变化时重新加载并通知容器
+type FileConfigSource struct {
+	// PollInterval 监听文件变化的轮询间隔（默认 2 秒，需在 AddConfigSource 之前设置）
+	PollInterval time.Duration
+
+	path    string
+	modTime time.Time
+
+	mutex  sync.RWMutex
+	values map[string]string
+}
+
+// LoadConfigFile 读取配置文件，按扩展名选择解码器
+//
+//	src, err := ioc233.LoadConfigFile("config/app.json")
+//	container.AddConfigSource(src)
+func LoadConfigFile(path string) (*FileConfigSource, error) {
+	f := &FileConfigSource{path: path}
+	if _, err := f.reload(); err != nil {
+		return nil, err
+	}
+	logInfo("[ioc233] 加载配置文件: %s (keys=%d)", path, len(f.values))
+	return f, nil
+}
+
+// Lookup 实现 ConfigSource
+func (f *FileConfigSource) Lookup(key string) (string, bool) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	v, ok := f.values[key]
+	return v, ok
+}
+
+// Path 配置文件路径
+func (f *FileConfigSource) Path() string {
+	return f.path
+}
+
+// Watch 实现 WatchableConfigSource（轮询文件修改时间）
+func (f *FileConfigSource) Watch(onChange func(keys []string)) (stop func()) {
+	interval := f.PollInterval
+	if interval <= 0 {
+		interval = 2 * time.Second
+	}
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				info, err := os.Stat(f.path)
+				f.mutex.RLock()
+				unchanged := err != nil || info.ModTime().Equal(f.modTime)
+				f.mutex.RUnlock()
+				if unchanged {
+					continue
+				}
+				changed, err := f.reload()
+				if err != nil {
+					logError("%s", err.Error())
+					continue
+				}
+				if len(changed) > 0 {
+					logInfo("[ioc233] 配置文件已变更: %s keys=%v", f.path, changed)
+					onChange(changed)
+				}
+			}
+		}
+	}()
+	var once sync.Once
+	return func() { once.Do(func() { close(done) }) }
+}
+
+// reload 重新读取文件，返回变化的 key
+func (f *FileConfigSource) reload() ([]string, error) {
+	ext := strings.ToLower(filepath.Ext(f.path))
+	if ext == "" && strings.HasPrefix(filepath.Base(f.path), ".env") {
+		ext = ".env"
+	}
+	configDecodersLock.RLock()
+	decode, ok := configDecoders[ext]
+	configDecodersLock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("[ioc233] 不支持的配置文件格式: %s（YAML/TOML 需导入 ioc233/configfile）", f.path)
+	}
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return nil, fmt.Errorf("[ioc233] 读取配置文件失败: %w", err)
+	}
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return nil, fmt.Errorf("[ioc233] 读取配置文件失败: %w", err)
+	}
+	tree, err := decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("[ioc233] 解析配置文件失败: %s: %w", f.path, err)
+	}
+	values := make(map[string]string)
+	flattenConfig("", reflect.ValueOf(tree), values)
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	changed := diffConfigKeys(f.values, values)
+	f.values, f.modTime = values, info.ModTime()
+	return changed, nil
+}
+
+// diffConfigKeys 返回新增、删除或值变化的 key（按字典序）
+func diffConfigKeys(old, current map[string]string) []string {
+	var keys []string
+	for k, v := range current {
+		if ov, ok := old[k]; !ok || ov != v {
+			keys = append(keys, k)
+		}
+	}
+	for k := range old {
+		if _, ok := current[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// flattenConfig 将嵌套的 map/切片展开为点分 key
+func flattenConfig(prefix string, v reflect.Value, out map[string]string) {
+	for v.Kind() == reflect.Interface || v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	join := func(k string) string {
+		if prefix == "" {
+			return k
+		}
+		return prefix + "." + k
+	}
+	switch v.Kind() {
+	case reflect.Map:
+		for _, k := range v.MapKeys() {
+			flattenConfig(join(fmt.Sprint(k.Interface())), v.MapIndex(k), out)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			flattenConfig(join(strconv.Itoa(i)), v.Index(i), out)
+		}
+	default:
+		if prefix != "" {
+			out[prefix] = fmt.Sprint(v.Interface())
+		}
+	}
+}
+
+// decodeJSONConfig 解码 JSON 配置（数字保留原始文本，避免大整数精度损失）
+func decodeJSONConfig(data []byte) (map[string]any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var tree map[string]any
+	if err := dec.Decode(&tree); err != nil {
+		return nil, err
+	}
+	return tree, nil
+}
+
+// decodeDotEnvConfig 解码 .env 文件（KEY=VALUE，支持 # 注释、export 前缀与引号）
+func decodeDotEnvConfig(data []byte) (map[string]any, error) {
+	tree := make(map[string]any)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		text = strings.TrimPrefix(text, "export ")
+		key, value, ok := strings.Cut(text, "=")
+		if !ok {
+			return nil, fmt.Errorf("第 %d 行缺少 '='", line)
+		}
+		value = strings.TrimSpace(value)
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			value = unquoted
+		} else if len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'' {
+			value = value[1 : len(value)-1]
+		}
+		tree[strings.TrimSpace(key)] = value
+	}
+	return tree, scanner.Err()
+}
+
+// BindConfig 将配置源中 prefix 下的配置绑定到结构体（target 必须为结构体指针）
+// 字段 key 取 config 标签，缺省为小写字段名；嵌套结构体递归绑定，基础类型切片按下标读取（hosts.0、hosts.1）；
+// 配置中不存在的字段保持原值（可先用 default 标签设置默认值）
+func (c *Container) BindConfig(prefix string, target any) error {
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("[ioc233] BindConfig 只支持非 nil 的结构体指针: %T", target)
+	}
+	var err error
+	c.withReadLock(func() {
+		err = c.bindConfigStruct(prefix, v.Elem())
+	})
+	return err
+}
+
+// ProvideConfig 绑定配置结构体并注册为 bean（名称为类型名，可按类型注入）
+// 配置变更（NotifyConfigChanged）涉及 prefix 下的 key 时重新绑定
+//
+//	type DBConfig struct {
+//	    Host string `config:"host" default:"localhost"`
+//	    Port int    `config:"port" default:"5432"`
+//	}
+//	_ = container.ProvideConfig("db", &DBConfig{})
+func (c *Container) ProvideConfig(prefix string, target any) error {
+	if err := c.BindConfig(prefix, target); err != nil {
+		return err
+	}
+	if err := c.Provide(target); err != nil {
+		return err
+	}
+	c.mutex.Lock()
+	c.configBindings = append(c.configBindings, configBinding{prefix: prefix, target: target})
+	c.mutex.Unlock()
+	return nil
+}
+
+// bindConfigStruct 按字段绑定配置（调用方需持有读锁）
+func (c *Container) bindConfigStruct(prefix string, v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := field.Tag.Get("config")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		key := name
+		if prefix != "" {
+			key = prefix + "." + name
+		}
+		if err := c.bindConfigValue(key, field.Type, v.Field(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bindConfigValue 绑定单个配置值（结构体递归，切片按下标读取）
+func (c *Container) bindConfigValue(key string, t reflect.Type, fv reflect.Value) error {
+	if t.Kind() == reflect.Struct && !isLiteralType(t) {
+		return c.bindConfigStruct(key, fv)
+	}
+	if t.Kind() == reflect.Slice && (t.Elem().Kind() != reflect.Struct || isLiteralType(t.Elem())) {
+		var items []reflect.Value
+		for i := 0; ; i++ {
+			raw, ok := c.lookupProperty(key + "." + strconv.Itoa(i))
+			if !ok {
+				break
+			}
+			item, err := parseLiteral(t.Elem(), raw)
+			if err != nil {
+				return fmt.Errorf("[ioc233] 配置值类型不匹配: key=%s.%d: %w", key, i, err)
+			}
+			items = append(items, item)
+		}
+		if len(items) > 0 {
+			slice := reflect.MakeSlice(t, 0, len(items))
+			fv.Set(reflect.Append(slice, items...))
+		}
+		return nil
+	}
+	raw, ok := c.lookupProperty(key)
+	if !ok {
+		return nil
+	}
+	v, err := parseLiteral(t, raw)
+	if err != nil {
+		return fmt.Errorf("[ioc233] 配置值类型不匹配: key=%s: %w", key, err)
+	}
+	fv.Set(v)
+	return nil
+}
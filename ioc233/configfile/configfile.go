@@ -0,0 +1,42 @@
+// Package configfile 为 ioc233.LoadConfigFile 注册 YAML 与 TOML 解码器
+// 独立模块，只有导入本包时才引入 yaml/toml 依赖：
+//
+//	import _ "github.com/neko233-com/ioc233-go/ioc233/configfile"
+//
+//	src, err := ioc233.LoadConfigFile("config/app.yaml")
+package configfile
+
+import (
+	"bytes"
+
+	"github.com/BurntSushi/toml"
+	"github.com/neko233-com/ioc233-go/ioc233"
+	"gopkg.in/yaml.v3"
+)
+
+func init() {
+	ioc233.RegisterConfigDecoder(".yaml", DecodeYAML)
+	ioc233.RegisterConfigDecoder(".yml", DecodeYAML)
+	ioc233.RegisterConfigDecoder(".toml", DecodeTOML)
+}
+
+// DecodeYAML 解码 YAML 配置
+func DecodeYAML(data []byte) (map[string]any, error) {
+	tree := make(map[string]any)
+	if len(bytes.TrimSpace(data)) == 0 {
+		return tree, nil
+	}
+	if err := yaml.Unmarshal(data, &tree); err != nil {
+		return nil, err
+	}
+	return tree, nil
+}
+
+// DecodeTOML 解码 TOML 配置
+func DecodeTOML(data []byte) (map[string]any, error) {
+	tree := make(map[string]any)
+	if _, err := toml.Decode(string(data), &tree); err != nil {
+		return nil, err
+	}
+	return tree, nil
+}
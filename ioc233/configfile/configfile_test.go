@@ -0,0 +1,47 @@
+package configfile_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+	_ "github.com/neko233-com/ioc233-go/ioc233/configfile"
+)
+
+type DBConfig struct {
+	Host  string   `config:"host"`
+	Port  int      `config:"port"`
+	Hosts []string `config:"replicas"`
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadConfigFile_YAMLAndTOML(t *testing.T) {
+	files := map[string]string{
+		"app.yaml": "db:\n  host: db.internal\n  port: 6543\n  replicas: [r1, r2]\n",
+		"app.toml": "[db]\nhost = \"db.internal\"\nport = 6543\nreplicas = [\"r1\", \"r2\"]\n",
+	}
+	for name, content := range files {
+		src, err := ioc233.LoadConfigFile(writeFile(t, name, content))
+		if err != nil {
+			t.Fatalf("%s: 加载应该成功, 错误: %v", name, err)
+		}
+		c := ioc233.NewContainer()
+		c.AddConfigSource(src)
+		cfg := &DBConfig{}
+		if err := c.ProvideConfig("db", cfg); err != nil {
+			t.Fatalf("%s: 绑定应该成功, 错误: %v", name, err)
+		}
+		if cfg.Host != "db.internal" || cfg.Port != 6543 || len(cfg.Hosts) != 2 || cfg.Hosts[1] != "r2" {
+			t.Errorf("%s: 绑定结果不正确: %+v", name, cfg)
+		}
+	}
+}
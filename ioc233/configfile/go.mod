@@ -0,0 +1,9 @@
+module github.com/neko233-com/ioc233-go/ioc233/configfile
+
+go 1.25
+
+require (
+	github.com/BurntSushi/toml v1.5.0
+	github.com/neko233-com/ioc233-go v0.0.1
+	gopkg.in/yaml.v3 v3.0.1
+)
@@ -0,0 +1,142 @@
+package ioc233
+
+import (
+	"reflect"
+	"strings"
+)
+
+// WatchableConfigSource 可报告变更的配置源
+// AddConfigSource 添加时开始监听，容器关闭时停止
+type WatchableConfigSource interface {
+	ConfigSource
+	// Watch 开始监听，配置变化时以变化的 key 调用 onChange；返回停止监听的函数
+	Watch(onChange func(keys []string)) (stop func())
+}
+
+// IConfigChanged 配置变更通知接口
+// 配置变化并重新解析 value 标签字段之后调用，参数为变化的 key
+type IConfigChanged interface {
+	// OnConfigChanged 配置变更后的回调方法
+	OnConfigChanged(keys []string)
+}
+
+// configBinding ProvideConfig 记录的配置绑定（配置变更时重新绑定）
+type configBinding struct {
+	prefix string
+	target any
+}
+
+// NotifyConfigChanged 通知容器配置已变化（WatchableConfigSource 自动调用；不支持监听的配置源刷新后手动调用）
+// 行为：
+// - 已启动的容器中，引用了变化 key 的 value 标签字段被重新解析并赋值
+// - ProvideConfig 绑定的配置结构体在 prefix 下有 key 变化时重新绑定
+// - 实现 IConfigChanged 的 bean 收到变化的 key 列表（在容器锁之外调用）
+//
+// 注意：字段在运行中被重新赋值，并发读取这些字段的代码需要自行同步，或在 OnConfigChanged 中拷贝使用；
+// 不要在生命周期回调中调用（会等待容器写锁）
+func (c *Container) NotifyConfigChanged(keys ...string) {
+	if len(keys) == 0 {
+		return
+	}
+	c.mutex.Lock()
+	listeners := c.refreshConfigLocked(keys)
+	c.mutex.Unlock()
+	for _, l := range listeners {
+		l.OnConfigChanged(keys)
+	}
+}
+
+// refreshConfigLocked 重新解析受影响的配置字段，返回需要通知的 bean（调用方需持有写锁）
+func (c *Container) refreshConfigLocked(keys []string) []IConfigChanged {
+	logInfo("[ioc233] 配置变更: keys=%v", keys)
+	for _, b := range c.configBindings {
+		if !configKeysUnder(keys, b.prefix) {
+			continue
+		}
+		if err := c.bindConfigStruct(b.prefix, reflect.ValueOf(b.target).Elem()); err != nil {
+			logError("%s", err.Error())
+		}
+	}
+
+	var listeners []IConfigChanged
+	for _, def := range c.beans {
+		if c.state == StateStarted {
+			c.refreshValueFieldsLocked(def.instance, keys)
+		}
+		if l, ok := def.instance.(IConfigChanged); ok {
+			listeners = append(listeners, l)
+		}
+	}
+	return listeners
+}
+
+// refreshValueFieldsLocked 重新解析引用了变化 key 的 value 标签字段
+func (c *Container) refreshValueFieldsLocked(instance any, keys []string) {
+	v := reflect.ValueOf(instance)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return
+	}
+	elem := v.Elem()
+	t := elem.Type()
+	structName := displayTypeName(t)
+	for _, field := range injectableFields(t) {
+		expr, ok := field.Tag.Lookup("value")
+		if !ok || !containsAnyKey(placeholderKeys(expr), keys) {
+			continue
+		}
+		fv := fieldByIndex(elem, field.Index, false)
+		if !fv.IsValid() || !fv.CanSet() {
+			continue
+		}
+		resolved, err := c.resolveValueTag(structName, field, expr)
+		if err != nil {
+			logError("%s", err.Error())
+			continue
+		}
+		fv.Set(resolved)
+		logInfo("[ioc233] 配置字段已更新: struct=%s field=%s", structName, field.Name)
+	}
+}
+
+// placeholderKeys 提取表达式中全部占位符的 key
+func placeholderKeys(expr string) []string {
+	var keys []string
+	for {
+		start := strings.Index(expr, "${")
+		if start < 0 {
+			return keys
+		}
+		end := strings.IndexByte(expr[start:], '}')
+		if end < 0 {
+			return keys
+		}
+		key, _, _ := strings.Cut(expr[start+2:start+end], ":")
+		keys = append(keys, strings.TrimSpace(key))
+		expr = expr[start+end+1:]
+	}
+}
+
+// containsAnyKey 判断 a 与 b 是否有相同的 key
+func containsAnyKey(a, b []string) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// configKeysUnder 判断变化的 key 中是否有位于 prefix 之下的
+func configKeysUnder(keys []string, prefix string) bool {
+	if prefix == "" {
+		return true
+	}
+	for _, k := range keys {
+		if k == prefix || strings.HasPrefix(k, prefix+".") {
+			return true
+		}
+	}
+	return false
+}
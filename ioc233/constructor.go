@@ -0,0 +1,71 @@
+package ioc233
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// RegisterConstructor 在默认容器注册类型 T 的按需构造函数（规则同 RegisterConstructorIn）
+func RegisterConstructor[T any](constructor any) error {
+	return RegisterConstructorIn[T](Default(), constructor)
+}
+
+// RegisterConstructorIn 在指定容器注册类型 T 的按需构造函数
+// 与 ProvideFactory 不同，StartUp 不会主动调用它：只有当某个字段或构造函数参数需要 T、
+// 而容器（含父容器）中没有 T 的 bean 时才调用，结果注册为单例 bean 并完成注入；
+// 构造函数自身缺失的参数同样按需构造，从而只构造依赖图中实际用到的部分
+//
+//	ioc233.RegisterConstructor[*sql.DB](func(cfg *DBConfig) (*sql.DB, error) {
+//	    return sql.Open("postgres", cfg.DSN)
+//	})
+func RegisterConstructorIn[T any](c *Container, constructor any) error {
+	target := reflect.TypeOf((*T)(nil)).Elem()
+	def, err := newFactoryDefinition("RegisterConstructor", constructor)
+	if err != nil {
+		return err
+	}
+	if !def.produces(target) {
+		return fmt.Errorf("[ioc233] RegisterConstructor 构造函数的返回值不能提供类型 %v: %v", target, def.fn.Type())
+	}
+	def.onDemand = true
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.factories = append(c.factories, def)
+	logInfo("[ioc233] 注册按需构造函数: type=%v func=%s", target, def.name)
+	return nil
+}
+
+// constructorFor 查找能提供类型 t 且尚未构造的按需构造函数
+func (c *Container) constructorFor(t reflect.Type) *factoryDefinition {
+	for _, f := range c.factories {
+		if f.onDemand && !f.built && f.produces(t) {
+			return f
+		}
+	}
+	return nil
+}
+
+// resolveByConstructor 按类型注入的字段未解析到实例时，调用能提供该类型的按需构造函数
+// 校验演练（resolveDryRun）时只判断是否存在可用的构造函数；构造需要写锁，只在 StartUp 或启动后注册的注入期间（resolveWrite）进行
+// 返回是否找到了构造函数
+func (c *Container) resolveByConstructor(structName string, field reflect.StructField, tag string, mode resolveMode) (reflect.Value, bool, error) {
+	if isNameTag(tag) || field.Type.Kind() == reflect.Slice {
+		return reflect.Value{}, false, nil
+	}
+	f := c.constructorFor(field.Type)
+	if f == nil {
+		return reflect.Value{}, false, nil
+	}
+	if mode == resolveDryRun {
+		return reflect.Value{}, true, nil
+	}
+	if mode != resolveWrite {
+		return reflect.Value{}, false, nil
+	}
+	logInfo("[ioc233] 按需构造依赖: struct=%s field=%s type=%v func=%s", structName, field.Name, field.Type, f.name)
+	if err := c.buildFactoryLocked(f, nil); err != nil {
+		return reflect.Value{}, true, err
+	}
+	v, err := c.resolveLocal(structName, field, tag, true)
+	return v, true, err
+}
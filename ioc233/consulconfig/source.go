@@ -0,0 +1,73 @@
+// Package consulconfig 基于 Consul KV 的 ioc233 配置源
+// 独立模块，只有导入本包时才引入 Consul 客户端依赖：
+//
+//	client, _ := api.NewClient(api.DefaultConfig())
+//	src, err := consulconfig.Load(client.KV(), "apps/order/")
+//	container.AddConfigSource(src)
+//
+// prefix 之后的 key 中的 / 转换为 .（apps/order/db/host -> db.host），与 value 标签中的占位符对应
+package consulconfig
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/consul/api"
+)
+
+// KV Consul KV 的最小接口（*api.KV 实现了该接口）
+type KV interface {
+	List(prefix string, q *api.QueryOptions) (api.KVPairs, *api.QueryMeta, error)
+}
+
+// Source Consul 配置源（Load 时读取 prefix 下全部 key 的快照）
+type Source struct {
+	kv     KV
+	prefix string
+
+	mutex  sync.RWMutex
+	values map[string]string
+}
+
+// Load 读取 prefix 下的全部 key 作为配置源
+func Load(kv KV, prefix string) (*Source, error) {
+	s := &Source{kv: kv, prefix: prefix}
+	if err := s.Refresh(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Refresh 重新读取 prefix 下的全部 key
+func (s *Source) Refresh() error {
+	pairs, _, err := s.kv.List(s.prefix, nil)
+	if err != nil {
+		return fmt.Errorf("[ioc233] 读取 Consul 配置失败: prefix=%s: %w", s.prefix, err)
+	}
+	values := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		if strings.HasSuffix(pair.Key, "/") {
+			continue // 目录节点
+		}
+		values[configKey(s.prefix, pair.Key)] = string(pair.Value)
+	}
+	s.mutex.Lock()
+	s.values = values
+	s.mutex.Unlock()
+	return nil
+}
+
+// Lookup 实现 ioc233.ConfigSource
+func (s *Source) Lookup(key string) (string, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	v, ok := s.values[key]
+	return v, ok
+}
+
+// configKey 去掉 prefix 并把路径分隔符转换为点分 key
+func configKey(prefix, key string) string {
+	key = strings.Trim(strings.TrimPrefix(key, prefix), "/")
+	return strings.ReplaceAll(key, "/", ".")
+}
@@ -0,0 +1,64 @@
+package consulconfig_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/consul/api"
+	"github.com/neko233-com/ioc233-go/ioc233"
+	"github.com/neko233-com/ioc233-go/ioc233/consulconfig"
+)
+
+// fakeKV 内存中的 Consul KV
+type fakeKV struct {
+	data map[string]string
+	err  error
+}
+
+func (f *fakeKV) List(prefix string, _ *api.QueryOptions) (api.KVPairs, *api.QueryMeta, error) {
+	if f.err != nil {
+		return nil, nil, f.err
+	}
+	var pairs api.KVPairs
+	for k, v := range f.data {
+		if strings.HasPrefix(k, prefix) {
+			pairs = append(pairs, &api.KVPair{Key: k, Value: []byte(v)})
+		}
+	}
+	return pairs, &api.QueryMeta{}, nil
+}
+
+type DB struct {
+	Host string `value:"${db.host}"`
+	Port int    `value:"${db.port:5432}"`
+}
+
+func TestLoad_InjectsThroughValueTags(t *testing.T) {
+	kv := &fakeKV{data: map[string]string{
+		"apps/order/":        "",
+		"apps/order/db/host": "db.internal",
+		"apps/other/db/host": "ignored",
+	}}
+	src, err := consulconfig.Load(kv, "apps/order/")
+	if err != nil {
+		t.Fatalf("加载应该成功, 错误: %v", err)
+	}
+
+	c := ioc233.NewContainer()
+	c.AddConfigSource(src)
+	db := &DB{}
+	c.Provide(db)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	if db.Host != "db.internal" || db.Port != 5432 {
+		t.Errorf("应该注入 Consul 中的配置, 实际: %+v", db)
+	}
+}
+
+func TestLoad_Error(t *testing.T) {
+	if _, err := consulconfig.Load(&fakeKV{err: errors.New("unavailable")}, "apps/"); err == nil {
+		t.Error("Consul 读取失败时应该返回错误")
+	}
+}
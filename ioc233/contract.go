@@ -0,0 +1,127 @@
+package ioc233
+
+import (
+	"errors"
+	"fmt"
+	"go/format"
+	"go/token"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// GenerateContractTests 根据依赖图生成接口契约测试桩（供 go:generate 使用）
+// 参数：
+//   - pkgPath/pkgName: 生成文件所在包的导入路径与包名（同包类型不加限定）
+//   - graph: 依赖图，通常来自装配完成（未必启动）的容器的 DependencyGraph()
+//
+// 对每条接口依赖边（含切片、负载均衡、懒加载），为当前选中的每个实现生成一个测试：
+//   - 编译期断言实现满足消费方期望的接口（接口新增/修改方法而实现未跟进时，go test 直接编译失败）
+//   - 运行期逐一核对接口方法在实现上存在，并列出缺失的方法
+//
+// 通过 profile、模块或 Swap 替换实现后重新生成，即可在 CI 中发现接口漂移；
+// 测试函数内预留了补充行为断言的位置。未导出的类型无法在其他包引用，会以注释形式跳过
+func GenerateContractTests(w io.Writer, pkgPath, pkgName string, graph *DependencyGraph) error {
+	if graph == nil || strings.TrimSpace(pkgName) == "" {
+		return errors.New("[ioc233] GenerateContractTests 参数非法")
+	}
+	g := &proxyGen{selfPath: pkgPath, imports: make(map[string]string)}
+	var body strings.Builder
+	usedNames := make(map[string]int)
+	seen := make(map[string]bool)
+	count := 0
+
+	for _, edge := range graph.Edges {
+		iface := edge.FieldType
+		if iface != nil && iface.Kind() == reflect.Slice {
+			iface = iface.Elem()
+		}
+		if iface == nil || iface.Kind() != reflect.Interface || iface.Name() == "" {
+			continue
+		}
+		for i, impl := range edge.ToTypes {
+			key := edge.From + "." + edge.Field + "|" + impl.String()
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			desc := fmt.Sprintf("%s.%s 期望 %v，当前实现 %v (bean=%s)", edge.From, edge.Field, iface, impl, edge.To[i])
+			if !referable(iface, pkgPath) || !referable(impl, pkgPath) {
+				fmt.Fprintf(&body, "\n// 跳过（类型未导出，无法在生成包中引用）: %s\n", desc)
+				continue
+			}
+			ifaceExpr, err := g.typeExpr(iface)
+			if err != nil {
+				return err
+			}
+			implExpr, err := g.typeExpr(impl)
+			if err != nil {
+				return err
+			}
+			testName := "TestContract_" + identifierFor(edge.From) + "_" + identifierFor(edge.Field)
+			if len(edge.ToTypes) > 1 {
+				testName += "_" + identifierFor(displayTypeName(impl))
+			}
+			if n := usedNames[testName]; n > 0 {
+				usedNames[testName] = n + 1
+				testName += "_" + strconv.Itoa(n+1)
+			} else {
+				usedNames[testName] = 1
+			}
+
+			fmt.Fprintf(&body, "\n// %s 契约: %s\n", testName, desc)
+			fmt.Fprintf(&body, "func %s(t *testing.T) {\n", testName)
+			fmt.Fprintf(&body, "\tvar _ %s = *new(%s)\n\n", ifaceExpr, implExpr)
+			fmt.Fprintf(&body, "\tiface := reflect.TypeOf((*%s)(nil)).Elem()\n", ifaceExpr)
+			fmt.Fprintf(&body, "\timpl := reflect.TypeOf((*%s)(nil)).Elem()\n", implExpr)
+			body.WriteString("\tfor i := 0; i < iface.NumMethod(); i++ {\n")
+			body.WriteString("\t\tif _, ok := impl.MethodByName(iface.Method(i).Name); !ok {\n")
+			body.WriteString("\t\t\tt.Errorf(\"%v 缺少 %v 期望的方法 %s\", impl, iface, iface.Method(i).Name)\n")
+			body.WriteString("\t\t}\n\t}\n")
+			fmt.Fprintf(&body, "\t// 在此补充 %s 对实现行为的期望\n}\n", edge.From)
+			count++
+		}
+	}
+
+	var out strings.Builder
+	out.WriteString("// 由 ioc233.GenerateContractTests 生成的接口契约测试桩。\n")
+	out.WriteString("// 补充行为断言后请将文件移出 go:generate 流程，避免重新生成时被覆盖。\n\n")
+	out.WriteString("package " + pkgName + "\n\n")
+	out.WriteString("import (\n")
+	if count > 0 {
+		out.WriteString("\t\"reflect\"\n\t\"testing\"\n")
+	}
+	paths := make([]string, 0, len(g.imports))
+	for p := range g.imports {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		fmt.Fprintf(&out, "\t%s %s\n", g.imports[p], strconv.Quote(p))
+	}
+	out.WriteString(")\n")
+	out.WriteString(body.String())
+
+	src, err := format.Source([]byte(out.String()))
+	if err != nil {
+		return fmt.Errorf("[ioc233] GenerateContractTests 生成代码格式化失败: %w", err)
+	}
+	_, err = w.Write(src)
+	return err
+}
+
+// referable 判断类型能否在 selfPath 包中引用（命名类型需导出或同包）
+func referable(t reflect.Type, selfPath string) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Name() == "" {
+		return true
+	}
+	if t.PkgPath() == "" || t.PkgPath() == selfPath {
+		return true
+	}
+	return token.IsExported(t.Name())
+}
@@ -0,0 +1,224 @@
+package ioc233
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// httpMethods 路由允许的 HTTP 方法（ANY 表示匹配所有方法）
+var httpMethods = map[string]bool{
+	"GET": true, "HEAD": true, "POST": true, "PUT": true, "PATCH": true,
+	"DELETE": true, "OPTIONS": true, "ANY": true,
+}
+
+// Route 控制器的路由元数据（与 HTTP 框架无关，由适配器挂载到具体框架）
+type Route struct {
+	// Method HTTP 方法（大写，ANY 表示匹配所有方法）
+	Method string
+	// Path 路径，支持 :name 路径参数与末尾的 *name 通配（例如 /users/:id、/static/*filepath）
+	Path string
+	// Handler 控制器上处理该路由的导出方法名
+	Handler string
+	// Summary 接口摘要（OpenAPI 文档使用，可选）
+	Summary string
+	// Request 请求体类型的示例值，例如 CreateUserRequest{}（OpenAPI 文档使用，可选）
+	Request any
+	// Response 响应体类型的示例值，例如 []User{}（OpenAPI 文档使用，可选）
+	Response any
+}
+
+// String 返回 "GET /users/:id -> GetUser" 形式的描述
+func (r Route) String() string {
+	return r.Method + " " + r.Path + " -> " + r.Handler
+}
+
+// IControllerGroup 控制器分组接口
+// 实现此接口的控制器的全部路由加上分组前缀（例如 /api/v1），GetControllers 按分组聚合返回
+type IControllerGroup interface {
+	// ControllerGroup 返回分组前缀，必须以 / 开头
+	ControllerGroup() string
+}
+
+// Controller 通过 ProvideController 注册的控制器及其路由
+type Controller struct {
+	// Name bean 名
+	Name string
+	// Group 分组前缀（IControllerGroup），未分组时为空
+	Group string
+	// Instance 控制器实例
+	Instance any
+	// Routes 路由（route 标签声明的在前，其次是 IWebSocketController 的端点，ProvideController 参数传入的在后）
+	Routes []Route
+}
+
+// ParseRoute 解析路由声明 "METHOD /path"，例如 "GET /users/:id"（Handler 需另行指定）
+func ParseRoute(expr string) (Route, error) {
+	method, path, ok := strings.Cut(strings.TrimSpace(expr), " ")
+	path = strings.TrimSpace(path)
+	if !ok || path == "" {
+		return Route{}, fmt.Errorf("[ioc233] 路由声明非法（应为 \"METHOD /path\"）: %q", expr)
+	}
+	return Route{Method: strings.ToUpper(method), Path: path}, nil
+}
+
+// ProvideController 注册控制器 bean 并记录其路由元数据，供 HTTP 框架适配器挂载（见 GetControllers）
+// 路由来源：结构体上的 route 标签（handler 标签指定处理方法）、IWebSocketController 的端点与 routes 参数：
+//
+//	type UserController struct {
+//		_     struct{}    `route:"GET /users/:id" handler:"Get"`
+//		_     struct{}    `route:"POST /users" handler:"Create"`
+//		Users UserService `autowire:"true"`
+//	}
+//
+// 路由字段的类型为 func(请求类型) 响应类型 时记录请求/响应类型，summary 标签记录摘要，供 OpenAPISpec 生成文档：
+//
+//	_ func(CreateUserRequest) User `route:"POST /users" handler:"Create" summary:"创建用户"`
+//
+//	c.ProvideController(&UserController{}, ioc233.Route{Method: "DELETE", Path: "/users/:id", Handler: "Delete"})
+//
+// 控制器实现 IControllerGroup 时全部路由加上分组前缀；实现 IOrdered 时参与排序（见 GetControllers）
+// 路由非法（方法未知、路径不以 / 开头、处理方法不存在）时不注册并返回错误；容器已冻结时返回 ErrContainerFrozen
+func (c *Container) ProvideController(instance any, routes ...Route) error {
+	if instance == nil {
+		return fmt.Errorf("[ioc233] ProvideController 实例不能为 nil")
+	}
+	all, err := controllerRoutes(instance, routes)
+	if err != nil {
+		return err
+	}
+	group, err := controllerGroup(instance)
+	if err != nil {
+		return err
+	}
+	for i := range all {
+		all[i].Path = group + all[i].Path
+	}
+	prepared := c.prepareBean(instance)
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if err := c.checkFrozenLocked("ProvideController", instance); err != nil {
+		return err
+	}
+	from := len(c.beans)
+	if err := c.providePreparedLocked(instance, prepared); err != nil {
+		return err
+	}
+	t := reflect.TypeOf(instance)
+	if registered, ok := c.typeToObjectMap.load(t); !ok || !sameInstance(registered, instance) {
+		// 重复类型（已记录警告）或延迟到 StartUp 的 profile 注册，不作为控制器记录
+		return nil
+	}
+	c.controllerMap[t] = instance
+	c.addControllerLocked(Controller{Name: displayTypeName(t), Group: group, Instance: instance, Routes: all})
+	for _, r := range all {
+		logInfo("[ioc233] 注册路由: %s %s", displayTypeName(t), r.String())
+	}
+	c.bindLateLocked(from)
+	return nil
+}
+
+// GetControllers 返回通过 ProvideController 注册的控制器：按分组前缀聚合（未分组的在前），
+// 组内按 IOrdered 排序，其余保持注册顺序；HTTP 适配器按此顺序挂载，结果稳定
+func (c *Container) GetControllers() []Controller {
+	var out []Controller
+	c.withReadLock(func() {
+		out = make([]Controller, len(c.controllers))
+		for i, ctrl := range c.controllers {
+			ctrl.Routes = append([]Route(nil), ctrl.Routes...)
+			out[i] = ctrl
+		}
+	})
+	return out
+}
+
+// addControllerLocked 按分组与 IOrdered 有序插入控制器，并同步 controllerList（调用方需持有写锁）
+func (c *Container) addControllerLocked(ctrl Controller) {
+	c.controllers = append(c.controllers, ctrl)
+	sort.SliceStable(c.controllers, func(i, j int) bool {
+		a, b := c.controllers[i], c.controllers[j]
+		if a.Group != b.Group {
+			return a.Group < b.Group
+		}
+		return orderOf(a.Instance) < orderOf(b.Instance)
+	})
+	c.controllerList = c.controllerList[:0]
+	for _, ctrl := range c.controllers {
+		c.controllerList = append(c.controllerList, ctrl.Instance)
+	}
+}
+
+// controllerGroup 返回规范化的分组前缀（去掉末尾的 /），未实现 IControllerGroup 时返回空
+func controllerGroup(instance any) (string, error) {
+	g, ok := instance.(IControllerGroup)
+	if !ok {
+		return "", nil
+	}
+	prefix := g.ControllerGroup()
+	if prefix != "" && !strings.HasPrefix(prefix, "/") {
+		return "", fmt.Errorf("[ioc233] 控制器分组前缀必须以 / 开头: %q (controller=%T)", prefix, instance)
+	}
+	return strings.TrimSuffix(prefix, "/"), nil
+}
+
+// controllerRoutes 汇总并校验控制器的路由：route 标签在前，参数传入的在后
+func controllerRoutes(instance any, extra []Route) ([]Route, error) {
+	var routes []Route
+	t := reflect.TypeOf(instance)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Struct {
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			expr, ok := field.Tag.Lookup("route")
+			if !ok {
+				continue
+			}
+			r, err := ParseRoute(expr)
+			if err != nil {
+				return nil, fmt.Errorf("%w (controller=%v)", err, t)
+			}
+			r.Handler = field.Tag.Get("handler")
+			r.Summary = field.Tag.Get("summary")
+			r.Request, r.Response = routeTypes(field.Type)
+			routes = append(routes, r)
+		}
+	}
+	if ws, ok := instance.(IWebSocketController); ok {
+		routes = append(routes, Route{Method: "GET", Path: ws.WebSocketPath(), Handler: "ServeWebSocket"})
+	}
+	routes = append(routes, extra...)
+
+	v := reflect.ValueOf(instance)
+	for i, r := range routes {
+		r.Method = strings.ToUpper(r.Method)
+		routes[i] = r
+		switch {
+		case !httpMethods[r.Method]:
+			return nil, fmt.Errorf("[ioc233] 路由 HTTP 方法未知: %s (controller=%v)", r.String(), t)
+		case !strings.HasPrefix(r.Path, "/"):
+			return nil, fmt.Errorf("[ioc233] 路由路径必须以 / 开头: %s (controller=%v)", r.String(), t)
+		case r.Handler == "":
+			return nil, fmt.Errorf("[ioc233] 路由缺少处理方法: %s (controller=%v)", r.String(), t)
+		case !v.MethodByName(r.Handler).IsValid():
+			return nil, fmt.Errorf("[ioc233] 路由处理方法不存在: %s (controller=%v)", r.String(), t)
+		}
+	}
+	return routes, nil
+}
+
+// routeTypes 从 func(请求类型) 响应类型 形式的路由字段类型中取出请求/响应类型的零值，其他类型返回 nil
+func routeTypes(t reflect.Type) (request, response any) {
+	if t.Kind() != reflect.Func {
+		return nil, nil
+	}
+	if t.NumIn() > 0 {
+		request = reflect.New(t.In(0)).Elem().Interface()
+	}
+	if t.NumOut() > 0 {
+		response = reflect.New(t.Out(0)).Elem().Interface()
+	}
+	return request, response
+}
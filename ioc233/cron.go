@@ -0,0 +1,183 @@
+package ioc233
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CronSchedule 解析后的 cron 表达式
+type CronSchedule struct {
+	expr                                  string
+	second, minute, hour, dom, month, dow uint64
+	// domStar/dowStar 日与星期字段是否为 *（两者都受限时按"或"匹配，与标准 cron 一致）
+	domStar, dowStar bool
+}
+
+// cron 描述符
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 0 1 1 *",
+	"@annually": "0 0 0 1 1 *",
+	"@monthly":  "0 0 0 1 * *",
+	"@weekly":   "0 0 0 * * 0",
+	"@daily":    "0 0 0 * * *",
+	"@midnight": "0 0 0 * * *",
+	"@hourly":   "0 0 * * * *",
+}
+
+var (
+	cronMonthNames = map[string]int{"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12}
+	cronDowNames = map[string]int{"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6}
+)
+
+// ParseCron 解析 cron 表达式
+// 支持：
+//   - 5 段标准格式：分 时 日 月 周（秒固定为 0）
+//   - 6 段带秒格式：秒 分 时 日 月 周
+//   - 描述符：@yearly、@monthly、@weekly、@daily、@hourly
+//
+// 每段支持 *、?、数值、区间 a-b、步长 */n 与 a-b/n、逗号列表；月与周支持英文缩写（JAN、MON），周 0 与 7 均表示周日
+func ParseCron(expr string) (*CronSchedule, error) {
+	spec := strings.TrimSpace(expr)
+	if d, ok := cronDescriptors[strings.ToLower(spec)]; ok {
+		spec = d
+	}
+	fields := strings.Fields(spec)
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, fmt.Errorf("[ioc233] cron 表达式应为 5 段或 6 段: %q", expr)
+	}
+
+	s := &CronSchedule{expr: expr}
+	var err error
+	parsers := []struct {
+		dst      *uint64
+		min, max int
+		names    map[string]int
+	}{
+		{&s.second, 0, 59, nil},
+		{&s.minute, 0, 59, nil},
+		{&s.hour, 0, 23, nil},
+		{&s.dom, 1, 31, nil},
+		{&s.month, 1, 12, cronMonthNames},
+		{&s.dow, 0, 7, cronDowNames},
+	}
+	for i, p := range parsers {
+		if *p.dst, err = parseCronField(fields[i], p.min, p.max, p.names); err != nil {
+			return nil, fmt.Errorf("[ioc233] cron 表达式 %q 第 %d 段非法: %w", expr, i+1, err)
+		}
+	}
+	// 周日既可写 0 也可写 7
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	s.domStar = isCronStar(fields[3])
+	s.dowStar = isCronStar(fields[5])
+	return s, nil
+}
+
+// String 返回原始表达式
+func (s *CronSchedule) String() string {
+	return s.expr
+}
+
+// Next 返回严格晚于 t 的下一个触发时间（按 t 的时区计算），5 年内无匹配时返回零值
+func (s *CronSchedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Add(time.Second - time.Duration(t.Nanosecond()))
+	limit := t.Year() + 5
+	for t.Year() <= limit {
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !s.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute()+1, 0, 0, loc)
+		case s.second&(1<<uint(t.Second())) == 0:
+			t = t.Add(time.Second)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// dayMatches 日与星期匹配：任一字段为 * 时两者同时满足，否则满足其一即可
+func (s *CronSchedule) dayMatches(t time.Time) bool {
+	domOK := s.dom&(1<<uint(t.Day())) != 0
+	dowOK := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domOK && dowOK
+	}
+	return domOK || dowOK
+}
+
+// isCronStar 判断字段是否不受限
+func isCronStar(field string) bool {
+	return field == "*" || field == "?"
+}
+
+// parseCronField 解析单个字段为位集合
+func parseCronField(field string, min, max int, names map[string]int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.IndexByte(part, '/'); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("步长非法: %q", part)
+			}
+			rangePart, step = part[:i], n
+		}
+
+		lo, hi := min, max
+		switch {
+		case isCronStar(rangePart):
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if lo, err = cronValue(bounds[0], names); err != nil {
+				return 0, err
+			}
+			if hi, err = cronValue(bounds[1], names); err != nil {
+				return 0, err
+			}
+		default:
+			v, err := cronValue(rangePart, names)
+			if err != nil {
+				return 0, err
+			}
+			lo, hi = v, v
+			if step > 1 {
+				hi = max
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return 0, fmt.Errorf("取值超出范围 [%d, %d]: %q", min, max, part)
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+// cronValue 解析数值或英文缩写
+func cronValue(s string, names map[string]int) (int, error) {
+	if v, ok := names[strings.ToUpper(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("取值非法: %q", s)
+	}
+	return v, nil
+}
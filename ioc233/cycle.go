@@ -0,0 +1,357 @@
+package ioc233
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// DependencyCycle 依赖环
+type DependencyCycle struct {
+	// Chain 环上的提供者（bean 名或构造函数产物名），首尾相同，例如 [A B C A]
+	Chain []string
+	// Fatal 是否为致命环：环上每一步都必须在依赖就绪之后才能创建（构造函数参数、派生函数参数、原型）
+	// 环上只要有一步是单例的字段注入（或懒加载注入），容器就可以先创建对象再回填字段，属于安全环
+	Fatal bool
+}
+
+// String 返回 "A -> B -> C -> A" 形式的描述
+func (d DependencyCycle) String() string {
+	return strings.Join(d.Chain, " -> ")
+}
+
+// CycleError StartUp 检测到致命依赖环时返回的错误
+type CycleError struct {
+	Cycles []DependencyCycle
+}
+
+// Error 实现 error 接口
+func (e *CycleError) Error() string {
+	chains := make([]string, 0, len(e.Cycles))
+	for _, cycle := range e.Cycles {
+		chains = append(chains, cycle.String())
+	}
+	return "[ioc233] 构造函数循环依赖: " + strings.Join(chains, "; ")
+}
+
+// DependencyCycles 检测容器注册的依赖环（不执行注入，不调用构造函数）
+// 依赖图覆盖 bean 的 autowire 字段、构造函数/派生函数/原型工厂的参数；
+// 致命环排在前面，StartUp 遇到致命环时返回 *CycleError，安全环记录到 StartupReport.FieldCycles
+func (c *Container) DependencyCycles() []DependencyCycle {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.dependencyCyclesLocked()
+}
+
+// cycleNode 依赖图节点（一个提供者）
+type cycleNode struct {
+	name string
+	// singleton 为 false 表示原型：字段注入也发生在创建过程中
+	singleton bool
+	edges     []cycleEdge
+}
+
+// cycleEdge 依赖边；breaks 为 true 表示对象创建之后才回填（单例字段注入、懒加载）
+type cycleEdge struct {
+	to     int
+	breaks bool
+}
+
+// cycleGraph 类型级依赖图（不依赖构造函数是否已调用）
+type cycleGraph struct {
+	c     *Container
+	nodes []*cycleNode
+	// 各类提供者对应的节点下标
+	beans      map[*beanDefinition]int
+	factories  map[*factoryDefinition]int
+	derived    map[*derivedDefinition]int
+	prototypes map[*prototypeDefinition]int
+}
+
+// dependencyCyclesLocked 构建依赖图并找出依赖环（调用方需持有锁）
+func (c *Container) dependencyCyclesLocked() []DependencyCycle {
+	g := &cycleGraph{
+		c:          c,
+		beans:      make(map[*beanDefinition]int),
+		factories:  make(map[*factoryDefinition]int),
+		derived:    make(map[*derivedDefinition]int),
+		prototypes: make(map[*prototypeDefinition]int),
+	}
+	for _, def := range c.beans {
+		g.beans[def] = g.add(def.name, true)
+	}
+	for _, f := range c.factories {
+		if !f.built {
+			g.factories[f] = g.add(displayTypeName(f.outs[0]), true)
+		}
+	}
+	for _, d := range c.derivedList {
+		if !d.computed {
+			g.derived[d] = g.add(displayTypeName(d.out), true)
+		}
+	}
+	for _, p := range c.prototypes {
+		g.prototypes[p] = g.add(p.name, false)
+	}
+
+	// 按注册顺序添加边，保证输出稳定
+	for _, def := range c.beans {
+		g.addFieldEdges(g.beans[def], def.typ)
+	}
+	for _, f := range c.factories {
+		if i, ok := g.factories[f]; ok {
+			for _, in := range f.in {
+				g.addParamEdge(i, in)
+			}
+			for _, out := range f.outs {
+				g.addFieldEdges(i, out)
+			}
+		}
+	}
+	for _, d := range c.derivedList {
+		if i, ok := g.derived[d]; ok {
+			for _, in := range d.in {
+				g.addParamEdge(i, in)
+			}
+		}
+	}
+	for _, p := range c.prototypes {
+		i := g.prototypes[p]
+		for _, in := range p.in {
+			g.addParamEdge(i, in)
+		}
+		g.addFieldEdges(i, p.out)
+	}
+
+	// 先在只含"创建前必须就绪"边的子图中找致命环，再在完整图中找其余的安全环
+	var cycles []DependencyCycle
+	inFatal := make(map[int]bool)
+	for _, scc := range g.components(false) {
+		cycles = append(cycles, DependencyCycle{Chain: g.cycleIn(scc, false), Fatal: true})
+		for _, n := range scc {
+			inFatal[n] = true
+		}
+	}
+	for _, scc := range g.components(true) {
+		if !inFatal[scc[0]] {
+			cycles = append(cycles, DependencyCycle{Chain: g.cycleIn(scc, true)})
+		}
+	}
+	return cycles
+}
+
+// add 添加节点并返回下标
+func (g *cycleGraph) add(name string, singleton bool) int {
+	g.nodes = append(g.nodes, &cycleNode{name: name, singleton: singleton})
+	return len(g.nodes) - 1
+}
+
+// addParamEdge 添加函数参数边（参数必须在调用前就绪）
+func (g *cycleGraph) addParamEdge(from int, in reflect.Type) {
+	if in == contextType || in == eventBusType {
+		return
+	}
+	if embedsDigMarker(in, "In") {
+		for i := 0; i < in.NumField(); i++ {
+			field := in.Field(i)
+			if !field.IsExported() || field.Anonymous || field.Tag.Get("group") != "" {
+				continue
+			}
+			if name := field.Tag.Get("name"); name != "" {
+				g.link(from, g.providerByName(name), false)
+			} else {
+				g.link(from, g.providerByType(field.Type), false)
+			}
+		}
+		return
+	}
+	if in.Kind() == reflect.Slice {
+		return
+	}
+	g.link(from, g.providerByType(in), false)
+}
+
+// addFieldEdges 添加 autowire 字段边；切片与负载均衡注入不参与环检测
+func (g *cycleGraph) addFieldEdges(from int, t reflect.Type) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return
+	}
+	breaks := g.nodes[from].singleton
+	for _, field := range injectableFields(t) {
+		tag := autowireTag(field)
+		if tag == "" || !field.IsExported() || field.Type == contextType || field.Type == eventBusType || field.Tag.Get("balance") != "" {
+			continue
+		}
+		fieldType, lazy := field.Type, false
+		if lb, ok := reflect.New(fieldType).Interface().(lazyBinder); ok {
+			fieldType, lazy = lb.lazyTarget(), true
+		} else if field.Tag.Get("lazy") == "true" && fieldType.Kind() == reflect.Interface {
+			lazy = true
+		}
+		switch {
+		case isNameTag(tag):
+			g.link(from, g.providerByName(tag), breaks || lazy)
+		case fieldType.Kind() != reflect.Slice:
+			g.link(from, g.providerByType(fieldType), breaks || lazy)
+		}
+	}
+}
+
+// link 添加边（to 为 -1 表示依赖未注册，由注入阶段报告）
+func (g *cycleGraph) link(from, to int, breaks bool) {
+	if to >= 0 {
+		g.nodes[from].edges = append(g.nodes[from].edges, cycleEdge{to: to, breaks: breaks})
+	}
+}
+
+// providerByType 按类型查找提供者：单例 bean 优先，其次构造函数、派生函数，最后原型
+func (g *cycleGraph) providerByType(t reflect.Type) int {
+	if impl, ok := g.c.bindings[t]; ok {
+		t = impl
+	}
+	matches := func(out reflect.Type) bool {
+		return out == t || out.AssignableTo(t) || (t.Kind() == reflect.Interface && implementsInterface(out, t))
+	}
+	for _, def := range g.c.beans {
+		if matches(def.typ) {
+			return g.beans[def]
+		}
+	}
+	for _, f := range g.c.factories {
+		if i, ok := g.factories[f]; ok && f.produces(t) {
+			return i
+		}
+	}
+	for _, d := range g.c.derivedList {
+		if i, ok := g.derived[d]; ok && matches(d.out) {
+			return i
+		}
+	}
+	if p := g.c.findPrototype(t); p != nil {
+		return g.prototypes[p]
+	}
+	return -1
+}
+
+// providerByName 按 bean 名查找提供者
+func (g *cycleGraph) providerByName(name string) int {
+	for _, def := range g.c.beans {
+		if def.name == name {
+			return g.beans[def]
+		}
+	}
+	for _, f := range g.c.factories {
+		if i, ok := g.factories[f]; ok && f.producesName(name) {
+			return i
+		}
+	}
+	if p := g.c.findPrototypeByName(name); p != nil {
+		return g.prototypes[p]
+	}
+	return -1
+}
+
+// components 返回含环的强连通分量（Tarjan 算法），withBreaks 为 false 时忽略可回填的边
+// 分量内的节点按下标排序，分量按最小下标排序，保证输出稳定
+func (g *cycleGraph) components(withBreaks bool) [][]int {
+	index := make([]int, len(g.nodes))
+	low := make([]int, len(g.nodes))
+	onStack := make([]bool, len(g.nodes))
+	for i := range index {
+		index[i] = -1
+	}
+	var (
+		stack  []int
+		result [][]int
+		next   int
+	)
+	var visit func(v int)
+	visit = func(v int) {
+		index[v], low[v] = next, next
+		next++
+		stack = append(stack, v)
+		onStack[v] = true
+		selfLoop := false
+		for _, e := range g.nodes[v].edges {
+			if e.breaks && !withBreaks {
+				continue
+			}
+			switch {
+			case e.to == v:
+				selfLoop = true
+			case index[e.to] < 0:
+				visit(e.to)
+				low[v] = min(low[v], low[e.to])
+			case onStack[e.to]:
+				low[v] = min(low[v], index[e.to])
+			}
+		}
+		if low[v] != index[v] {
+			return
+		}
+		var scc []int
+		for {
+			w := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[w] = false
+			scc = append(scc, w)
+			if w == v {
+				break
+			}
+		}
+		if len(scc) > 1 || selfLoop {
+			result = append(result, scc)
+		}
+	}
+	for v := range g.nodes {
+		if index[v] < 0 {
+			visit(v)
+		}
+	}
+	for _, scc := range result {
+		sort.Ints(scc)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i][0] < result[j][0] })
+	return result
+}
+
+// cycleIn 在强连通分量内找出从最小下标节点出发的最短环
+func (g *cycleGraph) cycleIn(scc []int, withBreaks bool) []string {
+	inSCC := make(map[int]bool, len(scc))
+	for _, n := range scc {
+		inSCC[n] = true
+	}
+	start := scc[0]
+	prev := map[int]int{}
+	queue := []int{start}
+	end := -1
+	for len(queue) > 0 && end < 0 {
+		v := queue[0]
+		queue = queue[1:]
+		for _, e := range g.nodes[v].edges {
+			if (e.breaks && !withBreaks) || !inSCC[e.to] {
+				continue
+			}
+			if e.to == start {
+				end = v
+				break
+			}
+			if _, seen := prev[e.to]; !seen {
+				prev[e.to] = v
+				queue = append(queue, e.to)
+			}
+		}
+	}
+	chain := []string{g.nodes[start].name}
+	for v := end; v != start; v = prev[v] {
+		chain = append(chain, g.nodes[v].name)
+	}
+	// 回溯得到的是逆序路径（不含首节点），翻转后首尾补上起点
+	for i, j := 1, len(chain)-1; i < j; i, j = i+1, j-1 {
+		chain[i], chain[j] = chain[j], chain[i]
+	}
+	return append(chain, g.nodes[start].name)
+}
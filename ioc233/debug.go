@@ -0,0 +1,142 @@
+package ioc233
+
+import (
+	_ "embed"
+	"encoding/json"
+	"net/http"
+	"path"
+	"time"
+)
+
+// DebugPath DebugHandler 建议挂载的路径前缀（与 /debug/pprof/ 一致的约定）
+const DebugPath = "/debug/ioc233/"
+
+// dashboardHTML 内嵌的调试面板（DebugHandler 的根路径）
+//
+//go:embed dashboard.html
+var dashboardHTML []byte
+
+// debugErrors /errors 端点的输出
+type debugErrors struct {
+	State string `json:"state"`
+	// StartupError 最近一次 StartUp 返回的错误
+	StartupError string `json:"startupError,omitempty"`
+	// Validation 装配校验错误（见 Validate，包含启动前的致命错误）
+	Validation []string `json:"validation,omitempty"`
+	// Cycles 依赖环（见 DependencyCycles）
+	Cycles []debugCycle `json:"cycles,omitempty"`
+	// NilFields 注入后仍为 nil 的字段（需开启 SetNilFieldScan）
+	NilFields []string `json:"nilFields,omitempty"`
+}
+
+// debugStatus /status 端点的输出
+type debugStatus struct {
+	State     string     `json:"state"`
+	Profiles  []string   `json:"profiles,omitempty"`
+	BeanCount int        `json:"beanCount"`
+	StartedAt *time.Time `json:"startedAt,omitempty"`
+	// Duration 启动耗时（纳秒）
+	Duration time.Duration `json:"duration,omitempty"`
+	// Timings 每个 bean 的启动耗时（按耗时降序）
+	Timings []debugTiming `json:"timings,omitempty"`
+}
+
+// debugTiming /status 端点中的 bean 启动耗时（纳秒）
+type debugTiming struct {
+	Bean      string        `json:"bean"`
+	Type      string        `json:"type"`
+	Construct time.Duration `json:"construct"`
+	Inject    time.Duration `json:"inject"`
+	Callbacks time.Duration `json:"callbacks"`
+	Total     time.Duration `json:"total"`
+}
+
+// debugCycle /errors 端点中的依赖环
+type debugCycle struct {
+	Chain string `json:"chain"`
+	Fatal bool   `json:"fatal"`
+}
+
+// DebugHandler 返回检查运行中容器的 http.Handler（类似 expvar/pprof，建议只挂在调试端口）
+// 按请求路径的最后一段分发，均为 GET 并输出 JSON：
+//
+//	beans   bean 列表与每个 autowire 字段的注入结果（见 Dump）
+//	graph   依赖图（见 DependencyGraph）
+//	status  容器状态与每个 bean 的启动耗时
+//	errors  启动错误、装配校验错误、依赖环与 nil 字段
+//
+// 其他路径（例如 /debug/ioc233/）返回内嵌的 HTML 调试面板，交互式展示以上内容：
+//
+//	mux.Handle(ioc233.DebugPath, ioc233.DebugHandler(container))
+func DebugHandler(c *Container) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		var body any
+		switch path.Base(r.URL.Path) {
+		case "beans":
+			body = c.Dump().Beans
+		case "graph":
+			body = c.DependencyGraph()
+		case "status":
+			body = c.debugStatus()
+		case "errors":
+			body = c.debugErrors()
+		default:
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			if _, err := w.Write(dashboardHTML); err != nil {
+				logError("[ioc233] 输出调试面板失败: %v", err)
+			}
+			return
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(body); err != nil {
+			logError("[ioc233] 输出调试信息失败: %v", err)
+		}
+	})
+}
+
+// debugStatus 汇总容器状态与启动耗时
+func (c *Container) debugStatus() *debugStatus {
+	profiles := c.ActiveProfiles()
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	out := &debugStatus{State: c.state.String(), Profiles: profiles, BeanCount: len(c.beans)}
+	if c.report != nil {
+		startedAt := c.report.StartedAt
+		out.StartedAt, out.Duration = &startedAt, c.report.Duration
+		for _, t := range c.report.SlowestBeans(0) {
+			out.Timings = append(out.Timings, debugTiming{
+				Bean: t.Bean, Type: t.Type, Construct: t.Construct, Inject: t.Inject, Callbacks: t.Callbacks, Total: t.Total(),
+			})
+		}
+	}
+	return out
+}
+
+// debugErrors 汇总容器当前的错误
+func (c *Container) debugErrors() *debugErrors {
+	out := &debugErrors{}
+	for _, err := range c.Validate() {
+		out.Validation = append(out.Validation, err.Error())
+	}
+	for _, cycle := range c.DependencyCycles() {
+		out.Cycles = append(out.Cycles, debugCycle{Chain: cycle.String(), Fatal: cycle.Fatal})
+	}
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	out.State = c.state.String()
+	if c.startupErr != nil {
+		out.StartupError = c.startupErr.Error()
+	}
+	if c.report != nil {
+		for _, issue := range c.report.NilFields {
+			out.NilFields = append(out.NilFields, issue.String())
+		}
+	}
+	return out
+}
@@ -0,0 +1,78 @@
+package ioc233
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// dependsOnOf 返回 bean 声明的启动依赖（bean 名）：IDependsOn 接口与结构体 dependsOn 标签合并
+func dependsOnOf(def *beanDefinition) []string {
+	var names []string
+	if obj, ok := def.instance.(IDependsOn); ok {
+		names = append(names, obj.DependsOn()...)
+	}
+	t := reflect.TypeOf(def.instance)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t != nil && t.Kind() == reflect.Struct {
+		names = append(names, structMetaOf(t).dependsOn...)
+	}
+	return names
+}
+
+// startupOrderLocked 计算 StartUp 的注入顺序：按阶段分组，组内按 dependsOn 拓扑排序（没有依赖关系的 bean 保持注册顺序）
+// 依赖未注册的 bean、依赖更晚阶段的 bean 或存在循环依赖时返回错误
+func (c *Container) startupOrderLocked(ranks map[*beanDefinition]int) ([][]*beanDefinition, error) {
+	byName := make(map[string]*beanDefinition, len(c.beans))
+	for _, def := range c.beans {
+		if _, exists := byName[def.name]; !exists {
+			byName[def.name] = def
+		}
+	}
+	order := make([][]*beanDefinition, len(c.phases)+1)
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[*beanDefinition]int, len(c.beans))
+	var visit func(def *beanDefinition, path []string) error
+	visit = func(def *beanDefinition, path []string) error {
+		switch state[def] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("[ioc233] dependsOn 循环依赖: %s -> %s", strings.Join(path, " -> "), def.name)
+		}
+		state[def] = visiting
+		path = append(path, def.name)
+		for _, name := range dependsOnOf(def) {
+			dep, ok := byName[name]
+			if !ok {
+				return fmt.Errorf("[ioc233] dependsOn 依赖的 bean 未注册: bean=%s dependsOn=%s", def.name, name)
+			}
+			if ranks[dep] > ranks[def] {
+				return fmt.Errorf("[ioc233] dependsOn 依赖的 bean 属于更晚的启动阶段: bean=%s(phase=%s) dependsOn=%s(phase=%s)",
+					def.name, c.phaseName(ranks[def]), name, c.phaseName(ranks[dep]))
+			}
+			if ranks[dep] < ranks[def] {
+				// 更早阶段的 bean 必然先完成
+				continue
+			}
+			if err := visit(dep, path); err != nil {
+				return err
+			}
+		}
+		state[def] = visited
+		order[ranks[def]] = append(order[ranks[def]], def)
+		return nil
+	}
+	for _, def := range c.beans {
+		if err := visit(def, nil); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
@@ -0,0 +1,159 @@
+package ioc233
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// derivedDefinition 派生 bean 定义：输出 bean 是若干输入 bean 的纯函数
+type derivedDefinition struct {
+	fn       reflect.Value
+	in       []reflect.Type
+	out      reflect.Type
+	name     string // 函数名（用于日志与错误信息）
+	computed bool
+}
+
+// consumes 判断派生函数是否以类型 t 的 bean 作为输入
+func (d *derivedDefinition) consumes(t reflect.Type) bool {
+	for _, in := range d.in {
+		if in == t || (in.Kind() == reflect.Interface && implementsInterface(t, in)) {
+			return true
+		}
+	}
+	return false
+}
+
+// ProvideDerived 注册派生 bean（计算型提供器）
+// fn 形如 func(a A, b B) C：参数按类型从容器解析，返回值作为 bean 注册（bean 名为 C 的类型名）
+// 说明：
+// - StartUp 时（注入之前）计算；StartUp 之后注册则立即计算
+// - 任一输入 bean 通过 Swap 替换后，C 会被重新计算并替换，依赖 C 的字段随之重新注入
+// - 派生函数之间可以相互依赖，但不能以自身输出作为输入
+func (c *Container) ProvideDerived(fn any) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if err := c.checkFrozenLocked("ProvideDerived", fn); err != nil {
+		return err
+	}
+
+	fv := reflect.ValueOf(fn)
+	if fn == nil || fv.Kind() != reflect.Func {
+		return errors.New("[ioc233] ProvideDerived 参数必须是函数")
+	}
+	ft := fv.Type()
+	if ft.NumOut() != 1 {
+		return fmt.Errorf("[ioc233] ProvideDerived 函数必须且只能返回一个值: %v", ft)
+	}
+	def := &derivedDefinition{
+		fn:   fv,
+		in:   make([]reflect.Type, 0, ft.NumIn()),
+		out:  ft.Out(0),
+		name: funcName(fv),
+	}
+	for i := 0; i < ft.NumIn(); i++ {
+		def.in = append(def.in, ft.In(i))
+	}
+	if def.consumes(def.out) {
+		return fmt.Errorf("[ioc233] ProvideDerived 函数不能以自身输出作为输入: %s", def.name)
+	}
+	c.derivedList = append(c.derivedList, def)
+	logInfo("[ioc233] 注册派生 bean: func=%s out=%v", def.name, def.out)
+
+	if c.state == StateStarted {
+		return c.computeDerivedOneLocked(def)
+	}
+	return nil
+}
+
+// computeDerivedLocked 计算所有尚未计算的派生 bean
+// 派生函数之间可能存在依赖，因此循环计算直到没有进展；仍无法计算的视为缺失输入
+func (c *Container) computeDerivedLocked() error {
+	for {
+		progress := false
+		var pending []*derivedDefinition
+		for _, def := range c.derivedList {
+			if def.computed {
+				continue
+			}
+			if _, ok := c.resolveDerivedArgs(def); !ok {
+				pending = append(pending, def)
+				continue
+			}
+			if err := c.computeDerivedOneLocked(def); err != nil {
+				return err
+			}
+			progress = true
+		}
+		if len(pending) == 0 {
+			return nil
+		}
+		if !progress {
+			names := make([]string, 0, len(pending))
+			for _, def := range pending {
+				names = append(names, def.name)
+			}
+			return fmt.Errorf("[ioc233] 派生 bean 缺少输入依赖，无法计算: %s", strings.Join(names, ", "))
+		}
+	}
+}
+
+// resolveDerivedArgs 按类型解析派生函数的全部参数
+func (c *Container) resolveDerivedArgs(def *derivedDefinition) ([]reflect.Value, bool) {
+	args := make([]reflect.Value, 0, len(def.in))
+	for _, in := range def.in {
+		v, ok := c.resolveByType(in)
+		if !ok {
+			return nil, false
+		}
+		args = append(args, v)
+	}
+	return args, true
+}
+
+// computeDerivedOneLocked 计算（或重新计算）单个派生 bean 并注册/替换其结果
+func (c *Container) computeDerivedOneLocked(def *derivedDefinition) error {
+	args, ok := c.resolveDerivedArgs(def)
+	if !ok {
+		return fmt.Errorf("[ioc233] 派生 bean 缺少输入依赖: func=%s in=%v", def.name, def.in)
+	}
+	out := def.fn.Call(args)[0]
+	if isNilValue(out) {
+		return fmt.Errorf("[ioc233] 派生函数返回了 nil: func=%s", def.name)
+	}
+	instance := out.Interface()
+
+	if def.computed {
+		logInfo("[ioc233] 重新计算派生 bean: func=%s out=%v", def.name, def.out)
+		return c.swapLocked(instance)
+	}
+	def.computed = true
+	from := len(c.beans)
+	c.provideLocked(instance)
+	// 启动后计算的派生 bean 与 Provide 一致，立即注入并执行完整生命周期
+	c.bindLateLocked(from)
+	return nil
+}
+
+// isNilValue 判断反射值是否为 nil（仅对可为 nil 的类型有效）
+func isNilValue(v reflect.Value) bool {
+	if !v.IsValid() {
+		return true
+	}
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+		return v.IsNil()
+	}
+	return false
+}
+
+// funcName 返回函数的完整名称（匿名函数返回编译器生成的名称）
+func funcName(fn reflect.Value) string {
+	if f := runtime.FuncForPC(fn.Pointer()); f != nil {
+		return f.Name()
+	}
+	return fn.Type().String()
+}
@@ -0,0 +1,179 @@
+package ioc233
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DiagnosticLevel 诊断条目级别
+type DiagnosticLevel string
+
+const (
+	// DiagnosticInfo 信息
+	DiagnosticInfo DiagnosticLevel = "info"
+	// DiagnosticWarn 警告
+	DiagnosticWarn DiagnosticLevel = "warn"
+	// DiagnosticError 错误
+	DiagnosticError DiagnosticLevel = "error"
+)
+
+// DiagnosticItem 诊断条目
+type DiagnosticItem struct {
+	Level DiagnosticLevel `json:"level"`
+	Key   string          `json:"key"`
+	Value string          `json:"value"`
+}
+
+// DiagnosticSection 一组诊断条目（例如启动摘要、装配校验）
+type DiagnosticSection struct {
+	// Name 机器可读的名称（summary、validation、report、scheduler）
+	Name string `json:"name"`
+	// Title 展示标题
+	Title string           `json:"title"`
+	Items []DiagnosticItem `json:"items"`
+}
+
+// Level 返回组内最严重的级别
+func (s DiagnosticSection) Level() DiagnosticLevel {
+	level := DiagnosticInfo
+	for _, item := range s.Items {
+		switch {
+		case item.Level == DiagnosticError:
+			return DiagnosticError
+		case item.Level == DiagnosticWarn:
+			level = DiagnosticWarn
+		}
+	}
+	return level
+}
+
+// add 追加条目
+func (s *DiagnosticSection) add(level DiagnosticLevel, key, value string) {
+	s.Items = append(s.Items, DiagnosticItem{Level: level, Key: key, Value: value})
+}
+
+// DiagnosticRenderer 生成一组诊断数据（只返回结构化数据，不输出日志）
+// 宿主框架可以组合内置渲染器与自定义渲染器，合并到自己的启动输出或健康检查页面
+type DiagnosticRenderer func(c *Container) DiagnosticSection
+
+// DefaultDiagnostics Diagnostics 未指定渲染器时使用的内置渲染器
+var DefaultDiagnostics = []DiagnosticRenderer{SummaryDiagnostics, ValidationDiagnostics, ReportDiagnostics, SchedulerDiagnostics}
+
+// Diagnostics 依次执行渲染器并返回诊断数据（未指定时使用 DefaultDiagnostics）
+// 空的分组会被省略
+func (c *Container) Diagnostics(renderers ...DiagnosticRenderer) []DiagnosticSection {
+	if len(renderers) == 0 {
+		renderers = DefaultDiagnostics
+	}
+	sections := make([]DiagnosticSection, 0, len(renderers))
+	for _, render := range renderers {
+		if section := render(c); len(section.Items) > 0 {
+			sections = append(sections, section)
+		}
+	}
+	return sections
+}
+
+// SummaryDiagnostics 启动摘要：状态、bean 数量、profile、启动耗时
+func SummaryDiagnostics(c *Container) DiagnosticSection {
+	s := DiagnosticSection{Name: "summary", Title: "ioc233 容器"}
+	profiles := c.ActiveProfiles()
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	level := DiagnosticInfo
+	if c.state == StateFailed {
+		level = DiagnosticError
+	}
+	s.add(level, "state", c.state.String())
+	s.add(DiagnosticInfo, "beans", fmt.Sprint(len(c.beans)))
+	if len(c.prototypes) > 0 {
+		s.add(DiagnosticInfo, "prototypes", fmt.Sprint(len(c.prototypes)))
+	}
+	s.add(DiagnosticInfo, "profiles", strings.Join(profiles, ","))
+	if c.report != nil {
+		s.add(DiagnosticInfo, "startedAt", c.report.StartedAt.Format(time.RFC3339))
+		s.add(DiagnosticInfo, "startupDuration", c.report.Duration.String())
+	}
+	return s
+}
+
+// ValidationDiagnostics 装配校验结果（见 Validate）
+func ValidationDiagnostics(c *Container) DiagnosticSection {
+	s := DiagnosticSection{Name: "validation", Title: "装配校验"}
+	for _, err := range c.Validate() {
+		s.add(DiagnosticError, "error", err.Error())
+	}
+	return s
+}
+
+// ReportDiagnostics 启动报告：注入后仍为 nil 的字段（见 SetNilFieldScan）、最慢的 bean 与字段注入循环依赖
+func ReportDiagnostics(c *Container) DiagnosticSection {
+	s := DiagnosticSection{Name: "report", Title: "启动报告"}
+	if report := c.StartupReport(); report != nil {
+		for _, issue := range report.NilFields {
+			s.add(DiagnosticWarn, issue.Struct+"."+issue.Field, issue.Type+" → "+issue.Reason)
+		}
+		for _, timing := range report.SlowestBeans(slowestBeansInReport) {
+			s.add(DiagnosticInfo, "slowBean", timing.String())
+		}
+		for _, cycle := range report.FieldCycles {
+			s.add(DiagnosticInfo, "fieldCycle", cycle.String())
+		}
+	}
+	return s
+}
+
+// SchedulerDiagnostics 调度任务状态（未启用调度器时为空）
+func SchedulerDiagnostics(c *Container) DiagnosticSection {
+	s := DiagnosticSection{Name: "scheduler", Title: "任务调度"}
+	c.mutex.RLock()
+	scheduler := c.scheduler
+	c.mutex.RUnlock()
+	if scheduler == nil {
+		return s
+	}
+	for _, job := range scheduler.Jobs() {
+		level, value := DiagnosticInfo, job.Schedule
+		switch {
+		case job.LastError != "":
+			level, value = DiagnosticWarn, value+" lastError="+job.LastError
+		case job.Missed > 0:
+			level, value = DiagnosticWarn, fmt.Sprintf("%s missed=%d", value, job.Missed)
+		}
+		if job.Paused {
+			value += " (paused)"
+		}
+		s.add(level, job.Name, value)
+	}
+	return s
+}
+
+// FormatDiagnostics 将诊断数据格式化为多行文本（纯函数，不输出日志）
+func FormatDiagnostics(sections []DiagnosticSection) string {
+	var b strings.Builder
+	for i, section := range sections {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "== %s ==\n", section.Title)
+		for _, item := range section.Items {
+			marker := " "
+			switch item.Level {
+			case DiagnosticWarn:
+				marker = "!"
+			case DiagnosticError:
+				marker = "x"
+			}
+			fmt.Fprintf(&b, "%s %s: %s\n", marker, item.Key, item.Value)
+		}
+	}
+	return b.String()
+}
+
+// SetQuietStartup 关闭 StartUp 自身输出的启动横幅与启动报告日志（嵌入其他框架时由宿主通过 Diagnostics 统一输出）
+func (c *Container) SetQuietStartup(quiet bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.quietStartup = quiet
+}
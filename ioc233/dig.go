@@ -0,0 +1,200 @@
+package ioc233
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// dig/fx 兼容：识别 go.uber.org/dig 的参数对象（嵌入 dig.In / fx.In）与结果对象（嵌入 dig.Out / fx.Out），
+// 通过反射按包路径识别，ioc233 本身不依赖 dig/fx
+
+// In 参数对象标记，与 dig.In 等价（不引入 dig 时使用）
+type In struct{}
+
+// Out 结果对象标记，与 dig.Out 等价（不引入 dig 时使用）
+type Out struct{}
+
+// digPackages 标记类型所在的包（fx.In/fx.Out 是 dig.In/dig.Out 的别名）
+var digPackages = map[string]bool{
+	"go.uber.org/dig":              true,
+	"go.uber.org/fx":               true,
+	reflect.TypeOf(In{}).PkgPath(): true,
+}
+
+// embedsDigMarker 判断结构体是否嵌入了 In 或 Out 标记
+func embedsDigMarker(t reflect.Type, marker string) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous && isDigMarker(f.Type, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// isDigMarker 判断类型是否为 dig 或 ioc233 的 In/Out 标记
+func isDigMarker(t reflect.Type, marker string) bool {
+	return t.Name() == marker && digPackages[t.PkgPath()]
+}
+
+// ProvideDig 注册为 dig/fx 编写的构造函数（fx.Provide 风格），便于从 dig/fx 逐步迁移
+// 在 ProvideFactory 的基础上支持：
+//   - 参数对象：嵌入 dig.In（或 ioc233.In）的结构体，字段支持 name:"x"（按名称解析）、optional:"true"（可选）、group:"x"（值分组切片）
+//   - 结果对象：嵌入 dig.Out（或 ioc233.Out）的结构体，每个字段各注册为一个 bean，字段支持 name:"x"（按名称注册）与 group:"x"（加入值分组）
+//
+// 普通构造函数与 ProvideFactory 的行为完全一致
+func (c *Container) ProvideDig(constructors ...any) error {
+	for _, ctor := range constructors {
+		if err := c.ProvideFactory(ctor); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DigConstructors 将容器中的单例 bean 导出为无参构造函数（func() T），
+// 可直接交给 dig/fx 使用：fx.Provide(container.DigConstructors()...)
+// 同一类型只导出一次；命名与值分组信息不导出
+func (c *Container) DigConstructors() []any {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	seen := make(map[reflect.Type]bool, len(c.beans))
+	ctors := make([]any, 0, len(c.beans))
+	for _, def := range c.beans {
+		if seen[def.typ] {
+			continue
+		}
+		seen[def.typ] = true
+		instance := reflect.ValueOf(def.instance)
+		fnType := reflect.FuncOf(nil, []reflect.Type{def.typ}, false)
+		ctors = append(ctors, reflect.MakeFunc(fnType, func([]reflect.Value) []reflect.Value {
+			return []reflect.Value{instance}
+		}).Interface())
+	}
+	return ctors
+}
+
+// resultObjectOutputs 展开结果对象的字段输出
+func resultObjectOutputs(result int, t reflect.Type) []factoryOutput {
+	var outputs []factoryOutput
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if (f.Anonymous && isDigMarker(f.Type, "Out")) || !f.IsExported() {
+			continue
+		}
+		outputs = append(outputs, factoryOutput{
+			result: result, field: i, typ: f.Type,
+			name: f.Tag.Get("name"), group: f.Tag.Get("group"),
+		})
+	}
+	return outputs
+}
+
+// resolveParamObjectLocked 构造参数对象：逐个解析字段（调用方需持有写锁）
+func (c *Container) resolveParamObjectLocked(f *factoryDefinition, t reflect.Type, path []*factoryDefinition) (reflect.Value, error) {
+	obj := reflect.New(t).Elem()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if (field.Anonymous && isDigMarker(field.Type, "In")) || !field.IsExported() {
+			continue
+		}
+		optional, _ := strconv.ParseBool(field.Tag.Get("optional"))
+		var (
+			v   reflect.Value
+			err error
+		)
+		switch name, group := field.Tag.Get("name"), field.Tag.Get("group"); {
+		case group != "":
+			v, err = c.resolveGroupLocked(field.Type, group, path)
+		case name != "":
+			v, err = c.resolveNamedLocked(field.Type, name, path)
+		default:
+			v, err = c.resolveFactoryArgLocked(f, field.Type, path)
+		}
+		if err != nil {
+			if optional {
+				continue
+			}
+			return reflect.Value{}, fmt.Errorf("[ioc233] 参数对象 %v 的字段 %s 解析失败: %w", t, field.Name, err)
+		}
+		obj.Field(i).Set(v)
+	}
+	return obj, nil
+}
+
+// resolveNamedLocked 按名称解析参数对象字段；名称由尚未构造的结果对象提供时先构造
+func (c *Container) resolveNamedLocked(t reflect.Type, name string, path []*factoryDefinition) (reflect.Value, error) {
+	if _, ok := c.nameToObjMap.load(name); !ok {
+		for _, dep := range c.factories {
+			if dep.producesName(name) {
+				if err := c.buildFactoryLocked(dep, path); err != nil {
+					return reflect.Value{}, err
+				}
+				break
+			}
+		}
+	}
+	obj, ok := c.nameToObjMap.load(name)
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("[ioc233] 未找到名称为 %q 的 bean%s", name, c.didYouMean(name))
+	}
+	v := reflect.ValueOf(obj)
+	if !v.Type().AssignableTo(t) {
+		return reflect.Value{}, fmt.Errorf("[ioc233] 名称为 %q 的 bean 类型 %v 不能赋值给 %v", name, v.Type(), t)
+	}
+	return v, nil
+}
+
+// resolveGroupLocked 解析值分组：先构造所有向该分组提供值的构造函数
+func (c *Container) resolveGroupLocked(t reflect.Type, group string, path []*factoryDefinition) (reflect.Value, error) {
+	if t.Kind() != reflect.Slice {
+		return reflect.Value{}, fmt.Errorf("[ioc233] 值分组 %q 的字段必须是切片: %v", group, t)
+	}
+	for _, dep := range c.factories {
+		if dep.producesGroup(group) {
+			if err := c.buildFactoryLocked(dep, path); err != nil {
+				return reflect.Value{}, err
+			}
+		}
+	}
+	slice := reflect.MakeSlice(t, 0, len(c.valueGroups[group]))
+	for _, v := range c.valueGroups[group] {
+		if v.Type().AssignableTo(t.Elem()) {
+			slice = reflect.Append(slice, v)
+		}
+	}
+	return slice, nil
+}
+
+// addToGroupLocked 将值加入分组（调用方需持有写锁）
+func (c *Container) addToGroupLocked(group string, v reflect.Value) {
+	if c.valueGroups == nil {
+		c.valueGroups = make(map[string][]reflect.Value)
+	}
+	c.valueGroups[group] = append(c.valueGroups[group], v)
+	logInfo("[ioc233] 加入值分组 | group = %s (type: %v)", group, v.Type())
+}
+
+// producesName 判断构造函数是否按名称提供 name
+func (f *factoryDefinition) producesName(name string) bool {
+	for _, out := range f.outputs {
+		if out.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// producesGroup 判断构造函数是否向分组 group 提供值
+func (f *factoryDefinition) producesGroup(group string) bool {
+	for _, out := range f.outputs {
+		if out.group == group {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,34 @@
+package ioc233
+
+import "context"
+
+// IDrain 优雅排空接口
+// 容器关闭时，在停止托管运行 bean（IRunnable）、OnStopping 钩子与 IDestroy 之前按注册逆序调用 OnDrain：
+// HTTP 服务停止接收新连接、队列消费者处理完进行中的消息，ctx 携带关闭时限
+type IDrain interface {
+	// OnDrain 停止接收新工作并等待进行中的工作完成，应在 ctx 结束时尽快返回
+	OnDrain(ctx context.Context)
+}
+
+// drainLocked 按注册逆序调用 IDrain，单个 bean 的 panic 只记录日志（调用方需持有写锁）
+func (c *Container) drainLocked(ctx context.Context) {
+	for i := len(c.beans) - 1; i >= 0; i-- {
+		def := c.beans[i]
+		d, ok := def.instance.(IDrain)
+		if !ok {
+			continue
+		}
+		logInfo("[ioc233] 排空: %s", def.name)
+		func() {
+			defer func() {
+				if p := recover(); p != nil {
+					logError("[ioc233] 排空 panic: name=%s: %v", def.name, p)
+				}
+			}()
+			d.OnDrain(ctx)
+		}()
+		if ctx.Err() != nil {
+			logWarn("[ioc233] 排空超出关闭时限: %s", def.name)
+		}
+	}
+}
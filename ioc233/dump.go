@@ -0,0 +1,133 @@
+package ioc233
+
+import (
+	"encoding/json"
+	"io"
+	"reflect"
+	"time"
+)
+
+// ContainerDump 容器内容转储（用于排查线上异常部署的事后分析）
+// 与 Snapshot 不同，转储记录的是字段当前实际注入的对象与错误，而不是按注册信息计算的依赖关系
+type ContainerDump struct {
+	// DumpedAt 转储时间
+	DumpedAt time.Time `json:"dumpedAt"`
+	// State 容器状态
+	State string `json:"state"`
+	// Profiles 激活的 profile
+	Profiles []string `json:"profiles,omitempty"`
+	// Beans bean 列表（按注册顺序，原型 bean 排在最后）
+	Beans []DumpBean `json:"beans"`
+	// Errors 启动前的致命错误与最近一次 StartUp 返回的错误
+	Errors []string `json:"errors,omitempty"`
+	// Report 最近一次成功启动的报告
+	Report *StartupReport `json:"report,omitempty"`
+}
+
+// DumpBean 转储中的 bean
+type DumpBean struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	// Prototype 是否为原型 bean（原型不跟踪实例，没有字段信息）
+	Prototype bool `json:"prototype,omitempty"`
+	// Tags 标签（WithTags）
+	Tags []string `json:"tags,omitempty"`
+	// Description 描述（WithDescription）
+	Description string `json:"description,omitempty"`
+	// Fields autowire 字段的注入结果
+	Fields []DumpField `json:"fields,omitempty"`
+}
+
+// DumpField 转储中的 autowire 字段
+type DumpField struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	// Tag autowire 标签值
+	Tag string `json:"tag"`
+	// Injected 字段当前是否为非零值
+	Injected bool `json:"injected"`
+	// Targets 字段当前引用的 bean 名（切片为每个元素；不是容器中的 bean 时为类型名）
+	Targets []string `json:"targets,omitempty"`
+	// Error 字段未注入时，按当前注册重新解析的失败原因
+	Error string `json:"error,omitempty"`
+}
+
+// Dump 生成容器内容转储
+func (c *Container) Dump() *ContainerDump {
+	profiles := c.ActiveProfiles()
+
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	dump := &ContainerDump{DumpedAt: time.Now(), State: c.state.String(), Profiles: profiles, Report: c.report}
+	for _, def := range c.beans {
+		dump.Beans = append(dump.Beans, DumpBean{
+			Name:        def.name,
+			Type:        def.typ.String(),
+			Tags:        def.tags,
+			Description: def.description,
+			Fields:      c.dumpFieldsLocked(def),
+		})
+	}
+	for _, p := range c.prototypes {
+		dump.Beans = append(dump.Beans, DumpBean{Name: p.name, Type: p.out.String(), Prototype: true})
+	}
+	for _, err := range c.fatalErrors {
+		dump.Errors = append(dump.Errors, err.Error())
+	}
+	if c.startupErr != nil {
+		dump.Errors = append(dump.Errors, c.startupErr.Error())
+	}
+	return dump
+}
+
+// DumpJSON 将容器内容转储以 JSON 写入 w（bean 名、类型、字段注入结果与错误）
+func (c *Container) DumpJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(c.Dump())
+}
+
+// dumpFieldsLocked 记录 bean 的 autowire 字段注入结果（调用方需持有锁）
+func (c *Container) dumpFieldsLocked(def *beanDefinition) []DumpField {
+	v := reflect.ValueOf(def.instance)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	v = v.Elem()
+	t := v.Type()
+	structName := displayTypeName(t)
+	var fields []DumpField
+	for _, field := range injectableFields(t) {
+		tag := autowireTag(field)
+		if tag == "" || !field.IsExported() {
+			continue
+		}
+		fv := fieldByIndex(v, field.Index, false)
+		df := DumpField{Name: field.Name, Type: field.Type.String(), Tag: tag, Injected: fv.IsValid() && !fv.IsZero()}
+		switch {
+		case !df.Injected:
+			if _, err := c.resolveField(structName, field, tag, resolveDryRun); err != nil {
+				df.Error = err.Error()
+			}
+		case fv.Kind() == reflect.Slice:
+			for j := 0; j < fv.Len(); j++ {
+				df.Targets = append(df.Targets, c.dumpTarget(fv.Index(j)))
+			}
+		case fv.Kind() == reflect.Ptr || fv.Kind() == reflect.Interface:
+			df.Targets = []string{c.dumpTarget(fv)}
+		}
+		fields = append(fields, df)
+	}
+	return fields
+}
+
+// dumpTarget 返回字段值引用的 bean 名
+func (c *Container) dumpTarget(v reflect.Value) string {
+	if v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "<nil>"
+		}
+		v = v.Elem()
+	}
+	return c.beanNameOf(v)
+}
@@ -0,0 +1,75 @@
+package ioc233
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// DuplicatePolicy 重复注册的处理策略（见 SetDuplicatePolicy）
+// Provide 的重复指类型已注册或默认 bean 名已被占用，ProvideByName 的重复指名称已被占用
+type DuplicatePolicy int
+
+const (
+	// DuplicateDefault 兼容行为（默认）：Provide 重复类型时保留首个实例并警告，ProvideByName 重复名称视为致命错误
+	DuplicateDefault DuplicatePolicy = iota
+	// DuplicateError 两条注册路径的重复都视为致命错误：注册返回 ErrDuplicateBean，StartUp 失败
+	DuplicateError
+	// DuplicateReplace 后注册的实例替换已有 bean（保留原 bean 名与注册顺序，已启动的容器重新注入依赖方）
+	DuplicateReplace
+	// DuplicateKeepFirst 两条注册路径都保留首个实例，忽略后注册的实例并记录警告
+	DuplicateKeepFirst
+)
+
+// ErrDuplicateBean 重复注册被视为错误时返回（errors.Is 判断）
+var ErrDuplicateBean = errors.New("[ioc233] 重复注册 bean")
+
+// SetDuplicatePolicy 设置 Provide 与 ProvideByName 的重复注册处理策略（默认 DuplicateDefault），对之后的注册生效
+func (c *Container) SetDuplicatePolicy(policy DuplicatePolicy) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.duplicatePolicy = policy
+}
+
+// duplicateOfLocked 返回与本次注册冲突的已有 bean：byType 为 true（Provide）时类型或名称相同即冲突，
+// 否则（ProvideByName）只比较名称；冲突的映射不属于任何 bean 时返回 exists=true、def=nil（调用方需持有锁）
+func (c *Container) duplicateOfLocked(name string, t reflect.Type, byType bool) (def *beanDefinition, exists bool) {
+	typeTaken := c.typeToObjectMap.taken(t)
+	nameTaken := c.nameToObjMap.taken(name)
+	if !nameTaken && !(byType && typeTaken) {
+		return nil, false
+	}
+	for _, d := range c.beans {
+		if d.name == name || (byType && d.typ == t) {
+			return d, true
+		}
+	}
+	return nil, true
+}
+
+// resolveDuplicateLocked 按策略处理重复注册：返回 true 表示本次注册已处理完毕（忽略、替换或报错），
+// 返回 false 表示继续按兼容行为注册（调用方需持有写锁）
+func (c *Container) resolveDuplicateLocked(api, name string, existing *beanDefinition, instance any) (bool, error) {
+	switch c.duplicatePolicy {
+	case DuplicateError:
+		return true, c.duplicateErrorLocked(api, name, instance)
+	case DuplicateReplace:
+		if existing == nil {
+			break
+		}
+		logInfo("[ioc233] %s 重复注册，替换已有 bean: name=%s", api, existing.name)
+		return true, c.replaceBeanLocked(existing, instance)
+	case DuplicateKeepFirst:
+		logWarn("[ioc233] %s 重复注册，保留首个实例: name=%s type=%v", api, name, reflect.TypeOf(instance))
+		return true, nil
+	}
+	return false, nil
+}
+
+// duplicateErrorLocked 记录重复注册的致命错误并返回（调用方需持有写锁）
+func (c *Container) duplicateErrorLocked(api, name string, instance any) error {
+	err := fmt.Errorf("%w: %s name=%s type=%v", ErrDuplicateBean, api, name, reflect.TypeOf(instance))
+	logError("%s", err.Error())
+	c.fatalErrors = append(c.fatalErrors, err)
+	return err
+}
@@ -0,0 +1,85 @@
+// Package echoioc ioc233 容器的 Echo 适配器
+// 独立模块，只有导入本包时才引入 labstack/echo 依赖：
+//
+//	e := echo.New()
+//	if err := echoioc.Mount(e, container); err != nil { ... }
+package echoioc
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// Middleware Echo 中间件 bean：Mount 时按 IOrdered 顺序通过 e.Use 注册
+type Middleware interface {
+	Middleware() echo.MiddlewareFunc
+}
+
+// Mount 将容器中的中间件 bean 与 ProvideController 注册的控制器路由挂载到 Echo
+// 路由路径中的 :id 原样保留（c.Param("id")），末尾通配 *path 转换为 Echo 的 *（c.Param("*")）；ANY 路由使用 e.Any
+// 核心包的 ioc233.IMiddleware bean 按 IOrdered 排序后作为路由级中间件包裹每一个控制器路由
+// 处理方法支持 func(echo.Context) error、net/http 的 func(http.ResponseWriter, *http.Request) 与 WebSocket 端点 func(*ioc233.WebSocketConn)；
+// 签名不支持时不挂载任何路由与中间件并返回错误
+func Mount(e *echo.Echo, c *ioc233.Container) error {
+	type mount struct {
+		route   ioc233.Route
+		handler echo.HandlerFunc
+	}
+	var mounts []mount
+	for _, ctrl := range c.GetControllers() {
+		for _, r := range ctrl.Routes {
+			h, err := handlerOf(c, ctrl.Instance, r.Handler)
+			if err != nil {
+				return fmt.Errorf("%w (controller=%s route=%s)", err, ctrl.Name, r.String())
+			}
+			mounts = append(mounts, mount{route: r, handler: h})
+		}
+	}
+
+	var chain []echo.MiddlewareFunc
+	for _, mw := range c.Middlewares() {
+		chain = append(chain, echo.WrapMiddleware(mw.Wrap))
+	}
+	for _, m := range ioc233.GetObjectsByTypeFrom[Middleware](c) {
+		e.Use(m.Middleware())
+	}
+	for _, m := range mounts {
+		path := pathOf(m.route.Path)
+		if m.route.Method == "ANY" {
+			e.Any(path, m.handler, chain...)
+			continue
+		}
+		e.Add(m.route.Method, path, m.handler, chain...)
+	}
+	return nil
+}
+
+// pathOf 将路由路径转换为 Echo 路径：末尾的 *name 转换为 *
+func pathOf(path string) string {
+	if i := strings.LastIndex(path, "/*"); i >= 0 && !strings.Contains(path[i+1:], "/") {
+		return path[:i] + "/*"
+	}
+	return path
+}
+
+// handlerOf 将控制器方法转换为 echo.HandlerFunc
+func handlerOf(c *ioc233.Container, instance any, name string) (echo.HandlerFunc, error) {
+	m := reflect.ValueOf(instance).MethodByName(name)
+	if !m.IsValid() {
+		return nil, fmt.Errorf("[ioc233] 路由处理方法不存在: %s", name)
+	}
+	switch fn := m.Interface().(type) {
+	case func(echo.Context) error:
+		return fn, nil
+	case func(http.ResponseWriter, *http.Request):
+		return echo.WrapHandler(http.HandlerFunc(fn)), nil
+	case func(*ioc233.WebSocketConn):
+		return echo.WrapHandler(c.WebSocketHandler(instance, fn)), nil
+	}
+	return nil, fmt.Errorf("[ioc233] 路由处理方法签名不支持 Echo: %s %v", name, m.Type())
+}
@@ -0,0 +1,113 @@
+package echoioc_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/neko233-com/ioc233-go/ioc233"
+	"github.com/neko233-com/ioc233-go/ioc233/echoioc"
+)
+
+type Greeter struct{ Prefix string }
+
+type HelloController struct {
+	_       struct{} `route:"GET /hello/:name" handler:"Hello"`
+	_       struct{} `route:"ANY /assets/*file" handler:"Assets"`
+	_       struct{} `route:"GET /legacy" handler:"Legacy"`
+	Greeter *Greeter `autowire:"true"`
+}
+
+func (h *HelloController) Hello(c echo.Context) error {
+	return c.String(http.StatusOK, h.Greeter.Prefix+c.Param("name"))
+}
+
+func (h *HelloController) Assets(c echo.Context) error {
+	return c.String(http.StatusOK, c.Request().Method+" "+c.Param("*"))
+}
+
+func (h *HelloController) Legacy(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusAccepted)
+}
+
+type TraceMiddleware struct{}
+
+func (TraceMiddleware) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Response().Header().Set("X-Trace", "on")
+			return next(c)
+		}
+	}
+}
+
+type VersionMiddleware struct{}
+
+func (VersionMiddleware) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Version", "v1")
+		next.ServeHTTP(w, r)
+	})
+}
+
+type BadController struct {
+	_ struct{} `route:"GET /bad" handler:"Bad"`
+}
+
+func (BadController) Bad() string { return "" }
+
+func request(e *echo.Echo, method, path string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+	return rec
+}
+
+func TestMount(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.SetQuietStartup(true)
+	c.Provide(&Greeter{Prefix: "hello "})
+	c.Provide(&TraceMiddleware{})
+	c.ProvideMiddleware(&VersionMiddleware{})
+	if err := c.ProvideController(&HelloController{}); err != nil {
+		t.Fatalf("注册控制器应该成功, 错误: %v", err)
+	}
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+
+	e := echo.New()
+	if err := echoioc.Mount(e, c); err != nil {
+		t.Fatalf("挂载应该成功, 错误: %v", err)
+	}
+	rec := request(e, http.MethodGet, "/hello/neko")
+	if rec.Body.String() != "hello neko" || rec.Header().Get("X-Trace") != "on" {
+		t.Errorf("路由与中间件应该生效, 实际: %d %q %v", rec.Code, rec.Body.String(), rec.Header())
+	}
+	if rec.Header().Get("X-Version") != "v1" {
+		t.Errorf("IMiddleware 应该包裹控制器路由, 实际: %v", rec.Header())
+	}
+	if rec := request(e, http.MethodGet, "/missing"); rec.Header().Get("X-Version") != "" {
+		t.Errorf("IMiddleware 只应该作用于控制器路由, 实际: %v", rec.Header())
+	}
+	if rec := request(e, http.MethodPut, "/assets/css/app.css"); rec.Body.String() != "PUT css/app.css" {
+		t.Errorf("ANY 与通配路由应该生效, 实际: %q", rec.Body.String())
+	}
+	if rec := request(e, http.MethodGet, "/legacy"); rec.Code != http.StatusAccepted {
+		t.Errorf("net/http 签名的处理方法应该被包装, 实际: %d", rec.Code)
+	}
+}
+
+func TestMount_UnsupportedSignature(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.SetQuietStartup(true)
+	c.ProvideController(&BadController{})
+	c.Provide(&TraceMiddleware{})
+	e := echo.New()
+	if err := echoioc.Mount(e, c); err == nil {
+		t.Fatal("签名不支持时应该返回错误")
+	}
+	if rec := request(e, http.MethodGet, "/bad"); rec.Code != http.StatusNotFound || rec.Header().Get("X-Trace") != "" {
+		t.Errorf("出错时不应该挂载任何路由与中间件, 实际: %d %v", rec.Code, rec.Header())
+	}
+}
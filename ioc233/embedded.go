@@ -0,0 +1,50 @@
+package ioc233
+
+import "reflect"
+
+// injectableFields 返回结构体类型中参与注入的字段
+// 未携带 autowire 标签的嵌入结构体（或结构体指针）字段会被展开，返回其中的字段（Index 为完整下标路径），
+// 使 BaseController 等基础结构体中的注入标签在嵌入后生效；嵌入字段本身带 autowire 标签时按普通字段注入，不展开
+// 结果按类型缓存（structMetaOf），调用方不得修改
+func injectableFields(t reflect.Type) []reflect.StructField {
+	return structMetaOf(t).fields
+}
+
+// collectInjectableFields injectableFields 的递归实现（visiting 防止自引用的嵌入指针无限展开）
+func collectInjectableFields(t reflect.Type, prefix []int, visiting map[reflect.Type]bool, out *[]reflect.StructField) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		field.Index = append(append([]int(nil), prefix...), i)
+		if field.Anonymous && autowireTag(field) == "" {
+			et := field.Type
+			if et.Kind() == reflect.Ptr {
+				et = et.Elem()
+			}
+			if et.Kind() == reflect.Struct && !visiting[et] {
+				visiting[et] = true
+				collectInjectableFields(et, field.Index, visiting, out)
+				delete(visiting, et)
+				continue
+			}
+		}
+		*out = append(*out, field)
+	}
+}
+
+// fieldByIndex 按下标路径取字段值
+// 路径上的嵌入指针为 nil 时：alloc 为 true 则分配新对象，否则返回无效值
+func fieldByIndex(v reflect.Value, index []int, alloc bool) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if !alloc || !v.CanSet() {
+					return reflect.Value{}
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
@@ -0,0 +1,38 @@
+package ioc233
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+)
+
+// envTagValue 解析 env:"NAME[,required]" 标签：环境变量存在时返回按字段类型转换后的值（覆盖已有值），不修改字段
+// 进程环境变量未设置时回退到配置源中同名的 key（例如 LoadConfigFile(".env") 加载的变量）
+// 变量未设置时返回无效值；变量值非法或 required 变量未设置时返回错误（调用方需持有读锁）
+func (c *Container) envTagValue(structName string, field reflect.StructField) (reflect.Value, error) {
+	tag, ok := field.Tag.Lookup("env")
+	if !ok {
+		return reflect.Value{}, nil
+	}
+	name, opts, _ := strings.Cut(tag, ",")
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return reflect.Value{}, fmt.Errorf("[ioc233] env 标签缺少变量名: struct=%s field=%s", structName, field.Name)
+	}
+	raw, exists := os.LookupEnv(name)
+	if !exists {
+		raw, exists = c.lookupProperty(name)
+	}
+	if !exists {
+		if strings.TrimSpace(opts) == "required" {
+			return reflect.Value{}, fmt.Errorf("[ioc233] 必需的环境变量未设置: struct=%s field=%s env=%s", structName, field.Name, name)
+		}
+		return reflect.Value{}, nil
+	}
+	v, err := parseLiteral(field.Type, raw)
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("[ioc233] 环境变量值非法: struct=%s field=%s env=%s: %w", structName, field.Name, name, err)
+	}
+	return v, nil
+}
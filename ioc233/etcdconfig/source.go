@@ -0,0 +1,65 @@
+// Package etcdconfig 基于 etcd KV 的 ioc233 配置源
+// 独立模块，只有导入本包时才引入 etcd 客户端依赖：
+//
+//	src, err := etcdconfig.Load(ctx, client, "/apps/order/")
+//	container.AddConfigSource(src)
+//
+// prefix 之后的 key 中的 / 转换为 .（/apps/order/db/host -> db.host），与 value 标签中的占位符对应
+package etcdconfig
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// Source etcd 配置源（Load 时读取 prefix 下全部 key 的快照）
+type Source struct {
+	kv     clientv3.KV
+	prefix string
+
+	mutex  sync.RWMutex
+	values map[string]string
+}
+
+// Load 读取 prefix 下的全部 key 作为配置源（kv 通常为 *clientv3.Client）
+func Load(ctx context.Context, kv clientv3.KV, prefix string) (*Source, error) {
+	s := &Source{kv: kv, prefix: prefix}
+	if err := s.Refresh(ctx); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Refresh 重新读取 prefix 下的全部 key
+func (s *Source) Refresh(ctx context.Context) error {
+	resp, err := s.kv.Get(ctx, s.prefix, clientv3.WithPrefix())
+	if err != nil {
+		return fmt.Errorf("[ioc233] 读取 etcd 配置失败: prefix=%s: %w", s.prefix, err)
+	}
+	values := make(map[string]string, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		values[configKey(s.prefix, string(kv.Key))] = string(kv.Value)
+	}
+	s.mutex.Lock()
+	s.values = values
+	s.mutex.Unlock()
+	return nil
+}
+
+// Lookup 实现 ioc233.ConfigSource
+func (s *Source) Lookup(key string) (string, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	v, ok := s.values[key]
+	return v, ok
+}
+
+// configKey 去掉 prefix 并把路径分隔符转换为点分 key
+func configKey(prefix, key string) string {
+	key = strings.Trim(strings.TrimPrefix(key, prefix), "/")
+	return strings.ReplaceAll(key, "/", ".")
+}
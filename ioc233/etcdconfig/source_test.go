@@ -0,0 +1,74 @@
+package etcdconfig_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+	"github.com/neko233-com/ioc233-go/ioc233/etcdconfig"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// fakeKV 内存中的 etcd KV（只实现 Get）
+type fakeKV struct {
+	clientv3.KV
+	data map[string]string
+	err  error
+}
+
+func (f *fakeKV) Get(_ context.Context, key string, _ ...clientv3.OpOption) (*clientv3.GetResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	resp := &clientv3.GetResponse{}
+	for k, v := range f.data {
+		if strings.HasPrefix(k, key) {
+			resp.Kvs = append(resp.Kvs, &mvccpb.KeyValue{Key: []byte(k), Value: []byte(v)})
+		}
+	}
+	return resp, nil
+}
+
+type DB struct {
+	Host string `value:"${db.host}"`
+	Port int    `value:"${db.port:5432}"`
+}
+
+func TestLoad_InjectsThroughValueTags(t *testing.T) {
+	kv := &fakeKV{data: map[string]string{
+		"/apps/order/db/host": "db.internal",
+		"/apps/other/db/host": "ignored",
+	}}
+	src, err := etcdconfig.Load(context.Background(), kv, "/apps/order/")
+	if err != nil {
+		t.Fatalf("加载应该成功, 错误: %v", err)
+	}
+
+	c := ioc233.NewContainer()
+	c.AddConfigSource(src)
+	db := &DB{}
+	c.Provide(db)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	if db.Host != "db.internal" || db.Port != 5432 {
+		t.Errorf("应该注入 etcd 中的配置, 实际: %+v", db)
+	}
+
+	kv.data["/apps/order/db/port"] = "6543"
+	if err := src.Refresh(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := src.Lookup("db.port"); !ok || v != "6543" {
+		t.Errorf("Refresh 后应该读到新 key, 实际: %q %v", v, ok)
+	}
+}
+
+func TestLoad_Error(t *testing.T) {
+	if _, err := etcdconfig.Load(context.Background(), &fakeKV{err: errors.New("unavailable")}, "/apps/"); err == nil {
+		t.Error("etcd 读取失败时应该返回错误")
+	}
+}
@@ -0,0 +1,169 @@
+package ioc233
+
+import (
+	"reflect"
+	"sync"
+)
+
+// eventBusType *EventBus 类型（注入字段、构造函数与 Invoke 参数直接解析为容器的事件总线）
+var eventBusType = reflect.TypeOf((*EventBus)(nil))
+
+// EventBus 容器内置的类型化事件总线，解耦的 bean 之间通过事件通信，不需要互相引用
+// 字段声明为 *ioc233.EventBus 并带 autowire 标签即可注入；子容器与作用域容器共享根容器的总线
+// 事件按静态类型 T 分发：PublishOn[T] 只通知 SubscribeOn[T] 的订阅者，按订阅顺序在发布方协程中同步调用
+type EventBus struct {
+	mutex    sync.RWMutex
+	handlers map[reflect.Type][]*typedHandler
+}
+
+// typedHandler 单个订阅者（以指针区分，用于取消订阅）
+type typedHandler struct {
+	fn func(event any)
+}
+
+// EventBus 返回容器的事件总线（首次调用时创建；子容器返回根容器的总线）
+func (c *Container) EventBus() *EventBus {
+	if c.parent != nil {
+		return c.parent.EventBus()
+	}
+	c.busOnce.Do(func() {
+		c.bus = &EventBus{handlers: make(map[reflect.Type][]*typedHandler)}
+	})
+	return c.bus
+}
+
+// Publish 向默认容器的事件总线发布事件
+func Publish[T any](event T) {
+	PublishOn(Default().EventBus(), event)
+}
+
+// Subscribe 订阅默认容器事件总线上类型为 T 的事件，返回取消订阅函数
+func Subscribe[T any](handler func(T)) func() {
+	return SubscribeOn(Default().EventBus(), handler)
+}
+
+// IEventHandler 事件处理接口
+// 实现 IEventHandler[T] 的 bean 在 StartUp（或启动后注册）注入完成时自动订阅 T 类型的事件，容器关闭时取消订阅
+// 也可以按命名约定声明处理方法：On<事件类型名>(event 事件类型)，例如 OnOrderPlaced(e OrderPlaced)
+type IEventHandler[T any] interface {
+	// HandleEvent 处理事件
+	HandleEvent(event T)
+}
+
+// eventMethod bean 上的事件处理方法
+type eventMethod struct {
+	index int
+	event reflect.Type
+}
+
+// eventMethodCache 类型 -> 事件处理方法（按方法名排序）
+var eventMethodCache sync.Map
+
+// eventMethodsOf 返回类型上的事件处理方法：HandleEvent(T)（IEventHandler[T]）与 On<T 的类型名>(T)
+func eventMethodsOf(t reflect.Type) []eventMethod {
+	if cached, ok := eventMethodCache.Load(t); ok {
+		return cached.([]eventMethod)
+	}
+	var methods []eventMethod
+	for i := 0; i < t.NumMethod(); i++ {
+		m := t.Method(i)
+		if m.Type.NumIn() != 2 || m.Type.NumOut() != 0 {
+			continue
+		}
+		event := m.Type.In(1)
+		if m.Name == "HandleEvent" || (event.Name() != "" && m.Name == "On"+event.Name()) {
+			methods = append(methods, eventMethod{index: i, event: event})
+		}
+	}
+	eventMethodCache.Store(t, methods)
+	return methods
+}
+
+// subscribeHandlersLocked 为 beans 中的事件处理方法订阅容器的事件总线（调用方需持有写锁）
+func (c *Container) subscribeHandlersLocked(beans []*beanDefinition) {
+	for _, def := range beans {
+		if def.instance == nil {
+			continue
+		}
+		v := reflect.ValueOf(def.instance)
+		for _, m := range eventMethodsOf(v.Type()) {
+			method, event := v.Method(m.index), m.event
+			unsubscribe := c.EventBus().subscribe(event, func(e any) {
+				arg := reflect.New(event).Elem()
+				if e != nil {
+					arg.Set(reflect.ValueOf(e))
+				}
+				method.Call([]reflect.Value{arg})
+			})
+			c.eventSubscriptions = append(c.eventSubscriptions, unsubscribe)
+			logInfo("[ioc233] 自动订阅事件: bean=%s event=%v", def.name, event)
+		}
+	}
+}
+
+// unsubscribeHandlersLocked 取消 StartUp 时自动建立的事件订阅（容器关闭时调用，调用方需持有写锁）
+func (c *Container) unsubscribeHandlersLocked() {
+	for _, unsubscribe := range c.eventSubscriptions {
+		unsubscribe()
+	}
+	c.eventSubscriptions = nil
+}
+
+// PublishOn 向指定事件总线发布事件：同步调用所有 T 类型的订阅者，单个订阅者 panic 只记录日志，不影响其他订阅者
+func PublishOn[T any](b *EventBus, event T) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	b.mutex.RLock()
+	handlers := b.handlers[t]
+	b.mutex.RUnlock()
+	for _, h := range handlers {
+		b.dispatch(t, h, event)
+	}
+}
+
+// SubscribeOn 订阅指定事件总线上类型为 T 的事件，返回取消订阅函数（重复调用是安全的）
+func SubscribeOn[T any](b *EventBus, handler func(T)) func() {
+	return b.subscribe(reflect.TypeOf((*T)(nil)).Elem(), func(event any) {
+		typed, _ := event.(T)
+		handler(typed)
+	})
+}
+
+// Subscribers 返回类型为 T 的事件当前的订阅者数量
+func Subscribers[T any](b *EventBus) int {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	return len(b.handlers[t])
+}
+
+// subscribe 按事件类型登记订阅者，返回取消订阅函数
+func (b *EventBus) subscribe(t reflect.Type, fn func(event any)) func() {
+	h := &typedHandler{fn: fn}
+	b.mutex.Lock()
+	b.handlers[t] = append(b.handlers[t], h)
+	b.mutex.Unlock()
+	return func() { b.unsubscribe(t, h) }
+}
+
+// dispatch 调用单个订阅者并恢复 panic
+func (b *EventBus) dispatch(t reflect.Type, h *typedHandler, event any) {
+	defer func() {
+		if p := recover(); p != nil {
+			logError("[ioc233] 事件处理 panic: event=%v: %v", t, p)
+		}
+	}()
+	h.fn(event)
+}
+
+// unsubscribe 移除订阅者（写时复制，发布中的快照不受影响）
+func (b *EventBus) unsubscribe(t reflect.Type, h *typedHandler) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	handlers := b.handlers[t]
+	for i, existing := range handlers {
+		if existing == h {
+			b.handlers[t] = append(append([]*typedHandler(nil), handlers[:i]...), handlers[i+1:]...)
+			return
+		}
+	}
+}
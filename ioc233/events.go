@@ -0,0 +1,101 @@
+package ioc233
+
+import (
+	"reflect"
+	"sync"
+	"time"
+)
+
+// Event 容器事件（BeanRegistered / InjectionStarted / InjectionFailed / StartupCompleted）
+// 供构建在 ioc233 之上的框架订阅，见 Subscribe
+type Event interface {
+	isEvent()
+}
+
+// BeanRegistered bean 注册完成（Provide、ProvideByName、ProvideValue、ProvidePrototype、构造函数产物等）
+type BeanRegistered struct {
+	Name string
+	Type reflect.Type
+	// Prototype 是否为原型 bean
+	Prototype bool
+}
+
+// InjectionStarted StartUp 开始注入某个 bean 的字段（在注入前回调之前）
+type InjectionStarted struct {
+	Bean string
+	Type reflect.Type
+}
+
+// InjectionFailed 字段注入失败（容器只记录错误日志，不中止启动）
+type InjectionFailed struct {
+	// Bean 字段所属的 bean 名（不是容器中的 bean 时为类型名）
+	Bean  string
+	Field string
+	Err   error
+}
+
+// StartupCompleted StartUp 结束（Err 非 nil 表示启动失败）
+type StartupCompleted struct {
+	Duration  time.Duration
+	BeanCount int
+	Err       error
+}
+
+func (BeanRegistered) isEvent()   {}
+func (InjectionStarted) isEvent() {}
+func (InjectionFailed) isEvent()  {}
+func (StartupCompleted) isEvent() {}
+
+// eventBus 事件订阅者列表（独立于容器锁，事件在持有容器锁时发出）
+type eventBus struct {
+	mutex       sync.RWMutex
+	subscribers []*eventSubscriber
+}
+
+// eventSubscriber 事件订阅者
+type eventSubscriber struct {
+	listener func(ev Event)
+}
+
+// Subscribe 订阅容器事件，返回取消订阅函数
+// 监听器按订阅顺序在发出事件的 goroutine 中同步调用，此时可能持有容器锁：
+// 不要在监听器中注册 bean 或调用 StartUp/Close，需要时自行转交其他 goroutine 处理
+//
+//	unsubscribe := container.Subscribe(func(ev ioc233.Event) {
+//	    if failed, ok := ev.(ioc233.InjectionFailed); ok {
+//	        alert(failed.Bean, failed.Field, failed.Err)
+//	    }
+//	})
+func (c *Container) Subscribe(listener func(ev Event)) (unsubscribe func()) {
+	sub := &eventSubscriber{listener: listener}
+	c.events.mutex.Lock()
+	defer c.events.mutex.Unlock()
+	c.events.subscribers = append(c.events.subscribers, sub)
+	return func() {
+		c.events.mutex.Lock()
+		defer c.events.mutex.Unlock()
+		for i, s := range c.events.subscribers {
+			if s == sub {
+				c.events.subscribers = append(c.events.subscribers[:i:i], c.events.subscribers[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// emit 按订阅顺序通知监听器（通知期间不持有订阅锁，监听器可以取消订阅）
+func (c *Container) emit(ev Event) {
+	c.events.mutex.RLock()
+	subscribers := c.events.subscribers
+	c.events.mutex.RUnlock()
+	for _, sub := range subscribers {
+		sub.listener(ev)
+	}
+}
+
+// injectionFailed 记录字段注入失败：错误日志、指标计数与 InjectionFailed 事件
+func (c *Container) injectionFailed(instance any, field reflect.StructField, err error) {
+	c.counters.injectionFailures.Add(1)
+	logError("%s", err.Error())
+	c.emit(InjectionFailed{Bean: c.beanNameOf(reflect.ValueOf(instance)), Field: field.Name, Err: err})
+}
@@ -0,0 +1,84 @@
+package ioc233
+
+import (
+	"expvar"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultExpvarName PublishExpvar 的默认变量名（/debug/vars 中的顶层 key）
+const DefaultExpvarName = "ioc233"
+
+// expvarMu 串行化 PublishExpvar 的检查与发布（expvar.Publish 对重名变量会 panic）
+var expvarMu sync.Mutex
+
+// expvarStats expvar 发布的容器统计（每次读取 /debug/vars 时重新计算）
+type expvarStats struct {
+	State      string `json:"state"`
+	BeanCount  int    `json:"beanCount"`
+	Prototypes int    `json:"prototypes"`
+	// FatalErrors 启动前的致命错误与最近一次 StartUp 返回的错误
+	FatalErrors []string `json:"fatalErrors"`
+	// LastStartup 最近一次成功启动的时间
+	LastStartup *time.Time `json:"lastStartup,omitempty"`
+	// StartupDurationMs 最近一次成功启动的耗时（毫秒）
+	StartupDurationMs float64 `json:"startupDurationMs"`
+	InjectionFailures uint64  `json:"injectionFailures"`
+	LazyResolutions   uint64  `json:"lazyResolutions"`
+	LazyFailures      uint64  `json:"lazyFailures"`
+}
+
+// PublishExpvar 通过标准库 expvar 发布容器统计（bean 数、致命错误、最近启动时间等），
+// 已有的 /debug/vars 采集无需引入任何依赖即可读到；name 为空时使用 DefaultExpvarName
+// expvar 变量是进程全局的，同名变量已存在时返回错误（同一进程发布多个容器需使用不同的 name）
+func (c *Container) PublishExpvar(name string) error {
+	if name == "" {
+		name = DefaultExpvarName
+	}
+	expvarMu.Lock()
+	defer expvarMu.Unlock()
+	if expvar.Get(name) != nil {
+		return fmt.Errorf("[ioc233] expvar 变量已存在: %s", name)
+	}
+	return publishExpvarFunc(name, func() any { return c.expvarStats() })
+}
+
+// publishExpvarFunc 发布 expvar 变量；其他代码绕过 expvarMu 抢先发布同名变量时，将 expvar.Publish 的 panic 转为错误
+func publishExpvarFunc(name string, fn func() any) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("[ioc233] expvar 变量已存在: %s: %v", name, p)
+		}
+	}()
+	expvar.Publish(name, expvar.Func(fn))
+	return nil
+}
+
+// expvarStats 汇总容器统计
+func (c *Container) expvarStats() *expvarStats {
+	m := c.Metrics()
+	out := &expvarStats{
+		State:             m.State.String(),
+		BeanCount:         m.Beans,
+		Prototypes:        m.Prototypes,
+		FatalErrors:       []string{},
+		StartupDurationMs: float64(m.StartupDuration) / float64(time.Millisecond),
+		InjectionFailures: m.InjectionFailures,
+		LazyResolutions:   m.LazyResolutions,
+		LazyFailures:      m.LazyFailures,
+	}
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	for _, err := range c.fatalErrors {
+		out.FatalErrors = append(out.FatalErrors, err.Error())
+	}
+	if c.startupErr != nil {
+		out.FatalErrors = append(out.FatalErrors, c.startupErr.Error())
+	}
+	if c.report != nil {
+		startedAt := c.report.StartedAt
+		out.LastStartup = &startedAt
+	}
+	return out
+}
@@ -0,0 +1,267 @@
+package ioc233
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// factoryDefinition 构造函数 bean 定义：StartUp 时按依赖顺序调用一次，返回值注册为单例 bean
+type factoryDefinition struct {
+	fn reflect.Value
+	in []reflect.Type
+	// outs 非 error 的返回值类型（每个注册为一个 bean）
+	outs []reflect.Type
+	// outputs 展开后的输出（结果对象 dig.Out 的每个字段各为一个输出）
+	outputs []factoryOutput
+	// hasErr 最后一个返回值是否为 error
+	hasErr bool
+	name   string // 函数名（用于日志与错误信息）
+	built  bool
+	// onDemand 为 true 时 StartUp 不主动构造，仅在依赖缺失时按需构造（RegisterConstructor）
+	onDemand bool
+}
+
+// factoryOutput 构造函数的单个输出
+type factoryOutput struct {
+	// result 返回值下标；field 为结果对象中的字段下标（-1 表示返回值本身）
+	result, field int
+	typ           reflect.Type
+	// name 非空时按名称注册；group 非空时加入值分组（见 dig 兼容）
+	name, group string
+}
+
+// produces 判断构造函数的某个按类型注册的输出能否满足类型 t
+func (f *factoryDefinition) produces(t reflect.Type) bool {
+	for _, out := range f.outputs {
+		if out.name != "" || out.group != "" {
+			continue
+		}
+		if out.typ.AssignableTo(t) || (t.Kind() == reflect.Interface && implementsInterface(out.typ, t)) {
+			return true
+		}
+	}
+	return false
+}
+
+// FactoryError 构造函数返回非 nil error 时 StartUp 返回的错误
+// 通过 errors.As 取得出错的构造函数，通过 errors.Is/As 判断原始错误
+type FactoryError struct {
+	// Func 构造函数名
+	Func string
+	// Outputs 构造函数的返回值类型（均未注册）
+	Outputs []string
+	// Err 构造函数返回的错误
+	Err error
+}
+
+// Error 实现 error 接口
+func (e *FactoryError) Error() string {
+	return fmt.Sprintf("[ioc233] 构造函数返回错误: func=%s out=[%s]: %v", e.Func, strings.Join(e.Outputs, ", "), e.Err)
+}
+
+// Unwrap 返回构造函数的原始错误
+func (e *FactoryError) Unwrap() error {
+	return e.Err
+}
+
+// ProvideFactory 注册构造函数（单例）
+// constructor 形如 func(us UserService, db *sql.DB) *OrderService，
+// 也可以返回多个值（例如 func(cfg *Config) (*Reader, *Writer, error)），每个非 error 返回值各注册为一个 bean：
+// - 参数按类型从容器解析；参数由其他构造函数提供时，先递归构造依赖（按拓扑顺序），循环依赖返回错误
+// - context.Context 参数取容器的 Context()，切片参数注入元素类型的全部实现
+// - 返回值以类型名为 bean 名注册，随后与其他 bean 一样执行字段注入与生命周期回调
+// - 最后一个返回值为 error 且非 nil 时构造失败，不注册任何返回值，StartUp 返回包装后的 *FactoryError
+// - StartUp 时（派生 bean 计算之前）构造；StartUp 之后注册则立即构造
+func (c *Container) ProvideFactory(constructor any) error {
+	def, err := newFactoryDefinition("ProvideFactory", constructor)
+	if err != nil {
+		return err
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if err := c.checkFrozenLocked("ProvideFactory", constructor); err != nil {
+		return err
+	}
+	c.factories = append(c.factories, def)
+	logInfo("[ioc233] 注册构造函数: func=%s out=%v", def.name, def.outs)
+
+	if c.state == StateStarted {
+		// 启动后注册时与 Provide 一致：构造出的 bean（含递归构造的依赖）立即注入并执行完整生命周期，再补齐待定依赖；
+		// 注入期间按需构造的 bean 追加在 c.beans 末尾，由 StartUp 或外层的 bindLateLocked 统一处理
+		from := len(c.beans)
+		err := c.buildFactoryLocked(def, nil)
+		c.bindLateLocked(from)
+		return err
+	}
+	return nil
+}
+
+// newFactoryDefinition 校验构造函数签名并解析参数与返回值（api 为调用方名称，用于错误信息）
+func newFactoryDefinition(api string, constructor any) (*factoryDefinition, error) {
+	fv := reflect.ValueOf(constructor)
+	if constructor == nil || fv.Kind() != reflect.Func {
+		return nil, fmt.Errorf("[ioc233] %s 参数必须是函数", api)
+	}
+	ft := fv.Type()
+	if ft.IsVariadic() {
+		return nil, fmt.Errorf("[ioc233] %s 不支持可变参数函数: %v", api, ft)
+	}
+	def := &factoryDefinition{fn: fv, name: funcName(fv)}
+	for i := 0; i < ft.NumIn(); i++ {
+		def.in = append(def.in, ft.In(i))
+	}
+	for i := 0; i < ft.NumOut(); i++ {
+		out := ft.Out(i)
+		if out == errorType {
+			if i != ft.NumOut()-1 {
+				return nil, fmt.Errorf("[ioc233] %s 构造函数的 error 必须是最后一个返回值: %v", api, ft)
+			}
+			def.hasErr = true
+			continue
+		}
+		for _, prev := range def.outs {
+			if prev == out {
+				return nil, fmt.Errorf("[ioc233] %s 构造函数返回了重复的类型 %v: %v", api, out, ft)
+			}
+		}
+		def.outs = append(def.outs, out)
+		if embedsDigMarker(out, "Out") {
+			def.outputs = append(def.outputs, resultObjectOutputs(i, out)...)
+		} else {
+			def.outputs = append(def.outputs, factoryOutput{result: i, field: -1, typ: out})
+		}
+	}
+	if len(def.outs) == 0 {
+		return nil, fmt.Errorf("[ioc233] %s 构造函数至少需要一个非 error 的返回值: %v", api, ft)
+	}
+	return def, nil
+}
+
+// buildFactoriesLocked 构造所有尚未构造的构造函数 bean（按需构造函数除外，调用方需持有写锁）
+func (c *Container) buildFactoriesLocked() error {
+	for _, f := range c.factories {
+		if f.onDemand {
+			continue
+		}
+		if err := c.buildFactoryLocked(f, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildFactoryLocked 先构造 f 依赖的构造函数，再调用 f 并注册结果
+// path 为当前递归路径，用于检测循环依赖
+func (c *Container) buildFactoryLocked(f *factoryDefinition, path []*factoryDefinition) error {
+	if f.built {
+		return nil
+	}
+	for i, p := range path {
+		if p == f {
+			names := make([]string, 0, len(path)-i+1)
+			for _, q := range path[i:] {
+				names = append(names, q.name)
+			}
+			return fmt.Errorf("[ioc233] 构造函数循环依赖: %s -> %s", strings.Join(names, " -> "), f.name)
+		}
+	}
+	path = append(path, f)
+
+	args := make([]reflect.Value, 0, len(f.in))
+	for _, in := range f.in {
+		v, err := c.resolveFactoryArgLocked(f, in, path)
+		if err != nil {
+			return err
+		}
+		c.markUsed(v)
+		args = append(args, v)
+	}
+	begin := time.Now()
+	results := f.fn.Call(args)
+	elapsed := time.Since(begin)
+	if f.hasErr {
+		if errVal := results[len(results)-1]; !errVal.IsNil() {
+			outputs := make([]string, 0, len(f.outs))
+			for _, out := range f.outs {
+				outputs = append(outputs, out.String())
+			}
+			return &FactoryError{Func: f.name, Outputs: outputs, Err: errVal.Interface().(error)}
+		}
+		results = results[:len(results)-1]
+	}
+	values := make([]reflect.Value, len(f.outputs))
+	for i, out := range f.outputs {
+		v := results[out.result]
+		if out.field >= 0 {
+			v = v.Field(out.field)
+		}
+		if isNilValue(v) {
+			return fmt.Errorf("[ioc233] 构造函数返回了 nil: func=%s out=%v", f.name, out.typ)
+		}
+		values[i] = v
+	}
+	f.built = true
+	logInfo("[ioc233] 构造函数完成: func=%s out=%v", f.name, f.outs)
+	for i, out := range f.outputs {
+		instance := values[i].Interface()
+		switch {
+		case out.group != "":
+			c.addToGroupLocked(out.group, values[i])
+		case out.name != "":
+			if err := c.provideByNameLocked(out.name, instance); err != nil {
+				return err
+			}
+		default:
+			c.provideLocked(instance)
+		}
+		if def := c.definitionOf(values[i]); def != nil {
+			def.constructTime = elapsed
+		}
+	}
+	return nil
+}
+
+// resolveFactoryArgLocked 解析构造函数参数：已注册的 bean 优先，否则递归构造能提供该类型的构造函数
+func (c *Container) resolveFactoryArgLocked(f *factoryDefinition, in reflect.Type, path []*factoryDefinition) (reflect.Value, error) {
+	if in == contextType {
+		return reflect.ValueOf(c.Context()), nil
+	}
+	if in == eventBusType {
+		return reflect.ValueOf(c.EventBus()), nil
+	}
+	if embedsDigMarker(in, "In") {
+		return c.resolveParamObjectLocked(f, in, path)
+	}
+	if in.Kind() == reflect.Slice {
+		for _, dep := range c.factories {
+			if dep.produces(in.Elem()) {
+				if err := c.buildFactoryLocked(dep, path); err != nil {
+					return reflect.Value{}, err
+				}
+			}
+		}
+		items := c.collectOrdered(in.Elem())
+		slice := reflect.MakeSlice(in, 0, len(items))
+		for _, item := range items {
+			slice = reflect.Append(slice, item)
+		}
+		return slice, nil
+	}
+	if v, ok := c.resolveByType(in); ok {
+		return v, nil
+	}
+	for _, dep := range c.factories {
+		if dep.built || !dep.produces(in) {
+			continue
+		}
+		if err := c.buildFactoryLocked(dep, path); err != nil {
+			return reflect.Value{}, err
+		}
+		if v, ok := c.resolveByType(in); ok {
+			return v, nil
+		}
+	}
+	return reflect.Value{}, fmt.Errorf("[ioc233] 构造函数缺少参数依赖: func=%s param=%v", f.name, in)
+}
@@ -0,0 +1,103 @@
+package ioc233
+
+import (
+	"reflect"
+	"strings"
+)
+
+// FeatureFlagSource 特性开关源，WithFeatureFlag 注册的 bean 仅在开关开启时激活（对接 LaunchDarkly、Unleash、配置中心等）
+type FeatureFlagSource interface {
+	// IsEnabled 特性开关是否开启
+	IsEnabled(flag string) bool
+}
+
+// FeatureFlagFunc 函数形式的 FeatureFlagSource
+type FeatureFlagFunc func(flag string) bool
+
+// IsEnabled 实现 FeatureFlagSource
+func (fn FeatureFlagFunc) IsEnabled(flag string) bool {
+	return fn(flag)
+}
+
+// StaticFeatureFlags 固定取值的特性开关（测试、本地开发），未列出的开关视为关闭
+type StaticFeatureFlags map[string]bool
+
+// IsEnabled 实现 FeatureFlagSource
+func (f StaticFeatureFlags) IsEnabled(flag string) bool {
+	return f[flag]
+}
+
+// SetFeatureFlagSource 设置特性开关源（子容器未设置时使用父容器的开关源；都未设置时所有开关视为关闭）
+func (c *Container) SetFeatureFlagSource(src FeatureFlagSource) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.featureFlags = src
+}
+
+// FeatureEnabled 按当前特性开关源查询开关是否开启
+func (c *Container) FeatureEnabled(flag string) bool {
+	var enabled bool
+	c.withReadLock(func() {
+		enabled = c.featureEnabledLocked(flag)
+	})
+	return enabled
+}
+
+// featureEnabledLocked 查询特性开关（调用方需持有锁）
+func (c *Container) featureEnabledLocked(flag string) bool {
+	if c.featureFlags != nil {
+		return c.featureFlags.IsEnabled(flag)
+	}
+	if c.parent != nil {
+		return c.parent.FeatureEnabled(flag)
+	}
+	return false
+}
+
+// WithFeatureFlag 仅当特性开关开启时激活 bean（注册、注入与生命周期），用于经由依赖注入的灰度发布：
+//
+//	c.SetFeatureFlagSource(flags)
+//	c.Provide(&NewMatchmaker{}, ioc233.WithFeatureFlag("new-matchmaker"))
+//
+// 开关在 StartUp 时求值（启动后注册的 bean 在注册时求值），之后开关变化不影响已激活的 bean；
+// 开关关闭时 bean 不会注册，依赖它的 autowire:"false" 可选字段保持 nil，可搭配 ProvideIfMissing 提供旧实现
+func WithFeatureFlag(flag string) BeanOption {
+	return func(o *beanOptions) {
+		o.featureFlag = strings.TrimSpace(flag)
+	}
+}
+
+// featureFlagOf 返回选项中声明的特性开关（未声明返回空）
+func featureFlagOf(opts []BeanOption) string {
+	var o beanOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o.featureFlag
+}
+
+// gateByFeatureFlagLocked 处理带 WithFeatureFlag 的注册，返回 true 表示已延迟到 StartUp 求值或因开关关闭跳过（调用方需持有写锁）
+// 结构体同时声明了 profile 标签时，两个条件都满足才注册
+func (c *Container) gateByFeatureFlagLocked(name string, instance any, opts []BeanOption) bool {
+	flag := featureFlagOf(opts)
+	if flag == "" || instance == nil || c.applyingConditionals {
+		return false
+	}
+	desc := "feature=" + flag
+	if c.state == StateStarted {
+		if c.featureEnabledLocked(flag) {
+			logInfo("[ioc233] 特性开关已开启，注册: %v (%s)", reflect.TypeOf(instance), desc)
+			return false
+		}
+		logInfo("[ioc233] 特性开关未开启，跳过注册: %v (%s)", reflect.TypeOf(instance), desc)
+		return true
+	}
+	cond := func() bool { return c.featureEnabledLocked(flag) }
+	if expr := profileTagOf(instance); expr != "" {
+		desc += ", profile=" + expr
+		cond = func() bool { return c.featureEnabledLocked(flag) && matchProfiles(expr, c.activeProfilesLocked()) }
+	}
+	c.addConditionalLocked(cond, name, instance, desc)
+	c.applyBeanOptionsLocked(instance, opts)
+	return true
+}
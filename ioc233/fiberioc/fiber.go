@@ -0,0 +1,88 @@
+// Package fiberioc ioc233 容器的 Fiber 适配器
+// 独立模块，只有导入本包时才引入 gofiber/fiber 依赖：
+//
+//	app := fiber.New()
+//	if err := fiberioc.Mount(app, container); err != nil { ... }
+package fiberioc
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// Middleware Fiber 中间件 bean：Mount 时按 IOrdered 顺序通过 app.Use 注册
+type Middleware interface {
+	Middleware() fiber.Handler
+}
+
+// Mount 将容器中的中间件 bean 与 ProvideController 注册的控制器路由挂载到 Fiber
+// 路由路径中的 :id 原样保留（c.Params("id")），末尾通配 *path 转换为 Fiber 的 *（c.Params("*")）；ANY 路由使用 app.All
+// 核心包的 ioc233.IMiddleware bean 按 IOrdered 排序后作为路由级中间件包裹每一个控制器路由
+// 处理方法支持 func(*fiber.Ctx) error 与 net/http 的 func(http.ResponseWriter, *http.Request)；
+// fasthttp 无法接管 net/http 连接，WebSocket 端点（func(*ioc233.WebSocketConn)）不受支持；
+// 签名不支持时不挂载任何路由与中间件并返回错误
+func Mount(app *fiber.App, c *ioc233.Container) error {
+	type mount struct {
+		route   ioc233.Route
+		handler fiber.Handler
+	}
+	var mounts []mount
+	for _, ctrl := range c.GetControllers() {
+		for _, r := range ctrl.Routes {
+			h, err := handlerOf(ctrl.Instance, r.Handler)
+			if err != nil {
+				return fmt.Errorf("%w (controller=%s route=%s)", err, ctrl.Name, r.String())
+			}
+			mounts = append(mounts, mount{route: r, handler: h})
+		}
+	}
+
+	var chain []fiber.Handler
+	for _, mw := range c.Middlewares() {
+		chain = append(chain, adaptor.HTTPMiddleware(mw.Wrap))
+	}
+	for _, m := range ioc233.GetObjectsByTypeFrom[Middleware](c) {
+		app.Use(m.Middleware())
+	}
+	for _, m := range mounts {
+		path := pathOf(m.route.Path)
+		handlers := append(append([]fiber.Handler(nil), chain...), m.handler)
+		if m.route.Method == "ANY" {
+			app.All(path, handlers...)
+			continue
+		}
+		app.Add(m.route.Method, path, handlers...)
+	}
+	return nil
+}
+
+// pathOf 将路由路径转换为 Fiber 路径：末尾的 *name 转换为 *
+func pathOf(path string) string {
+	if i := strings.LastIndex(path, "/*"); i >= 0 && !strings.Contains(path[i+1:], "/") {
+		return path[:i] + "/*"
+	}
+	return path
+}
+
+// handlerOf 将控制器方法转换为 fiber.Handler
+func handlerOf(instance any, name string) (fiber.Handler, error) {
+	m := reflect.ValueOf(instance).MethodByName(name)
+	if !m.IsValid() {
+		return nil, fmt.Errorf("[ioc233] 路由处理方法不存在: %s", name)
+	}
+	switch fn := m.Interface().(type) {
+	case func(*fiber.Ctx) error:
+		return fn, nil
+	case func(http.ResponseWriter, *http.Request):
+		return adaptor.HTTPHandlerFunc(fn), nil
+	case func(*ioc233.WebSocketConn):
+		return nil, fmt.Errorf("[ioc233] Fiber 不支持 WebSocket 端点（fasthttp 无法接管 net/http 连接）: %s", name)
+	}
+	return nil, fmt.Errorf("[ioc233] 路由处理方法签名不支持 Fiber: %s %v", name, m.Type())
+}
@@ -0,0 +1,117 @@
+package fiberioc_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/neko233-com/ioc233-go/ioc233"
+	"github.com/neko233-com/ioc233-go/ioc233/fiberioc"
+)
+
+type Greeter struct{ Prefix string }
+
+type HelloController struct {
+	_       struct{} `route:"GET /hello/:name" handler:"Hello"`
+	_       struct{} `route:"ANY /assets/*file" handler:"Assets"`
+	_       struct{} `route:"GET /legacy" handler:"Legacy"`
+	Greeter *Greeter `autowire:"true"`
+}
+
+func (h *HelloController) Hello(c *fiber.Ctx) error {
+	return c.SendString(h.Greeter.Prefix + c.Params("name"))
+}
+
+func (h *HelloController) Assets(c *fiber.Ctx) error {
+	return c.SendString(c.Method() + " " + c.Params("*"))
+}
+
+func (h *HelloController) Legacy(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusAccepted)
+}
+
+type TraceMiddleware struct{}
+
+func (TraceMiddleware) Middleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Set("X-Trace", "on")
+		return c.Next()
+	}
+}
+
+type VersionMiddleware struct{}
+
+func (VersionMiddleware) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Version", "v1")
+		next.ServeHTTP(w, r)
+	})
+}
+
+type BadController struct {
+	_ struct{} `route:"GET /bad" handler:"Bad"`
+}
+
+func (BadController) Bad() string { return "" }
+
+func request(t *testing.T, app *fiber.App, method, path string) (*http.Response, string) {
+	t.Helper()
+	resp, err := app.Test(httptest.NewRequest(method, path, nil))
+	if err != nil {
+		t.Fatalf("请求失败: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return resp, string(body)
+}
+
+func TestMount(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.SetQuietStartup(true)
+	c.Provide(&Greeter{Prefix: "hello "})
+	c.Provide(&TraceMiddleware{})
+	c.ProvideMiddleware(&VersionMiddleware{})
+	if err := c.ProvideController(&HelloController{}); err != nil {
+		t.Fatalf("注册控制器应该成功, 错误: %v", err)
+	}
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+
+	app := fiber.New()
+	if err := fiberioc.Mount(app, c); err != nil {
+		t.Fatalf("挂载应该成功, 错误: %v", err)
+	}
+	resp, body := request(t, app, http.MethodGet, "/hello/neko")
+	if body != "hello neko" || resp.Header.Get("X-Trace") != "on" {
+		t.Errorf("路由与中间件应该生效, 实际: %d %q %v", resp.StatusCode, body, resp.Header)
+	}
+	if resp.Header.Get("X-Version") != "v1" {
+		t.Errorf("IMiddleware 应该包裹控制器路由, 实际: %v", resp.Header)
+	}
+	if resp, _ := request(t, app, http.MethodGet, "/missing"); resp.Header.Get("X-Version") != "" {
+		t.Errorf("IMiddleware 只应该作用于控制器路由, 实际: %v", resp.Header)
+	}
+	if _, body := request(t, app, http.MethodPut, "/assets/css/app.css"); body != "PUT css/app.css" {
+		t.Errorf("ANY 与通配路由应该生效, 实际: %q", body)
+	}
+	if resp, _ := request(t, app, http.MethodGet, "/legacy"); resp.StatusCode != http.StatusAccepted {
+		t.Errorf("net/http 签名的处理方法应该被包装, 实际: %d", resp.StatusCode)
+	}
+}
+
+func TestMount_UnsupportedSignature(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.SetQuietStartup(true)
+	c.ProvideController(&BadController{})
+	c.Provide(&TraceMiddleware{})
+	app := fiber.New()
+	if err := fiberioc.Mount(app, c); err == nil {
+		t.Fatal("签名不支持时应该返回错误")
+	}
+	if resp, _ := request(t, app, http.MethodGet, "/bad"); resp.StatusCode != http.StatusNotFound || resp.Header.Get("X-Trace") != "" {
+		t.Errorf("出错时不应该挂载任何路由与中间件, 实际: %d %v", resp.StatusCode, resp.Header)
+	}
+}
@@ -3,16 +3,96 @@ package ioc233
 import (
 	"math/rand"
 	"reflect"
+	"strconv"
+	"sync"
 	"time"
 )
 
+// defaultProvider 用户注册的字段默认值提供器
+type defaultProvider struct {
+	match   func(field reflect.StructField) bool
+	provide func(fv reflect.Value)
+}
+
+var (
+	// defaultProviders 用户注册的默认值提供器（按注册顺序匹配）
+	defaultProviders     []defaultProvider
+	defaultProvidersLock sync.RWMutex
+)
+
+// RegisterDefaultProvider 注册字段默认值提供器（全局生效）
+// 字段为零值且 match 返回 true 时调用 provide 初始化字段；按注册顺序匹配，先于内置的 map/slice/*rand.Rand 初始化，
+// 只有第一个匹配的提供器生效：
+//
+//	ioc233.RegisterDefaultProvider(
+//	    func(f reflect.StructField) bool { return f.Type == reflect.TypeOf((*bytes.Buffer)(nil)) },
+//	    func(fv reflect.Value) { fv.Set(reflect.ValueOf(new(bytes.Buffer))) },
+//	)
+func RegisterDefaultProvider(match func(reflect.StructField) bool, provide func(reflect.Value)) {
+	if match == nil || provide == nil {
+		logError("[ioc233] RegisterDefaultProvider 参数非法")
+		return
+	}
+	defaultProvidersLock.Lock()
+	defer defaultProvidersLock.Unlock()
+	defaultProviders = append(defaultProviders, defaultProvider{match: match, provide: provide})
+}
+
+// applyCustomDefaultProviders 应用第一个匹配的用户提供器
+func applyCustomDefaultProviders(field reflect.StructField, fv reflect.Value) bool {
+	defaultProvidersLock.RLock()
+	providers := defaultProviders
+	defaultProvidersLock.RUnlock()
+	for _, p := range providers {
+		if p.match(field) {
+			p.provide(fv)
+			return true
+		}
+	}
+	return false
+}
+
+// zeroReadyPointerTypes 零值即可使用的 sync 类型，字段为 nil 指针时初始化为 new(T)
+var zeroReadyPointerTypes = map[reflect.Type]bool{
+	reflect.TypeOf((*sync.Map)(nil)):       true,
+	reflect.TypeOf((*sync.WaitGroup)(nil)): true,
+}
+
+// isZeroReadyPointer 判断字段类型是否为零值可用的同步类型指针（sync/atomic 的类型均零值可用，含泛型 *atomic.Pointer[T]）
+func isZeroReadyPointer(t reflect.Type) bool {
+	if zeroReadyPointerTypes[t] {
+		return true
+	}
+	return t.Kind() == reflect.Ptr && t.Elem().PkgPath() == "sync/atomic" && t.Elem().Kind() == reflect.Struct
+}
+
+// chanBufferSize 读取 chan 字段的 buffer 标签（缺省或非法时为无缓冲）
+func chanBufferSize(field reflect.StructField) int {
+	tag, ok := field.Tag.Lookup("buffer")
+	if !ok {
+		return 0
+	}
+	size, err := strconv.Atoi(tag)
+	if err != nil || size < 0 {
+		logError("[ioc233] chan 字段 buffer 标签非法，按无缓冲初始化: field=%s buffer=%q", field.Name, tag)
+		return 0
+	}
+	return size
+}
+
 // ApplyDefaultProviders 为字段应用默认值提供器
-// 支持 map、slice、*rand.Rand 等类型的自动初始化
+// 支持 map、slice、chan（buffer 标签指定缓冲大小）、*rand.Rand、*sync.Map / *sync.WaitGroup 等同步类型、
+// sync/atomic 类型指针的自动初始化，以及 RegisterDefaultProvider 注册的提供器
 func ApplyDefaultProviders(field reflect.StructField, fv reflect.Value) bool {
 	if !fv.CanSet() {
 		return false
 	}
 
+	// 用户注册的提供器（仅零值字段）
+	if fv.IsZero() && applyCustomDefaultProviders(field, fv) {
+		return true
+	}
+
 	fieldType := field.Type
 
 	// 初始化 map
@@ -33,6 +113,24 @@ func ApplyDefaultProviders(field reflect.StructField, fv reflect.Value) bool {
 		return false
 	}
 
+	// 初始化双向 chan（单向 chan 无法单独创建，跳过）
+	if fieldType.Kind() == reflect.Chan {
+		if fv.IsNil() && fieldType.ChanDir() == reflect.BothDir {
+			fv.Set(reflect.MakeChan(fieldType, chanBufferSize(field)))
+			return true
+		}
+		return false
+	}
+
+	// 初始化 sync / sync/atomic 类型指针
+	if isZeroReadyPointer(fieldType) {
+		if fv.IsNil() {
+			fv.Set(reflect.New(fieldType.Elem()))
+			return true
+		}
+		return false
+	}
+
 	// 初始化 *rand.Rand
 	if fieldType == reflect.TypeOf((*rand.Rand)(nil)) {
 		if fv.IsNil() {
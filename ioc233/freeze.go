@@ -0,0 +1,43 @@
+package ioc233
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrContainerFrozen 容器冻结（Freeze）后注册新的 bean 返回的错误
+var ErrContainerFrozen = errors.New("[ioc233] 容器已冻结，不能注册新的 bean")
+
+// Freeze 冻结容器：之后的 Provide、ProvideByName、ProvideValue、ProvideFactory、ProvidePrototype、
+// ProvideDerived 与条件注册均被拒绝并返回 ErrContainerFrozen，用于发现启动完成后意外的注册
+// 冻结不可撤销；Swap、Override 等替换已有 bean 的操作不受影响
+func (c *Container) Freeze() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.frozen = true
+	logInfo("[ioc233] 容器已冻结")
+}
+
+// IsFrozen 返回容器是否已冻结
+func (c *Container) IsFrozen() bool {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.frozen
+}
+
+// SetFreezeAfterStartUp 开启后 StartUp 成功时自动冻结容器
+func (c *Container) SetFreezeAfterStartUp(enabled bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.freezeAfterStartUp = enabled
+}
+
+// checkFrozenLocked 容器已冻结时记录错误并返回 ErrContainerFrozen（api 为调用方名称，调用方需持有锁）
+func (c *Container) checkFrozenLocked(api string, what any) error {
+	if !c.frozen {
+		return nil
+	}
+	err := fmt.Errorf("%w: %s %T", ErrContainerFrozen, api, what)
+	logError("%s", err.Error())
+	return err
+}
@@ -0,0 +1,114 @@
+// Package ginioc ioc233 容器的 Gin 适配器
+// 独立模块，只有导入本包时才引入 gin-gonic/gin 依赖：
+//
+//	r := gin.New()
+//	if err := ginioc.Mount(r, container); err != nil { ... }
+package ginioc
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+
+	"github.com/gin-gonic/gin"
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// Middleware Gin 中间件 bean：Mount 时按 IOrdered 顺序通过 r.Use 注册
+type Middleware interface {
+	Middleware() gin.HandlerFunc
+}
+
+// Mount 将容器中的中间件 bean 与 ProvideController 注册的控制器路由挂载到 Gin
+// 路由路径与 Gin 语法一致，原样注册：:id 通过 c.Param("id") 读取，末尾通配 *path 通过 c.Param("path") 读取（带前导 /）；ANY 路由使用 r.Any
+// 核心包的 ioc233.IMiddleware bean 按 IOrdered 排序后作为路由级中间件包裹每一个控制器路由
+// 处理方法支持 func(*gin.Context)、net/http 的 func(http.ResponseWriter, *http.Request) 与 WebSocket 端点 func(*ioc233.WebSocketConn)；
+// 签名不支持时不挂载任何路由与中间件并返回错误
+func Mount(r *gin.Engine, c *ioc233.Container) error {
+	type mount struct {
+		route   ioc233.Route
+		handler gin.HandlerFunc
+	}
+	var mounts []mount
+	for _, ctrl := range c.GetControllers() {
+		for _, route := range ctrl.Routes {
+			h, err := handlerOf(c, ctrl.Instance, route.Handler)
+			if err != nil {
+				return fmt.Errorf("%w (controller=%s route=%s)", err, ctrl.Name, route.String())
+			}
+			mounts = append(mounts, mount{route: route, handler: h})
+		}
+	}
+
+	var chain []gin.HandlerFunc
+	for _, mw := range c.Middlewares() {
+		chain = append(chain, wrapMiddleware(mw))
+	}
+	for _, m := range ioc233.GetObjectsByTypeFrom[Middleware](c) {
+		r.Use(m.Middleware())
+	}
+	for _, m := range mounts {
+		handlers := append(append([]gin.HandlerFunc(nil), chain...), m.handler)
+		if m.route.Method == "ANY" {
+			r.Any(m.route.Path, handlers...)
+			continue
+		}
+		r.Handle(m.route.Method, m.route.Path, handlers...)
+	}
+	return nil
+}
+
+// handlerOf 将控制器方法转换为 gin.HandlerFunc
+func handlerOf(c *ioc233.Container, instance any, name string) (gin.HandlerFunc, error) {
+	m := reflect.ValueOf(instance).MethodByName(name)
+	if !m.IsValid() {
+		return nil, fmt.Errorf("[ioc233] 路由处理方法不存在: %s", name)
+	}
+	switch fn := m.Interface().(type) {
+	case func(*gin.Context):
+		return fn, nil
+	case func(http.ResponseWriter, *http.Request):
+		return gin.WrapF(fn), nil
+	case func(*ioc233.WebSocketConn):
+		return gin.WrapH(c.WebSocketHandler(instance, fn)), nil
+	}
+	return nil, fmt.Errorf("[ioc233] 路由处理方法签名不支持 Gin: %s %v", name, m.Type())
+}
+
+// wrapMiddleware 将 net/http 风格的 ioc233.IMiddleware 转换为 Gin 中间件：
+// 中间件调用 next 时继续 Gin 处理链（使用其传入的请求与 ResponseWriter），未调用 next 时中断处理链
+func wrapMiddleware(mw ioc233.IMiddleware) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		called := false
+		writer := ctx.Writer
+		mw.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+			ctx.Request = r
+			if w != http.ResponseWriter(writer) {
+				ctx.Writer = &responseWriter{ResponseWriter: writer, w: w}
+			}
+			ctx.Next()
+			ctx.Writer = writer
+		})).ServeHTTP(writer, ctx.Request)
+		if !called {
+			ctx.Abort()
+		}
+	}
+}
+
+// responseWriter 中间件替换了 ResponseWriter 时，将后续处理方法的写入转发给它
+type responseWriter struct {
+	gin.ResponseWriter
+	w http.ResponseWriter
+}
+
+func (rw *responseWriter) Header() http.Header { return rw.w.Header() }
+
+func (rw *responseWriter) WriteHeader(code int) { rw.w.WriteHeader(code) }
+
+func (rw *responseWriter) WriteHeaderNow() {}
+
+func (rw *responseWriter) Write(b []byte) (int, error) { return rw.w.Write(b) }
+
+func (rw *responseWriter) WriteString(s string) (int, error) { return io.WriteString(rw.w, s) }
@@ -0,0 +1,151 @@
+package ginioc_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/neko233-com/ioc233-go/ioc233"
+	"github.com/neko233-com/ioc233-go/ioc233/ginioc"
+)
+
+type Greeter struct{ Prefix string }
+
+type HelloController struct {
+	_       struct{} `route:"GET /hello/:name" handler:"Hello"`
+	_       struct{} `route:"ANY /assets/*file" handler:"Assets"`
+	_       struct{} `route:"GET /legacy" handler:"Legacy"`
+	Greeter *Greeter `autowire:"true"`
+}
+
+func (h *HelloController) Hello(c *gin.Context) {
+	c.String(http.StatusOK, h.Greeter.Prefix+c.Param("name"))
+}
+
+func (h *HelloController) Assets(c *gin.Context) {
+	c.String(http.StatusOK, c.Request.Method+" "+c.Param("file"))
+}
+
+func (h *HelloController) Legacy(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusAccepted)
+}
+
+type TraceMiddleware struct{}
+
+func (TraceMiddleware) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("X-Trace", "on")
+		c.Next()
+	}
+}
+
+type VersionMiddleware struct{}
+
+func (VersionMiddleware) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Version", "v1")
+		next.ServeHTTP(w, r)
+	})
+}
+
+type DenyMiddleware struct{}
+
+func (DenyMiddleware) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Deny") != "" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+type WrapWriterMiddleware struct{}
+
+type wrappedWriter struct{ http.ResponseWriter }
+
+func (w wrappedWriter) Write(b []byte) (int, error) {
+	w.Header().Set("X-Wrapped", "on")
+	return w.ResponseWriter.Write(b)
+}
+
+func (WrapWriterMiddleware) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(wrappedWriter{w}, r)
+	})
+}
+
+type BadController struct {
+	_ struct{} `route:"GET /bad" handler:"Bad"`
+}
+
+func (BadController) Bad() string { return "" }
+
+func request(r *gin.Engine, req *http.Request) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestMount(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c := ioc233.NewContainer()
+	c.SetQuietStartup(true)
+	c.Provide(&Greeter{Prefix: "hello "})
+	c.Provide(&TraceMiddleware{})
+	c.ProvideMiddleware(&VersionMiddleware{})
+	c.ProvideMiddleware(&DenyMiddleware{})
+	c.ProvideMiddleware(&WrapWriterMiddleware{})
+	if err := c.ProvideController(&HelloController{}); err != nil {
+		t.Fatalf("注册控制器应该成功, 错误: %v", err)
+	}
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+
+	r := gin.New()
+	if err := ginioc.Mount(r, c); err != nil {
+		t.Fatalf("挂载应该成功, 错误: %v", err)
+	}
+	rec := request(r, httptest.NewRequest(http.MethodGet, "/hello/neko", nil))
+	if rec.Body.String() != "hello neko" || rec.Header().Get("X-Trace") != "on" {
+		t.Errorf("路由与中间件应该生效, 实际: %d %q %v", rec.Code, rec.Body.String(), rec.Header())
+	}
+	if rec.Header().Get("X-Version") != "v1" {
+		t.Errorf("IMiddleware 应该包裹控制器路由, 实际: %v", rec.Header())
+	}
+	if rec.Header().Get("X-Wrapped") != "on" {
+		t.Errorf("IMiddleware 替换的 ResponseWriter 应该接收处理方法的写入, 实际: %v", rec.Header())
+	}
+	if rec := request(r, httptest.NewRequest(http.MethodGet, "/missing", nil)); rec.Header().Get("X-Version") != "" {
+		t.Errorf("IMiddleware 只应该作用于控制器路由, 实际: %v", rec.Header())
+	}
+	if rec := request(r, httptest.NewRequest(http.MethodPut, "/assets/css/app.css", nil)); rec.Body.String() != "PUT /css/app.css" {
+		t.Errorf("ANY 与通配路由应该生效, 实际: %q", rec.Body.String())
+	}
+	if rec := request(r, httptest.NewRequest(http.MethodGet, "/legacy", nil)); rec.Code != http.StatusAccepted {
+		t.Errorf("net/http 签名的处理方法应该被包装, 实际: %d", rec.Code)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/hello/neko", nil)
+	req.Header.Set("X-Deny", "1")
+	if rec := request(r, req); rec.Code != http.StatusForbidden || rec.Body.Len() != 0 {
+		t.Errorf("IMiddleware 未调用 next 时应该中断处理链, 实际: %d %q", rec.Code, rec.Body.String())
+	}
+}
+
+func TestMount_UnsupportedSignature(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c := ioc233.NewContainer()
+	c.SetQuietStartup(true)
+	c.ProvideController(&BadController{})
+	c.Provide(&TraceMiddleware{})
+	r := gin.New()
+	if err := ginioc.Mount(r, c); err == nil {
+		t.Fatal("签名不支持时应该返回错误")
+	}
+	if rec := request(r, httptest.NewRequest(http.MethodGet, "/bad", nil)); rec.Code != http.StatusNotFound || rec.Header().Get("X-Trace") != "" {
+		t.Errorf("出错时不应该挂载任何路由与中间件, 实际: %d %v", rec.Code, rec.Header())
+	}
+}
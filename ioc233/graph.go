@@ -0,0 +1,203 @@
+package ioc233
+
+import (
+	"reflect"
+	"strings"
+)
+
+// 依赖边类型
+const (
+	// EdgeByType 按类型注入（具体类型）
+	EdgeByType = "type"
+	// EdgeByInterface 按接口注入
+	EdgeByInterface = "interface"
+	// EdgeByName 按名称注入
+	EdgeByName = "name"
+	// EdgeSlice 切片注入（全部实现）
+	EdgeSlice = "slice"
+	// EdgeBalance 负载均衡门面（全部实现）
+	EdgeBalance = "balance"
+	// EdgeGroup 分组注入（分组的全部成员）
+	EdgeGroup = "group"
+)
+
+// DependencyGraph 容器的依赖图（bean 为节点，autowire 字段为边）
+type DependencyGraph struct {
+	Beans []GraphBean      `json:"beans"`
+	Edges []DependencyEdge `json:"edges"`
+}
+
+// GraphBean 依赖图节点
+type GraphBean struct {
+	Name     string       `json:"name"`
+	TypeName string       `json:"type"`
+	Type     reflect.Type `json:"-"`
+}
+
+// DependencyEdge 依赖图的边：From 的 Field 字段依赖 To 中的 bean
+type DependencyEdge struct {
+	// From 消费方 bean 名
+	From string `json:"from"`
+	// Field 字段名
+	Field string `json:"field"`
+	// FieldTypeName 字段期望的类型（Lazy[T] 取 T）
+	FieldTypeName string       `json:"fieldType"`
+	FieldType     reflect.Type `json:"-"`
+	// Tag autowire 标签值
+	Tag string `json:"tag"`
+	// Kind 注入方式（EdgeByType/EdgeByInterface/EdgeByName/EdgeSlice/EdgeBalance/EdgeGroup）
+	Kind string `json:"kind"`
+	// Lazy 是否为懒加载注入
+	Lazy bool `json:"lazy,omitempty"`
+	// To 被依赖的 bean 名（原型 bean 取原型名）
+	To []string `json:"to,omitempty"`
+	// ToTypeNames 被依赖实例的具体类型
+	ToTypeNames []string       `json:"toTypes,omitempty"`
+	ToTypes     []reflect.Type `json:"-"`
+	// Prototype 是否由原型 bean 满足
+	Prototype bool `json:"prototype,omitempty"`
+	// Error 解析失败原因（为空表示可解析或可选未命中）
+	Error string `json:"error,omitempty"`
+}
+
+// DependencyGraph 计算容器当前的依赖图（不执行注入，不触发回调）
+// 父容器中被依赖的 bean 也会出现在边的 To 中，但不会作为本容器的节点
+func (c *Container) DependencyGraph() *DependencyGraph {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	graph := &DependencyGraph{}
+	for _, def := range c.beans {
+		graph.Beans = append(graph.Beans, GraphBean{Name: def.name, TypeName: def.typ.String(), Type: def.typ})
+	}
+	for _, def := range c.beans {
+		graph.Edges = append(graph.Edges, c.edgesOf(def)...)
+	}
+	return graph
+}
+
+// edgesOf 计算单个 bean 的依赖边
+func (c *Container) edgesOf(def *beanDefinition) []DependencyEdge {
+	v := reflect.ValueOf(def.instance)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	t := v.Elem().Type()
+	structName := displayTypeName(t)
+	var edges []DependencyEdge
+	for _, field := range injectableFields(t) {
+		tag := autowireTag(field)
+		if tag == "" || !field.IsExported() {
+			continue
+		}
+		edge := DependencyEdge{From: def.name, Field: field.Name, Tag: tag}
+		if lb, ok := reflect.New(field.Type).Interface().(lazyBinder); ok {
+			field.Type = lb.lazyTarget()
+			edge.Lazy = true
+		} else if field.Tag.Get("lazy") == "true" && field.Type.Kind() == reflect.Interface {
+			edge.Lazy = true
+		}
+		edge.FieldType, edge.FieldTypeName = field.Type, field.Type.String()
+
+		switch {
+		case field.Type == contextType && c.ctx != nil, field.Type == eventBusType:
+			continue
+		case field.Tag.Get("balance") != "" && field.Type.Kind() == reflect.Interface:
+			edge.Kind = EdgeBalance
+			for _, item := range c.findImplementations(field.Type) {
+				edge.addTarget(c.beanNameOf(item), item.Type(), false)
+			}
+		case (tag == "true" || tag == "false") && field.Type.Kind() == reflect.Slice:
+			edge.Kind = EdgeSlice
+			for _, item := range c.collectOrdered(field.Type.Elem()) {
+				edge.addTarget(c.beanNameOf(item), item.Type(), false)
+			}
+		case strings.HasPrefix(tag, groupTagPrefix):
+			edge.Kind = EdgeGroup
+			group, _ := groupOfTag(tag)
+			resolved, err := c.resolveGroupField(structName, field, group)
+			if err != nil {
+				edge.Error = err.Error()
+			}
+			for i := 0; resolved.IsValid() && i < resolved.Len(); i++ {
+				edge.addTarget(c.beanNameOf(resolved.Index(i)), resolved.Index(i).Type(), false)
+			}
+		default:
+			switch {
+			case isNameTag(tag):
+				edge.Kind = EdgeByName
+			case field.Type.Kind() == reflect.Interface:
+				edge.Kind = EdgeByInterface
+			default:
+				edge.Kind = EdgeByType
+			}
+			resolved, err := c.resolveField(structName, field, tag, resolveDryRun)
+			switch {
+			case err != nil:
+				edge.Error = err.Error()
+			case resolved.IsValid():
+				edge.addTarget(c.beanNameOf(resolved), resolved.Type(), false)
+			default:
+				if p := c.prototypeFor(field.Type, tag); p != nil {
+					edge.addTarget(p.name, p.out, true)
+				}
+			}
+		}
+		edges = append(edges, edge)
+	}
+	return edges
+}
+
+// addTarget 记录被依赖的 bean
+func (e *DependencyEdge) addTarget(name string, t reflect.Type, prototype bool) {
+	e.To = append(e.To, name)
+	e.ToTypes = append(e.ToTypes, t)
+	e.ToTypeNames = append(e.ToTypeNames, t.String())
+	e.Prototype = e.Prototype || prototype
+}
+
+// prototypeFor 查找满足字段的原型定义（本容器与父容器）
+func (c *Container) prototypeFor(t reflect.Type, tag string) *prototypeDefinition {
+	for cur := c; cur != nil; cur = cur.parent {
+		var p *prototypeDefinition
+		cur.withReadLockIfParent(c, func() {
+			if isNameTag(tag) {
+				p = cur.findPrototypeByName(tag)
+			} else {
+				p = cur.findPrototype(t)
+			}
+		})
+		if p != nil {
+			return p
+		}
+	}
+	return nil
+}
+
+// beanNameOf 按实例反查 bean 名（本容器与父容器），未找到返回类型名
+func (c *Container) beanNameOf(v reflect.Value) string {
+	for cur := c; cur != nil; cur = cur.parent {
+		name := ""
+		cur.withReadLockIfParent(c, func() {
+			for _, def := range cur.beans {
+				if sameInstance(def.instance, v.Interface()) {
+					name = def.name
+					return
+				}
+			}
+		})
+		if name != "" {
+			return name
+		}
+	}
+	return displayTypeName(v.Type())
+}
+
+// withReadLockIfParent 遍历容器链时使用：self 已由调用方加锁，祖先容器需加读锁
+func (c *Container) withReadLockIfParent(self *Container, fn func()) {
+	if c == self {
+		fn()
+		return
+	}
+	c.withReadLock(fn)
+}
@@ -0,0 +1,202 @@
+package ioc233
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// HealthPath HealthHandler 建议挂载的路径（Kubernetes livenessProbe）
+const HealthPath = "/healthz"
+
+// ReadyPath ReadyHandler 建议挂载的路径（Kubernetes readinessProbe）
+const ReadyPath = "/readyz"
+
+// probeTimeout 探针端点单次健康检查的最长时间（请求 ctx 更早结束时以请求为准）
+const probeTimeout = 5 * time.Second
+
+// IHealthCheck 健康检查接口
+// Container.Health 并发调用所有实现此接口的 bean，返回 nil 表示健康
+type IHealthCheck interface {
+	// Health 检查自身健康状况（数据库连通性、下游可用性等），应在 ctx 结束时尽快返回
+	Health(ctx context.Context) error
+}
+
+// HealthReport 容器健康检查报告（见 Container.Health）
+type HealthReport struct {
+	// CheckedAt 检查开始时间
+	CheckedAt time.Time
+	// Duration 检查总耗时
+	Duration time.Duration
+	// Checks 每个 bean 的检查结果（按注册顺序）
+	Checks []HealthCheckResult
+}
+
+// HealthCheckResult 单个 bean 的健康检查结果
+type HealthCheckResult struct {
+	// Bean bean 名
+	Bean string
+	// Type bean 类型
+	Type string
+	// Err 检查失败的原因（nil 表示健康；panic 与 ctx 结束时未返回同样记为失败）
+	Err error
+	// Duration 检查耗时
+	Duration time.Duration
+}
+
+// Healthy 返回该 bean 是否健康
+func (r HealthCheckResult) Healthy() bool {
+	return r.Err == nil
+}
+
+// Healthy 返回所有检查是否都通过（没有检查时为 true）
+func (r *HealthReport) Healthy() bool {
+	return len(r.Failed()) == 0
+}
+
+// Failed 返回未通过的检查
+func (r *HealthReport) Failed() []HealthCheckResult {
+	var failed []HealthCheckResult
+	for _, check := range r.Checks {
+		if !check.Healthy() {
+			failed = append(failed, check)
+		}
+	}
+	return failed
+}
+
+// String 返回可读的健康检查摘要
+func (r *HealthReport) String() string {
+	var b strings.Builder
+	status := "UP"
+	if !r.Healthy() {
+		status = "DOWN"
+	}
+	fmt.Fprintf(&b, "[ioc233] 健康检查: %s (checks=%d failed=%d, %v)", status, len(r.Checks), len(r.Failed()), r.Duration)
+	for _, check := range r.Checks {
+		if check.Healthy() {
+			fmt.Fprintf(&b, "\n  ✓ %s (%v)", check.Bean, check.Duration)
+		} else {
+			fmt.Fprintf(&b, "\n  ✗ %s (%v): %v", check.Bean, check.Duration, check.Err)
+		}
+	}
+	return b.String()
+}
+
+// Health 并发执行所有实现 IHealthCheck 的 bean 的健康检查，返回每个 bean 的结果
+// ctx 结束时仍未返回的检查记为失败（检查在后台继续执行）；检查期间不持有容器锁
+func (c *Container) Health(ctx context.Context) *HealthReport {
+	var checks []HealthCheckResult
+	var checkers []IHealthCheck
+	c.withReadLock(func() {
+		for _, def := range c.beans {
+			if h, ok := def.instance.(IHealthCheck); ok {
+				checks = append(checks, HealthCheckResult{Bean: def.name, Type: def.typ.String()})
+				checkers = append(checkers, h)
+			}
+		}
+	})
+
+	report := &HealthReport{CheckedAt: time.Now(), Checks: checks}
+	var wg sync.WaitGroup
+	for i := range checks {
+		wg.Add(1)
+		go func(result *HealthCheckResult, h IHealthCheck) {
+			defer wg.Done()
+			began := time.Now()
+			result.Err = runHealthCheck(ctx, h)
+			result.Duration = time.Since(began)
+		}(&checks[i], checkers[i])
+	}
+	wg.Wait()
+	report.Duration = time.Since(report.CheckedAt)
+	return report
+}
+
+// runHealthCheck 执行单个检查：panic 转换为错误，ctx 结束时不再等待
+func runHealthCheck(ctx context.Context, h IHealthCheck) error {
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				done <- fmt.Errorf("健康检查 panic: %v", p)
+			}
+		}()
+		done <- h.Health(ctx)
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("健康检查未完成: %w", ctx.Err())
+	}
+}
+
+// probeResponse 探针端点的 JSON 响应
+type probeResponse struct {
+	Status string       `json:"status"`
+	State  string       `json:"state"`
+	Checks []probeCheck `json:"checks"`
+}
+
+// probeCheck 探针响应中单个 bean 的检查结果
+type probeCheck struct {
+	Bean     string        `json:"bean"`
+	Status   string        `json:"status"`
+	Error    string        `json:"error,omitempty"`
+	Duration time.Duration `json:"duration"`
+}
+
+// HealthHandler 返回存活探针的 http.Handler：容器未启动失败或关闭且所有健康检查通过时返回 200，否则返回 503
+//
+//	mux.Handle(ioc233.HealthPath, ioc233.HealthHandler(container))
+func HealthHandler(c *Container) http.Handler {
+	return probeHandler(c, func(state ContainerState) bool {
+		return state != StateFailed && state != StateClosed
+	})
+}
+
+// ReadyHandler 返回就绪探针的 http.Handler：容器启动完成且所有健康检查通过时返回 200，否则返回 503
+//
+//	mux.Handle(ioc233.ReadyPath, ioc233.ReadyHandler(container))
+func ReadyHandler(c *Container) http.Handler {
+	return probeHandler(c, func(state ContainerState) bool {
+		return state == StateStarted
+	})
+}
+
+// probeHandler 按容器状态与聚合健康检查结果输出 200/503 与 JSON 详情
+func probeHandler(c *Container, stateOK func(ContainerState) bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		state := c.State()
+		ctx, cancel := context.WithTimeout(r.Context(), probeTimeout)
+		defer cancel()
+		report := c.Health(ctx)
+
+		resp := probeResponse{Status: "UP", State: state.String(), Checks: []probeCheck{}}
+		for _, check := range report.Checks {
+			pc := probeCheck{Bean: check.Bean, Status: "UP", Duration: check.Duration}
+			if !check.Healthy() {
+				pc.Status, pc.Error = "DOWN", check.Err.Error()
+			}
+			resp.Checks = append(resp.Checks, pc)
+		}
+		code := http.StatusOK
+		if !stateOK(state) || !report.Healthy() {
+			resp.Status, code = "DOWN", http.StatusServiceUnavailable
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(code)
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			logError("[ioc233] 输出健康检查结果失败: %v", err)
+		}
+	})
+}
@@ -0,0 +1,56 @@
+package ioc233
+
+import (
+	"reflect"
+	"sync"
+)
+
+// implIndex 接口类型 -> 实现该接口的 bean（按注册顺序）
+// 接口在首次按接口解析时扫描建立索引，之后注册的 bean 在注册时追加到已建立的索引中，
+// 按接口注入与 GetObjectByType 不再逐个 bean 调用 Implements
+// 有独立的锁：读锁下的并发解析（含并行注入）也会建立索引
+type implIndex struct {
+	mutex   sync.Mutex
+	byIface map[reflect.Type][]*beanDefinition
+}
+
+// addBeanLocked 追加 bean 定义并更新已建立的接口索引（调用方需持有写锁）
+func (c *Container) addBeanLocked(def *beanDefinition) {
+	c.beans = append(c.beans, def)
+	c.invalidateReadViewLocked()
+	c.impls.mutex.Lock()
+	defer c.impls.mutex.Unlock()
+	for iface, defs := range c.impls.byIface {
+		if implementsInterface(def.typ, iface) {
+			c.impls.byIface[iface] = append(defs, def)
+		}
+	}
+}
+
+// resetImplIndexLocked 清空接口索引（bean 顺序或类型变化时调用，调用方需持有写锁）
+func (c *Container) resetImplIndexLocked() {
+	c.invalidateReadViewLocked()
+	c.impls.mutex.Lock()
+	defer c.impls.mutex.Unlock()
+	c.impls.byIface = nil
+}
+
+// implementationsOf 返回实现了接口的 bean 定义（调用方需持有读锁）
+func (c *Container) implementationsOf(iface reflect.Type) []*beanDefinition {
+	c.impls.mutex.Lock()
+	defer c.impls.mutex.Unlock()
+	if defs, ok := c.impls.byIface[iface]; ok {
+		return defs
+	}
+	var defs []*beanDefinition
+	for _, def := range c.beans {
+		if implementsInterface(def.typ, iface) {
+			defs = append(defs, def)
+		}
+	}
+	if c.impls.byIface == nil {
+		c.impls.byIface = make(map[reflect.Type][]*beanDefinition)
+	}
+	c.impls.byIface[iface] = defs
+	return defs
+}
@@ -0,0 +1,109 @@
+package ioc233
+
+import (
+	"context"
+	"time"
+)
+
+// startupRun StartUp 过程中的注入进度（已完成的 bean 与耗时统计）
+type startupRun struct {
+	completed []*beanDefinition
+	timings   []BeanTiming
+	timingOf  map[*beanDefinition]int
+}
+
+// injectTask 单个 bean 的注入任务：beginInjectLocked 之后、finishInjectLocked 之前
+type injectTask struct {
+	def       *beanDefinition
+	ctx       context.Context
+	end       func(err error)
+	timing    BeanTiming
+	failures  int
+	injectErr error
+}
+
+// injectOneLocked 按顺序完成单个 bean 的注入与注入回调（调用方需持有写锁）
+// 出错时中止启动，返回 abortStartUpLocked 的结果
+func (c *Container) injectOneLocked(ctx context.Context, run *startupRun, def *beanDefinition) error {
+	task, err := c.beginInjectLocked(ctx, run, def)
+	if err != nil {
+		return err
+	}
+	task.inject(c, resolveWrite)
+	return c.finishInjectLocked(run, task)
+}
+
+// beginInjectLocked 注入前阶段：检查 ctx、BeforeInject 后置处理器、InjectionStarted 事件、IInjectBefore 回调
+func (c *Container) beginInjectLocked(ctx context.Context, run *startupRun, def *beanDefinition) (*injectTask, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, c.abortStartUpLocked(err, run.completed, nil, c.uninjectedLocked(nil))
+	}
+
+	// 后置处理器（注入前，可替换实例）
+	begin := time.Now()
+	if err := c.postProcessLocked(def, BeanPostProcessor.BeforeInject); err != nil {
+		return nil, c.abortStartUpLocked(err, run.completed, nil, c.uninjectedLocked(nil))
+	}
+	processed := time.Since(begin)
+
+	t := def.typ
+	logInfo("[ioc233] 开始注入对象字段: struct=%s", displayTypeName(t))
+	c.emit(InjectionStarted{Bean: def.name, Type: t})
+	task := &injectTask{def: def, timing: BeanTiming{Bean: def.name, Type: t.String(), Construct: def.constructTime, Callbacks: processed}}
+	task.ctx, task.end = c.startSpanLocked(ctx, SpanInject,
+		SpanAttr{Key: SpanAttrBean, Value: def.name},
+		SpanAttr{Key: SpanAttrType, Value: t.String()})
+
+	// 触发注入前回调
+	begin = time.Now()
+	if obj, ok := def.instance.(IInjectBefore); ok {
+		logInfo("[ioc233] 触发注入前回调: %v", t)
+		if err := c.traceCallbackLocked(task.ctx, def, "OnInjectBefore", obj.OnInjectBefore); err != nil {
+			task.end(err)
+			return nil, c.abortStartUpLocked(err, run.completed, def, c.uninjectedLocked(def))
+		}
+	}
+	task.timing.Callbacks += time.Since(begin)
+	return task, nil
+}
+
+// inject 执行字段注入（并行注入时在工作协程中以 resolveRead 调用，只读取容器）
+func (t *injectTask) inject(c *Container, mode resolveMode) {
+	begin := time.Now()
+	var fieldErrs []error
+	fieldErrs, t.injectErr = c.injectFieldErrs(t.ctx, t.def.instance, mode)
+	t.failures = len(fieldErrs)
+	t.timing.Inject = time.Since(begin)
+}
+
+// finishInjectLocked 注入后阶段：登记待定依赖、IInjectAfter 回调、AfterInject 后置处理器，并记录完成
+func (c *Container) finishInjectLocked(run *startupRun, task *injectTask) error {
+	def := task.def
+	if task.injectErr != nil {
+		task.end(task.injectErr)
+		return c.abortStartUpLocked(task.injectErr, run.completed, def, c.uninjectedLocked(def))
+	}
+	c.collectPendingLocked(def)
+
+	// 触发注入后回调
+	begin := time.Now()
+	if obj, ok := def.instance.(IInjectAfter); ok {
+		logInfo("[ioc233] 触发注入后回调: %v", def.typ)
+		if err := c.traceCallbackLocked(task.ctx, def, "OnInjectAfter", obj.OnInjectAfter); err != nil {
+			task.end(err)
+			return c.abortStartUpLocked(err, run.completed, def, c.uninjectedLocked(def))
+		}
+	}
+	// 后置处理器（注入后，可替换实例，例如包装为代理）
+	if err := c.postProcessLocked(def, BeanPostProcessor.AfterInject); err != nil {
+		task.end(err)
+		return c.abortStartUpLocked(err, run.completed, def, c.uninjectedLocked(def))
+	}
+	task.timing.Callbacks += time.Since(begin)
+	task.end(injectionFailuresError(task.failures))
+	def.injected = true
+	run.completed = append(run.completed, def)
+	run.timingOf[def] = len(run.timings)
+	run.timings = append(run.timings, task.timing)
+	return nil
+}
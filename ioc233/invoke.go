@@ -0,0 +1,72 @@
+package ioc233
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// errorType error 接口类型
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// Invoke 从容器解析函数的全部参数并调用（函数注入）
+// 参数解析规则：
+//   - context.Context 取容器的 Context()
+//   - 切片类型注入元素类型的全部实现（按 IOrdered 排序）
+//   - 其他类型按 GetObjectByType 的规则解析，未找到时返回错误且不调用函数
+//
+// 函数最后一个返回值为 error 时将其返回，其余返回值被忽略；函数在容器锁之外调用，可以再访问容器：
+//
+//	err := container.Invoke(func(users UserService, db *sql.DB) error {
+//		return users.Warmup(db)
+//	})
+func (c *Container) Invoke(fn any) error {
+	fv := reflect.ValueOf(fn)
+	if fn == nil || fv.Kind() != reflect.Func {
+		return errors.New("[ioc233] Invoke 参数必须是函数")
+	}
+	ft := fv.Type()
+	if ft.IsVariadic() {
+		return fmt.Errorf("[ioc233] Invoke 不支持可变参数函数: %v", ft)
+	}
+
+	args := make([]reflect.Value, ft.NumIn())
+	var missing []string
+	c.withReadLock(func() {
+		for i := range args {
+			in := ft.In(i)
+			switch {
+			case in == contextType:
+				args[i] = reflect.ValueOf(c.Context())
+			case in == eventBusType:
+				args[i] = reflect.ValueOf(c.EventBus())
+			case in.Kind() == reflect.Slice:
+				items := c.collectOrdered(in.Elem())
+				args[i] = reflect.MakeSlice(in, 0, len(items))
+				for _, item := range items {
+					args[i] = reflect.Append(args[i], item)
+				}
+			default:
+				v, ok := c.resolveByType(in)
+				if !ok {
+					missing = append(missing, in.String())
+					continue
+				}
+				args[i] = v
+			}
+		}
+	})
+	if len(missing) > 0 {
+		return fmt.Errorf("[ioc233] Invoke 缺少参数依赖: func=%s missing=[%s]", funcName(fv), strings.Join(missing, ", "))
+	}
+	for _, arg := range args {
+		c.markUsed(arg)
+	}
+
+	out := fv.Call(args)
+	if n := ft.NumOut(); n > 0 && ft.Out(n-1) == errorType && !out[n-1].IsNil() {
+		return out[n-1].Interface().(error)
+	}
+	return nil
+}
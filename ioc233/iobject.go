@@ -29,3 +29,54 @@ type IObject interface {
 	// OnInjectComplete 所有依赖注入完成后的回调方法
 	OnInjectComplete()
 }
+
+// IDependencyChanged 依赖变更通知接口
+// 当对象的某个注入字段因 Swap（或派生 bean 重新计算）被替换为新实例后调用
+type IDependencyChanged interface {
+	// OnDependencyChanged 注入字段被重新注入后的回调方法，参数为字段名
+	OnDependencyChanged(field string)
+}
+
+// IDependencyResolved 待定依赖补齐通知接口
+// 启动时未能注入的必须字段，在匹配的 bean 于启动后注册并被补齐注入后调用（见 PendingDependencies）
+type IDependencyResolved interface {
+	// OnDependencyResolved 待定字段被补齐注入后的回调方法，参数为字段名
+	OnDependencyResolved(field string)
+}
+
+// IDependsOn 启动依赖声明接口
+// 返回的 bean 完成注入与注入回调之后当前 bean 才开始注入，OnInjectComplete 也在其之后触发
+// 与字段注入无关，也可用结构体 dependsOn 标签声明；依赖的 bean 属于更早的启动阶段时天然满足
+type IDependsOn interface {
+	// DependsOn 返回需要先完成启动的 bean 名
+	DependsOn() []string
+}
+
+// IOrdered 排序接口
+// 注入切片（例如中间件链、处理器管道）时，实现此接口的对象按 Order() 升序排列；
+// 未实现此接口的对象排在最后，相同顺序值保持注册顺序
+type IOrdered interface {
+	// Order 返回排序值，越小越靠前
+	Order() int
+}
+
+// IDestroy 停止生命周期接口
+// 容器关闭（Close）或启动被中止时，已完成注入的对象按注册逆序调用 OnDestroy 方法
+type IDestroy interface {
+	// OnDestroy 对象停止时的回调方法
+	OnDestroy()
+}
+
+// IWeighted 负载均衡权重接口
+// 注入 balance:"weighted" 门面时，实现此接口的对象按 Weight() 分配调用比例（默认 1）
+type IWeighted interface {
+	// Weight 返回权重，<= 0 表示不参与分发
+	Weight() int
+}
+
+// IAvailable 负载均衡可用性接口
+// 实现此接口且 Available() 返回 false 的对象在负载均衡分发时被临时排除
+type IAvailable interface {
+	// Available 返回当前实例是否可用
+	Available() bool
+}
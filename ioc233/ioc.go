@@ -1,10 +1,14 @@
 package ioc233
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"reflect"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // Container 全局 IOC 容器
@@ -14,6 +18,7 @@ import (
 //   - 注入语义说明：
 //     autowire:"true"  -> 必须注入，按字段类型（接口或具体类型）自动查找实现；找不到记录错误
 //     autowire:"false" -> 可选注入，按字段类型自动查找实现；找不到则保持 nil
+//     autowire:"new"   -> 按字段类型注入结构体指针；找不到时自动创建实例并注册
 //     autowire:"名称"   -> 名称注入，按 bean 名称查找；类型不兼容或未找到则记录错误
 type Container struct {
 	mutex sync.RWMutex
@@ -21,53 +26,336 @@ type Container struct {
 	// 业务模块依赖容器
 	serviceMap      map[reflect.Type]any
 	controllerMap   map[reflect.Type]any
-	typeToObjectMap map[reflect.Type]any
-	nameToObjMap    map[string]any
+	typeToObjectMap *shardedMap[reflect.Type]
+	nameToObjMap    *shardedMap[string]
 
 	// 控制器列表
 	controllerList []any
+	// 控制器及路由元数据（ProvideController）
+	controllers []Controller
+	// 业务服务（ProvideService）
+	services []Service
+	// 已升级的 WebSocket 连接（容器关闭时关闭）
+	webSockets webSocketHub
+	// 关闭时未能按时退出的托管运行 bean 与 Close 失败的资源（见 LeakReport）
+	stuckRunnables []*runnableEntry
+	closeFailures  []Leak
+
+	// 按注册顺序记录的 bean（注入、回调均按此顺序执行，保证结果稳定）
+	beans []*beanDefinition
+
+	// 派生 bean 定义（ProvideDerived）
+	derivedList []*derivedDefinition
+
+	// 构造函数定义（ProvideFactory）
+	factories []*factoryDefinition
+
+	// 值分组（dig 结果对象的 group 字段，见 ProvideDig）
+	valueGroups map[string][]reflect.Value
+
+	// 原型 bean 定义（ProvidePrototype）
+	prototypes []*prototypeDefinition
+
+	// 显式接口绑定（接口类型 -> 实现类型，Bind）
+	bindings map[reflect.Type]reflect.Type
+
+	// 负载均衡权重（bean 名 -> 权重）
+	balanceWeights map[string]int
+
+	// 激活的 profile（nil 表示未设置）
+	profiles []string
+	// 条件注册定义（StartUp 时求值）
+	conditionals []*conditionalDefinition
+	// 兜底实现定义（ProvideIfMissing，在其他条件注册之后求值）
+	missingDefaults []*conditionalDefinition
+	// 是否正在求值条件注册
+	applyingConditionals bool
+
+	// 停止原型预热后台补充的信号
+	standbyStop chan struct{}
+
+	// 是否在启动后扫描仍为 nil 的注入字段
+	nilFieldScan bool
+	// 接口 -> 实现 bean 索引（见 implementationsOf）
+	impls implIndex
+	// 启动后发布的只读注册快照（见 readView）
+	view atomic.Pointer[readView]
+
+	// 是否已冻结（Freeze），冻结后拒绝注册新的 bean
+	frozen bool
+	// StartUp 成功后是否自动冻结
+	freezeAfterStartUp bool
+
+	// 单个生命周期回调的最长执行时间与超时策略（见 SetCallbackTimeout）
+	callbackTimeout       time.Duration
+	callbackTimeoutPolicy CallbackTimeoutPolicy
+	// 预热失败后的处理策略（见 SetWarmUpPolicy）
+	warmUpPolicy WarmUpPolicy
+	// 重复注册的处理策略（见 SetDuplicatePolicy）
+	duplicatePolicy DuplicatePolicy
+	// 默认 bean 名是否带包路径（见 SetNamespacedNames）
+	namespacedNames atomic.Bool
+
+	// 托管运行 bean（IRunnable）：运行上下文、已启动列表与关闭等待时长
+	runCtx              context.Context
+	runCancel           context.CancelFunc
+	runnables           []*runnableEntry
+	runnableStopTimeout time.Duration
+	restartPolicies     map[string]RestartPolicy
+
+	// 并行注入的工作协程数（<=1 时按顺序注入，见 SetInjectionWorkers）
+	injectionWorkers int
+	// 最近一次成功启动的报告
+	report *StartupReport
+
+	// 已安装的模块（Install 去重）
+	installedModules map[Module]bool
+
+	// 迁移使用的 *sql.DB bean 名（为空时取第一个 *sql.DB bean）
+	migrationDB string
+
+	// 父容器（作用域/子容器解析未命中时回退查找）
+	parent *Container
+	// 作用域上下文（NewScope 创建的容器可注入 context.Context 字段）
+	ctx context.Context
+
+	// 容器生命周期状态
+	state ContainerState
+
+	// 适配器注册的启动完成/关闭钩子（ContainerAdapter.OnStarted/OnStopping）
+	// 由 hooksMu 单独保护：注册方可能是其他协程，也可能是只持有容器读锁的标签处理器
+	hooksMu       sync.Mutex
+	startedHooks  []func()
+	stoppingHooks []func()
+	// 钩子是否已经执行（之后注册的钩子立即执行）
+	startedHooksFired  bool
+	stoppingHooksFired bool
+
+	// 是否有 StartUp 正在执行（并发或在回调中重复调用时返回 ErrStartUpInProgress）
+	startingUp atomic.Bool
+
+	// 是否关闭启动横幅与启动报告日志（SetQuietStartup）
+	quietStartup bool
+
+	// 测试覆盖层栈（PushOverlay/PopOverlay）
+	overlays []*overlayFrame
+
+	// 任务调度器（EnableScheduler 启用后非 nil）
+	scheduler *Scheduler
 
 	// 启动前的致命错误（例如重复的 ProvideByName）
 	fatalErrors []error
+	// 最近一次 StartUp 返回的错误（成功时为 nil，见 DumpJSON）
+	startupErr error
+
+	// 运行指标计数器（见 Metrics）
+	counters containerCounters
+
+	// 启动追踪钩子（SetStartupTracer）
+	tracer StartupTracer
+
+	// 事件订阅者（Subscribe）
+	events eventBus
+	// 类型化事件总线（见 EventBus，首次使用时创建）
+	bus     *EventBus
+	busOnce sync.Once
+	// 自动订阅（IEventHandler / On<事件类型名>）的取消函数，容器关闭时调用
+	eventSubscriptions []func()
+
+	// bean 后置处理器（RegisterPostProcessor）
+	postProcessors []BeanPostProcessor
+
+	// 自定义标签处理器（RegisterTagHandler）
+	tagHandlers []tagHandlerEntry
+
+	// 配置源（AddConfigSource，按添加顺序查找 value 标签中的占位符）
+	configSources []ConfigSource
+	// 配置源监听的停止函数（WatchableConfigSource，Close 时调用）
+	configWatchStops []func()
+	// ProvideConfig 记录的配置绑定（配置变更时重新绑定）
+	configBindings []configBinding
+
+	// 密钥源（AddSecretSource，按添加顺序查找 secret 标签）
+	secretSources []SecretSource
+
+	// 特性开关源（SetFeatureFlagSource，WithFeatureFlag 注册的 bean 按开关激活）
+	featureFlags FeatureFlagSource
+
+	// 未能注入的必须字段（匹配的 bean 启动后注册时补齐，见 PendingDependencies）
+	pending []*pendingField
+
+	// 启动阶段（DefinePhases，按顺序分批注入）
+	phases []string
+
+	// 运行期被注入或获取过的实例（见 UnusedBeans）
+	used sync.Map
+	// 成功的字段注入记录（见 InjectionAudit）
+	audit injectionAudit
+}
+
+// beanDefinition 已注册 bean 的元信息
+type beanDefinition struct {
+	name     string
+	typ      reflect.Type
+	instance any
+	// visibleTo 允许注入的消费方模块（为空表示不限制，见 VisibleTo）
+	visibleTo []string
+	// constructTime 构造函数耗时（ProvideFactory 创建的 bean，用于启动报告）
+	constructTime time.Duration
+	// injected 是否已完成注入（重复调用 StartUp 时只注入尚未注入的 bean）
+	injected bool
+	// phase 所属启动阶段（InPhase 选项，为空时取结构体 phase 标签）
+	phase string
+	// noAutoClose 容器关闭时不调用 io.Closer（WithoutAutoClose 选项）
+	noAutoClose bool
+	// tags 标签（WithTags 选项，见 FindBeansByTag）
+	tags []string
+	// description 描述（WithDescription 选项）
+	description string
+	// groups 所属的命名分组（InGroup 选项，见 autowire:"group:名称"）
+	groups []string
 }
 
 var (
-	_instance *Container
-	_once     sync.Once
-	_testMode bool // 测试模式标志
+	// _default 包级默认容器（包级函数与 Instance 均委托给它）
+	_default     *Container
+	_defaultLock sync.Mutex
+	_testMode    bool // 测试模式标志
 )
 
 // Reset 重置容器实例（仅用于测试）
-// 注意：此函数会清空所有已注册的对象，仅应在测试环境中使用
+// 注意：此函数会清空所有已注册的对象，仅应在测试环境中使用；测试中优先使用 ResetForTesting
 func Reset() {
-	_instance = nil
-	_once = sync.Once{}
+	_defaultLock.Lock()
+	defer _defaultLock.Unlock()
+	_default = nil
 }
 
-// Instance 获取全局 IOC 容器实例（单例）
-func Instance() *Container {
-	_once.Do(func() {
-		_instance = &Container{
-			serviceMap:      make(map[reflect.Type]any),
-			controllerMap:   make(map[reflect.Type]any),
-			typeToObjectMap: make(map[reflect.Type]any),
-			nameToObjMap:    make(map[string]any),
-			controllerList:  make([]any, 0, 64),
-			fatalErrors:     make([]error, 0, 8),
+// TestingT ResetForTesting 依赖的测试对象能力（*testing.T、*testing.B 均满足）
+type TestingT interface {
+	Helper()
+	Cleanup(func())
+}
+
+// ResetForTesting 为当前测试准备全新的默认容器并返回
+// 测试结束时关闭该容器，并恢复之前的默认容器与测试模式，无需 build tag：
+//
+//	func TestX(t *testing.T) {
+//		c := ioc233.ResetForTesting(t)
+//		c.Provide(&UserServiceImpl{})
+//	}
+func ResetForTesting(t TestingT) *Container {
+	t.Helper()
+	fresh := NewContainer()
+	_defaultLock.Lock()
+	prev, prevTestMode := _default, _testMode
+	_default = fresh
+	_defaultLock.Unlock()
+
+	t.Cleanup(func() {
+		_ = fresh.Close()
+		_defaultLock.Lock()
+		defer _defaultLock.Unlock()
+		if _default == fresh {
+			_default = prev
 		}
+		_testMode = prevTestMode
 	})
-	return _instance
+	return fresh
+}
+
+// Default 返回包级默认容器（首次调用时创建）
+// 包级函数（GetObjectByType、GetObjectsByType 等）都委托给默认容器；
+// 库代码应优先接收显式的 *Container，而不是依赖默认容器
+func Default() *Container {
+	_defaultLock.Lock()
+	defer _defaultLock.Unlock()
+	if _default == nil {
+		_default = NewContainer()
+	}
+	return _default
+}
+
+// SetDefault 替换包级默认容器，返回之前的默认容器（可能为 nil）
+// 传入 nil 表示下次调用 Default 时重新创建
+func SetDefault(c *Container) *Container {
+	_defaultLock.Lock()
+	defer _defaultLock.Unlock()
+	prev := _default
+	_default = c
+	return prev
+}
+
+// Instance 获取全局 IOC 容器实例（单例）
+// 等价于 Default()，保留以兼容已有调用方
+func Instance() *Container {
+	return Default()
+}
+
+// NewContainer 创建一个独立的 IOC 容器（非全局单例）
+// 适用于单元测试、装配校验或同一进程内需要多份隔离注册表的场景
+func NewContainer() *Container {
+	return &Container{
+		serviceMap:      make(map[reflect.Type]any),
+		controllerMap:   make(map[reflect.Type]any),
+		typeToObjectMap: newShardedMap[reflect.Type](),
+		nameToObjMap:    newShardedMap[string](),
+		controllerList:  make([]any, 0, 64),
+		beans:           make([]*beanDefinition, 0, 64),
+		fatalErrors:     make([]error, 0, 8),
+	}
 }
 
 // Provide 注册一个对象到 IOC 容器（自动使用结构体名作为 bean 名）
 // 说明：
 // - 仅在 ioc 内维护类型/名称到实例的映射
 // - 不进行业务维度的分类判断（Controller/Service/ConfigManager），由 apps 统一处理
-func (c *Container) Provide(instance any) {
+// - StartUp 之后注册的对象立即执行注入与生命周期回调
+// - 容器已冻结（Freeze）时不注册并返回 ErrContainerFrozen
+// - 重复注册按 SetDuplicatePolicy 处理：默认保留首个实例并记录警告，返回 nil；DuplicateError 策略下返回 ErrDuplicateBean
+func (c *Container) Provide(instance any, opts ...BeanOption) error {
+	prepared := c.prepareBean(instance)
+	if c.provideFast("", instance, prepared, opts) {
+		return nil
+	}
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
+	if err := c.checkFrozenLocked("Provide", instance); err != nil {
+		return err
+	}
+	if c.gateByFeatureFlagLocked("", instance, opts) {
+		return nil
+	}
+	from := len(c.beans)
+	if err := c.providePreparedLocked(instance, prepared); err != nil {
+		return err
+	}
+	c.applyBeanOptionsLocked(instance, opts)
+	c.bindLateLocked(from)
+	return nil
+}
+
+// provideLocked Provide 的内部实现（调用方需持有写锁）
+// 重复注册的错误已记录在 fatalErrors 中，由 StartUp 报告
+func (c *Container) provideLocked(instance any) {
+	_ = c.providePreparedLocked(instance, nil)
+}
+
+// providePreparedLocked 注册对象；prepared 不为 nil 时使用在读锁下预先解析的基础字段值（见 prepareBean，调用方需持有写锁）
+func (c *Container) providePreparedLocked(instance any, prepared *preparedBean) error {
 	if instance == nil {
-		return
+		return nil
+	}
+
+	// 带 profile 标签的结构体延迟到 StartUp 时按激活的 profile 注册
+	if expr := profileTagOf(instance); expr != "" && !c.applyingConditionals {
+		c.addProfileConditionalLocked(expr, "", instance)
+		return nil
+	}
+
+	if len(c.overlays) > 0 {
+		c.provideOverlayLocked("", instance)
+		return nil
 	}
 
 	t := reflect.TypeOf(instance)
@@ -75,33 +363,35 @@ func (c *Container) Provide(instance any) {
 		logWarn("[ioc233] Provide 建议注册指针类型: %v", t)
 	}
 
+	// 默认 bean 名为结构体名（不含包名；SetNamespacedNames 开启时带包路径）
+	beanName := c.defaultBeanName(t)
+	if existing, exists := c.duplicateOfLocked(beanName, t, true); exists {
+		if handled, err := c.resolveDuplicateLocked("Provide", beanName, existing, instance); handled {
+			return err
+		}
+	}
+
 	// 初始化基础字段（跳过 autowire:"true"）
-	c.initBasicFields(instance)
+	c.initPreparedLocked(instance, prepared)
 
 	// 记录类型映射（重复类型则忽略并警告，保留首个实例）
-	if _, exists := c.typeToObjectMap[t]; exists {
+	if c.typeToObjectMap.taken(t) {
 		logWarn("[ioc233] Provide 重复类型注册，忽略: %v", t)
-		return
+		return nil
 	}
-	c.typeToObjectMap[t] = instance
+	c.typeToObjectMap.store(t, instance)
 
-	// 默认 bean 名为结构体名（不含包名）
-	beanName := t.Name()
-	if beanName == "" && t.Kind() == reflect.Ptr {
-		beanName = t.Elem().Name()
-	}
-	if beanName == "" {
-		beanName = t.String()
-	}
 	// 如果默认名已存在，警告并跳过名称注册（不阻断启动）
-	if _, exists := c.nameToObjMap[beanName]; exists {
+	if c.nameToObjMap.taken(beanName) {
 		logWarn("[ioc233] Provide 默认 bean 名重复，忽略: %s", beanName)
 	} else {
-		c.nameToObjMap[beanName] = instance
+		c.nameToObjMap.store(beanName, instance)
 	}
+	c.addBeanLocked(&beanDefinition{name: beanName, typ: t, instance: instance})
 
 	typeName := t.String()
 	logInfo("[ioc233] 注册 bean | struct name = %s (type: %v)", typeName, t)
+	c.emit(BeanRegistered{Name: beanName, Type: t})
 
 	// 触发注册后回调
 	if obj, ok := instance.(IProvideAfter); ok {
@@ -110,23 +400,61 @@ func (c *Container) Provide(instance any) {
 	}
 
 	// 业务分类与 ConfigManager 的注册由 apps 包负责
+	return nil
 }
 
-// ProvideByName 按指定名称注册对象（重复名视为致命错误）
+// ProvideByName 按指定名称注册对象（默认重复名视为致命错误，见 SetDuplicatePolicy）
 // 说明：
 // - 仅维护名称到实例的映射；业务维度的分类与注册交由 apps 包处理
-func (c *Container) ProvideByName(name string, instance any) error {
+// - StartUp 之后注册的对象立即执行注入与生命周期回调
+func (c *Container) ProvideByName(name string, instance any, opts ...BeanOption) error {
+	prepared := c.prepareBean(instance)
+	if strings.TrimSpace(name) != "" && c.provideFast(name, instance, prepared, opts) {
+		return nil
+	}
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
+	if err := c.checkFrozenLocked("ProvideByName", instance); err != nil {
+		return err
+	}
+	if strings.TrimSpace(name) != "" && c.gateByFeatureFlagLocked(name, instance, opts) {
+		return nil
+	}
+	from := len(c.beans)
+	if err := c.provideByNamePreparedLocked(name, instance, prepared); err != nil {
+		return err
+	}
+	c.applyBeanOptionsLocked(instance, opts)
+	c.bindLateLocked(from)
+	return nil
+}
+
+// provideByNameLocked ProvideByName 的内部实现（调用方需持有写锁）
+func (c *Container) provideByNameLocked(name string, instance any) error {
+	return c.provideByNamePreparedLocked(name, instance, nil)
+}
+
+// provideByNamePreparedLocked 按名称注册对象；prepared 含义同 providePreparedLocked（调用方需持有写锁）
+func (c *Container) provideByNamePreparedLocked(name string, instance any, prepared *preparedBean) error {
 	if instance == nil || strings.TrimSpace(name) == "" {
 		return errors.New("[ioc233] ProvideByName 参数非法")
 	}
 
-	if _, exists := c.nameToObjMap[name]; exists {
-		err := errors.New("[ioc233] ProvideByName 重复注册: name=" + name)
-		logError("%s", err.Error())
-		c.fatalErrors = append(c.fatalErrors, err)
-		return err
+	if expr := profileTagOf(instance); expr != "" && !c.applyingConditionals {
+		c.addProfileConditionalLocked(expr, name, instance)
+		return nil
+	}
+
+	if len(c.overlays) > 0 {
+		c.provideOverlayLocked(name, instance)
+		return nil
+	}
+
+	if existing, exists := c.duplicateOfLocked(name, reflect.TypeOf(instance), false); exists {
+		if handled, err := c.resolveDuplicateLocked("ProvideByName", name, existing, instance); handled {
+			return err
+		}
+		return c.duplicateErrorLocked("ProvideByName", name, instance)
 	}
 
 	t := reflect.TypeOf(instance)
@@ -134,13 +462,15 @@ func (c *Container) ProvideByName(name string, instance any) error {
 		logWarn("[ioc233] ProvideByName 建议注册指针类型: %v", t)
 	}
 
-	c.initBasicFields(instance)
+	c.initPreparedLocked(instance, prepared)
 
-	c.typeToObjectMap[t] = instance
-	c.nameToObjMap[name] = instance
+	c.typeToObjectMap.store(t, instance)
+	c.nameToObjMap.store(name, instance)
+	c.addBeanLocked(&beanDefinition{name: name, typ: t, instance: instance})
 
 	typeName := t.String()
 	logInfo("[ioc233] 注册 bean(byName) | name = %s, struct = %s (type: %v)", name, typeName, t)
+	c.emit(BeanRegistered{Name: name, Type: t})
 
 	// 触发注册后回调
 	if obj, ok := instance.(IProvideAfter); ok {
@@ -152,245 +482,796 @@ func (c *Container) ProvideByName(name string, instance any) error {
 	return nil
 }
 
+// ErrStartUpInProgress StartUp 正在执行时（其他协程并发调用，或在生命周期回调中调用）再次调用 StartUp 返回的错误
+var ErrStartUpInProgress = errors.New("[ioc233] StartUp 正在执行中，不能并发或在回调中重复调用")
+
 // StartUp 执行依赖注入（autowire）
 // 行为：
 // - 遍历所有注册对象，按字段标签执行注入
 // - 触发对象的 OnInjectComplete 生命周期回调
 // - 若之前记录致命错误（如 ProvideByName 重复），则阻止启动
+// - 已启动的容器再次调用时只注入上次调用之后新增、尚未注入的 bean，已注入的 bean 不会重复触发回调
 func (c *Container) StartUp() error {
+	return c.StartUpCtx(context.Background())
+}
+
+// StartUpWithTimeout 在 d 时间内完成启动，超时则中止（d <= 0 时等同 StartUp）
+// 超时返回 *StartupAbortedError（errors.Is(err, context.DeadlineExceeded) 为 true），
+// 并记录一条日志列出注入到一半与尚未完成注入的 bean，便于定位卡住的启动；
+// 卡在生命周期回调中时同样在截止时间中止（回调在后台继续执行）
+func (c *Container) StartUpWithTimeout(d time.Duration) error {
+	if d <= 0 {
+		return c.StartUp()
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	defer cancel()
+	err := c.StartUpCtx(ctx)
+	var aborted *StartupAbortedError
+	if errors.As(err, &aborted) && errors.Is(err, context.DeadlineExceeded) {
+		logError("[ioc233] 启动超时（%v）: %v; 注入到一半=%q 未完成注入=%v", d, aborted.Cause, aborted.Partial, aborted.Pending)
+	}
+	return err
+}
+
+// StartUpCtx 执行依赖注入（可取消）
+// 行为与 StartUp 一致，额外支持通过 ctx 中止启动：
+// - 每个对象、每个注入字段之间都会检查 ctx
+// - 中止时已完成注入的对象按逆序触发 IDestroy 停止回调
+// - 返回 *StartupAbortedError，列出已完成、注入到一半和尚未开始的对象
+// - 任何启动失败都会使容器进入 StateFailed 状态
+func (c *Container) StartUpCtx(ctx context.Context) (err error) {
+	if !c.startingUp.CompareAndSwap(false, true) {
+		return ErrStartUpInProgress
+	}
+	defer c.startingUp.Store(false)
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
+	defer func() { c.startupErr = err }()
 
-	logInfo("[ioc233] 🚀 正在启动 IOC 容器并执行依赖注入...")
+	if c.state == StateFailed || c.state == StateClosed {
+		return fmt.Errorf("[ioc233] 容器处于 %s 状态，无法启动", c.state)
+	}
+	if c.state == StateStarted {
+		// 重复调用：只补齐尚未注入的 bean
+		c.bindLateLocked(0)
+		return ctx.Err()
+	}
+
+	if !c.quietStartup {
+		logInfo("[ioc233] 🚀 正在启动 IOC 容器并执行依赖注入...")
+	}
+	c.state = StateStarting
+	startedAt := time.Now()
+	ctx, endStartup := c.startSpanLocked(ctx, SpanStartUp)
+	defer func() { endStartup(err) }()
+	defer func() { c.emit(StartupCompleted{Duration: time.Since(startedAt), BeanCount: len(c.beans), Err: err}) }()
+
+	// 求值条件注册（profile 等）
+	c.applyConditionalsLocked()
 
 	// 先检查是否存在致命错误（例如重复 ProvideByName）
 	if len(c.fatalErrors) > 0 {
 		for _, e := range c.fatalErrors {
 			logError("[ioc233] 致命错误: %v", e)
 		}
-		return errors.New("[ioc233] 容器存在致命错误，启动失败")
+		c.state = StateFailed
+		return fmt.Errorf("[ioc233] 容器存在致命错误，启动失败: %w", errors.Join(c.fatalErrors...))
 	}
 
-	// 注入字段
-	for t, instance := range c.typeToObjectMap {
-		typeName := t.Name()
-		if typeName == "" && t.Kind() == reflect.Ptr {
-			typeName = t.Elem().Name()
+	// 依赖环检测：构造函数等必须先就绪的依赖成环时无法启动；字段注入成环可以安全注入
+	var fieldCycles []DependencyCycle
+	if cycles := c.dependencyCyclesLocked(); len(cycles) > 0 {
+		var fatal []DependencyCycle
+		for _, cycle := range cycles {
+			if cycle.Fatal {
+				fatal = append(fatal, cycle)
+			} else {
+				fieldCycles = append(fieldCycles, cycle)
+				logInfo("[ioc233] 字段注入循环依赖（可安全注入）: %s", cycle)
+			}
 		}
-		if typeName == "" {
-			typeName = t.String()
+		if len(fatal) > 0 {
+			err := &CycleError{Cycles: fatal}
+			logError("%s", err.Error())
+			c.state = StateFailed
+			return err
 		}
-		logInfo("[ioc233] 开始注入对象字段: struct=%s", typeName)
+	}
 
-		// 触发注入前回调
-		if obj, ok := instance.(IInjectBefore); ok {
-			logInfo("[ioc233] 触发注入前回调: %v", t)
-			obj.OnInjectBefore()
-		}
+	// 按依赖顺序调用构造函数（ProvideFactory）
+	if err := c.buildFactoriesLocked(); err != nil {
+		logError("%s", err.Error())
+		c.state = StateFailed
+		return err
+	}
 
-		// 执行注入
-		c.injectInternal(instance)
+	// 计算派生 bean（依赖的 bean 均已注册）
+	if err := c.computeDerivedLocked(); err != nil {
+		c.state = StateFailed
+		return err
+	}
 
-		// 触发注入后回调
-		if obj, ok := instance.(IInjectAfter); ok {
-			logInfo("[ioc233] 触发注入后回调: %v", t)
-			obj.OnInjectAfter()
+	// 可见性受限的 bean 不允许注入到未授权的模块
+	if errs := c.visibilityViolationsLocked(); len(errs) > 0 {
+		for _, e := range errs {
+			logError("%s", e.Error())
 		}
+		c.state = StateFailed
+		return errors.Join(errs...)
+	}
+
+	// 启动阶段（DefinePhases）：每个阶段完成注入与 OnInjectComplete 回调后再进入下一阶段；未定义阶段时只有一个阶段
+	// 阶段内按 dependsOn（IDependsOn 或结构体 dependsOn 标签）排序：依赖的 bean 先完成注入与注入回调，OnInjectComplete 也按此顺序触发
+	ranks, err := c.phaseRanksLocked()
+	var order [][]*beanDefinition
+	if err == nil {
+		order, err = c.startupOrderLocked(ranks)
+	}
+	if err != nil {
+		logError("%s", err.Error())
+		c.state = StateFailed
+		return err
 	}
 
-	// 注入完成回调
-	for t, instance := range c.typeToObjectMap {
-		if obj, ok := instance.(IObject); ok {
-			logInfo("[ioc233] 注入完成回调: %v", t)
-			obj.OnInjectComplete()
+	// 注入字段（同时记录每个 bean 的注入与回调耗时）
+	run := &startupRun{timingOf: make(map[*beanDefinition]int, len(c.beans))}
+	extra := len(c.beans)
+	for wave := 0; wave <= len(c.phases); wave++ {
+		if len(c.phases) > 0 {
+			logInfo("[ioc233] 进入启动阶段: %s", c.phaseName(wave))
 		}
+		waveStart := len(run.completed)
+		// next 先按顺序取本阶段的 bean，再取注入过程中自动创建（autowire:"new"、按需构造）追加到末尾的 bean，后者归入当前阶段
+		pos := 0
+		next := func() *beanDefinition {
+			for ; pos < len(order[wave]); pos++ {
+				if !order[wave][pos].injected {
+					pos++
+					return order[wave][pos-1]
+				}
+			}
+			for ; extra < len(c.beans); extra++ {
+				if !c.beans[extra].injected {
+					extra++
+					return c.beans[extra-1]
+				}
+			}
+			return nil
+		}
+		if c.injectionWorkers > 1 && len(c.postProcessors) == 0 {
+			if err := c.injectWaveParallelLocked(ctx, run, order[wave]); err != nil {
+				return err
+			}
+		}
+		for def := next(); def != nil; def = next() {
+			if err := c.injectOneLocked(ctx, run, def); err != nil {
+				return err
+			}
+		}
+
+		// 迁移阶段：依赖已就绪、对象尚未对外提供服务之前执行数据库迁移（分阶段启动时在第一个阶段注入完成后执行）
+		if wave == 0 {
+			if err := c.runMigrationsLocked(ctx); err != nil {
+				c.destroyLocked(run.completed)
+				c.state = StateFailed
+				logError("%s", err.Error())
+				return err
+			}
+		}
+
+		// 事件处理 bean 在完成回调之前订阅，回调中发布的事件也能送达
+		c.subscribeHandlersLocked(run.completed[waveStart:])
+
+		// 注入完成回调
+		for _, def := range run.completed[waveStart:] {
+			if err := ctx.Err(); err != nil {
+				return c.abortStartUpLocked(err, run.completed, nil, c.uninjectedLocked(nil))
+			}
+			if obj, ok := def.instance.(IObject); ok {
+				logInfo("[ioc233] 注入完成回调: %v", def.typ)
+				begin := time.Now()
+				if err := c.traceCallbackLocked(ctx, def, "OnInjectComplete", obj.OnInjectComplete); err != nil {
+					return c.abortStartUpLocked(err, run.completed, nil, c.uninjectedLocked(nil))
+				}
+				if i, ok := run.timingOf[def]; ok {
+					run.timings[i].Callbacks += time.Since(begin)
+				}
+			}
+		}
+	}
+
+	// 预热：所有 bean 注入与完成回调结束后并发执行
+	warmUpFailures, err := c.warmUpLocked(ctx, run.completed)
+	if err != nil {
+		return c.abortStartUpLocked(err, run.completed, nil, nil)
 	}
 
-	logInfo("[ioc233] ✅ IOC 容器启动完成，所有依赖注入已就绪")
+	report := &StartupReport{StartedAt: startedAt, BeanCount: len(c.beans), BeanTimings: run.timings, FieldCycles: fieldCycles, WarmUpFailures: warmUpFailures}
+	if c.nilFieldScan {
+		report.NilFields = c.scanNilFieldsLocked()
+	}
+	report.Duration = time.Since(startedAt)
+	c.report = report
+	if len(report.NilFields) > 0 && !c.quietStartup {
+		logWarn("%s", report.String())
+	}
+
+	c.state = StateStarted
+	c.publishReadViewLocked()
+	if c.freezeAfterStartUp {
+		c.frozen = true
+	}
+	c.startStandbyAllLocked()
+	for _, hook := range c.takeStartedHooks() {
+		hook()
+	}
+	c.warnUnscheduledLocked()
+	c.startRunnablesLocked(c.beans)
+
+	if !c.quietStartup {
+		logInfo("[ioc233] ✅ IOC 容器启动完成，所有依赖注入已就绪")
+	}
 	return nil
 }
 
 // initBasicFields 初始化基础字段（map、slice、*rand.Rand 等）
 // 规则：
 // - 跳过携带 autowire/inject 标签的字段，避免与注入阶段冲突
+// - 携带 env 标签且环境变量存在的字段设置为变量值（必需变量缺失或值非法记为致命错误）
+// - 携带 default 标签的零值字段设置为标签中的字面量（非法字面量记为致命错误）
 // - 对 map/slice/*rand.Rand 等可导出字段进行默认初始化
 func (c *Container) initBasicFields(instance any) {
+	c.initPreparedLocked(instance, nil)
+}
+
+// initBasicFieldsErrs initBasicFields 的实现，返回需要记为致命错误的字段错误
+// prepared 不为 nil 时使用预先解析的 env/default 标签值（见 prepareBean），否则在此解析（调用方需持有锁）
+func (c *Container) initBasicFieldsErrs(instance any, prepared *preparedBean) []error {
 	v := reflect.ValueOf(instance)
 	if v.Kind() != reflect.Ptr {
-		return
+		return nil
 	}
 	elem := v.Elem()
 	if elem.Kind() != reflect.Struct {
-		return
+		return nil
 	}
+	var errs []error
 
 	t := elem.Type()
-	for i := 0; i < t.NumField(); i++ {
-		field := t.Field(i)
-		if !elem.Field(i).CanSet() {
+	for i, plan := range structMetaOf(t).plans {
+		field := plan.field
+		fv := fieldByIndex(elem, field.Index, false)
+		if !fv.IsValid() || !fv.CanSet() {
 			continue
 		}
-		aw := field.Tag.Get("autowire")
-		inj := field.Tag.Get("inject")
-		if aw != "" || inj != "" {
+		if plan.autowire != "" {
 			// 任何声明了 autowire/inject 的字段都跳过基础初始化
 			continue
 		}
-		fv := elem.Field(i)
+
+		// env:"NAME" 环境变量（优先于 default 标签），其次 default:"..." 字面量默认值
+		var r basicFieldValue
+		if prepared != nil {
+			r = prepared.fields[i]
+		} else {
+			r = c.resolveBasicField(t.Name(), field, fv)
+		}
+		for _, err := range r.errs {
+			logError("%s", err.Error())
+			errs = append(errs, err)
+		}
+		if r.value.IsValid() {
+			fv.Set(r.value)
+			if r.fromEnv {
+				logDebug("[ioc233] 环境变量注入: struct=%s field=%s", t.Name(), field.Name)
+			}
+		}
+		if r.handled {
+			continue
+		}
 
 		if ApplyDefaultProviders(field, fv) {
 			logDebug("[ioc233] 字段默认值提供器应用: struct=%s field=%s type=%s", t.Name(), field.Name, field.Type.String())
 		}
 	}
+	return errs
 }
 
 // injectInternal 执行依赖注入（核心）
 // 规则：
 // - autowire:"true"  -> 必须按类型注入；找不到实现则记录错误
 // - autowire:"false" -> 可选按类型注入；找不到实现则保持 nil
+// - autowire:"new"   -> 按类型注入结构体指针；找不到时自动创建并注册
+// - 切片字段         -> 注入元素类型的全部实现（按 IOrdered 排序）
 // - 其他             -> 作为名称注入；不兼容或未找到则记录错误
-func (c *Container) injectInternal(instance any) {
+// - mode 为调用方持有的锁对应的解析方式（见 resolveMode）
+func (c *Container) injectInternal(instance any, mode resolveMode) {
+	_ = c.injectFields(context.Background(), instance, mode)
+}
+
+// injectFields 执行单个对象的字段注入，每个字段注入前检查 ctx，被取消时返回 ctx 错误
+func (c *Container) injectFields(ctx context.Context, instance any, mode resolveMode) error {
+	_, err := c.injectFieldErrs(ctx, instance, mode)
+	return err
+}
+
+// injectFieldErrs injectFields 的实现，额外返回本次注入失败的字段错误（均已记录日志并发出 InjectionFailed 事件）
+func (c *Container) injectFieldErrs(ctx context.Context, instance any, mode resolveMode) ([]error, error) {
 	v := reflect.ValueOf(instance)
 	if v.Kind() != reflect.Ptr {
-		return
+		return nil, nil
 	}
 	v = v.Elem()
 	if v.Kind() != reflect.Struct {
-		return
+		return nil, nil
+	}
+	var failed []error
+	fail := func(field reflect.StructField, err error) {
+		failed = append(failed, err)
+		c.injectionFailed(instance, field, err)
 	}
 
 	t := v.Type()
-	for i := 0; i < t.NumField(); i++ {
-		field := t.Field(i)
-		tag := field.Tag.Get("autowire")
+	structName := displayTypeName(t)
+	for _, plan := range structMetaOf(t).plans {
+		field, tag := plan.field, plan.autowire
+		expr, hasValue := plan.value, plan.hasValue
+		secretPath, hasSecret := plan.secret, plan.hasSecret
+		handlers := c.tagHandlersFor(field)
+		if tag == "" && !hasValue && !hasSecret && len(handlers) == 0 {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return failed, err
+		}
+		fv := fieldByIndex(v, field.Index, true)
+		if !fv.IsValid() || !fv.CanSet() {
+			logError("[ioc233] 字段 %s.%s 带有注入标签但不可导出，跳过注入", t.Name(), field.Name)
+			continue
+		}
+
+		// 配置占位符（value:"${key:default}"）
+		if hasValue {
+			if resolved, err := c.resolveValueTag(structName, field, expr); err != nil {
+				fail(field, err)
+			} else {
+				fv.Set(resolved)
+			}
+		}
+		// 密钥（secret:"path"）
+		if hasSecret {
+			if resolved, err := c.resolveSecretTag(ctx, structName, field, secretPath); err != nil {
+				fail(field, err)
+			} else {
+				fv.Set(resolved)
+			}
+		}
+		// 自定义标签处理器（RegisterTagHandler）
+		if len(handlers) > 0 {
+			if err := c.applyTagHandlers(instance, field, fv, handlers); err != nil {
+				failed = append(failed, err)
+			}
+		}
 		if tag == "" {
-			tag = field.Tag.Get("inject")
-			if tag == "" {
-				continue
+			continue
+		}
+
+		logInfo("[ioc233] 尝试注入: struct=%s field=%s type=%v autowire=%s", structName, field.Name, field.Type, tag)
+
+		// 懒加载字段（Lazy[T] 或 lazy:"true" 接口代理）
+		if c.injectLazy(instance, structName, field, fv, tag) {
+			continue
+		}
+		// 负载均衡门面（balance:"round-robin|weighted"）
+		if handled, err := c.injectBalanced(instance, structName, field, fv); handled {
+			if err != nil {
+				fail(field, err)
 			}
+			continue
 		}
-		if !v.Field(i).CanSet() {
-			logError("[ioc233] 字段 %s.%s 带有 autowire 标签但不可导出，跳过注入", t.Name(), field.Name)
+
+		resolved, err := c.resolveAutowire(structName, field, tag, mode)
+		if err == nil {
+			err = c.checkVisible(t, field, resolved)
+		}
+		if err != nil {
+			fail(field, err)
 			continue
 		}
+		resolved = c.filterVisible(t, field, resolved)
+		if resolved.IsValid() {
+			fv.Set(resolved)
+			c.markUsed(resolved)
+			c.recordInjection(instance, field, injectionMode(field, tag), false, resolved)
+		}
+	}
+	return failed, nil
+}
 
-		fieldType := field.Type
-		structName := t.Name()
-		if structName == "" && t.Kind() == reflect.Ptr {
-			structName = t.Elem().Name()
+// autowireTag 读取字段的注入标签（autowire 优先，inject 为兼容别名）
+func autowireTag(field reflect.StructField) string {
+	tag := field.Tag.Get("autowire")
+	if tag == "" {
+		tag = field.Tag.Get("inject")
+	}
+	return tag
+}
+
+// displayTypeName 返回用于日志与默认 bean 名的类型名（指针取元素名，匿名类型退化为完整类型串）
+func displayTypeName(t reflect.Type) string {
+	name := t.Name()
+	if name == "" && t.Kind() == reflect.Ptr {
+		name = t.Elem().Name()
+	}
+	if name == "" {
+		name = t.String()
+	}
+	return name
+}
+
+// implementsInterface 判断对象类型（或其指针元素）是否实现了接口
+func implementsInterface(objType, iface reflect.Type) bool {
+	return objType.Implements(iface) || (objType.Kind() == reflect.Ptr && objType.Elem().Implements(iface))
+}
+
+// findImplementations 按注册顺序查找实现了接口的所有 bean
+func (c *Container) findImplementations(iface reflect.Type) []reflect.Value {
+	var candidates []reflect.Value
+	for _, def := range c.implementationsOf(iface) {
+		if def.instance != nil {
+			candidates = append(candidates, reflect.ValueOf(def.instance))
 		}
-		if structName == "" {
-			structName = t.String()
+	}
+	return candidates
+}
+
+// resolveByType 按类型解析单个 bean（接口取首个实现，具体类型精确匹配）
+// 单例未找到时回退到原型 bean（每次解析创建新实例）
+func (c *Container) resolveByType(t reflect.Type) (reflect.Value, bool) {
+	// 显式绑定的接口直接解析绑定的实现（本容器未注册时回退父容器）
+	if v, bound, err := c.resolveBound(t, true); bound {
+		if err == nil {
+			return v, true
+		}
+	} else {
+		if t.Kind() == reflect.Interface {
+			if candidates := c.findImplementations(t); len(candidates) > 0 {
+				return candidates[0], true
+			}
+		} else if obj, ok := c.typeToObjectMap.load(t); ok && obj != nil {
+			return reflect.ValueOf(obj), true
 		}
-		logInfo("[ioc233] 尝试注入: struct=%s field=%s type=%v autowire=%s", structName, field.Name, fieldType, tag)
+		if proto := c.findPrototype(t); proto != nil {
+			v, err := c.createPrototype(proto)
+			if err != nil {
+				logError("%s", err.Error())
+				return reflect.Value{}, false
+			}
+			return v, true
+		}
+	}
+	if c.parent != nil {
+		var (
+			v  reflect.Value
+			ok bool
+		)
+		c.parent.withReadLock(func() {
+			v, ok = c.parent.resolveByType(t)
+		})
+		return v, ok
+	}
+	return reflect.Value{}, false
+}
 
-		// 选择注入模式：true/false 按类型；其他值按名称
-		if tag == "true" || tag == "false" {
-			mandatory := tag == "true"
-			// 自动按字段类型注入
-			if fieldType.Kind() == reflect.Interface {
-				var candidates []reflect.Value
-				for _, obj := range c.typeToObjectMap {
-					if obj == nil {
-						continue
-					}
-					objVal := reflect.ValueOf(obj)
-					objType := objVal.Type()
-					if objType.Implements(fieldType) || (objType.Kind() == reflect.Ptr && objType.Elem().Implements(fieldType)) {
-						candidates = append(candidates, objVal)
-					}
+// resolveAutowire 解析单个字段应注入的值（不修改字段，供注入与校验共用）
+// 返回：
+// - (值, nil)     -> 找到可注入的实例
+// - (无效值, nil) -> 可选注入未找到，保持 nil
+// - (无效值, err) -> 必须注入失败或名称注入失败
+// mode 由调用方按所持有的锁显式传入，见 resolveMode
+func (c *Container) resolveAutowire(structName string, field reflect.StructField, tag string, mode resolveMode) (reflect.Value, error) {
+	return c.resolveField(structName, field, tag, mode)
+}
+
+// resolveMode 字段解析方式，由调用方按当前持有的容器锁显式传入
+type resolveMode int
+
+const (
+	// resolveDryRun 只做可解析性检查（Validate 演练），命中原型 bean 或构造函数时不创建实例
+	resolveDryRun resolveMode = iota
+	// resolveRead 调用方持有读锁：可以创建原型实例，不会向容器注册新 bean
+	resolveRead
+	// resolveWrite 调用方持有写锁（StartUp、启动后注册与待定依赖补齐的注入）：可以自动创建依赖并注册到容器
+	resolveWrite
+)
+
+// resolveField resolveAutowire 的实现
+// 本容器未解析到时回退到父容器（作用域容器场景，父容器只持有读锁）
+func (c *Container) resolveField(structName string, field reflect.StructField, tag string, mode resolveMode) (reflect.Value, error) {
+	if field.Type == contextType && c.ctx != nil {
+		return reflect.ValueOf(c.ctx), nil
+	}
+	if field.Type == eventBusType {
+		return reflect.ValueOf(c.EventBus()), nil
+	}
+	if tag == autowireNew {
+		return c.resolveOrNew(structName, field, mode)
+	}
+	create := mode != resolveDryRun
+	v, err := c.resolveLocal(structName, field, tag, create)
+	if (err != nil || !v.IsValid()) && c.parent != nil && field.Type.Kind() != reflect.Slice {
+		var (
+			pv   reflect.Value
+			perr error
+		)
+		c.parent.withReadLock(func() {
+			pv, perr = c.parent.resolveField(structName, field, tag, min(mode, resolveRead))
+		})
+		if perr == nil && (pv.IsValid() || !create) {
+			return pv, nil
+		}
+	}
+	// 仍未解析到时调用按需构造函数（RegisterConstructor）
+	if err != nil || !v.IsValid() {
+		if cv, found, cerr := c.resolveByConstructor(structName, field, tag, mode); found {
+			return cv, cerr
+		}
+	}
+	return v, err
+}
+
+// resolveLocal 仅在本容器内解析字段
+func (c *Container) resolveLocal(structName string, field reflect.StructField, tag string, create bool) (reflect.Value, error) {
+	fieldType := field.Type
+
+	// 选择注入模式：true/false 按类型；其他值按名称
+	if tag == "true" || tag == "false" {
+		mandatory := tag == "true"
+		// 切片类型：收集元素类型的全部实现，按 IOrdered 排序后注入
+		if fieldType.Kind() == reflect.Slice {
+			items := c.collectOrdered(fieldType.Elem())
+			if len(items) == 0 && mandatory {
+				return reflect.Value{}, fmt.Errorf("[ioc233] 切片类型注入失败: struct=%s field=%s (未找到元素类型 %v 的实现)", structName, field.Name, fieldType.Elem())
+			}
+			slice := reflect.MakeSlice(fieldType, 0, len(items))
+			for _, item := range items {
+				slice = reflect.Append(slice, item)
+			}
+			logDebug("[ioc233] 切片类型注入成功: %s.%s (elem=%v, count=%d)", structName, field.Name, fieldType.Elem(), len(items))
+			return slice, nil
+		}
+		// 自动按字段类型注入
+		if fieldType.Kind() == reflect.Interface {
+			// 显式绑定（Bind）优先，O(1) 查找
+			if v, bound, err := c.resolveBound(fieldType, create); bound {
+				if err == nil {
+					logDebug("[ioc233] 接口绑定注入成功: %s.%s (iface=%v)", structName, field.Name, fieldType)
+					return v, nil
 				}
-				if len(candidates) >= 1 {
-					v.Field(i).Set(candidates[0])
-					if len(candidates) > 1 {
-						typeNames := make([]string, 0, len(candidates))
-						for _, cnd := range candidates {
-							typeNames = append(typeNames, cnd.Type().String())
-						}
-						logWarn("[ioc233] 接口类型存在多个实现，默认注入第一个: struct=%s field=%s iface=%v impls=%v",
-							structName, field.Name, fieldType, typeNames)
-					} else {
-						logDebug("[ioc233] 接口类型注入成功: %s.%s (iface=%v, impl=%v)", structName, field.Name, fieldType, candidates[0].Type())
-					}
-				} else if mandatory {
-					logError("[ioc233] 接口类型注入失败: struct=%s field=%s (未找到实现 iface=%v)", structName, field.Name, fieldType)
-				} else {
-					// 可选注入：不报错，保持 nil
-					logInfo("[ioc233] 接口类型可选注入: 未找到实现，保持 nil (struct=%s field=%s iface=%v)", structName, field.Name, fieldType)
+				if mandatory {
+					return reflect.Value{}, fmt.Errorf("[ioc233] 接口类型注入失败: struct=%s field=%s (%v)", structName, field.Name, err)
 				}
-				continue
+				logInfo("[ioc233] 接口类型可选注入: %v，保持 nil (struct=%s field=%s)", err, structName, field.Name)
+				return reflect.Value{}, nil
 			}
-			// 非接口类型：按类型名在 nameToObjMap 查找
-			typeName := fieldType.Name()
-			if typeName == "" && fieldType.Kind() == reflect.Ptr {
-				typeName = fieldType.Elem().Name()
-			}
-			if typeName == "" {
-				typeName = fieldType.String()
-			}
-			if obj, ok := c.nameToObjMap[typeName]; ok && obj != nil {
-				objVal := reflect.ValueOf(obj)
-				objType := objVal.Type()
-				if objType.AssignableTo(fieldType) {
-					v.Field(i).Set(objVal)
-					logDebug("[ioc233] 类型名注入成功: %s.%s (typeName=%s, actualType=%v)", structName, field.Name, typeName, objType)
-				} else if mandatory {
-					logError("[ioc233] 类型名注入不匹配: struct=%s field=%s (fieldType=%v, foundType=%v)",
-						structName, field.Name, fieldType, objType)
+			candidates := c.findImplementations(fieldType)
+			if len(candidates) >= 1 {
+				if len(candidates) > 1 {
+					typeNames := make([]string, 0, len(candidates))
+					for _, cnd := range candidates {
+						typeNames = append(typeNames, cnd.Type().String())
+					}
+					logWarn("[ioc233] 接口类型存在多个实现，默认注入第一个: struct=%s field=%s iface=%v impls=%v",
+						structName, field.Name, fieldType, typeNames)
 				} else {
-					logInfo("[ioc233] 类型名可选注入不匹配，保持 nil: struct=%s field=%s (fieldType=%v, foundType=%v)",
-						structName, field.Name, fieldType, objType)
+					logDebug("[ioc233] 接口类型注入成功: %s.%s (iface=%v, impl=%v)", structName, field.Name, fieldType, candidates[0].Type())
 				}
-			} else if mandatory {
-				logError("[ioc233] 类型名注入失败: struct=%s field=%s (未找到类型名=%q 的实例)", structName, field.Name, typeName)
-			} else {
-				logInfo("[ioc233] 类型名可选注入: 未找到实例，保持 nil (struct=%s field=%s typeName=%q)", structName, field.Name, typeName)
+				return candidates[0], nil
 			}
-			continue
+			if proto := c.findPrototype(fieldType); proto != nil {
+				return c.injectablePrototype(proto, create)
+			}
+			if mandatory {
+				return reflect.Value{}, fmt.Errorf("[ioc233] 接口类型注入失败: struct=%s field=%s (未找到实现 iface=%v)", structName, field.Name, fieldType)
+			}
+			// 可选注入：不报错，保持 nil
+			logInfo("[ioc233] 接口类型可选注入: 未找到实现，保持 nil (struct=%s field=%s iface=%v)", structName, field.Name, fieldType)
+			return reflect.Value{}, nil
 		}
-
-		// 名称注入：autowire:"BeanName"
-		if obj, ok := c.nameToObjMap[tag]; ok && obj != nil {
+		// 非接口类型：优先按精确类型查找，其次按类型名在 nameToObjMap 查找
+		if obj, ok := c.typeToObjectMap.load(fieldType); ok && obj != nil {
+			logDebug("[ioc233] 类型注入成功: %s.%s (type=%v)", structName, field.Name, fieldType)
+			return reflect.ValueOf(obj), nil
+		}
+		typeName := c.defaultBeanName(fieldType)
+		if obj, ok := c.nameToObjMap.load(typeName); ok && obj != nil {
 			objVal := reflect.ValueOf(obj)
 			objType := objVal.Type()
-			compatible := objType.AssignableTo(fieldType) ||
-				(fieldType.Kind() == reflect.Interface && (objType.Implements(fieldType) ||
-					(objType.Kind() == reflect.Ptr && objType.Elem().Implements(fieldType))))
-			if compatible {
-				v.Field(i).Set(objVal)
-				logDebug("[ioc233] 名称注入成功: %s.%s (name=%s, type=%v)", structName, field.Name, tag, objType)
-			} else {
-				logError("[ioc233] 名称注入类型不匹配: struct=%s field=%s (name=%s, fieldType=%v, foundType=%v)",
-					structName, field.Name, tag, fieldType, objType)
+			if objType.AssignableTo(fieldType) {
+				logDebug("[ioc233] 类型名注入成功: %s.%s (typeName=%s, actualType=%v)", structName, field.Name, typeName, objType)
+				return objVal, nil
 			}
-		} else {
-			logError("[ioc233] 名称注入失败: struct=%s field=%s (未找到名称为 %q 的实例)", structName, field.Name, tag)
+			if mandatory {
+				return reflect.Value{}, fmt.Errorf("[ioc233] 类型名注入不匹配: struct=%s field=%s (fieldType=%v, foundType=%v)",
+					structName, field.Name, fieldType, objType)
+			}
+			logInfo("[ioc233] 类型名可选注入不匹配，保持 nil: struct=%s field=%s (fieldType=%v, foundType=%v)",
+				structName, field.Name, fieldType, objType)
+			return reflect.Value{}, nil
+		}
+		if proto := c.findPrototype(fieldType); proto != nil {
+			return c.injectablePrototype(proto, create)
+		}
+		if mandatory {
+			return reflect.Value{}, fmt.Errorf("[ioc233] 类型名注入失败: struct=%s field=%s (未找到类型名=%q 的实例)", structName, field.Name, typeName)
+		}
+		logInfo("[ioc233] 类型名可选注入: 未找到实例，保持 nil (struct=%s field=%s typeName=%q)", structName, field.Name, typeName)
+		return reflect.Value{}, nil
+	}
+
+	// 分组注入：autowire:"group:handlers"
+	if group, ok := groupOfTag(tag); ok {
+		return c.resolveGroupField(structName, field, group)
+	}
+
+	// 名称注入：autowire:"BeanName"
+	if obj, ok := c.nameToObjMap.load(tag); ok && obj != nil {
+		objVal := reflect.ValueOf(obj)
+		objType := objVal.Type()
+		compatible := objType.AssignableTo(fieldType) ||
+			(fieldType.Kind() == reflect.Interface && implementsInterface(objType, fieldType))
+		if compatible {
+			logDebug("[ioc233] 名称注入成功: %s.%s (name=%s, type=%v)", structName, field.Name, tag, objType)
+			return objVal, nil
+		}
+		return reflect.Value{}, fmt.Errorf("[ioc233] 名称注入类型不匹配: struct=%s field=%s (name=%s, fieldType=%v, foundType=%v)",
+			structName, field.Name, tag, fieldType, objType)
+	}
+	if proto := c.findPrototypeByName(tag); proto != nil {
+		if !proto.out.AssignableTo(fieldType) && !(fieldType.Kind() == reflect.Interface && implementsInterface(proto.out, fieldType)) {
+			return reflect.Value{}, fmt.Errorf("[ioc233] 名称注入类型不匹配: struct=%s field=%s (name=%s, fieldType=%v, prototypeType=%v)",
+				structName, field.Name, tag, fieldType, proto.out)
+		}
+		return c.injectablePrototype(proto, create)
+	}
+	return reflect.Value{}, fmt.Errorf("[ioc233] 名称注入失败: struct=%s field=%s (未找到名称为 %q 的实例%s)", structName, field.Name, tag, c.didYouMean(tag))
+}
+
+// Validate 校验容器装配（不执行注入，不触发生命周期回调）
+// 行为：
+// - 返回已记录的致命错误（如 ProvideByName 重复）
+// - 对所有已注册对象的 autowire 字段做一次"演练"解析，汇总必须注入失败、名称注入失败等错误
+// 适用于 CI/单元测试中提前发现"某个二进制漏注册了依赖"的问题
+func (c *Container) Validate() []error {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	errs := make([]error, 0, len(c.fatalErrors))
+	errs = append(errs, c.fatalErrors...)
+	for _, def := range c.beans {
+		errs = append(errs, c.validateInstance(def.instance)...)
+	}
+	ranks, err := c.phaseRanksLocked()
+	if err == nil {
+		_, err = c.startupOrderLocked(ranks)
+	}
+	if err != nil {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// validateInstance 演练解析单个对象的所有注入字段
+func (c *Container) validateInstance(instance any) []error {
+	v := reflect.ValueOf(instance)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	t := v.Elem().Type()
+	structName := displayTypeName(t)
+	var errs []error
+	for _, field := range injectableFields(t) {
+		if expr, ok := field.Tag.Lookup("value"); ok && field.IsExported() {
+			if _, err := c.resolveValueTag(structName, field, expr); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		tag := autowireTag(field)
+		if tag == "" {
+			continue
+		}
+		if !field.IsExported() {
+			errs = append(errs, fmt.Errorf("[ioc233] 字段 %s.%s 带有 autowire 标签但不可导出", structName, field.Name))
+			continue
+		}
+		// Lazy[T] 字段按目标类型校验
+		if lb, ok := reflect.New(field.Type).Interface().(lazyBinder); ok {
+			field.Type = lb.lazyTarget()
+		}
+		resolved, err := c.resolveField(structName, field, tag, resolveDryRun)
+		if err == nil {
+			err = c.checkVisible(t, field, resolved)
+		}
+		if err != nil {
+			errs = append(errs, err)
 		}
-		continue
 	}
+	return errs
 }
 
-// GetObjectByType 按类型获取对象（泛型）
+// visibilityViolationsLocked 汇总所有 bean 注入字段的可见性违规（调用方需持有锁）
+func (c *Container) visibilityViolationsLocked() []error {
+	var errs []error
+	for _, def := range c.beans {
+		v := reflect.ValueOf(def.instance)
+		if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+			continue
+		}
+		t := v.Elem().Type()
+		for _, field := range injectableFields(t) {
+			tag := autowireTag(field)
+			if tag == "" || !field.IsExported() {
+				continue
+			}
+			if lb, ok := reflect.New(field.Type).Interface().(lazyBinder); ok {
+				field.Type = lb.lazyTarget()
+			}
+			resolved, err := c.resolveField(displayTypeName(t), field, tag, resolveDryRun)
+			if err != nil {
+				continue
+			}
+			if err := c.checkVisible(t, field, resolved); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errs
+}
+
+// GetObjectByType 按类型获取对象（泛型，使用默认容器）
 // 优先查找：serviceMap/controllerMap/typeToObjectMap
 // 如果 T 是接口类型，会查找实现了该接口的具体类型
 func GetObjectByType[T any]() T {
-	c := Instance()
-	c.mutex.RLock()
-	defer c.mutex.RUnlock()
+	return GetObjectByTypeFrom[T](Default())
+}
+
+// GetObjectByTypeFrom 从指定容器按类型获取对象（泛型）
+// 查找规则与 GetObjectByType 一致
+func GetObjectByTypeFrom[T any](c *Container) (result T) {
+	defer func() { c.markUsed(reflect.ValueOf(&result).Elem()) }()
 	var zero T
 	targetType := reflect.TypeOf((*T)(nil)).Elem()
+	// 启动后优先查只读快照（无锁）
+	if view := c.view.Load(); view != nil {
+		if obj, ok := view.lookupType(targetType); ok {
+			if typed, ok := obj.(T); ok {
+				return typed
+			}
+		}
+	}
+
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	c.publishReadViewLocked()
+
+	// 显式绑定的接口直接解析绑定的实现
+	if v, bound, err := c.resolveBound(targetType, true); bound {
+		if err == nil {
+			typed, _ := v.Interface().(T)
+			return typed
+		}
+		if c.parent != nil {
+			return GetObjectByTypeFrom[T](c.parent)
+		}
+		logError("%s", err.Error())
+		return zero
+	}
 
 	// 如果是接口类型，查找实现了该接口的对象
 	if targetType.Kind() == reflect.Interface {
-		for _, instance := range c.typeToObjectMap {
-			if instance == nil {
-				continue
-			}
-			objType := reflect.TypeOf(instance)
-			if objType.Implements(targetType) || (objType.Kind() == reflect.Ptr && objType.Elem().Implements(targetType)) {
-				if typed, ok := instance.(T); ok {
-					return typed
-				}
+		for _, candidate := range c.findImplementations(targetType) {
+			if typed, ok := candidate.Interface().(T); ok {
+				return typed
 			}
 		}
 		// 也检查 serviceMap 和 controllerMap
@@ -398,8 +1279,7 @@ func GetObjectByType[T any]() T {
 			if instance == nil {
 				continue
 			}
-			objType := reflect.TypeOf(instance)
-			if objType.Implements(targetType) || (objType.Kind() == reflect.Ptr && objType.Elem().Implements(targetType)) {
+			if implementsInterface(reflect.TypeOf(instance), targetType) {
 				if typed, ok := instance.(T); ok {
 					return typed
 				}
@@ -409,13 +1289,20 @@ func GetObjectByType[T any]() T {
 			if instance == nil {
 				continue
 			}
-			objType := reflect.TypeOf(instance)
-			if objType.Implements(targetType) || (objType.Kind() == reflect.Ptr && objType.Elem().Implements(targetType)) {
+			if implementsInterface(reflect.TypeOf(instance), targetType) {
 				if typed, ok := instance.(T); ok {
 					return typed
 				}
 			}
 		}
+		if v, ok := c.resolvePrototypeAs(targetType); ok {
+			if typed, ok := v.Interface().(T); ok {
+				return typed
+			}
+		}
+		if c.parent != nil {
+			return GetObjectByTypeFrom[T](c.parent)
+		}
 		logError("[ioc233] 未找到实现接口 %v 的实例", targetType)
 		return zero
 	}
@@ -431,19 +1318,26 @@ func GetObjectByType[T any]() T {
 			return typed
 		}
 	}
-	if instance, ok := c.typeToObjectMap[targetType]; ok {
+	if instance, ok := c.typeToObjectMap.load(targetType); ok {
 		if typed, ok := instance.(T); ok {
 			return typed
 		}
 	}
+	if v, ok := c.resolvePrototypeAs(targetType); ok {
+		if typed, ok := v.Interface().(T); ok {
+			return typed
+		}
+	}
+	if c.parent != nil {
+		return GetObjectByTypeFrom[T](c.parent)
+	}
 	logError("[ioc233] 未找到类型的实例: %v", targetType)
 	return zero
 }
 
-// GetControllersAny 获取所有控制器（兼容旧代码）
-// 说明：实际的控制器列表由 apps 维护；此处仅保留以兼容历史调用
+// GetControllersAny 获取通过 ProvideController 注册的控制器实例（兼容旧代码，新代码使用 GetControllers），顺序与 GetControllers 一致
 func (c *Container) GetControllersAny() []any {
 	c.mutex.RLock()
 	defer c.mutex.RUnlock()
-	return c.controllerList
+	return append([]any(nil), c.controllerList...)
 }
@@ -0,0 +1,64 @@
+package ioc233
+
+// bindLateLocked 对 StartUp 之后注册、尚未注入的 bean（c.beans[from:]）立即执行注入与生命周期回调（调用方需持有写锁）
+// 顺序与 StartUp 一致：BeforeInject 后置处理器 -> IInjectBefore -> 字段注入 -> IInjectAfter -> AfterInject 后置处理器 -> 事件订阅 -> IObject -> IWarmUp -> IRunnable
+// 注入过程中自动创建或按需构造的 bean 追加在末尾，同样在这里完成注入；最后补齐此前登记的待定依赖
+func (c *Container) bindLateLocked(from int) {
+	if c.state != StateStarted || len(c.overlays) > 0 {
+		// 覆盖层注册自行完成注入（见 provideOverlayLocked）
+		return
+	}
+	ctx := c.Context()
+	for i := from; i < len(c.beans); i++ {
+		def := c.beans[i]
+		if def.injected {
+			continue
+		}
+		def.injected = true
+		logInfo("[ioc233] 启动后注册，立即注入: name=%s type=%v", def.name, def.typ)
+		if err := c.postProcessLocked(def, BeanPostProcessor.BeforeInject); err != nil {
+			logError("%s", err.Error())
+			continue
+		}
+		c.emit(InjectionStarted{Bean: def.name, Type: def.typ})
+		if obj, ok := def.instance.(IInjectBefore); ok {
+			if err := c.traceCallbackLocked(ctx, def, "OnInjectBefore", obj.OnInjectBefore); err != nil {
+				continue
+			}
+		}
+		if err := c.injectFields(ctx, def.instance, resolveWrite); err != nil {
+			logError("[ioc233] 启动后注入失败: name=%s: %v", def.name, err)
+			continue
+		}
+		c.collectPendingLocked(def)
+		if obj, ok := def.instance.(IInjectAfter); ok {
+			if err := c.traceCallbackLocked(ctx, def, "OnInjectAfter", obj.OnInjectAfter); err != nil {
+				continue
+			}
+		}
+		if err := c.postProcessLocked(def, BeanPostProcessor.AfterInject); err != nil {
+			logError("%s", err.Error())
+			continue
+		}
+		c.subscribeHandlersLocked([]*beanDefinition{def})
+		if obj, ok := def.instance.(IObject); ok {
+			_ = c.traceCallbackLocked(ctx, def, "OnInjectComplete", obj.OnInjectComplete)
+		}
+		if _, err := c.warmUpLocked(ctx, []*beanDefinition{def}); err != nil {
+			logError("%s", err.Error())
+			continue
+		}
+		c.startRunnablesLocked([]*beanDefinition{def})
+	}
+	// 新注册的 bean 可能满足此前登记的待定依赖
+	c.resolvePendingLocked()
+}
+
+// markInjectedLocked 标记实例对应的 bean 已完成注入（启动后直接注入实例的路径调用，调用方需持有写锁）
+func (c *Container) markInjectedLocked(instance any) {
+	for _, def := range c.beans {
+		if sameInstance(def.instance, instance) {
+			def.injected = true
+		}
+	}
+}
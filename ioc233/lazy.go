@@ -0,0 +1,201 @@
+package ioc233
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Lazy 懒加载依赖包装
+// 字段声明为 ioc233.Lazy[T] 并带 autowire 标签时，注入阶段只绑定解析器，
+// 首次调用 Get 时才真正从容器解析 T，用于打破初始化顺序耦合、加快启动：
+//
+//	type OrderService struct {
+//	    Users ioc233.Lazy[UserService] `autowire:"true"`
+//	}
+//
+//	s.Users.Get().GetUser(1)
+type Lazy[T any] struct {
+	once    sync.Once
+	resolve func() (reflect.Value, error)
+	value   T
+	err     error
+}
+
+// Get 返回懒加载的依赖（首次调用时解析，之后复用）
+// 解析失败时 panic，错误信息包含字段与解析原因
+func (l *Lazy[T]) Get() T {
+	v, err := l.TryGet()
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// TryGet 返回懒加载的依赖与解析错误（首次调用时解析，之后复用）
+func (l *Lazy[T]) TryGet() (T, error) {
+	l.once.Do(func() {
+		if l.resolve == nil {
+			l.err = fmt.Errorf("[ioc233] Lazy[%v] 尚未由容器绑定", reflect.TypeOf((*T)(nil)).Elem())
+			return
+		}
+		v, err := l.resolve()
+		if err != nil {
+			l.err = err
+			return
+		}
+		if v.IsValid() {
+			l.value, _ = v.Interface().(T)
+		}
+	})
+	return l.value, l.err
+}
+
+// lazyTarget 返回懒加载的目标类型（供容器识别 Lazy 字段）
+func (l *Lazy[T]) lazyTarget() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// bindLazy 绑定解析器（由容器在注入阶段调用）
+func (l *Lazy[T]) bindLazy(resolve func() (reflect.Value, error)) {
+	l.resolve = resolve
+}
+
+// lazyBinder Lazy[T] 的内部识别接口
+type lazyBinder interface {
+	lazyTarget() reflect.Type
+	bindLazy(resolve func() (reflect.Value, error))
+}
+
+// lazyBinderOf 判断字段是否为 Lazy[T]，是则返回其绑定接口
+func lazyBinderOf(fv reflect.Value) (lazyBinder, bool) {
+	if fv.Kind() != reflect.Struct || !fv.CanAddr() {
+		return nil, false
+	}
+	lb, ok := fv.Addr().Interface().(lazyBinder)
+	return lb, ok
+}
+
+var (
+	// proxyFactories 接口类型 -> 代理工厂（target 返回真实对象）
+	proxyFactories     = make(map[reflect.Type]func(target func() any) any)
+	proxyFactoriesLock sync.RWMutex
+)
+
+// RegisterProxy 注册接口 T 的代理工厂
+// 代理对象的每个方法都应调用 target() 获取真实对象后转发；
+// 可以手写，也可以由 GenerateProxy 生成。注册后：
+//   - 接口字段带 lazy:"true" 时注入懒加载代理（首次方法调用时解析真实 bean）
+//
+// 重复注册同一接口会覆盖之前的工厂
+func RegisterProxy[T any](factory func(target func() T) T) {
+	iface := reflect.TypeOf((*T)(nil)).Elem()
+	if iface.Kind() != reflect.Interface {
+		logError("[ioc233] RegisterProxy 只支持接口类型: %v", iface)
+		return
+	}
+	proxyFactoriesLock.Lock()
+	defer proxyFactoriesLock.Unlock()
+	proxyFactories[iface] = func(target func() any) any {
+		return factory(func() T {
+			v, _ := target().(T)
+			return v
+		})
+	}
+}
+
+// proxyFactoryFor 查找接口类型的代理工厂
+func proxyFactoryFor(iface reflect.Type) (func(target func() any) any, bool) {
+	proxyFactoriesLock.RLock()
+	defer proxyFactoriesLock.RUnlock()
+	f, ok := proxyFactories[iface]
+	return f, ok
+}
+
+// lazyResolver 构造延迟解析函数
+// 解析时获取容器读锁，不能在持有容器锁的生命周期回调中首次调用 Get（会等待 StartUp / Close 结束）
+// 解析过程中的 panic（如原型工厂 panic）转换为错误，由 Lazy / 懒加载代理记录并在之后每次调用时重新抛出
+func (c *Container) lazyResolver(structName string, field reflect.StructField, tag string) func() (reflect.Value, error) {
+	return func() (reflect.Value, error) {
+		var (
+			v   reflect.Value
+			err error
+		)
+		c.withReadLock(func() {
+			defer func() {
+				if p := recover(); p != nil {
+					v, err = reflect.Value{}, fmt.Errorf("[ioc233] 懒加载依赖解析 panic: struct=%s field=%s: %v", structName, field.Name, p)
+				}
+			}()
+			v, err = c.resolveAutowire(structName, field, tag, resolveRead)
+		})
+		if err == nil && !v.IsValid() {
+			err = fmt.Errorf("[ioc233] 懒加载依赖未找到: struct=%s field=%s type=%v", structName, field.Name, field.Type)
+		}
+		c.counters.lazyResolutions.Add(1)
+		if err != nil {
+			c.counters.lazyFailures.Add(1)
+		}
+		c.markUsed(v)
+		return v, err
+	}
+}
+
+// auditedLazy 包装懒加载解析器：解析成功时记录注入（见 InjectionAudit）
+func (c *Container) auditedLazy(owner any, field reflect.StructField, tag string, resolve func() (reflect.Value, error)) func() (reflect.Value, error) {
+	return func() (reflect.Value, error) {
+		v, err := resolve()
+		if err == nil && c.audit.enabled() {
+			c.withReadLock(func() {
+				c.recordInjection(owner, field, injectionMode(field, tag), true, v)
+			})
+		}
+		return v, err
+	}
+}
+
+// injectLazy 处理懒加载字段；返回 true 表示字段已作为懒加载处理
+// - Lazy[T] 字段：绑定解析器
+// - 带 lazy:"true" 的接口字段：注入已注册的代理；未注册代理时回退为立即注入
+func (c *Container) injectLazy(owner any, structName string, field reflect.StructField, fv reflect.Value, tag string) bool {
+	if lb, ok := lazyBinderOf(fv); ok {
+		target := field
+		target.Type = lb.lazyTarget()
+		lb.bindLazy(c.auditedLazy(owner, target, tag, c.lazyResolver(structName, target, tag)))
+		logDebug("[ioc233] 懒加载字段绑定: %s.%s (target=%v)", structName, field.Name, target.Type)
+		return true
+	}
+	if field.Tag.Get("lazy") != "true" {
+		return false
+	}
+	if field.Type.Kind() != reflect.Interface {
+		logWarn("[ioc233] lazy 标签仅支持接口字段，回退为立即注入: %s.%s", structName, field.Name)
+		return false
+	}
+	factory, ok := proxyFactoryFor(field.Type)
+	if !ok {
+		logWarn("[ioc233] 接口 %v 未注册代理（RegisterProxy），回退为立即注入: %s.%s", field.Type, structName, field.Name)
+		return false
+	}
+	resolve := c.auditedLazy(owner, field, tag, c.lazyResolver(structName, field, tag))
+	var (
+		once   sync.Once
+		target any
+		err    error
+	)
+	proxy := factory(func() any {
+		once.Do(func() {
+			var v reflect.Value
+			if v, err = resolve(); err == nil {
+				target = v.Interface()
+			}
+		})
+		if err != nil {
+			panic(err)
+		}
+		return target
+	})
+	fv.Set(reflect.ValueOf(proxy))
+	logDebug("[ioc233] 懒加载代理注入: %s.%s (iface=%v)", structName, field.Name, field.Type)
+	return true
+}
@@ -0,0 +1,111 @@
+package ioc233
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// 泄漏资源类型
+const (
+	// LeakRunnable 托管运行 bean（IRunnable）的 Start 仍未返回
+	LeakRunnable = "runnable"
+	// LeakCloser io.Closer 的 Close 返回错误或 panic，资源可能仍处于打开状态
+	LeakCloser = "closer"
+	// LeakJob 调度任务仍在执行
+	LeakJob = "job"
+	// LeakWebSocket WebSocket 处理方法仍未返回
+	LeakWebSocket = "websocket"
+)
+
+// Leak 一个仍在运行或未能关闭的托管资源
+type Leak struct {
+	// Bean 持有资源的 bean 名（调度任务为任务名，WebSocket 为请求路径）
+	Bean string
+	// Kind 资源类型（LeakRunnable/LeakCloser/LeakJob/LeakWebSocket）
+	Kind string
+	// Detail 补充说明（错误信息、运行中的执行数等）
+	Detail string
+}
+
+// String 返回 "kind bean: detail" 形式的描述
+func (l Leak) String() string {
+	if l.Detail == "" {
+		return l.Kind + " " + l.Bean
+	}
+	return l.Kind + " " + l.Bean + ": " + l.Detail
+}
+
+// LeakReport 资源泄漏报告（见 Container.LeakReport）
+type LeakReport struct {
+	// CheckedAt 检查时间
+	CheckedAt time.Time
+	// State 检查时的容器状态
+	State ContainerState
+	// Leaks 仍在运行或未能关闭的资源
+	Leaks []Leak
+}
+
+// HasLeaks 是否存在泄漏
+func (r *LeakReport) HasLeaks() bool {
+	return len(r.Leaks) > 0
+}
+
+// String 返回多行文本报告
+func (r *LeakReport) String() string {
+	if !r.HasLeaks() {
+		return fmt.Sprintf("[ioc233] 资源泄漏报告 (state=%s): 无", r.State)
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "[ioc233] 资源泄漏报告 (state=%s): %d 项", r.State, len(r.Leaks))
+	for _, l := range r.Leaks {
+		b.WriteString("\n  - ")
+		b.WriteString(l.String())
+	}
+	return b.String()
+}
+
+// LeakReport 列出容器托管的、仍在运行或未能关闭的资源，用于排查关闭卡住与资源泄漏：
+//   - 托管运行 bean 的 Start 仍未返回（包括 Close 时超出停止时限的）
+//   - io.Closer 的 Close 失败
+//   - 调度任务仍在执行
+//   - WebSocket 处理方法仍未返回
+//
+// 报告反映调用时的实时状态，通常在 Close 之后调用；运行中的容器会列出全部存活的资源
+//
+//	if err := container.Close(); err != nil {
+//		log.Println(container.LeakReport())
+//	}
+func (c *Container) LeakReport() *LeakReport {
+	report := &LeakReport{CheckedAt: time.Now()}
+	c.withReadLock(func() {
+		report.State = c.state
+		for _, e := range append(append([]*runnableEntry(nil), c.stuckRunnables...), c.runnables...) {
+			if !e.exited() {
+				report.Leaks = append(report.Leaks, Leak{Bean: e.name, Kind: LeakRunnable, Detail: "Start 未返回"})
+			}
+		}
+		report.Leaks = append(report.Leaks, c.closeFailures...)
+		if c.scheduler != nil {
+			for _, job := range c.scheduler.Jobs() {
+				if job.Running > 0 {
+					report.Leaks = append(report.Leaks, Leak{Bean: job.Name, Kind: LeakJob, Detail: fmt.Sprintf("执行中 %d", job.Running)})
+				}
+			}
+		}
+	})
+	for _, path := range c.webSockets.active() {
+		report.Leaks = append(report.Leaks, Leak{Bean: path, Kind: LeakWebSocket, Detail: "处理方法未返回"})
+	}
+	return report
+}
+
+// exited 运行协程（含重启）是否已结束
+func (e *runnableEntry) exited() bool {
+	select {
+	case <-e.done:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,156 @@
+package ioc233
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ContainerState 容器生命周期状态
+type ContainerState int
+
+const (
+	// StateCreated 已创建，尚未启动
+	StateCreated ContainerState = iota
+	// StateStarting 正在执行 StartUp
+	StateStarting
+	// StateStarted 启动完成
+	StateStarted
+	// StateFailed 启动失败（含被取消），不能再次启动
+	StateFailed
+	// StateClosed 已关闭
+	StateClosed
+)
+
+// String 返回状态名
+func (s ContainerState) String() string {
+	switch s {
+	case StateCreated:
+		return "Created"
+	case StateStarting:
+		return "Starting"
+	case StateStarted:
+		return "Started"
+	case StateFailed:
+		return "Failed"
+	case StateClosed:
+		return "Closed"
+	}
+	return fmt.Sprintf("ContainerState(%d)", int(s))
+}
+
+// State 返回容器当前的生命周期状态
+func (c *Container) State() ContainerState {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.state
+}
+
+// StartupAbortedError StartUpCtx 被取消时返回的错误
+// 通过 errors.Is(err, context.Canceled / context.DeadlineExceeded) 可判断中止原因
+type StartupAbortedError struct {
+	// Cause 中止原因（ctx.Err()）
+	Cause error
+	// Completed 已完成注入的 bean（已按逆序触发停止回调）
+	Completed []string
+	// Partial 注入到一半被中止的 bean（为空表示没有）
+	Partial string
+	// Pending 尚未开始注入的 bean
+	Pending []string
+}
+
+// Error 实现 error 接口
+func (e *StartupAbortedError) Error() string {
+	var b strings.Builder
+	b.WriteString("[ioc233] 容器启动被中止: ")
+	b.WriteString(e.Cause.Error())
+	fmt.Fprintf(&b, " (completed=%d", len(e.Completed))
+	if e.Partial != "" {
+		b.WriteString(", partial=" + e.Partial)
+	}
+	fmt.Fprintf(&b, ", pending=%d)", len(e.Pending))
+	return b.String()
+}
+
+// Unwrap 返回中止原因
+func (e *StartupAbortedError) Unwrap() error {
+	return e.Cause
+}
+
+// abortStartUpLocked 中止启动：逆序停止并关闭（io.Closer）已完成的 bean，容器进入 StateFailed
+func (c *Container) abortStartUpLocked(cause error, completed []*beanDefinition, partial *beanDefinition, pending []*beanDefinition) error {
+	abortErr := &StartupAbortedError{Cause: cause}
+	for _, def := range completed {
+		abortErr.Completed = append(abortErr.Completed, def.name)
+	}
+	if partial != nil {
+		abortErr.Partial = partial.name
+		logWarn("[ioc233] 启动中止时 bean 仅完成部分注入: %s", partial.name)
+	}
+	for _, def := range pending {
+		abortErr.Pending = append(abortErr.Pending, def.name)
+	}
+
+	c.unsubscribeHandlersLocked()
+	c.destroyLocked(completed)
+	// 失败原因已逐个记录日志，中止错误以启动失败原因为准
+	_ = c.closeBeansLocked(completed)
+	c.state = StateFailed
+	logError("%s", abortErr.Error())
+	return abortErr
+}
+
+// destroyLocked 按逆序触发 IDestroy 停止回调
+func (c *Container) destroyLocked(beans []*beanDefinition) {
+	for i := len(beans) - 1; i >= 0; i-- {
+		def := beans[i]
+		if obj, ok := def.instance.(IDestroy); ok {
+			logInfo("[ioc233] 触发停止回调: %v", def.typ)
+			obj.OnDestroy()
+		}
+	}
+}
+
+// Close 关闭容器
+// 已启动的容器先排空（IDrain），再停止托管运行 bean（IRunnable），再逆序执行 OnStopping 钩子，再按注册逆序触发 IDestroy 停止回调，
+// 最后按依赖逆序调用实现 io.Closer 的 bean 的 Close（WithoutAutoClose 注册的除外）；重复调用是安全的
+// 托管运行 bean 未能在停止超时内退出时返回 ErrShutdownTimeout，Close 失败的错误合并返回
+func (c *Container) Close() error {
+	return c.CloseCtx(context.Background())
+}
+
+// CloseCtx 关闭容器，行为与 Close 一致
+// ctx 限定排空与等待托管运行 bean 停止的时间（同时受 SetRunnableStopTimeout 约束），用于优雅停机宽限期；
+// 有 bean 未能按时退出时仍完成关闭，并返回 ErrShutdownTimeout
+func (c *Container) CloseCtx(ctx context.Context) (err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.state == StateClosed {
+		return nil
+	}
+	for _, stop := range c.configWatchStops {
+		stop()
+	}
+	c.configWatchStops = nil
+	if c.state == StateStarted {
+		shutdownCtx, cancel := c.shutdownContext(ctx)
+		c.drainLocked(shutdownCtx)
+		c.webSockets.closeAll()
+		err = c.stopRunnablesLocked(shutdownCtx)
+		cancel()
+		hooks := c.takeStoppingHooks()
+		for i := len(hooks) - 1; i >= 0; i-- {
+			hooks[i]()
+		}
+		c.stopStandbyLocked()
+		c.unsubscribeHandlersLocked()
+		c.destroyLocked(c.beans)
+		err = errors.Join(err, c.closeBeansLocked(c.beans))
+	}
+	c.pending = nil
+	c.state = StateClosed
+	c.invalidateReadViewLocked()
+	logInfo("[ioc233] 容器已关闭")
+	return err
+}
@@ -0,0 +1,111 @@
+package ioc233
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	timeType     = reflect.TypeOf(time.Time{})
+)
+
+// timeLayouts time.Time 字面量依次尝试的格式
+var timeLayouts = []string{time.RFC3339Nano, "2006-01-02 15:04:05", "2006-01-02"}
+
+// parseLiteral 将字符串字面量解析为类型 t 的值（default、env、value 等标签与配置绑定共用）
+// 支持 string、bool、整数、无符号整数、浮点数（含以这些为底层类型的自定义类型）、
+// time.Duration（"30s"，纯整数按纳秒兼容）与 time.Time（RFC3339、"2006-01-02 15:04:05"、"2006-01-02"）
+func parseLiteral(t reflect.Type, s string) (reflect.Value, error) {
+	v := reflect.New(t).Elem()
+	switch t {
+	case durationType:
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			n, nerr := strconv.ParseInt(s, 10, 64)
+			if nerr != nil {
+				return reflect.Value{}, err
+			}
+			d = time.Duration(n)
+		}
+		v.SetInt(int64(d))
+		return v, nil
+	case timeType:
+		tm, err := parseTime(s)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		v.Set(reflect.ValueOf(tm))
+		return v, nil
+	}
+	switch t.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 0, t.Bits())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(s, 0, t.Bits())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, t.Bits())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		v.SetFloat(f)
+	default:
+		return reflect.Value{}, fmt.Errorf("不支持的字段类型 %v", t)
+	}
+	return v, nil
+}
+
+// parseTime 按 timeLayouts 依次解析时间（不带时区的格式按 UTC 解析）
+func parseTime(s string) (time.Time, error) {
+	var firstErr error
+	for _, layout := range timeLayouts {
+		tm, err := time.Parse(layout, s)
+		if err == nil {
+			return tm, nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	return time.Time{}, firstErr
+}
+
+// isLiteralType 判断结构体类型是否按字面量整体解析（配置绑定时不递归展开）
+func isLiteralType(t reflect.Type) bool {
+	return t == timeType
+}
+
+// defaultTagValue 解析 default:"..." 标签：字段为零值时返回标签中的字面量，不修改字段
+// 返回是否携带 default 标签；字段已有值时返回无效值；字面量非法时返回错误
+func defaultTagValue(structName string, field reflect.StructField, fv reflect.Value) (reflect.Value, bool, error) {
+	literal, ok := field.Tag.Lookup("default")
+	if !ok {
+		return reflect.Value{}, false, nil
+	}
+	if !fv.IsZero() {
+		return reflect.Value{}, true, nil
+	}
+	v, err := parseLiteral(field.Type, literal)
+	if err != nil {
+		return reflect.Value{}, true, fmt.Errorf("[ioc233] default 标签非法: struct=%s field=%s default=%q: %w", structName, field.Name, literal, err)
+	}
+	return v, true, nil
+}
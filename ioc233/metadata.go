@@ -0,0 +1,91 @@
+package ioc233
+
+import (
+	"slices"
+	"strings"
+)
+
+// WithTags 为 bean 附加标签（FindBeansByTag 按标签筛选 bean 子集），空白标签忽略、重复标签合并：
+//
+//	c.Provide(&RedisCache{}, ioc233.WithTags("cache", "critical"))
+func WithTags(tags ...string) BeanOption {
+	return func(o *beanOptions) {
+		for _, tag := range tags {
+			tag = strings.TrimSpace(tag)
+			if tag != "" && !slices.Contains(o.tags, tag) {
+				o.tags = append(o.tags, tag)
+			}
+		}
+	}
+}
+
+// WithDescription 为 bean 附加描述（出现在 BeanInfo 与 Dump 中）
+func WithDescription(description string) BeanOption {
+	return func(o *beanOptions) {
+		o.description = description
+	}
+}
+
+// BeanInfo bean 及其注册时附加的元数据
+type BeanInfo struct {
+	// Name bean 名
+	Name string
+	// TypeName 实例的具体类型
+	TypeName string
+	// Tags 标签（WithTags）
+	Tags []string
+	// Description 描述（WithDescription）
+	Description string
+	// Instance bean 实例
+	Instance any
+}
+
+// HasTag 是否带有标签
+func (b BeanInfo) HasTag(tag string) bool {
+	return slices.Contains(b.Tags, tag)
+}
+
+// FindBeansByTag 按注册顺序返回本容器中带有 tag 标签的 bean，供工具、健康检查、分批启动等针对 bean 子集操作：
+//
+//	for _, b := range c.FindBeansByTag("critical") {
+//		if hc, ok := b.Instance.(ioc233.IHealthCheck); ok { ... }
+//	}
+func (c *Container) FindBeansByTag(tag string) []BeanInfo {
+	var out []BeanInfo
+	c.withReadLock(func() {
+		for _, def := range c.beans {
+			if def.instance != nil && slices.Contains(def.tags, tag) {
+				out = append(out, beanInfoOf(def))
+			}
+		}
+	})
+	return out
+}
+
+// LookupBean 按 bean 名返回 bean 及其元数据（只查找本容器）
+func (c *Container) LookupBean(name string) (BeanInfo, bool) {
+	var (
+		info BeanInfo
+		ok   bool
+	)
+	c.withReadLock(func() {
+		for _, def := range c.beans {
+			if def.name == name && def.instance != nil {
+				info, ok = beanInfoOf(def), true
+				return
+			}
+		}
+	})
+	return info, ok
+}
+
+// beanInfoOf 由 bean 定义生成 BeanInfo（标签复制一份，调用方修改不影响容器）
+func beanInfoOf(def *beanDefinition) BeanInfo {
+	return BeanInfo{
+		Name:        def.name,
+		TypeName:    def.typ.String(),
+		Tags:        slices.Clone(def.tags),
+		Description: def.description,
+		Instance:    def.instance,
+	}
+}
@@ -0,0 +1,47 @@
+package ioc233
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// ContainerMetrics 容器运行指标快照（供监控系统采集，见 ioc233/prom）
+type ContainerMetrics struct {
+	// State 容器状态
+	State ContainerState
+	// Beans 已注册的单例 bean 数
+	Beans int
+	// Prototypes 已注册的原型 bean 数
+	Prototypes int
+	// InjectionFailures 字段注入失败次数（累计）
+	InjectionFailures uint64
+	// LazyResolutions 懒加载依赖（Lazy[T] 与 lazy:"true" 代理）的实际解析次数（累计）
+	LazyResolutions uint64
+	// LazyFailures 懒加载依赖解析失败次数（累计）
+	LazyFailures uint64
+	// StartupDuration 最近一次成功启动的耗时（未启动时为 0）
+	StartupDuration time.Duration
+}
+
+// containerCounters 容器累计计数器（原子操作，懒加载解析可能发生在任意 goroutine）
+type containerCounters struct {
+	injectionFailures atomic.Uint64
+	lazyResolutions   atomic.Uint64
+	lazyFailures      atomic.Uint64
+}
+
+// Metrics 返回容器当前的运行指标
+func (c *Container) Metrics() ContainerMetrics {
+	m := ContainerMetrics{
+		InjectionFailures: c.counters.injectionFailures.Load(),
+		LazyResolutions:   c.counters.lazyResolutions.Load(),
+		LazyFailures:      c.counters.lazyFailures.Load(),
+	}
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	m.State, m.Beans, m.Prototypes = c.state, len(c.beans), len(c.prototypes)
+	if c.report != nil {
+		m.StartupDuration = c.report.Duration
+	}
+	return m
+}
@@ -0,0 +1,52 @@
+package ioc233
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// IMiddleware 控制器路由中间件接口（net/http 风格，与 HTTP 框架无关）
+// 容器中实现此接口的 bean 由 HTTP 适配器（MountServeMux、echoioc、fiberioc）发现，按 IOrdered 排序后组成处理链，
+// 包裹每一个控制器路由：Order 越小越靠外层（越先执行），未实现 IOrdered 的排在最内层
+//
+//	type AuthMiddleware struct {
+//		Tokens TokenService `autowire:"true"`
+//	}
+//
+//	func (m *AuthMiddleware) Order() int { return 10 }
+//
+//	func (m *AuthMiddleware) Wrap(next http.Handler) http.Handler {
+//		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+//			if !m.Tokens.Valid(r.Header.Get("Authorization")) {
+//				http.Error(w, "unauthorized", http.StatusUnauthorized)
+//				return
+//			}
+//			next.ServeHTTP(w, r)
+//		})
+//	}
+type IMiddleware interface {
+	// Wrap 返回包裹 next 的处理器；不调用 next 即中断处理链
+	Wrap(next http.Handler) http.Handler
+}
+
+// ProvideMiddleware 注册中间件 bean（与 Provide 一样参与注入与生命周期）
+// 实现 IMiddleware 的 bean 通过 Provide 注册同样会被发现，此方法在编译期约束类型并拒绝 nil
+func (c *Container) ProvideMiddleware(mw IMiddleware, opts ...BeanOption) error {
+	if mw == nil {
+		return fmt.Errorf("[ioc233] ProvideMiddleware 中间件不能为 nil")
+	}
+	return c.Provide(mw, opts...)
+}
+
+// Middlewares 返回容器（含父容器）中的中间件，按 IOrdered 排序（最外层在前）
+func (c *Container) Middlewares() []IMiddleware {
+	return GetObjectsByTypeFrom[IMiddleware](c)
+}
+
+// ChainMiddlewares 用 mws 依次包裹 h：mws[0] 位于最外层，最先执行
+func ChainMiddlewares(h http.Handler, mws ...IMiddleware) http.Handler {
+	for i := len(mws) - 1; i >= 0; i-- {
+		h = mws[i].Wrap(h)
+	}
+	return h
+}
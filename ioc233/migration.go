@@ -0,0 +1,202 @@
+package ioc233
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// IMigration 数据库迁移接口
+// 实现此接口的 bean 会在启动的迁移阶段（字段注入完成后、OnInjectComplete 之前）
+// 按 Version 升序对容器中的 *sql.DB bean 执行，已执行过的版本自动跳过
+type IMigration interface {
+	// Version 迁移版本号（全局唯一，升序执行）
+	Version() int
+	// Up 执行迁移
+	Up(ctx context.Context, db *sql.DB) error
+}
+
+// IMigrationStore 迁移版本记录接口（容器中存在实现此接口的 bean 时替换默认实现）
+type IMigrationStore interface {
+	// AppliedVersions 返回已执行的版本集合
+	AppliedVersions(ctx context.Context, db *sql.DB) (map[int]bool, error)
+	// MarkApplied 记录版本已执行
+	MarkApplied(ctx context.Context, db *sql.DB, version int) error
+}
+
+// IMigrationLock 迁移锁接口（容器中存在实现此接口的 bean 时替换默认实现）
+// 用于防止多个副本同时执行迁移
+type IMigrationLock interface {
+	// Lock 获取迁移锁，返回释放函数
+	Lock(ctx context.Context, db *sql.DB) (unlock func() error, err error)
+}
+
+// SetMigrationDatabase 指定迁移使用的 *sql.DB bean 名（默认使用第一个 *sql.DB bean）
+func (c *Container) SetMigrationDatabase(beanName string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.migrationDB = beanName
+}
+
+// sqlDBType *sql.DB 类型
+var sqlDBType = reflect.TypeOf((*sql.DB)(nil))
+
+// runMigrationsLocked 执行迁移阶段（调用方需持有写锁）
+func (c *Container) runMigrationsLocked(ctx context.Context) error {
+	var migrations []IMigration
+	for _, def := range c.beans {
+		if m, ok := def.instance.(IMigration); ok {
+			migrations = append(migrations, m)
+		}
+	}
+	if len(migrations) == 0 {
+		return nil
+	}
+	sort.SliceStable(migrations, func(i, j int) bool { return migrations[i].Version() < migrations[j].Version() })
+	for i := 1; i < len(migrations); i++ {
+		if migrations[i].Version() == migrations[i-1].Version() {
+			return fmt.Errorf("[ioc233] 迁移版本重复: version=%d (%T, %T)", migrations[i].Version(), migrations[i-1], migrations[i])
+		}
+	}
+
+	db, err := c.migrationDatabase()
+	if err != nil {
+		return err
+	}
+	var store IMigrationStore = SQLMigrationStore{}
+	if v, ok := c.resolveByType(reflect.TypeOf((*IMigrationStore)(nil)).Elem()); ok {
+		store = v.Interface().(IMigrationStore)
+	}
+	var lock IMigrationLock = &TableMigrationLock{}
+	if v, ok := c.resolveByType(reflect.TypeOf((*IMigrationLock)(nil)).Elem()); ok {
+		lock = v.Interface().(IMigrationLock)
+	}
+
+	logInfo("[ioc233] 开始执行数据库迁移: count=%d", len(migrations))
+	unlock, err := lock.Lock(ctx, db)
+	if err != nil {
+		return fmt.Errorf("[ioc233] 获取迁移锁失败: %w", err)
+	}
+	defer func() {
+		if err := unlock(); err != nil {
+			logError("[ioc233] 释放迁移锁失败: %v", err)
+		}
+	}()
+
+	applied, err := store.AppliedVersions(ctx, db)
+	if err != nil {
+		return fmt.Errorf("[ioc233] 读取已执行迁移版本失败: %w", err)
+	}
+	for _, m := range migrations {
+		version := m.Version()
+		if applied[version] {
+			logDebug("[ioc233] 迁移已执行，跳过: version=%d", version)
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		logInfo("[ioc233] 执行迁移: version=%d (%T)", version, m)
+		if err := m.Up(ctx, db); err != nil {
+			return fmt.Errorf("[ioc233] 迁移执行失败: version=%d (%T): %w", version, m, err)
+		}
+		if err := store.MarkApplied(ctx, db, version); err != nil {
+			return fmt.Errorf("[ioc233] 记录迁移版本失败: version=%d: %w", version, err)
+		}
+	}
+	logInfo("[ioc233] 数据库迁移完成")
+	return nil
+}
+
+// migrationDatabase 查找迁移使用的 *sql.DB bean
+func (c *Container) migrationDatabase() (*sql.DB, error) {
+	if c.migrationDB != "" {
+		if db, ok := c.nameToObjMap.get(c.migrationDB).(*sql.DB); ok {
+			return db, nil
+		}
+		return nil, fmt.Errorf("[ioc233] 未找到名称为 %q 的 *sql.DB bean", c.migrationDB)
+	}
+	if v, ok := c.resolveByType(sqlDBType); ok {
+		return v.Interface().(*sql.DB), nil
+	}
+	return nil, errors.New("[ioc233] 存在迁移 bean，但容器中没有 *sql.DB bean")
+}
+
+// SQLMigrationStore 默认迁移版本记录：使用表 ioc233_schema_migrations
+// 只使用各主流数据库通用的 SQL（不依赖占位符风格）
+type SQLMigrationStore struct{}
+
+// AppliedVersions 实现 IMigrationStore
+func (SQLMigrationStore) AppliedVersions(ctx context.Context, db *sql.DB) (map[int]bool, error) {
+	if _, err := db.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS ioc233_schema_migrations (version BIGINT PRIMARY KEY)"); err != nil {
+		return nil, err
+	}
+	rows, err := db.QueryContext(ctx, "SELECT version FROM ioc233_schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	applied := make(map[int]bool)
+	for rows.Next() {
+		var v int
+		if err := rows.Scan(&v); err != nil {
+			return nil, err
+		}
+		applied[v] = true
+	}
+	return applied, rows.Err()
+}
+
+// MarkApplied 实现 IMigrationStore
+func (SQLMigrationStore) MarkApplied(ctx context.Context, db *sql.DB, version int) error {
+	_, err := db.ExecContext(ctx, fmt.Sprintf("INSERT INTO ioc233_schema_migrations (version) VALUES (%d)", version))
+	return err
+}
+
+// TableMigrationLock 默认迁移锁：利用表 ioc233_migration_lock 的主键唯一性实现跨副本互斥
+// 说明：
+// - 获取失败时按 RetryInterval 重试，直到 ctx 结束
+// - 持有时间超过 StaleAfter 的锁视为残留（进程崩溃未释放），会被清理
+// - 对锁语义要求更高时（例如 PostgreSQL advisory lock），可注册自定义 IMigrationLock bean
+type TableMigrationLock struct {
+	// RetryInterval 重试间隔（默认 1s）
+	RetryInterval time.Duration
+	// StaleAfter 锁残留判定时长（默认 10min）
+	StaleAfter time.Duration
+}
+
+// Lock 实现 IMigrationLock
+func (l *TableMigrationLock) Lock(ctx context.Context, db *sql.DB) (func() error, error) {
+	retry, stale := l.RetryInterval, l.StaleAfter
+	if retry <= 0 {
+		retry = time.Second
+	}
+	if stale <= 0 {
+		stale = 10 * time.Minute
+	}
+	if _, err := db.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS ioc233_migration_lock (id INT PRIMARY KEY, locked_at BIGINT NOT NULL)"); err != nil {
+		return nil, err
+	}
+	for {
+		now := time.Now().Unix()
+		if _, err := db.ExecContext(ctx, fmt.Sprintf("DELETE FROM ioc233_migration_lock WHERE id = 1 AND locked_at < %d", now-int64(stale/time.Second))); err != nil {
+			return nil, err
+		}
+		if _, err := db.ExecContext(ctx, fmt.Sprintf("INSERT INTO ioc233_migration_lock (id, locked_at) VALUES (1, %d)", now)); err == nil {
+			return func() error {
+				_, err := db.ExecContext(context.Background(), "DELETE FROM ioc233_migration_lock WHERE id = 1")
+				return err
+			}, nil
+		}
+		logInfo("[ioc233] 迁移锁被其他实例持有，%v 后重试", retry)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(retry):
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package ioc233
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Module 注册模块：把一组内聚的注册（数据库模块、缓存模块、游戏世界模块……）收拢在一起
+//
+//	type DBModule struct{ DSN string }
+//
+//	func (m *DBModule) Configure(c *ioc233.Container) error {
+//	    db, err := sql.Open("mysql", m.DSN)
+//	    if err != nil {
+//	        return err
+//	    }
+//	    c.Provide(db)
+//	    c.Provide(&UserRepository{})
+//	    return nil
+//	}
+//
+//	err := c.Install(&DBModule{DSN: dsn}, &CacheModule{}, &WorldModule{})
+type Module interface {
+	Configure(c *Container) error
+}
+
+// Configure 使注册表模块函数同时实现 Module，可直接传给 Install
+func (f RegistryModuleFunc) Configure(c *Container) error {
+	return f(c)
+}
+
+// Install 按顺序安装模块
+// 说明：
+// - 模块的 Configure 中可以继续 Install 其依赖的模块
+// - 同一个（可比较的）模块实例重复安装时只执行一次，便于多个模块共同依赖基础模块
+// - 任一模块返回错误时立即停止，错误信息包含模块类型
+func (c *Container) Install(modules ...Module) error {
+	for _, m := range modules {
+		if m == nil {
+			continue
+		}
+		if reflect.TypeOf(m).Comparable() {
+			installed := false
+			c.withWriteLock(func() {
+				if c.installedModules == nil {
+					c.installedModules = make(map[Module]bool)
+				}
+				installed = c.installedModules[m]
+				c.installedModules[m] = true
+			})
+			if installed {
+				logDebug("[ioc233] 模块已安装，跳过: %T", m)
+				continue
+			}
+		}
+		logInfo("[ioc233] 安装模块: %T", m)
+		if err := m.Configure(c); err != nil {
+			return fmt.Errorf("[ioc233] 模块安装失败: %T: %w", m, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package ioc233
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// SetNamespacedNames 开启后默认 bean 名带包路径："example.com/app/pkg2/Config"，
+// 不同包的同名结构体（pkg1.Config 与 pkg2.Config）不再冲突；只影响之后注册的 bean，应在注册前调用
+// 未开启时默认 bean 名为结构体名 "Config"
+func (c *Container) SetNamespacedNames(enabled bool) {
+	c.namespacedNames.Store(enabled)
+}
+
+// NamespacedName 返回实例带包路径的 bean 名（"包路径/类型名"，指针取元素类型；无包路径的类型返回类型名）
+// 开启 SetNamespacedNames 后可用于名称注入标签或 ResolveByName
+func NamespacedName(instance any) string {
+	return namespacedTypeName(reflect.TypeOf(instance))
+}
+
+// namespacedTypeName 返回 "包路径/类型名"
+func namespacedTypeName(t reflect.Type) string {
+	if t == nil {
+		return ""
+	}
+	elem := t
+	if elem.Name() == "" && elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+	if elem.PkgPath() == "" || elem.Name() == "" {
+		return displayTypeName(t)
+	}
+	return elem.PkgPath() + "/" + elem.Name()
+}
+
+// defaultBeanName 返回 Provide 使用的默认 bean 名（见 SetNamespacedNames）
+func (c *Container) defaultBeanName(t reflect.Type) string {
+	if c.namespacedNames.Load() {
+		return namespacedTypeName(t)
+	}
+	return displayTypeName(t)
+}
+
+// ProvideInNamespace 以 "namespace/类型名" 为 bean 名注册对象，例如 ProvideInNamespace("pkg2", &pkg2.Config{})
+// 注册为 "pkg2/Config"，按名称注入时使用 autowire:"pkg2/Config"；其余行为同 ProvideByName
+func (c *Container) ProvideInNamespace(namespace string, instance any, opts ...BeanOption) error {
+	namespace = strings.Trim(strings.TrimSpace(namespace), "/")
+	if namespace == "" || instance == nil {
+		return errors.New("[ioc233] ProvideInNamespace 参数非法")
+	}
+	return c.ProvideByName(namespace+"/"+displayTypeName(reflect.TypeOf(instance)), instance, opts...)
+}
@@ -0,0 +1,309 @@
+package ioc233
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// OpenAPIVersion OpenAPISpec 生成的文档版本
+const OpenAPIVersion = "3.0.3"
+
+// OpenAPIDocument OpenAPI 3 文档（可直接 json.Marshal）
+type OpenAPIDocument struct {
+	OpenAPI    string                                  `json:"openapi"`
+	Info       OpenAPIInfo                             `json:"info"`
+	Paths      map[string]map[string]*OpenAPIOperation `json:"paths"`
+	Components *OpenAPIComponents                      `json:"components,omitempty"`
+}
+
+// OpenAPIInfo 文档基本信息
+type OpenAPIInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// OpenAPIOperation 一个路由对应的操作
+type OpenAPIOperation struct {
+	OperationID string                      `json:"operationId"`
+	Summary     string                      `json:"summary,omitempty"`
+	Tags        []string                    `json:"tags,omitempty"`
+	Parameters  []*OpenAPIParameter         `json:"parameters,omitempty"`
+	RequestBody *OpenAPIRequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*OpenAPIResponse `json:"responses"`
+}
+
+// OpenAPIParameter 路径或查询参数
+type OpenAPIParameter struct {
+	Name     string         `json:"name"`
+	In       string         `json:"in"`
+	Required bool           `json:"required,omitempty"`
+	Schema   *OpenAPISchema `json:"schema"`
+}
+
+// OpenAPIRequestBody 请求体
+type OpenAPIRequestBody struct {
+	Required bool                         `json:"required,omitempty"`
+	Content  map[string]*OpenAPIMediaType `json:"content"`
+}
+
+// OpenAPIResponse 响应
+type OpenAPIResponse struct {
+	Description string                       `json:"description"`
+	Content     map[string]*OpenAPIMediaType `json:"content,omitempty"`
+}
+
+// OpenAPIMediaType 请求/响应内容
+type OpenAPIMediaType struct {
+	Schema *OpenAPISchema `json:"schema"`
+}
+
+// OpenAPIComponents 可复用组件（命名结构体类型的 schema）
+type OpenAPIComponents struct {
+	Schemas map[string]*OpenAPISchema `json:"schemas,omitempty"`
+}
+
+// OpenAPISchema JSON Schema（OpenAPI 3.0 子集）
+type OpenAPISchema struct {
+	Ref                  string                    `json:"$ref,omitempty"`
+	Type                 string                    `json:"type,omitempty"`
+	Format               string                    `json:"format,omitempty"`
+	Nullable             bool                      `json:"nullable,omitempty"`
+	Items                *OpenAPISchema            `json:"items,omitempty"`
+	Properties           map[string]*OpenAPISchema `json:"properties,omitempty"`
+	Required             []string                  `json:"required,omitempty"`
+	AdditionalProperties *OpenAPISchema            `json:"additionalProperties,omitempty"`
+}
+
+// openAPIMethods ANY 路由在文档中展开的方法
+var openAPIMethods = []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
+
+// OpenAPISpec 根据 ProvideController 注册的路由生成 OpenAPI 3 文档，文档始终与实际挂载的路由一致
+//   - 路径参数 :id / 末尾通配 *path 转换为 {id} / {path}
+//   - Route.Request：GET/HEAD/DELETE 的请求结构体字段展开为查询参数，其他方法作为 JSON 请求体
+//   - Route.Response：作为 200 响应的 JSON 内容
+//   - 命名结构体类型放入 components/schemas 并通过 $ref 引用，字段名遵循 json 标签，无 omitempty 的字段为必填
+//   - ANY 路由展开为所有方法
+//
+// Info 默认为 {Title: "ioc233", Version: "1.0.0"}，可在返回的文档上修改
+func (c *Container) OpenAPISpec() *OpenAPIDocument {
+	doc := &OpenAPIDocument{
+		OpenAPI: OpenAPIVersion,
+		Info:    OpenAPIInfo{Title: "ioc233", Version: "1.0.0"},
+		Paths:   make(map[string]map[string]*OpenAPIOperation),
+	}
+	g := &schemaGenerator{schemas: make(map[string]*OpenAPISchema), names: make(map[reflect.Type]string)}
+	for _, ctrl := range c.GetControllers() {
+		for _, r := range ctrl.Routes {
+			path, params := openAPIPath(r.Path)
+			methods := []string{r.Method}
+			if r.Method == "ANY" {
+				methods = openAPIMethods
+			}
+			item := doc.Paths[path]
+			if item == nil {
+				item = make(map[string]*OpenAPIOperation)
+				doc.Paths[path] = item
+			}
+			for _, method := range methods {
+				item[strings.ToLower(method)] = g.operation(ctrl.Name, r, method, params)
+			}
+		}
+	}
+	if len(g.schemas) > 0 {
+		doc.Components = &OpenAPIComponents{Schemas: g.schemas}
+	}
+	return doc
+}
+
+// OpenAPIHandler 返回以 JSON 输出 OpenAPISpec 的 http.Handler（每次请求重新生成，反映启动后注册的控制器）
+//
+//	mux.Handle("GET /openapi.json", ioc233.OpenAPIHandler(container))
+func OpenAPIHandler(c *Container) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(c.OpenAPISpec())
+	})
+}
+
+// openAPIPath 将路由路径转换为 OpenAPI 路径，并返回路径参数
+func openAPIPath(path string) (string, []*OpenAPIParameter) {
+	var params []*OpenAPIParameter
+	segments := strings.Split(path, "/")
+	for i, seg := range segments {
+		if (strings.HasPrefix(seg, ":") || strings.HasPrefix(seg, "*")) && len(seg) > 1 {
+			segments[i] = "{" + seg[1:] + "}"
+			params = append(params, &OpenAPIParameter{Name: seg[1:], In: "path", Required: true, Schema: &OpenAPISchema{Type: "string"}})
+		}
+	}
+	return strings.Join(segments, "/"), params
+}
+
+// schemaGenerator 由 Go 类型生成 schema，命名结构体登记到 components
+type schemaGenerator struct {
+	schemas map[string]*OpenAPISchema
+	names   map[reflect.Type]string
+}
+
+// operation 生成路由在某个方法下的操作
+func (g *schemaGenerator) operation(controller string, r Route, method string, params []*OpenAPIParameter) *OpenAPIOperation {
+	op := &OpenAPIOperation{
+		OperationID: controller + "." + r.Handler,
+		Summary:     r.Summary,
+		Tags:        []string{controller},
+		Parameters:  append([]*OpenAPIParameter(nil), params...),
+		Responses:   map[string]*OpenAPIResponse{"200": {Description: "OK"}},
+	}
+	if r.Method == "ANY" {
+		op.OperationID += "." + strings.ToLower(method)
+	}
+	if t := reflect.TypeOf(r.Request); t != nil {
+		switch method {
+		case "GET", "HEAD", "DELETE":
+			op.Parameters = append(op.Parameters, g.queryParameters(t)...)
+		default:
+			op.RequestBody = &OpenAPIRequestBody{Required: true, Content: jsonContent(g.schemaOf(t))}
+		}
+	}
+	if t := reflect.TypeOf(r.Response); t != nil {
+		op.Responses["200"].Content = jsonContent(g.schemaOf(t))
+	}
+	return op
+}
+
+// queryParameters 将结构体的字段展开为查询参数（非结构体类型不展开）
+func (g *schemaGenerator) queryParameters(t reflect.Type) []*OpenAPIParameter {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	var params []*OpenAPIParameter
+	for _, f := range jsonFields(t) {
+		params = append(params, &OpenAPIParameter{Name: f.name, In: "query", Required: f.required, Schema: g.schemaOf(f.typ)})
+	}
+	return params
+}
+
+// schemaOf 返回类型的 schema；命名结构体返回 $ref
+func (g *schemaGenerator) schemaOf(t reflect.Type) *OpenAPISchema {
+	nullable := false
+	for t.Kind() == reflect.Ptr {
+		t, nullable = t.Elem(), true
+	}
+	s := g.baseSchema(t)
+	if nullable && s.Ref == "" {
+		s.Nullable = true
+	}
+	return s
+}
+
+// baseSchema 返回非指针类型的 schema
+func (g *schemaGenerator) baseSchema(t reflect.Type) *OpenAPISchema {
+	if t == timeType {
+		return &OpenAPISchema{Type: "string", Format: "date-time"}
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return &OpenAPISchema{Type: "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &OpenAPISchema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		return &OpenAPISchema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &OpenAPISchema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &OpenAPISchema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &OpenAPISchema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &OpenAPISchema{Type: "string", Format: "byte"}
+		}
+		return &OpenAPISchema{Type: "array", Items: g.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &OpenAPISchema{Type: "object", AdditionalProperties: g.schemaOf(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		return &OpenAPISchema{Ref: "#/components/schemas/" + g.register(t)}
+	}
+	// interface、func 等无法描述的类型：任意值
+	return &OpenAPISchema{}
+}
+
+// register 登记命名结构体并返回组件名（同名不同包的类型使用 包名.类型名 区分）
+func (g *schemaGenerator) register(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+	name := t.Name()
+	if _, taken := g.schemas[name]; taken {
+		name = strings.ReplaceAll(t.String(), "*", "")
+	}
+	g.names[t] = name
+	// 先占位，支持自引用类型
+	g.schemas[name] = &OpenAPISchema{}
+	*g.schemas[name] = *g.structSchema(t)
+	return name
+}
+
+// structSchema 生成结构体的 object schema
+func (g *schemaGenerator) structSchema(t reflect.Type) *OpenAPISchema {
+	s := &OpenAPISchema{Type: "object", Properties: make(map[string]*OpenAPISchema)}
+	for _, f := range jsonFields(t) {
+		s.Properties[f.name] = g.schemaOf(f.typ)
+		if f.required {
+			s.Required = append(s.Required, f.name)
+		}
+	}
+	return s
+}
+
+// jsonField 结构体按 encoding/json 规则序列化的字段
+type jsonField struct {
+	name     string
+	typ      reflect.Type
+	required bool
+}
+
+// jsonFields 按 encoding/json 规则列出结构体字段：跳过未导出与 json:"-" 字段，展开无 json 名的匿名结构体
+func jsonFields(t reflect.Type) []jsonField {
+	var fields []jsonField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		ft := f.Type
+		if f.Anonymous && name == "" {
+			for ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				fields = append(fields, jsonFields(ft)...)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields = append(fields, jsonField{name: name, typ: f.Type, required: !strings.Contains(opts, "omitempty")})
+	}
+	return fields
+}
+
+// jsonContent 返回 application/json 内容
+func jsonContent(s *OpenAPISchema) map[string]*OpenAPIMediaType {
+	return map[string]*OpenAPIMediaType{"application/json": {Schema: s}}
+}
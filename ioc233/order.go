@@ -0,0 +1,62 @@
+package ioc233
+
+import (
+	"math"
+	"reflect"
+	"sort"
+)
+
+// orderOf 返回对象的排序值（未实现 IOrdered 的对象排在最后）
+func orderOf(instance any) int {
+	if o, ok := instance.(IOrdered); ok {
+		return o.Order()
+	}
+	return math.MaxInt
+}
+
+// collectOrdered 收集可赋值给 elemType 的所有 bean，并按 IOrdered 稳定排序
+// 接口类型收集全部实现；具体类型收集类型完全一致（或可赋值）的 bean
+func (c *Container) collectOrdered(elemType reflect.Type) []reflect.Value {
+	var items []reflect.Value
+	if elemType.Kind() == reflect.Interface {
+		items = c.findImplementations(elemType)
+	} else {
+		for _, def := range c.beans {
+			if def.instance != nil && def.typ.AssignableTo(elemType) {
+				items = append(items, reflect.ValueOf(def.instance))
+			}
+		}
+	}
+	// 作用域容器：合并父容器中的实现（本容器的排在前面，再整体按 Order 稳定排序）
+	if c.parent != nil {
+		c.parent.withReadLock(func() {
+			items = append(items, c.parent.collectOrdered(elemType)...)
+		})
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return orderOf(items[i].Interface()) < orderOf(items[j].Interface())
+	})
+	return items
+}
+
+// GetObjectsByType 按类型获取全部对象（泛型，使用默认容器），按 IOrdered 排序
+func GetObjectsByType[T any]() []T {
+	return GetObjectsByTypeFrom[T](Default())
+}
+
+// GetObjectsByTypeFrom 从指定容器按类型获取全部对象，按 IOrdered 排序
+// 如果 T 是接口类型，返回所有实现了该接口的对象
+func GetObjectsByTypeFrom[T any](c *Container) []T {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	targetType := reflect.TypeOf((*T)(nil)).Elem()
+	items := c.collectOrdered(targetType)
+	result := make([]T, 0, len(items))
+	for _, item := range items {
+		if typed, ok := item.Interface().(T); ok {
+			result = append(result, typed)
+			c.markUsed(item)
+		}
+	}
+	return result
+}
@@ -0,0 +1,49 @@
+// Package otelioc ioc233 容器启动流程的 OpenTelemetry 追踪
+// 独立模块，只有导入本包时才引入 OpenTelemetry 依赖：
+//
+//	container.SetStartupTracer(otelioc.NewStartupTracer(otelioc.DefaultTracer()))
+package otelioc
+
+import (
+	"context"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName 默认 tracer 的 instrumentation scope 名
+const InstrumentationName = "github.com/neko233-com/ioc233-go/ioc233"
+
+// StartupTracer 基于 OpenTelemetry 的启动追踪钩子（实现 ioc233.StartupTracer）
+type StartupTracer struct {
+	tracer trace.Tracer
+}
+
+// NewStartupTracer 创建启动追踪钩子
+func NewStartupTracer(tracer trace.Tracer) *StartupTracer {
+	return &StartupTracer{tracer: tracer}
+}
+
+// DefaultTracer 从全局 TracerProvider 获取 tracer（启动前需先调用 otel.SetTracerProvider）
+func DefaultTracer() trace.Tracer {
+	return otel.Tracer(InstrumentationName)
+}
+
+// StartSpan 实现 ioc233.StartupTracer
+func (t *StartupTracer) StartSpan(ctx context.Context, name string, attrs ...ioc233.SpanAttr) (context.Context, func(err error)) {
+	kvs := make([]attribute.KeyValue, 0, len(attrs))
+	for _, a := range attrs {
+		kvs = append(kvs, attribute.String(a.Key, a.Value))
+	}
+	ctx, span := t.tracer.Start(ctx, name, trace.WithAttributes(kvs...))
+	return ctx, func(err error) {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+}
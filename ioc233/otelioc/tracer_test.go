@@ -0,0 +1,70 @@
+package otelioc_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+	"github.com/neko233-com/ioc233-go/ioc233/otelioc"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type Repo struct{}
+
+type Cache struct{}
+
+type Service struct {
+	Repo    *Repo  `autowire:"true"`
+	Missing *Cache `autowire:"true"`
+}
+
+func (s *Service) OnInjectComplete() {}
+
+func TestStartupTracer(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	defer func() { _ = provider.Shutdown(context.Background()) }()
+
+	c := ioc233.NewContainer()
+	c.SetQuietStartup(true)
+	c.SetStartupTracer(otelioc.NewStartupTracer(provider.Tracer(otelioc.InstrumentationName)))
+	c.Provide(&Repo{})
+	c.Provide(&Service{})
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "boot")
+	if err := c.StartUpCtx(ctx); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	parent.End()
+
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, s := range recorder.Ended() {
+		key := s.Name()
+		for _, kv := range s.Attributes() {
+			if kv.Key == attribute.Key(ioc233.SpanAttrBean) {
+				key += "/" + kv.Value.AsString()
+			}
+		}
+		spans[key] = s
+	}
+	root, ok := spans[ioc233.SpanStartUp]
+	if !ok || root.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Fatalf("启动 span 应该是调用方 span 的子 span, 实际: %v", spans)
+	}
+	service, ok := spans[ioc233.SpanInject+"/Service"]
+	if !ok || service.Parent().SpanID() != root.SpanContext().SpanID() {
+		t.Fatalf("应该为每个 bean 记录注入 span, 实际: %v", spans)
+	}
+	if service.Status().Code != codes.Error || len(service.Events()) == 0 {
+		t.Errorf("字段注入失败时 span 应该记录错误, 实际: %+v", service.Status())
+	}
+	if repo := spans[ioc233.SpanInject+"/Repo"]; repo == nil || repo.Status().Code == codes.Error {
+		t.Errorf("注入成功的 bean 不应该记录错误")
+	}
+	if _, ok := spans[ioc233.SpanCallback+"/Service"]; !ok {
+		t.Errorf("应该为生命周期回调记录 span, 实际: %v", spans)
+	}
+}
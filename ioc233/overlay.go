@@ -0,0 +1,102 @@
+package ioc233
+
+import (
+	"errors"
+	"maps"
+	"reflect"
+)
+
+// overlayFrame 一层覆盖层：记录压入前的注册状态，弹出时恢复
+type overlayFrame struct {
+	beans           []*beanDefinition
+	typeToObjectMap map[reflect.Type]any
+	nameToObjMap    map[string]any
+	bindings        map[reflect.Type]reflect.Type
+	// rewired 覆盖层 bean 替换掉的注入（旧实例 -> 覆盖层实例），弹出时逆序还原
+	rewired [][2]any
+}
+
+// PushOverlay 压入一层覆盖层（测试层）
+// 之后通过 Provide/ProvideByName 注册的 bean 位于覆盖层：同类型或同名的底层 bean 被遮蔽，
+// 按接口解析时覆盖层 bean 优先；容器已启动时依赖被遮蔽 bean 的字段会改为注入覆盖层 bean。
+// 覆盖层可以嵌套，PopOverlay 弹出最近一层并恢复原有注册与注入：
+//
+//	c.PushOverlay()
+//	defer c.PopOverlay()
+//	c.Provide(&MockMailer{})
+func (c *Container) PushOverlay() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.overlays = append(c.overlays, &overlayFrame{
+		beans:           append([]*beanDefinition(nil), c.beans...),
+		typeToObjectMap: c.typeToObjectMap.clone(),
+		nameToObjMap:    c.nameToObjMap.clone(),
+		bindings:        maps.Clone(c.bindings),
+	})
+}
+
+// PopOverlay 弹出最近一层覆盖层，移除其中注册的 bean 并恢复被遮蔽的 bean
+func (c *Container) PopOverlay() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if len(c.overlays) == 0 {
+		return errors.New("[ioc233] PopOverlay 失败: 没有覆盖层")
+	}
+	frame := c.overlays[len(c.overlays)-1]
+	c.overlays = c.overlays[:len(c.overlays)-1]
+
+	for i := len(frame.rewired) - 1; i >= 0; i-- {
+		c.rewireDependentsLocked(frame.rewired[i][1], frame.rewired[i][0])
+	}
+	c.beans = frame.beans
+	c.resetImplIndexLocked()
+	c.typeToObjectMap.restore(frame.typeToObjectMap)
+	c.nameToObjMap.restore(frame.nameToObjMap)
+	c.bindings = frame.bindings
+	logInfo("[ioc233] 弹出覆盖层，剩余层数: %d", len(c.overlays))
+	return nil
+}
+
+// provideOverlayLocked 在最近一层覆盖层中注册 bean（调用方需持有写锁）
+// name 为空时使用默认 bean 名
+func (c *Container) provideOverlayLocked(name string, instance any) {
+	t := reflect.TypeOf(instance)
+	if name == "" {
+		name = c.defaultBeanName(t)
+	}
+	frame := c.overlays[len(c.overlays)-1]
+	c.initBasicFields(instance)
+
+	// 遮蔽同类型或同名的底层 bean
+	kept := make([]*beanDefinition, 0, len(c.beans)+1)
+	var shadowed []any
+	for _, def := range c.beans {
+		if def.typ == t || def.name == name {
+			shadowed = append(shadowed, def.instance)
+			if obj, ok := c.typeToObjectMap.load(def.typ); ok && sameInstance(obj, def.instance) {
+				c.typeToObjectMap.delete(def.typ)
+			}
+			continue
+		}
+		kept = append(kept, def)
+	}
+	// 覆盖层 bean 排在最前，按接口解析时优先命中
+	c.beans = append([]*beanDefinition{{name: name, typ: t, instance: instance}}, kept...)
+	c.resetImplIndexLocked()
+	c.typeToObjectMap.store(t, instance)
+	c.nameToObjMap.store(name, instance)
+	logInfo("[ioc233] 覆盖层注册 bean | name = %s (type: %v), 遮蔽 %d 个 bean", name, t, len(shadowed))
+	c.emit(BeanRegistered{Name: name, Type: t})
+
+	if c.state == StateStarted {
+		c.injectInternal(instance, resolveWrite)
+		c.markInjectedLocked(instance)
+		for _, old := range shadowed {
+			c.rewireDependentsLocked(old, instance)
+			frame.rewired = append(frame.rewired, [2]any{old, instance})
+		}
+	}
+	if obj, ok := instance.(IProvideAfter); ok {
+		obj.OnProvideAfter()
+	}
+}
@@ -0,0 +1,73 @@
+package ioc233
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// SetTestMode 开启/关闭测试模式
+// 测试模式下允许在容器启动后调用 Override/OverrideByName 替换 bean
+func SetTestMode(enabled bool) {
+	_defaultLock.Lock()
+	defer _defaultLock.Unlock()
+	_testMode = enabled
+}
+
+// IsTestMode 是否处于测试模式
+func IsTestMode() bool {
+	_defaultLock.Lock()
+	defer _defaultLock.Unlock()
+	return _testMode
+}
+
+// Override 用 instance 覆盖同类型的已注册 bean；未注册时直接注册（不产生重复注册警告）
+// 仅允许在 StartUp 之前调用，或在测试模式（SetTestMode）下调用；
+// 替换为不同类型的假实现（例如用 FakeMailer 替换 SMTPMailer）请使用 OverrideByName
+func (c *Container) Override(instance any) error {
+	if instance == nil {
+		return errors.New("[ioc233] Override 参数非法")
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if err := c.checkOverridableLocked(); err != nil {
+		return err
+	}
+	t := reflect.TypeOf(instance)
+	for _, def := range c.beans {
+		if def.typ == t {
+			return c.replaceBeanLocked(def, instance)
+		}
+	}
+	c.provideLocked(instance)
+	return nil
+}
+
+// OverrideByName 用 instance 覆盖名为 name 的已注册 bean；未注册时按名称注册
+// instance 可以与原 bean 类型不同：旧类型的映射会被移除，按接口注入时将解析到新实例，
+// 已启动容器中依赖旧实例的字段会被重新注入。调用时机限制同 Override
+func (c *Container) OverrideByName(name string, instance any) error {
+	if instance == nil || strings.TrimSpace(name) == "" {
+		return errors.New("[ioc233] OverrideByName 参数非法")
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if err := c.checkOverridableLocked(); err != nil {
+		return err
+	}
+	for _, def := range c.beans {
+		if def.name == name {
+			return c.replaceBeanLocked(def, instance)
+		}
+	}
+	return c.provideByNameLocked(name, instance)
+}
+
+// checkOverridableLocked 检查当前是否允许覆盖 bean（调用方需持有锁）
+func (c *Container) checkOverridableLocked() error {
+	if c.state == StateCreated || IsTestMode() {
+		return nil
+	}
+	return fmt.Errorf("[ioc233] 容器状态为 %v，仅允许在 StartUp 之前或测试模式下覆盖 bean", c.state)
+}
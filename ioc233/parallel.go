@@ -0,0 +1,113 @@
+package ioc233
+
+import (
+	"context"
+	"reflect"
+	"sync"
+)
+
+// SetInjectionWorkers 设置 StartUp 字段注入的并行工作协程数（n <= 1 时按顺序注入，默认顺序注入）
+// 并行模式下每个启动阶段内：先按依赖顺序触发全部 IInjectBefore 回调，再由 n 个协程并发注入各 bean 的字段，
+// 最后按依赖顺序触发 IInjectAfter 与 OnInjectComplete 回调。
+// 以下字段会在容器中创建对象或执行用户代码，所在 bean 仍在并发注入之后按顺序注入：
+// autowire:"new"、懒加载、balance、自定义标签处理器、命中原型 bean 或按需构造函数的字段。
+// 注册了 BeanPostProcessor 时整体回退为顺序注入（注入后替换的实例需对后续 bean 可见）。
+// 并行注入时 InjectionFailed 事件监听器、配置源与密钥源可能被并发调用，需保证并发安全
+//
+//	container.SetInjectionWorkers(runtime.NumCPU())
+func (c *Container) SetInjectionWorkers(n int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.injectionWorkers = n
+}
+
+// injectWaveParallelLocked 并行注入一个启动阶段内尚未注入的 bean（调用方需持有写锁）
+func (c *Container) injectWaveParallelLocked(ctx context.Context, run *startupRun, defs []*beanDefinition) error {
+	var tasks []*injectTask
+	abort := func(from int, err error) error {
+		for _, task := range tasks[from:] {
+			task.end(err)
+		}
+		return err
+	}
+	for _, def := range defs {
+		if def.injected {
+			continue
+		}
+		task, err := c.beginInjectLocked(ctx, run, def)
+		if err != nil {
+			return abort(0, err)
+		}
+		tasks = append(tasks, task)
+	}
+
+	// 同一实例只并发注入一次，其余与不可并发的 bean 一起顺序注入
+	var parallel, serial []*injectTask
+	seen := make(map[any]bool, len(tasks))
+	for _, task := range tasks {
+		instance := task.def.instance
+		if reflect.ValueOf(instance).Kind() == reflect.Ptr && !seen[instance] && c.parallelSafeLocked(instance) {
+			seen[instance] = true
+			parallel = append(parallel, task)
+		} else {
+			serial = append(serial, task)
+		}
+	}
+	logDebug("[ioc233] 并行注入: workers=%d parallel=%d serial=%d", c.injectionWorkers, len(parallel), len(serial))
+
+	jobs := make(chan *injectTask)
+	var wg sync.WaitGroup
+	for i := 0; i < min(c.injectionWorkers, len(parallel)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for task := range jobs {
+				task.inject(c, resolveRead)
+			}
+		}()
+	}
+	for _, task := range parallel {
+		jobs <- task
+	}
+	close(jobs)
+	wg.Wait()
+	for _, task := range serial {
+		task.inject(c, resolveWrite)
+	}
+
+	for i, task := range tasks {
+		if err := c.finishInjectLocked(run, task); err != nil {
+			return abort(i+1, err)
+		}
+	}
+	return nil
+}
+
+// parallelSafeLocked 判断对象的字段注入是否只读取容器（不创建 bean、不执行用户代码），可与其他 bean 并发注入
+func (c *Container) parallelSafeLocked(instance any) bool {
+	t := reflect.TypeOf(instance).Elem()
+	if t.Kind() != reflect.Struct {
+		return true
+	}
+	structName := displayTypeName(t)
+	for _, plan := range structMetaOf(t).plans {
+		field, tag := plan.field, plan.autowire
+		if len(c.tagHandlersFor(field)) > 0 {
+			return false
+		}
+		if tag == "" {
+			continue
+		}
+		if tag == autowireNew || field.Tag.Get("lazy") != "" || field.Tag.Get("balance") != "" {
+			return false
+		}
+		if _, ok := lazyBinderOf(reflect.New(field.Type).Elem()); ok {
+			return false
+		}
+		// 演练解析：未解析到且没有错误说明将创建原型 bean 或调用按需构造函数（或可选依赖缺失）
+		if v, err := c.resolveField(structName, field, tag, resolveDryRun); err == nil && !v.IsValid() {
+			return false
+		}
+	}
+	return true
+}
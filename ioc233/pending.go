@@ -0,0 +1,111 @@
+package ioc233
+
+import "reflect"
+
+// PendingDependency 尚未满足的必须依赖（字段注入失败，等待匹配的 bean 注册）
+type PendingDependency struct {
+	// Bean 依赖方 bean 名
+	Bean string
+	// Struct 依赖方结构体名
+	Struct string
+	// Field 字段名
+	Field string
+	// Type 字段类型
+	Type string
+	// Tag autowire 标签值
+	Tag string
+}
+
+// pendingField 待补齐的字段
+type pendingField struct {
+	def   *beanDefinition
+	owner reflect.Type
+	field reflect.StructField
+	tag   string
+}
+
+// PendingDependencies 返回尚未满足的必须依赖（按登记顺序）
+// 启动时未能注入的 autowire:"true" 与名称注入字段会被登记；之后通过 Provide/ProvideByName/ProvideFactory
+// 注册了匹配的 bean 时自动补齐注入并触发 IDependencyResolved 通知，适用于插件式的实现延迟到达
+func (c *Container) PendingDependencies() []PendingDependency {
+	var out []PendingDependency
+	c.withReadLock(func() {
+		out = make([]PendingDependency, 0, len(c.pending))
+		for _, p := range c.pending {
+			out = append(out, PendingDependency{
+				Bean:   p.def.name,
+				Struct: displayTypeName(p.owner),
+				Field:  p.field.Name,
+				Type:   p.field.Type.String(),
+				Tag:    p.tag,
+			})
+		}
+	})
+	return out
+}
+
+// collectPendingLocked 登记 bean 中注入后仍为空、且当前无法解析的必须字段（调用方需持有写锁）
+func (c *Container) collectPendingLocked(def *beanDefinition) {
+	v := reflect.ValueOf(def.instance)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return
+	}
+	elem := v.Elem()
+	t := elem.Type()
+	for _, field := range injectableFields(t) {
+		tag := autowireTag(field)
+		if tag == "" || (tag != "true" && !isNameTag(tag)) || !field.IsExported() || field.Type.Kind() == reflect.Slice {
+			continue
+		}
+		if _, ok := reflect.New(field.Type).Interface().(lazyBinder); ok {
+			continue
+		}
+		fv := fieldByIndex(elem, field.Index, false)
+		if fv.IsValid() && !fv.IsZero() {
+			continue
+		}
+		if _, err := c.resolveField(displayTypeName(t), field, tag, resolveDryRun); err == nil {
+			continue
+		}
+		c.pending = append(c.pending, &pendingField{def: def, owner: t, field: field, tag: tag})
+		logWarn("[ioc233] 登记待定依赖: bean=%s field=%s type=%v", def.name, field.Name, field.Type)
+	}
+}
+
+// resolvePendingLocked 尝试补齐待定依赖（调用方需持有写锁）
+// 补齐后的字段触发 IDependencyResolved；依赖方已被替换为其他类型或字段已被手动赋值时不再跟踪
+func (c *Container) resolvePendingLocked() {
+	if len(c.pending) == 0 {
+		return
+	}
+	kept := c.pending[:0]
+	for _, p := range c.pending {
+		v := reflect.ValueOf(p.def.instance)
+		if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Type() != p.owner {
+			continue
+		}
+		fv := fieldByIndex(v.Elem(), p.field.Index, true)
+		if !fv.IsValid() || !fv.IsZero() {
+			continue
+		}
+		structName := displayTypeName(p.owner)
+		resolved, err := c.resolveField(structName, p.field, p.tag, resolveWrite)
+		if err == nil {
+			err = c.checkVisible(p.owner, p.field, resolved)
+		}
+		if err != nil || !resolved.IsValid() {
+			kept = append(kept, p)
+			continue
+		}
+		fv.Set(resolved)
+		c.recordInjection(p.def.instance, p.field, injectionMode(p.field, p.tag), false, resolved)
+		logInfo("[ioc233] 待定依赖已补齐: bean=%s field=%s type=%v", p.def.name, p.field.Name, resolved.Type())
+		if obj, ok := p.def.instance.(IDependencyResolved); ok {
+			obj.OnDependencyResolved(p.field.Name)
+		}
+	}
+	for i := len(kept); i < len(c.pending); i++ {
+		c.pending[i] = nil
+	}
+	c.pending = kept
+}
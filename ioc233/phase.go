@@ -0,0 +1,108 @@
+package ioc233
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// DefinePhases 定义启动阶段及其顺序（需在 StartUp 之前调用，重复调用以最后一次为准）
+// StartUp 按阶段分批注入：每个阶段的 bean 完成注入与 OnInjectComplete 回调后，才开始下一阶段；
+// 未声明阶段的 bean 归入最后的默认阶段。bean 通过 InPhase 选项或结构体 phase 标签声明所属阶段：
+//
+//	container.DefinePhases("infrastructure", "domain", "controllers")
+//	container.Provide(db, ioc233.InPhase("infrastructure"))
+//
+//	type OrderController struct {
+//	    _ struct{} `phase:"controllers"`
+//	}
+func (c *Container) DefinePhases(names ...string) error {
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("[ioc233] DefinePhases 阶段名不能为空")
+		}
+		if seen[name] {
+			return fmt.Errorf("[ioc233] DefinePhases 阶段名重复: %s", name)
+		}
+		seen[name] = true
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.state != StateCreated {
+		return fmt.Errorf("[ioc233] DefinePhases 只能在 StartUp 之前调用（当前状态 %s）", c.state)
+	}
+	c.phases = append([]string(nil), names...)
+	return nil
+}
+
+// InPhase 声明 bean 所属的启动阶段（见 DefinePhases）
+func InPhase(name string) BeanOption {
+	return func(o *beanOptions) {
+		o.phase = name
+	}
+}
+
+// phaseOf 返回 bean 所属阶段：InPhase 选项优先，其次结构体 phase 标签
+func phaseOf(def *beanDefinition) string {
+	if def.phase != "" {
+		return def.phase
+	}
+	t := reflect.TypeOf(def.instance)
+	if t == nil {
+		return ""
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return ""
+	}
+	return structMetaOf(t).phase
+}
+
+// phaseRanksLocked 计算每个 bean 所属阶段的序号（默认阶段为 len(c.phases)）；声明了未定义的阶段时返回错误
+func (c *Container) phaseRanksLocked() (map[*beanDefinition]int, error) {
+	index := make(map[string]int, len(c.phases))
+	for i, name := range c.phases {
+		index[name] = i
+	}
+	ranks := make(map[*beanDefinition]int, len(c.beans))
+	var unknown []string
+	for _, def := range c.beans {
+		name := phaseOf(def)
+		if name == "" {
+			ranks[def] = len(c.phases)
+			continue
+		}
+		i, ok := index[name]
+		if !ok {
+			unknown = append(unknown, fmt.Sprintf("%s(phase=%s)", def.name, name))
+			continue
+		}
+		ranks[def] = i
+	}
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("[ioc233] bean 声明了未定义的启动阶段: %s（已定义: %v）", strings.Join(unknown, ", "), c.phases)
+	}
+	return ranks, nil
+}
+
+// phaseName 返回阶段序号对应的名称（默认阶段为 "default"）
+func (c *Container) phaseName(rank int) string {
+	if rank < len(c.phases) {
+		return c.phases[rank]
+	}
+	return "default"
+}
+
+// uninjectedLocked 返回尚未注入的 bean（按注册顺序，排除 except）
+func (c *Container) uninjectedLocked(except *beanDefinition) []*beanDefinition {
+	var defs []*beanDefinition
+	for _, def := range c.beans {
+		if !def.injected && def != except {
+			defs = append(defs, def)
+		}
+	}
+	return defs
+}
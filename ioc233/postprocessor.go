@@ -0,0 +1,47 @@
+package ioc233
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// BeanPostProcessor bean 后置处理器（类似 Spring 的 BeanPostProcessor）
+// StartUp 对每个 bean 依次调用所有已注册处理器的钩子，用于代理包装等横切关注点：
+//   - BeforeInject 在字段注入（与注入前回调）之前调用
+//   - AfterInject 在字段注入与注入后回调之后、OnInjectComplete 之前调用
+//
+// 钩子返回的对象与传入的不同时替换容器中的 bean（类型可以不同，例如包装为实现同一接口的代理），
+// 已注入旧实例的字段会被改为新实例，后续的生命周期回调（OnInjectComplete、IDestroy）作用于新实例；
+// 返回 nil 表示保持不变，返回错误则中止启动
+type BeanPostProcessor interface {
+	BeforeInject(name string, bean any) (any, error)
+	AfterInject(name string, bean any) (any, error)
+}
+
+// RegisterPostProcessor 注册 bean 后置处理器（按注册顺序调用，需在 StartUp 之前注册）
+func (c *Container) RegisterPostProcessor(p BeanPostProcessor) {
+	if p == nil {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.postProcessors = append(c.postProcessors, p)
+}
+
+// postProcessLocked 依次调用后置处理器的钩子，返回值与当前实例不同时替换 bean（调用方需持有写锁）
+func (c *Container) postProcessLocked(def *beanDefinition, hook func(p BeanPostProcessor, name string, bean any) (any, error)) error {
+	for _, p := range c.postProcessors {
+		replacement, err := hook(p, def.name, def.instance)
+		if err != nil {
+			return fmt.Errorf("[ioc233] 后置处理器 %T 处理 bean 失败: name=%s: %w", p, def.name, err)
+		}
+		if isNilValue(reflect.ValueOf(replacement)) || sameInstance(replacement, def.instance) {
+			continue
+		}
+		logInfo("[ioc233] 后置处理器 %T 替换 bean: name=%s %v -> %T", p, def.name, def.typ, replacement)
+		if err := c.replaceBeanLocked(def, replacement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
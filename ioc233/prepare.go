@@ -0,0 +1,146 @@
+package ioc233
+
+import "reflect"
+
+// 并发注册说明：
+// 不修改实例的标签解析（prepareBean）与名称、类型的预留（provideFast，按分片竞争）都在容器读锁下进行，
+// 写锁内只设置字段、登记 bean 并触发回调，注册日志在释放写锁后输出；并发注册的开销见 BenchmarkProvideByName_Concurrent
+
+// preparedBean 在读锁下预先解析的 bean 基础字段（见 prepareBean）
+type preparedBean struct {
+	// fields 按 structMeta.plans 下标排列的解析结果
+	fields []basicFieldValue
+}
+
+// basicFieldValue 基础字段 env/default 标签的解析结果
+type basicFieldValue struct {
+	// value 要设置的值（无效值表示不设置）
+	value reflect.Value
+	// handled 标签已处理该字段，不再应用默认值提供器
+	handled bool
+	// fromEnv 值来自 env 标签
+	fromEnv bool
+	// errs 解析错误，注册时记入 fatalErrors
+	errs []error
+}
+
+// prepareBean 在读锁下预先解析 bean 基础字段的 env、default 标签（反射与字面量解析），不修改实例：
+// 并发的 Provide/ProvideByName 可以同时解析，写锁内通过重复检查后才由 initPreparedLocked 设置字段，
+// 被拒绝的重复注册不会留下任何修改；默认值提供器可能有副作用，仍在写锁内执行
+// 延迟到 StartUp 的 profile 条件注册返回 nil，由注册流程按原有顺序初始化
+func (c *Container) prepareBean(instance any) *preparedBean {
+	if instance == nil || profileTagOf(instance) != "" {
+		return nil
+	}
+	v := reflect.ValueOf(instance)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	elem := v.Elem()
+	plans := structMetaOf(elem.Type()).plans
+	prepared := &preparedBean{fields: make([]basicFieldValue, len(plans))}
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	for i, plan := range plans {
+		fv := fieldByIndex(elem, plan.field.Index, false)
+		if !fv.IsValid() || !fv.CanSet() || plan.autowire != "" {
+			continue
+		}
+		prepared.fields[i] = c.resolveBasicField(elem.Type().Name(), plan.field, fv)
+	}
+	return prepared
+}
+
+// provideFast 启动前并发注册的快速路径：在容器读锁下按分片预留名称（Provide 同时预留类型），
+// 通过后在写锁内设置预先解析的字段、登记 bean 并触发 IProvideAfter，注册日志在释放写锁后输出
+// name 为空时按 Provide 注册（默认 bean 名）；返回 false 表示未注册，由调用方走完整的注册流程：
+// profile 条件注册、特性开关、覆盖层、已冻结、非 Created 状态以及名称或类型已被占用（按重复策略处理）
+func (c *Container) provideFast(name string, instance any, prepared *preparedBean, opts []BeanOption) bool {
+	if prepared == nil || featureFlagOf(opts) != "" {
+		return false
+	}
+	t := reflect.TypeOf(instance)
+	byType := name == ""
+	r := &reservation{}
+	release := func() {
+		if byType {
+			c.typeToObjectMap.release(t, r)
+		}
+		c.nameToObjMap.release(name, r)
+	}
+
+	c.mutex.RLock()
+	if !c.fastProvidableLocked() {
+		c.mutex.RUnlock()
+		return false
+	}
+	if byType {
+		name = c.defaultBeanName(t)
+		if !c.typeToObjectMap.reserve(t, r) {
+			c.mutex.RUnlock()
+			return false
+		}
+	}
+	if !c.nameToObjMap.reserve(name, r) {
+		release()
+		c.mutex.RUnlock()
+		return false
+	}
+	c.mutex.RUnlock()
+
+	c.mutex.Lock()
+	// 释放读锁到获取写锁之间容器可能被冻结、压入覆盖层或开始启动，预留也可能被 ProvideByName 覆盖
+	if !c.fastProvidableLocked() || (byType && !c.typeToObjectMap.holds(t, r)) || !c.nameToObjMap.holds(name, r) {
+		release()
+		c.mutex.Unlock()
+		return false
+	}
+	c.initPreparedLocked(instance, prepared)
+	c.typeToObjectMap.store(t, instance)
+	c.nameToObjMap.store(name, instance)
+	c.addBeanLocked(&beanDefinition{name: name, typ: t, instance: instance})
+	c.emit(BeanRegistered{Name: name, Type: t})
+	if obj, ok := instance.(IProvideAfter); ok {
+		obj.OnProvideAfter()
+	}
+	c.applyBeanOptionsLocked(instance, opts)
+	c.mutex.Unlock()
+
+	if byType {
+		logInfo("[ioc233] 注册 bean | struct name = %s (type: %v)", t.String(), t)
+	} else {
+		logInfo("[ioc233] 注册 bean(byName) | name = %s, struct = %s (type: %v)", name, t.String(), t)
+	}
+	return true
+}
+
+// fastProvidableLocked 判断当前能否走并发注册的快速路径（调用方需持有锁）
+func (c *Container) fastProvidableLocked() bool {
+	return c.state == StateCreated && !c.frozen && len(c.overlays) == 0
+}
+
+// resolveBasicField 解析基础字段的 env 与 default 标签（env 优先），不修改字段（调用方需持有锁）
+func (c *Container) resolveBasicField(structName string, field reflect.StructField, fv reflect.Value) basicFieldValue {
+	var r basicFieldValue
+	v, err := c.envTagValue(structName, field)
+	if err != nil {
+		r.errs = append(r.errs, err)
+	}
+	if v.IsValid() {
+		r.value, r.handled, r.fromEnv = v, true, true
+		return r
+	}
+	v, handled, err := defaultTagValue(structName, field, fv)
+	if err != nil {
+		r.errs = append(r.errs, err)
+	}
+	r.value, r.handled = v, handled
+	return r
+}
+
+// initPreparedLocked 初始化基础字段：prepared 不为 nil 时设置预先解析的值，否则在此解析（调用方需持有写锁）
+func (c *Container) initPreparedLocked(instance any, prepared *preparedBean) {
+	if errs := c.initBasicFieldsErrs(instance, prepared); len(errs) > 0 {
+		c.fatalErrors = append(c.fatalErrors, errs...)
+	}
+}
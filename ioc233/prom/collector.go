@@ -0,0 +1,68 @@
+// Package prom ioc233 容器的 Prometheus 指标采集器
+// 独立模块，只有导入本包时才引入 prometheus/client_golang 依赖：
+//
+//	prometheus.MustRegister(prom.NewCollector(container, nil))
+package prom
+
+import (
+	"github.com/neko233-com/ioc233-go/ioc233"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// namespace 指标名前缀
+const namespace = "ioc233"
+
+// Collector 容器指标采集器（实现 prometheus.Collector，每次采集读取 Container.Metrics 快照）
+type Collector struct {
+	container *ioc233.Container
+
+	beans             *prometheus.Desc
+	started           *prometheus.Desc
+	startupDuration   *prometheus.Desc
+	injectionFailures *prometheus.Desc
+	lazyResolutions   *prometheus.Desc
+	lazyFailures      *prometheus.Desc
+}
+
+// NewCollector 创建容器指标采集器
+// constLabels 附加到每个指标的固定标签（同一进程注册多个容器时用于区分，可为 nil）
+func NewCollector(c *ioc233.Container, constLabels prometheus.Labels) *Collector {
+	desc := func(name, help string, labels ...string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "", name), help, labels, constLabels)
+	}
+	return &Collector{
+		container:         c,
+		beans:             desc("beans", "Number of registered beans by scope.", "scope"),
+		started:           desc("started", "Whether the container has started successfully (1) or not (0)."),
+		startupDuration:   desc("startup_duration_seconds", "Duration of the last successful StartUp in seconds."),
+		injectionFailures: desc("injection_failures_total", "Total number of autowire fields that failed to inject."),
+		lazyResolutions:   desc("lazy_resolutions_total", "Total number of lazy dependencies resolved on first use."),
+		lazyFailures:      desc("lazy_resolution_failures_total", "Total number of lazy dependency resolutions that failed."),
+	}
+}
+
+// Describe 实现 prometheus.Collector
+func (p *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- p.beans
+	ch <- p.started
+	ch <- p.startupDuration
+	ch <- p.injectionFailures
+	ch <- p.lazyResolutions
+	ch <- p.lazyFailures
+}
+
+// Collect 实现 prometheus.Collector
+func (p *Collector) Collect(ch chan<- prometheus.Metric) {
+	m := p.container.Metrics()
+	started := 0.0
+	if m.State == ioc233.StateStarted {
+		started = 1
+	}
+	ch <- prometheus.MustNewConstMetric(p.beans, prometheus.GaugeValue, float64(m.Beans), "singleton")
+	ch <- prometheus.MustNewConstMetric(p.beans, prometheus.GaugeValue, float64(m.Prototypes), "prototype")
+	ch <- prometheus.MustNewConstMetric(p.started, prometheus.GaugeValue, started)
+	ch <- prometheus.MustNewConstMetric(p.startupDuration, prometheus.GaugeValue, m.StartupDuration.Seconds())
+	ch <- prometheus.MustNewConstMetric(p.injectionFailures, prometheus.CounterValue, float64(m.InjectionFailures))
+	ch <- prometheus.MustNewConstMetric(p.lazyResolutions, prometheus.CounterValue, float64(m.LazyResolutions))
+	ch <- prometheus.MustNewConstMetric(p.lazyFailures, prometheus.CounterValue, float64(m.LazyFailures))
+}
@@ -0,0 +1,59 @@
+package prom_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+	"github.com/neko233-com/ioc233-go/ioc233/prom"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type Repo struct{}
+
+type Service struct {
+	Repo    *Repo              `autowire:"true"`
+	Missing *strings.Builder   `autowire:"true"`
+	Lazy    ioc233.Lazy[*Repo] `autowire:"true"`
+}
+
+func TestCollector(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.SetQuietStartup(true)
+	svc := &Service{}
+	c.Provide(&Repo{})
+	c.Provide(svc)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	svc.Lazy.Get()
+
+	collector := prom.NewCollector(c, prometheus.Labels{"app": "demo"})
+	if n := testutil.CollectAndCount(collector); n != 7 {
+		t.Fatalf("应该采集 7 个指标, 实际: %d", n)
+	}
+	expected := `
+# HELP ioc233_beans Number of registered beans by scope.
+# TYPE ioc233_beans gauge
+ioc233_beans{app="demo",scope="prototype"} 0
+ioc233_beans{app="demo",scope="singleton"} 2
+# HELP ioc233_injection_failures_total Total number of autowire fields that failed to inject.
+# TYPE ioc233_injection_failures_total counter
+ioc233_injection_failures_total{app="demo"} 1
+# HELP ioc233_lazy_resolutions_total Total number of lazy dependencies resolved on first use.
+# TYPE ioc233_lazy_resolutions_total counter
+ioc233_lazy_resolutions_total{app="demo"} 1
+# HELP ioc233_started Whether the container has started successfully (1) or not (0).
+# TYPE ioc233_started gauge
+ioc233_started{app="demo"} 1
+`
+	err := testutil.CollectAndCompare(collector, strings.NewReader(expected),
+		"ioc233_beans", "ioc233_injection_failures_total", "ioc233_lazy_resolutions_total", "ioc233_started")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if problems, err := testutil.CollectAndLint(collector); err != nil || len(problems) > 0 {
+		t.Errorf("指标命名应该符合规范: %v %v", problems, err)
+	}
+}
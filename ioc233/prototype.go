@@ -0,0 +1,160 @@
+package ioc233
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// prototypeDefinition 原型 bean 定义：每次解析都调用工厂创建新实例
+type prototypeDefinition struct {
+	fn   reflect.Value
+	in   []reflect.Type
+	out  reflect.Type
+	name string // bean 名（默认取输出类型名）
+
+	// 预热备用实例（SetPrototypeStandby 开启，nil 表示未开启）
+	standby chan reflect.Value
+	// 补充信号
+	refill chan struct{}
+}
+
+// ProvidePrototype 注册原型作用域的 bean
+// factory 形如 func(a A, b B) T，参数按类型从容器解析（可无参数）
+// 说明：
+// - 每次 GetObjectByType / 字段注入都会调用工厂创建新实例，适用于有状态的单任务对象
+// - 新实例会执行基础字段初始化与 autowire 注入，并依次触发 IInjectBefore/IInjectAfter/IObject 回调
+// - 基础字段或注入失败时本次创建失败，错误返回给解析方，不记为容器的致命错误
+// - 容器不跟踪原型实例，Close 时不会触发其 IDestroy 回调
+// - 同类型已存在单例 bean 时单例优先；原型之间不能相互循环依赖
+func (c *Container) ProvidePrototype(factory any) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if err := c.checkFrozenLocked("ProvidePrototype", factory); err != nil {
+		return err
+	}
+
+	fv := reflect.ValueOf(factory)
+	if factory == nil || fv.Kind() != reflect.Func {
+		return errors.New("[ioc233] ProvidePrototype 参数必须是函数")
+	}
+	ft := fv.Type()
+	if ft.NumOut() != 1 {
+		return fmt.Errorf("[ioc233] ProvidePrototype 工厂函数必须且只能返回一个值: %v", ft)
+	}
+	def := &prototypeDefinition{
+		fn:   fv,
+		in:   make([]reflect.Type, 0, ft.NumIn()),
+		out:  ft.Out(0),
+		name: c.defaultBeanName(ft.Out(0)),
+	}
+	for i := 0; i < ft.NumIn(); i++ {
+		def.in = append(def.in, ft.In(i))
+	}
+	for _, p := range c.prototypes {
+		if p.out == def.out {
+			return fmt.Errorf("[ioc233] ProvidePrototype 重复注册类型: %v", def.out)
+		}
+	}
+	c.prototypes = append(c.prototypes, def)
+	logInfo("[ioc233] 注册原型 bean | name = %s (type: %v)", def.name, def.out)
+	c.emit(BeanRegistered{Name: def.name, Type: def.out, Prototype: true})
+	return nil
+}
+
+// findPrototype 查找可赋值给 t 的原型定义（接口类型匹配实现）
+func (c *Container) findPrototype(t reflect.Type) *prototypeDefinition {
+	for _, p := range c.prototypes {
+		if p.out == t {
+			return p
+		}
+	}
+	for _, p := range c.prototypes {
+		if p.out.AssignableTo(t) || (t.Kind() == reflect.Interface && implementsInterface(p.out, t)) {
+			return p
+		}
+	}
+	return nil
+}
+
+// findPrototypeByName 按 bean 名查找原型定义
+func (c *Container) findPrototypeByName(name string) *prototypeDefinition {
+	for _, p := range c.prototypes {
+		if p.name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// injectablePrototype 为字段注入提供原型实例；create 为 false 时仅表示"可解析"
+func (c *Container) injectablePrototype(p *prototypeDefinition, create bool) (reflect.Value, error) {
+	if !create {
+		return reflect.Value{}, nil
+	}
+	return c.createPrototype(p)
+}
+
+// resolvePrototypeAs 按目标类型创建原型实例（供 GetObjectByType 回退使用）
+func (c *Container) resolvePrototypeAs(t reflect.Type) (reflect.Value, bool) {
+	p := c.findPrototype(t)
+	if p == nil {
+		return reflect.Value{}, false
+	}
+	v, err := c.createPrototype(p)
+	if err != nil {
+		logError("%s", err.Error())
+		return reflect.Value{}, false
+	}
+	return v, true
+}
+
+// createPrototype 获取原型实例：优先取预热的备用实例，否则立即创建
+// 调用方需持有容器的读锁或写锁
+func (c *Container) createPrototype(p *prototypeDefinition) (reflect.Value, error) {
+	if v, ok := p.takeStandby(); ok {
+		logDebug("[ioc233] 使用预热的原型实例: name=%s", p.name)
+		return v, nil
+	}
+	return c.buildPrototype(p)
+}
+
+// buildPrototype 调用工厂创建原型实例，并完成基础初始化、注入与回调
+// 调用方需持有容器的读锁或写锁；只读取容器状态，基础字段与注入的错误返回给调用方，不记入 fatalErrors
+func (c *Container) buildPrototype(p *prototypeDefinition) (reflect.Value, error) {
+	args := make([]reflect.Value, 0, len(p.in))
+	for _, in := range p.in {
+		v, ok := c.resolveByType(in)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("[ioc233] 原型 bean 缺少工厂参数依赖: name=%s param=%v", p.name, in)
+		}
+		args = append(args, v)
+	}
+	out := p.fn.Call(args)[0]
+	if isNilValue(out) {
+		return reflect.Value{}, fmt.Errorf("[ioc233] 原型工厂返回了 nil: name=%s", p.name)
+	}
+	instance := out.Interface()
+
+	errs := c.initBasicFieldsErrs(instance, nil)
+	if obj, ok := instance.(IInjectBefore); ok {
+		obj.OnInjectBefore()
+	}
+	fieldErrs, err := c.injectFieldErrs(context.Background(), instance, resolveRead)
+	errs = append(errs, fieldErrs...)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		return reflect.Value{}, fmt.Errorf("[ioc233] 原型实例初始化失败: name=%s: %w", p.name, errors.Join(errs...))
+	}
+	if obj, ok := instance.(IInjectAfter); ok {
+		obj.OnInjectAfter()
+	}
+	if obj, ok := instance.(IObject); ok {
+		obj.OnInjectComplete()
+	}
+	logDebug("[ioc233] 创建原型实例: name=%s type=%v", p.name, p.out)
+	return out, nil
+}
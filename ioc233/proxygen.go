@@ -0,0 +1,261 @@
+package ioc233
+
+import (
+	"errors"
+	"fmt"
+	"go/format"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// GenerateProxy 为接口生成转发代理源码，并在 init 中通过 RegisterProxy 注册
+// 参数：
+//   - pkgPath/pkgName: 生成文件所在包的导入路径与包名（同包类型不加限定）
+//   - iface: 接口类型，例如 reflect.TypeOf((*UserService)(nil)).Elem()
+//
+// 生成的代理每次方法调用都通过 target() 获取真实对象后转发，
+// 可用于 lazy:"true" 懒加载注入等需要"先占位、后解析"的场景。
+// 通常在 go:generate 调用的小程序中使用
+func GenerateProxy(w io.Writer, pkgPath, pkgName string, iface reflect.Type) error {
+	if iface == nil || iface.Kind() != reflect.Interface {
+		return errors.New("[ioc233] GenerateProxy 只支持接口类型")
+	}
+	if iface.Name() == "" {
+		return errors.New("[ioc233] GenerateProxy 不支持匿名接口")
+	}
+	g := &proxyGen{selfPath: pkgPath, imports: make(map[string]string)}
+	g.imports["github.com/neko233-com/ioc233-go/ioc233"] = "ioc233"
+
+	ifaceName, err := g.typeExpr(iface)
+	if err != nil {
+		return err
+	}
+	proxyName := lowerFirst(iface.Name()) + "Proxy"
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "// %s %s 的转发代理\n", proxyName, iface.Name())
+	fmt.Fprintf(&body, "type %s struct {\n\ttarget func() %s\n}\n", proxyName, ifaceName)
+	for i := 0; i < iface.NumMethod(); i++ {
+		m := iface.Method(i)
+		if !m.IsExported() {
+			return fmt.Errorf("[ioc233] GenerateProxy 不支持未导出方法: %v.%s", iface, m.Name)
+		}
+		if err := g.writeMethod(&body, proxyName, m); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintf(&body, "\nfunc init() {\n\tioc233.RegisterProxy[%s](func(target func() %s) %s {\n\t\treturn &%s{target: target}\n\t})\n}\n",
+		ifaceName, ifaceName, ifaceName, proxyName)
+
+	var out strings.Builder
+	out.WriteString("// Code generated by ioc233.GenerateProxy. DO NOT EDIT.\n\n")
+	out.WriteString("package " + pkgName + "\n\n")
+	out.WriteString("import (\n")
+	paths := make([]string, 0, len(g.imports))
+	for p := range g.imports {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		fmt.Fprintf(&out, "\t%s %s\n", g.imports[p], strconv.Quote(p))
+	}
+	out.WriteString(")\n\n")
+	out.WriteString(body.String())
+
+	src, err := format.Source([]byte(out.String()))
+	if err != nil {
+		return fmt.Errorf("[ioc233] GenerateProxy 生成代码格式化失败: %w", err)
+	}
+	_, err = w.Write(src)
+	return err
+}
+
+// proxyGen 代理生成上下文（负责类型表达式与导入别名）
+type proxyGen struct {
+	selfPath string
+	// 导入路径 -> 别名
+	imports map[string]string
+}
+
+// writeMethod 生成单个转发方法
+func (g *proxyGen) writeMethod(b *strings.Builder, proxyName string, m reflect.Method) error {
+	ft := m.Type
+	params := make([]string, 0, ft.NumIn())
+	args := make([]string, 0, ft.NumIn())
+	for i := 0; i < ft.NumIn(); i++ {
+		pt := ft.In(i)
+		name := "a" + strconv.Itoa(i)
+		if ft.IsVariadic() && i == ft.NumIn()-1 {
+			expr, err := g.typeExpr(pt.Elem())
+			if err != nil {
+				return err
+			}
+			params = append(params, name+" ..."+expr)
+			args = append(args, name+"...")
+			continue
+		}
+		expr, err := g.typeExpr(pt)
+		if err != nil {
+			return err
+		}
+		params = append(params, name+" "+expr)
+		args = append(args, name)
+	}
+	results := make([]string, 0, ft.NumOut())
+	for i := 0; i < ft.NumOut(); i++ {
+		expr, err := g.typeExpr(ft.Out(i))
+		if err != nil {
+			return err
+		}
+		results = append(results, expr)
+	}
+
+	fmt.Fprintf(b, "\nfunc (p *%s) %s(%s)", proxyName, m.Name, strings.Join(params, ", "))
+	switch len(results) {
+	case 0:
+	case 1:
+		b.WriteString(" " + results[0])
+	default:
+		b.WriteString(" (" + strings.Join(results, ", ") + ")")
+	}
+	b.WriteString(" {\n\t")
+	if len(results) > 0 {
+		b.WriteString("return ")
+	}
+	fmt.Fprintf(b, "p.target().%s(%s)\n}\n", m.Name, strings.Join(args, ", "))
+	return nil
+}
+
+// typeExpr 返回类型在生成代码中的表达式，并登记所需导入
+func (g *proxyGen) typeExpr(t reflect.Type) (string, error) {
+	if t.Name() != "" {
+		if t.PkgPath() == "" || t.PkgPath() == g.selfPath {
+			return t.Name(), nil
+		}
+		return g.alias(t) + "." + t.Name(), nil
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		e, err := g.typeExpr(t.Elem())
+		return "*" + e, err
+	case reflect.Slice:
+		e, err := g.typeExpr(t.Elem())
+		return "[]" + e, err
+	case reflect.Array:
+		e, err := g.typeExpr(t.Elem())
+		return "[" + strconv.Itoa(t.Len()) + "]" + e, err
+	case reflect.Map:
+		k, err := g.typeExpr(t.Key())
+		if err != nil {
+			return "", err
+		}
+		v, err := g.typeExpr(t.Elem())
+		return "map[" + k + "]" + v, err
+	case reflect.Chan:
+		e, err := g.typeExpr(t.Elem())
+		switch t.ChanDir() {
+		case reflect.RecvDir:
+			return "<-chan " + e, err
+		case reflect.SendDir:
+			return "chan<- " + e, err
+		}
+		return "chan " + e, err
+	case reflect.Func:
+		return g.funcExpr(t)
+	case reflect.Interface:
+		if t.NumMethod() == 0 {
+			return "any", nil
+		}
+	case reflect.Struct:
+		if t.NumField() == 0 {
+			return "struct{}", nil
+		}
+	}
+	return "", fmt.Errorf("[ioc233] 代码生成不支持的类型: %v", t)
+}
+
+// funcExpr 返回匿名函数类型表达式
+func (g *proxyGen) funcExpr(t reflect.Type) (string, error) {
+	in := make([]string, 0, t.NumIn())
+	for i := 0; i < t.NumIn(); i++ {
+		pt := t.In(i)
+		prefix := ""
+		if t.IsVariadic() && i == t.NumIn()-1 {
+			pt, prefix = pt.Elem(), "..."
+		}
+		e, err := g.typeExpr(pt)
+		if err != nil {
+			return "", err
+		}
+		in = append(in, prefix+e)
+	}
+	out := make([]string, 0, t.NumOut())
+	for i := 0; i < t.NumOut(); i++ {
+		e, err := g.typeExpr(t.Out(i))
+		if err != nil {
+			return "", err
+		}
+		out = append(out, e)
+	}
+	expr := "func(" + strings.Join(in, ", ") + ")"
+	switch len(out) {
+	case 0:
+	case 1:
+		expr += " " + out[0]
+	default:
+		expr += " (" + strings.Join(out, ", ") + ")"
+	}
+	return expr, nil
+}
+
+// alias 返回命名类型所在包的导入别名（同名包自动追加序号）
+func (g *proxyGen) alias(t reflect.Type) string {
+	base := t.String()
+	if i := strings.Index(base, "."); i > 0 {
+		base = base[:i]
+	}
+	return g.aliasFor(t.PkgPath(), strings.TrimLeft(base, "*[]"))
+}
+
+// aliasFor 返回导入路径的别名，base 为期望的别名（非法字符替换为下划线）
+func (g *proxyGen) aliasFor(path, base string) string {
+	if a, ok := g.imports[path]; ok {
+		return a
+	}
+	base = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return r
+		}
+		return '_'
+	}, base)
+	candidate := base
+	for n := 2; g.aliasUsed(candidate); n++ {
+		candidate = base + strconv.Itoa(n)
+	}
+	g.imports[path] = candidate
+	return candidate
+}
+
+// aliasUsed 判断别名是否已被占用
+func (g *proxyGen) aliasUsed(alias string) bool {
+	for _, a := range g.imports {
+		if a == alias {
+			return true
+		}
+	}
+	return false
+}
+
+// lowerFirst 首字母小写
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
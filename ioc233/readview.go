@@ -0,0 +1,77 @@
+package ioc233
+
+import (
+	"maps"
+	"reflect"
+	"sync"
+)
+
+// readView 启动后发布的只读注册快照，GetObjectByType 与 ContainerAdapter 的查找命中快照时无需加锁
+// 快照只缓存命中结果：显式绑定的接口、原型 bean、父容器回退与未找到的日志仍走加锁路径
+// 注册、替换、覆盖层、绑定变化与 Close 时丢弃快照，之后第一次加锁查找重新发布
+type readView struct {
+	byType map[reflect.Type]any
+	byName map[string]any
+	bound  map[reflect.Type]reflect.Type
+	// beans 按注册顺序的实例（复制实例，不引用可变的 beanDefinition）
+	beans []viewBean
+	// ifaces 接口类型 -> 第一个实现（不存在实现时不缓存）
+	ifaces sync.Map
+}
+
+// viewBean 快照中的 bean
+type viewBean struct {
+	typ      reflect.Type
+	instance any
+}
+
+// publishReadViewLocked 发布当前注册状态的快照（调用方需持有锁；未启动或处于生命周期流程中时不发布）
+func (c *Container) publishReadViewLocked() {
+	if c.state != StateStarted || c.view.Load() != nil {
+		return
+	}
+	v := &readView{
+		byType: c.typeToObjectMap.clone(),
+		byName: c.nameToObjMap.clone(),
+		bound:  maps.Clone(c.bindings),
+		beans:  make([]viewBean, 0, len(c.beans)),
+	}
+	for _, def := range c.beans {
+		if def.instance != nil {
+			v.beans = append(v.beans, viewBean{typ: def.typ, instance: def.instance})
+		}
+	}
+	c.view.Store(v)
+}
+
+// invalidateReadViewLocked 丢弃快照（修改注册状态时调用，调用方需持有写锁）
+func (c *Container) invalidateReadViewLocked() {
+	c.view.Store(nil)
+}
+
+// lookupType 按类型查找快照（接口取第一个实现）；未命中时返回 false，调用方回退到加锁路径
+func (v *readView) lookupType(t reflect.Type) (any, bool) {
+	if _, ok := v.bound[t]; ok {
+		return nil, false
+	}
+	if t.Kind() != reflect.Interface {
+		obj, ok := v.byType[t]
+		return obj, ok && obj != nil
+	}
+	if obj, ok := v.ifaces.Load(t); ok {
+		return obj, true
+	}
+	for _, b := range v.beans {
+		if implementsInterface(b.typ, t) {
+			v.ifaces.Store(t, b.instance)
+			return b.instance, true
+		}
+	}
+	return nil, false
+}
+
+// lookupName 按名称查找快照
+func (v *readView) lookupName(name string) (any, bool) {
+	obj, ok := v.byName[name]
+	return obj, ok && obj != nil
+}
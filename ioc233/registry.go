@@ -0,0 +1,194 @@
+package ioc233
+
+import (
+	"errors"
+	"fmt"
+	"go/format"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// RegistryModuleFunc 注册表模块：在给定容器上完成一组 bean 的注册
+type RegistryModuleFunc func(c *Container) error
+
+// Registry 共享 bean 注册表（多二进制 monorepo 场景）
+// 设计目标：
+//   - 所有二进制（server、worker、cron ...）共用同一份注册定义，按模块划分
+//   - 每个二进制通过 include 列表选择自己需要的模块，而不是各自复制注册代码
+//   - 提供 Check 与代码生成，确保每个二进制所选模块内的 autowire 引用都能解析，
+//     避免出现"server 正常、worker 装配缺失"的漂移
+type Registry struct {
+	mutex sync.RWMutex
+
+	// 模块名 -> 注册函数
+	modules map[string]RegistryModuleFunc
+	// 模块声明顺序（Apply 时按此顺序注册，保证结果稳定）
+	moduleOrder []string
+	// 二进制名 -> 包含的模块列表
+	binaries map[string][]string
+	// 二进制声明顺序
+	binaryOrder []string
+}
+
+// NewRegistry 创建一个空的共享注册表
+func NewRegistry() *Registry {
+	return &Registry{
+		modules:  make(map[string]RegistryModuleFunc),
+		binaries: make(map[string][]string),
+	}
+}
+
+// Module 声明一个注册模块（重复声明同名模块会覆盖并警告）
+func (r *Registry) Module(name string, fn RegistryModuleFunc) *Registry {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if _, exists := r.modules[name]; exists {
+		logWarn("[ioc233] Registry 模块重复声明，覆盖: %s", name)
+	} else {
+		r.moduleOrder = append(r.moduleOrder, name)
+	}
+	r.modules[name] = fn
+	return r
+}
+
+// Binary 声明一个二进制及其包含的模块列表
+func (r *Registry) Binary(name string, modules ...string) *Registry {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if _, exists := r.binaries[name]; !exists {
+		r.binaryOrder = append(r.binaryOrder, name)
+	}
+	r.binaries[name] = append([]string(nil), modules...)
+	return r
+}
+
+// Binaries 返回已声明的二进制名（按声明顺序）
+func (r *Registry) Binaries() []string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return append([]string(nil), r.binaryOrder...)
+}
+
+// Apply 将指定二进制包含的模块注册到容器
+// 模块按声明顺序注册（与 include 列表书写顺序无关），未知的二进制或模块返回错误
+func (r *Registry) Apply(c *Container, binary string) error {
+	selected, err := r.selectModules(binary)
+	if err != nil {
+		return err
+	}
+	for _, m := range selected {
+		logInfo("[ioc233] Registry 安装模块: binary=%s module=%s", binary, m.name)
+		if err := m.fn(c); err != nil {
+			return fmt.Errorf("[ioc233] Registry 模块注册失败: binary=%s module=%s: %w", binary, m.name, err)
+		}
+	}
+	return nil
+}
+
+// Check 在一个全新的独立容器中应用指定二进制的模块，并校验所有 autowire 引用都可解析
+// 不会调用 StartUp，也不会影响全局容器
+func (r *Registry) Check(binary string) error {
+	c := NewContainer()
+	if err := r.Apply(c, binary); err != nil {
+		return err
+	}
+	errs := c.Validate()
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("[ioc233] Registry 装配校验失败: binary=%s: %w", binary, errors.Join(errs...))
+}
+
+// CheckAll 校验所有已声明的二进制，汇总返回错误
+func (r *Registry) CheckAll() error {
+	var errs []error
+	for _, b := range r.Binaries() {
+		if err := r.Check(b); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// registryModule 选中的模块（名称 + 注册函数）
+type registryModule struct {
+	name string
+	fn   RegistryModuleFunc
+}
+
+// selectModules 根据二进制的 include 列表选出模块（按模块声明顺序）
+func (r *Registry) selectModules(binary string) ([]registryModule, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	include, ok := r.binaries[binary]
+	if !ok {
+		return nil, fmt.Errorf("[ioc233] Registry 未声明的二进制: %s", binary)
+	}
+	wanted := make(map[string]bool, len(include))
+	for _, name := range include {
+		if _, exists := r.modules[name]; !exists {
+			return nil, fmt.Errorf("[ioc233] Registry 二进制 %s 引用了未声明的模块: %s", binary, name)
+		}
+		wanted[name] = true
+	}
+	selected := make([]registryModule, 0, len(include))
+	for _, name := range r.moduleOrder {
+		if wanted[name] {
+			selected = append(selected, registryModule{name: name, fn: r.modules[name]})
+		}
+	}
+	return selected, nil
+}
+
+// GenerateRegistryCheck 生成装配校验测试文件（供 go:generate 使用）
+// 参数：
+//   - pkgName: 生成文件的包名
+//   - registryExpr: 在该包内可访问注册表的表达式，例如 "wiring.Registry" 或 "NewRegistry()"
+//   - imports: 生成文件需要额外导入的包路径
+//   - binaries: 需要校验的二进制名列表
+//
+// 生成的每个 TestWiring_<binary> 会调用 Registry.Check，
+// 在 CI 中 go test 即可发现各二进制之间的装配漂移
+func GenerateRegistryCheck(w io.Writer, pkgName, registryExpr string, imports []string, binaries ...string) error {
+	if strings.TrimSpace(pkgName) == "" || strings.TrimSpace(registryExpr) == "" {
+		return errors.New("[ioc233] GenerateRegistryCheck 参数非法")
+	}
+	var b strings.Builder
+	b.WriteString("// Code generated by ioc233.GenerateRegistryCheck. DO NOT EDIT.\n\n")
+	b.WriteString("package " + pkgName + "\n\n")
+	b.WriteString("import (\n\t\"testing\"\n")
+	for _, imp := range imports {
+		b.WriteString("\t" + strconv.Quote(imp) + "\n")
+	}
+	b.WriteString(")\n")
+	for _, bin := range binaries {
+		b.WriteString("\nfunc TestWiring_" + identifierFor(bin) + "(t *testing.T) {\n")
+		b.WriteString("\tif err := " + registryExpr + ".Check(" + strconv.Quote(bin) + "); err != nil {\n")
+		b.WriteString("\t\tt.Fatal(err)\n\t}\n}\n")
+	}
+	src, err := format.Source([]byte(b.String()))
+	if err != nil {
+		return fmt.Errorf("[ioc233] GenerateRegistryCheck 生成代码格式化失败: %w", err)
+	}
+	_, err = w.Write(src)
+	return err
+}
+
+// identifierFor 将任意名称转换为合法的 Go 标识符片段
+func identifierFor(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('_')
+		}
+	}
+	if b.Len() == 0 {
+		return "_"
+	}
+	return b.String()
+}
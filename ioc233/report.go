@@ -0,0 +1,174 @@
+package ioc233
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// slowestBeansInReport 汇总报告中列出的最慢 bean 数量
+const slowestBeansInReport = 5
+
+// StartupReport 容器启动报告（StartUp 成功后生成）
+type StartupReport struct {
+	// StartedAt 启动开始时间
+	StartedAt time.Time
+	// Duration 启动耗时
+	Duration time.Duration
+	// BeanCount 参与启动的 bean 数量
+	BeanCount int
+	// BeanTimings 每个 bean 的启动耗时（按注册顺序，见 SlowestBeans）
+	BeanTimings []BeanTiming
+	// NilFields 注入后仍为 nil 的 autowire 字段（需开启 SetNilFieldScan）
+	NilFields []NilFieldIssue
+	// FieldCycles 启动时检测到的字段注入循环依赖（可安全注入，仅供排查）
+	FieldCycles []DependencyCycle
+	// WarmUpFailures 预热失败的 bean（WarmUpContinue 策略下启动继续）
+	WarmUpFailures []WarmUpFailure
+}
+
+// BeanTiming 单个 bean 在 StartUp 中的耗时
+type BeanTiming struct {
+	// Bean bean 名
+	Bean string
+	// Type bean 类型
+	Type string
+	// Construct 构造函数耗时（ProvideFactory 创建的 bean；多返回值构造函数的每个产物记录同一耗时）
+	Construct time.Duration
+	// Inject 字段注入耗时（含懒加载代理、原型实例的创建）
+	Inject time.Duration
+	// Callbacks 生命周期回调耗时（IInjectBefore/IInjectAfter/IObject）
+	Callbacks time.Duration
+}
+
+// Total 总耗时
+func (t BeanTiming) Total() time.Duration {
+	return t.Construct + t.Inject + t.Callbacks
+}
+
+// String 返回 "bean total (construct/inject/callbacks)" 形式的描述
+func (t BeanTiming) String() string {
+	return fmt.Sprintf("%s %v (construct=%v inject=%v callbacks=%v)", t.Bean, t.Total(), t.Construct, t.Inject, t.Callbacks)
+}
+
+// SlowestBeans 返回总耗时最长的 n 个 bean（按耗时降序，n <= 0 时返回全部）
+func (r *StartupReport) SlowestBeans(n int) []BeanTiming {
+	sorted := append([]BeanTiming(nil), r.BeanTimings...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Total() > sorted[j].Total() })
+	if n > 0 && n < len(sorted) {
+		sorted = sorted[:n]
+	}
+	return sorted
+}
+
+// NilFieldIssue 注入后仍为 nil 的字段
+type NilFieldIssue struct {
+	// Bean bean 名
+	Bean string
+	// Struct 结构体名
+	Struct string
+	// Field 字段名
+	Field string
+	// Type 字段类型
+	Type string
+	// Reason 未注入原因
+	Reason string
+}
+
+// String 返回 "struct.field → reason" 形式的描述
+func (i NilFieldIssue) String() string {
+	return fmt.Sprintf("%s.%s (%s) → %s", i.Struct, i.Field, i.Type, i.Reason)
+}
+
+// String 返回可读的汇总报告
+func (r *StartupReport) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "[ioc233] 启动报告: beans=%d duration=%v", r.BeanCount, r.Duration)
+	if slowest := r.SlowestBeans(slowestBeansInReport); len(slowest) > 0 {
+		b.WriteString("\n最慢的 bean:")
+		for _, timing := range slowest {
+			b.WriteString("\n  - ")
+			b.WriteString(timing.String())
+		}
+	}
+	if len(r.WarmUpFailures) > 0 {
+		fmt.Fprintf(&b, "\n预热失败的 bean (%d):", len(r.WarmUpFailures))
+		for _, f := range r.WarmUpFailures {
+			fmt.Fprintf(&b, "\n  - %s: %v", f.Bean, f.Err)
+		}
+	}
+	if len(r.NilFields) == 0 {
+		return b.String()
+	}
+	fmt.Fprintf(&b, "\n注入后仍为 nil 的字段 (%d):", len(r.NilFields))
+	for _, issue := range r.NilFields {
+		b.WriteString("\n  - ")
+		b.WriteString(issue.String())
+	}
+	return b.String()
+}
+
+// SetNilFieldScan 开启/关闭启动后的 nil 字段扫描
+// 开启后 StartUp 完成时扫描所有 bean 中带 autowire 标签、但仍为 nil 的可导出指针/接口字段，
+// 汇总到 StartupReport.NilFields 并输出一条汇总日志，用于集中发现"忘记注册 X"
+func (c *Container) SetNilFieldScan(enabled bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.nilFieldScan = enabled
+}
+
+// StartupReport 返回最近一次成功启动的报告（未启动时返回 nil）
+func (c *Container) StartupReport() *StartupReport {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.report
+}
+
+// scanNilFieldsLocked 扫描注入后仍为 nil 的 autowire 字段（调用方需持有锁）
+func (c *Container) scanNilFieldsLocked() []NilFieldIssue {
+	var issues []NilFieldIssue
+	for _, def := range c.beans {
+		v := reflect.ValueOf(def.instance)
+		if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+			continue
+		}
+		v = v.Elem()
+		t := v.Type()
+		structName := displayTypeName(t)
+		for _, field := range injectableFields(t) {
+			tag := autowireTag(field)
+			if tag == "" || !field.IsExported() {
+				continue
+			}
+			if k := field.Type.Kind(); k != reflect.Ptr && k != reflect.Interface {
+				continue
+			}
+			if fv := fieldByIndex(v, field.Index, false); fv.IsValid() && !fv.IsNil() {
+				continue
+			}
+			issues = append(issues, NilFieldIssue{
+				Bean:   def.name,
+				Struct: structName,
+				Field:  field.Name,
+				Type:   field.Type.String(),
+				Reason: c.nilFieldReason(structName, field, tag),
+			})
+		}
+	}
+	return issues
+}
+
+// nilFieldReason 重新演练解析字段，给出未注入的原因
+func (c *Container) nilFieldReason(structName string, field reflect.StructField, tag string) string {
+	_, err := c.resolveField(structName, field, tag, resolveDryRun)
+	switch {
+	case err != nil:
+		return err.Error()
+	case tag == "false":
+		return fmt.Sprintf("可选注入未找到 %v 的实现（autowire:\"false\"），请确认是否漏注册", field.Type)
+	default:
+		return "依赖已可解析，但字段未被赋值（可能在注入后被置为 nil，或依赖在启动后才注册）"
+	}
+}
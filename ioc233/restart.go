@@ -0,0 +1,105 @@
+package ioc233
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// RestartMode 托管运行 bean（IRunnable）的 Start 返回后是否重启
+type RestartMode int
+
+const (
+	// RestartNever 不重启（默认）
+	RestartNever RestartMode = iota
+	// RestartOnFailure Start 返回错误或 panic 时重启
+	RestartOnFailure
+	// RestartAlways Start 返回后总是重启（包括正常返回）
+	RestartAlways
+)
+
+// 默认重启退避：首次 1s，每次翻倍，最长 30s
+const (
+	defaultRestartInitialBackoff = time.Second
+	defaultRestartMaxBackoff     = 30 * time.Second
+)
+
+// RestartPolicy 托管运行 bean 的重启策略
+// 重启前等待 InitialBackoff，之后每次翻倍直到 MaxBackoff；单次运行时长超过 MaxBackoff 时退避重新从 InitialBackoff 开始
+type RestartPolicy struct {
+	Mode RestartMode
+	// InitialBackoff 首次重启前的等待时间（<= 0 时为 1s）
+	InitialBackoff time.Duration
+	// MaxBackoff 退避上限（<= 0 时为 30s）
+	MaxBackoff time.Duration
+	// MaxRestarts 最多重启次数（<= 0 表示不限）
+	MaxRestarts int
+}
+
+// IRestartable 重启策略接口
+// 实现此接口的托管运行 bean 按 RestartPolicy() 重启；SetRestartPolicy 的配置优先
+type IRestartable interface {
+	// RestartPolicy 返回 Start 返回后的重启策略
+	RestartPolicy() RestartPolicy
+}
+
+// SetRestartPolicy 设置托管运行 bean 的重启策略（通常来自配置），对之后启动的 bean 生效
+// 优先级：SetRestartPolicy > IRestartable.RestartPolicy() > RestartNever
+func (c *Container) SetRestartPolicy(beanName string, policy RestartPolicy) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.restartPolicies == nil {
+		c.restartPolicies = make(map[string]RestartPolicy)
+	}
+	c.restartPolicies[beanName] = policy
+}
+
+// restartPolicyLocked 返回 bean 的重启策略（调用方需持有锁）
+func (c *Container) restartPolicyLocked(def *beanDefinition) RestartPolicy {
+	if p, ok := c.restartPolicies[def.name]; ok {
+		return p
+	}
+	if r, ok := def.instance.(IRestartable); ok {
+		return r.RestartPolicy()
+	}
+	return RestartPolicy{}
+}
+
+// shouldRestart 返回第 restarts 次重启前的判断：failed 表示本次运行以错误或 panic 结束
+func (p RestartPolicy) shouldRestart(failed bool, restarts int) bool {
+	if p.MaxRestarts > 0 && restarts >= p.MaxRestarts {
+		return false
+	}
+	switch p.Mode {
+	case RestartAlways:
+		return true
+	case RestartOnFailure:
+		return failed
+	}
+	return false
+}
+
+// backoffBounds 返回生效的初始退避与退避上限
+func (p RestartPolicy) backoffBounds() (initial, max time.Duration) {
+	initial, max = p.InitialBackoff, p.MaxBackoff
+	if initial <= 0 {
+		initial = defaultRestartInitialBackoff
+	}
+	if max <= 0 {
+		max = defaultRestartMaxBackoff
+	}
+	if initial > max {
+		initial = max
+	}
+	return initial, max
+}
+
+// startOnce 执行一次 Start，panic 转换为错误
+func (e *runnableEntry) startOnce(ctx context.Context) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("panic: %v", p)
+		}
+	}()
+	return e.runnable.Start(ctx)
+}
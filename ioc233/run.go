@@ -0,0 +1,100 @@
+package ioc233
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// ErrShutdownTimeout 关闭容器时未能在时限内完成（托管运行 bean 未退出或停止回调卡住）返回的错误
+var ErrShutdownTimeout = errors.New("[ioc233] 未能在时限内完成关闭")
+
+// defaultShutdownGracePeriod Run 关闭容器的默认宽限期
+const defaultShutdownGracePeriod = 30 * time.Second
+
+// RunOption Run 的附加选项
+type RunOption func(*runOptions)
+
+// runOptions Run 选项汇总
+type runOptions struct {
+	gracePeriod time.Duration
+	signals     []os.Signal
+}
+
+// WithGracePeriod 设置收到停止信号后关闭容器的宽限期（默认 30s）：
+// 托管运行 bean 需在宽限期内停止，超过宽限期 Run 不再等待并返回 ErrShutdownTimeout
+func WithGracePeriod(d time.Duration) RunOption {
+	return func(o *runOptions) {
+		o.gracePeriod = d
+	}
+}
+
+// WithSignals 替换触发关闭的信号（默认 SIGINT、SIGTERM）
+func WithSignals(sigs ...os.Signal) RunOption {
+	return func(o *runOptions) {
+		o.signals = sigs
+	}
+}
+
+// Run 应用入口：启动容器，阻塞直到收到 SIGINT/SIGTERM，然后在宽限期内停止托管运行 bean 并关闭容器
+// c 为 nil 时使用默认容器；启动失败时关闭容器并返回启动错误
+//
+//	func main() {
+//		if err := ioc233.Run(ioc233.Default(), ioc233.WithGracePeriod(15*time.Second)); err != nil {
+//			log.Fatal(err)
+//		}
+//	}
+func Run(c *Container, opts ...RunOption) error {
+	return RunContext(context.Background(), c, opts...)
+}
+
+// RunContext 与 Run 一致，ctx 被取消时同样触发关闭（用于测试或由上层进程管理器控制退出）
+func RunContext(ctx context.Context, c *Container, opts ...RunOption) error {
+	if c == nil {
+		c = Default()
+	}
+	o := runOptions{gracePeriod: defaultShutdownGracePeriod, signals: []os.Signal{os.Interrupt, syscall.SIGTERM}}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	sigCtx, stop := signal.NotifyContext(ctx, o.signals...)
+	defer stop()
+	if err := c.StartUpCtx(sigCtx); err != nil {
+		_ = c.Close()
+		return err
+	}
+	<-sigCtx.Done()
+	stop()
+	logInfo("[ioc233] 收到停止信号，开始关闭容器（宽限期 %v）", o.gracePeriod)
+	return closeWithin(c, o.gracePeriod)
+}
+
+// closeWithin 在 grace 内关闭容器，超时返回 ErrShutdownTimeout（关闭流程在后台继续执行）
+func closeWithin(c *Container, grace time.Duration) error {
+	if grace <= 0 {
+		return c.Close()
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), grace)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- c.CloseCtx(ctx) }()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		select {
+		case err := <-done:
+			// 关闭恰好在截止时刻完成
+			return err
+		case <-time.After(time.Second):
+			// CloseCtx 对托管运行 bean 的等待受 ctx 约束，仍未返回说明卡在停止回调中
+		}
+		logError("[ioc233] 宽限期 %v 内未能完成关闭", grace)
+		return fmt.Errorf("%w: %v", ErrShutdownTimeout, grace)
+	}
+}
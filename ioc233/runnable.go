@@ -0,0 +1,143 @@
+package ioc233
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// IRunnable 托管运行接口
+// 容器启动完成后，实现此接口的 bean 各自在独立协程中执行 Start（游戏主循环、消息消费者、轮询器等）；
+// 容器关闭时按注册逆序调用 Stop，随后取消 Start 的 ctx 并等待其返回
+type IRunnable interface {
+	// Start 阻塞运行直到 ctx 被取消或工作结束，返回的错误会被记录
+	Start(ctx context.Context) error
+	// Stop 通知运行结束，ctx 携带关闭超时
+	Stop(ctx context.Context) error
+}
+
+// defaultRunnableStopTimeout 关闭时等待托管运行 bean 退出的默认时长
+const defaultRunnableStopTimeout = 30 * time.Second
+
+// runnableEntry 已启动的托管运行 bean
+type runnableEntry struct {
+	name     string
+	runnable IRunnable
+	policy   RestartPolicy
+	// stopping 已开始停止（Stop 之后 Start 的返回不再触发重启）
+	stopping atomic.Bool
+	// done 运行协程（含重启）结束后关闭
+	done chan struct{}
+}
+
+// SetRunnableStopTimeout 设置关闭时排空（IDrain）与等待托管运行 bean（IRunnable）停止的总时长，<= 0 时使用默认值 30s
+func (c *Container) SetRunnableStopTimeout(d time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.runnableStopTimeout = d
+}
+
+// startRunnablesLocked 为 beans 中实现 IRunnable 且尚未启动的实例启动运行协程（调用方需持有写锁）
+func (c *Container) startRunnablesLocked(beans []*beanDefinition) {
+	for _, def := range beans {
+		r, ok := def.instance.(IRunnable)
+		if !ok || c.runnableStartedLocked(def.instance) {
+			continue
+		}
+		if c.runCancel == nil {
+			c.runCtx, c.runCancel = context.WithCancel(c.Context())
+		}
+		e := &runnableEntry{name: def.name, runnable: r, policy: c.restartPolicyLocked(def), done: make(chan struct{})}
+		c.runnables = append(c.runnables, e)
+		logInfo("[ioc233] 启动托管运行 bean: %s", e.name)
+		go e.run(c.runCtx)
+	}
+}
+
+// runnableStartedLocked 返回实例是否已作为托管运行 bean 启动（调用方需持有锁）
+func (c *Container) runnableStartedLocked(instance any) bool {
+	for _, e := range c.runnables {
+		if sameInstance(e.runnable, instance) {
+			return true
+		}
+	}
+	return false
+}
+
+// run 执行 Start 并按重启策略重启，ctx 取消或已开始停止时导致的返回不视为错误
+func (e *runnableEntry) run(ctx context.Context) {
+	defer close(e.done)
+	initial, max := e.policy.backoffBounds()
+	backoff := initial
+	for restarts := 0; ; restarts++ {
+		began := time.Now()
+		err := e.startOnce(ctx)
+		if ctx.Err() != nil || e.stopping.Load() {
+			return
+		}
+		if err != nil {
+			logError("[ioc233] 托管运行 bean 异常退出: name=%s: %v", e.name, err)
+		}
+		if !e.policy.shouldRestart(err != nil, restarts) {
+			return
+		}
+		if time.Since(began) >= max {
+			backoff = initial
+		}
+		logWarn("[ioc233] 托管运行 bean 将在 %v 后重启: name=%s restarts=%d", backoff, e.name, restarts+1)
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		backoff = min(backoff*2, max)
+	}
+}
+
+// shutdownContext 返回关闭流程使用的 ctx：不晚于 parent 结束，且不超过 SetRunnableStopTimeout 设置的时长（调用方需持有锁）
+func (c *Container) shutdownContext(parent context.Context) (context.Context, context.CancelFunc) {
+	timeout := c.runnableStopTimeout
+	if timeout <= 0 {
+		timeout = defaultRunnableStopTimeout
+	}
+	return context.WithTimeout(parent, timeout)
+}
+
+// stopRunnablesLocked 按启动逆序调用 Stop，取消运行 ctx 并在 ctx 结束前等待所有 Start 返回（调用方需持有写锁）
+// 有 bean 未能按时退出时返回 ErrShutdownTimeout
+func (c *Container) stopRunnablesLocked(ctx context.Context) error {
+	if c.runCancel == nil {
+		return nil
+	}
+	for _, e := range c.runnables {
+		e.stopping.Store(true)
+	}
+	for i := len(c.runnables) - 1; i >= 0; i-- {
+		e := c.runnables[i]
+		logInfo("[ioc233] 停止托管运行 bean: %s", e.name)
+		if err := e.runnable.Stop(ctx); err != nil {
+			logError("[ioc233] 停止托管运行 bean 失败: name=%s: %v", e.name, err)
+		}
+	}
+	c.runCancel()
+	var stuck []string
+	for i := len(c.runnables) - 1; i >= 0; i-- {
+		e := c.runnables[i]
+		select {
+		case <-e.done:
+		case <-ctx.Done():
+			logWarn("[ioc233] 托管运行 bean 未在停止时限内退出: %s", e.name)
+			stuck = append(stuck, e.name)
+			c.stuckRunnables = append(c.stuckRunnables, e)
+		}
+	}
+	c.runnables = nil
+	c.runCtx, c.runCancel = nil, nil
+	if len(stuck) > 0 {
+		return fmt.Errorf("%w: 托管运行 bean 未退出 %v", ErrShutdownTimeout, stuck)
+	}
+	return nil
+}
@@ -0,0 +1,689 @@
+package ioc233
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SchedulerPath SchedulerHandler 建议挂载的路径
+const SchedulerPath = "/ioc233/jobs"
+
+// OverlapPolicy 上一次执行尚未结束时再次触发的处理策略
+type OverlapPolicy string
+
+const (
+	// OverlapSkip 跳过本次触发（默认）
+	OverlapSkip OverlapPolicy = "skip"
+	// OverlapQueue 排队，在上一次结束后依次执行
+	OverlapQueue OverlapPolicy = "queue"
+	// OverlapConcurrent 并发执行
+	OverlapConcurrent OverlapPolicy = "concurrent"
+)
+
+// ScheduleSpec 任务调度声明（Cron、FixedDelay、FixedRate 三选一）
+type ScheduleSpec struct {
+	// Name 任务名（为空时使用 bean 名）
+	Name string
+	// Cron cron 表达式（见 ParseCron）
+	Cron string
+	// FixedDelay 上一次执行结束后间隔固定时间再执行（不会重叠）
+	FixedDelay time.Duration
+	// FixedRate 按固定频率触发（不等待上一次结束，重叠时按 Overlap 处理）
+	FixedRate time.Duration
+	// InitialDelay FixedDelay/FixedRate 首次执行前的等待时间（默认启动后立即执行）
+	InitialDelay time.Duration
+	// Overlap 重叠策略（默认 OverlapSkip）
+	Overlap OverlapPolicy
+}
+
+// ParseSchedule 解析 schedule 标签：cron:<表达式>、fixedDelay:<时长>、fixedRate:<时长>
+//
+//	_ struct{} `schedule:"fixedRate:1m" overlap:"queue"`
+func ParseSchedule(expr string) (ScheduleSpec, error) {
+	kind, value, ok := strings.Cut(strings.TrimSpace(expr), ":")
+	if !ok {
+		return ScheduleSpec{}, fmt.Errorf("[ioc233] schedule 标签非法: %q", expr)
+	}
+	value = strings.TrimSpace(value)
+	var spec ScheduleSpec
+	switch kind {
+	case "cron":
+		spec.Cron = value
+	case "fixedDelay", "fixedRate":
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return ScheduleSpec{}, fmt.Errorf("[ioc233] schedule 时长非法: %q", expr)
+		}
+		if kind == "fixedDelay" {
+			spec.FixedDelay = d
+		} else {
+			spec.FixedRate = d
+		}
+	default:
+		return ScheduleSpec{}, fmt.Errorf("[ioc233] schedule 标签类型未知: %q", expr)
+	}
+	return spec, nil
+}
+
+// IJob 可被调度的任务（配合 schedule 标签使用）
+type IJob interface {
+	Run(ctx context.Context) error
+}
+
+// IScheduledJob 通过接口声明调度方式的任务
+type IScheduledJob interface {
+	IJob
+	Schedule() ScheduleSpec
+}
+
+// Schedule bean 声明的单个周期任务（见 ISchedule）
+type Schedule struct {
+	// ScheduleSpec 调度方式（Name 为空时使用 "bean名#序号"）
+	ScheduleSpec
+	// Run 任务函数
+	Run func(ctx context.Context) error
+}
+
+// ISchedule 一个 bean 声明多个周期任务
+// 调度器启动时为每个 Schedule 启动独立的调度循环，容器关闭时停止
+type ISchedule interface {
+	Schedules() []Schedule
+}
+
+// IMissedRunHandler 任务实现该接口时，启动时检测到停机期间错过的执行会回调通知
+type IMissedRunHandler interface {
+	OnMissedRuns(lastRun time.Time, missed int)
+}
+
+// JobRun 一次任务执行记录
+type JobRun struct {
+	Job         string
+	ScheduledAt time.Time
+	StartedAt   time.Time
+	FinishedAt  time.Time
+	// Err 执行错误（为空表示成功）
+	Err string
+}
+
+// JobStore 任务执行记录的持久化钩子
+// 容器中注册了 JobStore 实现时会自动注入调度器，用于重启后检测错过的执行
+type JobStore interface {
+	// LastRun 返回任务最近一次执行的计划时间，ok=false 表示没有记录
+	LastRun(job string) (t time.Time, ok bool, err error)
+	// SaveRun 保存一次执行记录
+	SaveRun(run JobRun) error
+}
+
+// JobStatus 任务运行状态（管理端点输出）
+type JobStatus struct {
+	Name         string        `json:"name"`
+	Schedule     string        `json:"schedule"`
+	Overlap      OverlapPolicy `json:"overlap"`
+	Paused       bool          `json:"paused"`
+	Running      int           `json:"running"`
+	Pending      int           `json:"pending"`
+	NextRun      time.Time     `json:"nextRun"`
+	LastRun      time.Time     `json:"lastRun"`
+	LastDuration time.Duration `json:"lastDuration"`
+	LastError    string        `json:"lastError,omitempty"`
+	Runs         int64         `json:"runs"`
+	Skipped      int64         `json:"skipped"`
+	// Missed 启动时检测到的错过执行次数
+	Missed int `json:"missed"`
+}
+
+// scheduledJob 调度器内部的任务
+type scheduledJob struct {
+	name   string
+	spec   ScheduleSpec
+	cron   *CronSchedule
+	run    func(ctx context.Context) error
+	target any
+
+	mu     sync.Mutex
+	status JobStatus
+}
+
+// Scheduler 任务调度器
+// 通过 Container.EnableScheduler 启用后作为 bean 注册（可被注入），容器启动完成后
+// 自动发现实现 IScheduledJob / ISchedule 的 bean、带 schedule 标签的 IJob bean 与 schedule+method 标签声明的方法，容器关闭时停止
+type Scheduler struct {
+	// Store 执行记录持久化（可选）
+	Store JobStore `autowire:"false"`
+	// ShutdownTimeout 关闭时等待运行中任务结束的最长时间（默认 30s）
+	ShutdownTimeout time.Duration
+
+	c       *Container
+	mu      sync.RWMutex
+	jobs    []*scheduledJob
+	ctx     context.Context
+	cancel  context.CancelFunc
+	loops   sync.WaitGroup
+	running sync.WaitGroup
+}
+
+// EnableScheduler 启用任务调度器（重复调用返回同一个调度器）
+// 应在 StartUp 之前调用，以便调度器作为 bean 注册并完成 Store 注入
+func (c *Container) EnableScheduler() *Scheduler {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.scheduler != nil {
+		return c.scheduler
+	}
+	s := &Scheduler{c: c, ShutdownTimeout: 30 * time.Second}
+	c.scheduler = s
+	c.provideLocked(s)
+	c.addStoppingHook(s.stop)
+	if !c.addStartedHook(s.startLocked) {
+		logWarn("[ioc233] 调度器在容器启动后启用，仅调度已注册的 bean")
+		s.startLocked()
+	}
+	return s
+}
+
+// Schedule 以编程方式注册任务；调度器已启动时立即开始调度
+func (s *Scheduler) Schedule(name string, spec ScheduleSpec, run func(ctx context.Context) error) error {
+	if strings.TrimSpace(name) == "" || run == nil {
+		return errors.New("[ioc233] Schedule 参数非法")
+	}
+	spec.Name = name
+	j, err := newScheduledJob(spec, run, nil)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.findLocked(name) != nil {
+		return fmt.Errorf("[ioc233] 任务重复注册: %s", name)
+	}
+	s.jobs = append(s.jobs, j)
+	if s.ctx != nil && s.ctx.Err() == nil {
+		s.startJobLocked(j)
+	}
+	return nil
+}
+
+// Pause 暂停任务（运行中的执行不受影响，暂停期间的触发被忽略）
+func (s *Scheduler) Pause(name string) error {
+	return s.setPaused(name, true)
+}
+
+// Resume 恢复任务
+func (s *Scheduler) Resume(name string) error {
+	return s.setPaused(name, false)
+}
+
+// Trigger 立即触发一次任务（遵循重叠策略，暂停的任务不会执行）
+func (s *Scheduler) Trigger(name string) error {
+	s.mu.RLock()
+	j := s.findLocked(name)
+	s.mu.RUnlock()
+	if j == nil {
+		return fmt.Errorf("[ioc233] 任务不存在: %s", name)
+	}
+	s.fire(j, time.Now())
+	return nil
+}
+
+// Jobs 返回全部任务的运行状态（按注册顺序）
+func (s *Scheduler) Jobs() []JobStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]JobStatus, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		j.mu.Lock()
+		out = append(out, j.status)
+		j.mu.Unlock()
+	}
+	return out
+}
+
+// SchedulerHandler 返回调度器管理端点：
+//
+//	GET  {prefix}                  任务状态列表
+//	POST {prefix}/{name}/pause     暂停任务
+//	POST {prefix}/{name}/resume    恢复任务
+//	POST {prefix}/{name}/run       立即触发一次
+//
+//	mux.Handle(ioc233.SchedulerPath+"/", ioc233.SchedulerHandler(scheduler))
+func SchedulerHandler(s *Scheduler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			if err := json.NewEncoder(w).Encode(s.Jobs()); err != nil {
+				logError("[ioc233] 输出任务状态失败: %v", err)
+			}
+		case http.MethodPost:
+			parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+			if len(parts) < 2 {
+				http.Error(w, "expected /{name}/{pause|resume|run}", http.StatusNotFound)
+				return
+			}
+			name, action := parts[len(parts)-2], parts[len(parts)-1]
+			var err error
+			switch action {
+			case "pause":
+				err = s.Pause(name)
+			case "resume":
+				err = s.Resume(name)
+			case "run":
+				err = s.Trigger(name)
+			default:
+				http.Error(w, "unknown action: "+action, http.StatusNotFound)
+				return
+			}
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+// newScheduledJob 校验调度声明并创建任务
+func newScheduledJob(spec ScheduleSpec, run func(ctx context.Context) error, target any) (*scheduledJob, error) {
+	modes := 0
+	desc := ""
+	if spec.Cron != "" {
+		modes++
+		desc = "cron:" + spec.Cron
+	}
+	if spec.FixedDelay > 0 {
+		modes++
+		desc = "fixedDelay:" + spec.FixedDelay.String()
+	}
+	if spec.FixedRate > 0 {
+		modes++
+		desc = "fixedRate:" + spec.FixedRate.String()
+	}
+	if modes != 1 {
+		return nil, fmt.Errorf("[ioc233] 任务 %s 必须且只能声明 Cron、FixedDelay、FixedRate 之一", spec.Name)
+	}
+	switch spec.Overlap {
+	case "":
+		spec.Overlap = OverlapSkip
+	case OverlapSkip, OverlapQueue, OverlapConcurrent:
+	default:
+		return nil, fmt.Errorf("[ioc233] 任务 %s 的重叠策略未知: %s", spec.Name, spec.Overlap)
+	}
+	j := &scheduledJob{name: spec.Name, spec: spec, run: run, target: target}
+	if spec.Cron != "" {
+		cron, err := ParseCron(spec.Cron)
+		if err != nil {
+			return nil, err
+		}
+		j.cron = cron
+	}
+	j.status = JobStatus{Name: spec.Name, Schedule: desc, Overlap: spec.Overlap}
+	return j, nil
+}
+
+// jobsOf 从 bean 中识别任务声明：IScheduledJob、ISchedule、带 method 的 schedule 标签，
+// 以及 IJob bean 上第一个不带 method 的 schedule 标签；非法声明逐条返回错误，不影响其他任务
+func jobsOf(def *beanDefinition) ([]*scheduledJob, []error) {
+	var jobs []*scheduledJob
+	var errs []error
+	add := func(spec ScheduleSpec, run func(ctx context.Context) error) {
+		j, err := newScheduledJob(spec, run, def.instance)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%w (bean=%s)", err, def.name))
+			return
+		}
+		jobs = append(jobs, j)
+	}
+
+	if sj, ok := def.instance.(IScheduledJob); ok {
+		spec := sj.Schedule()
+		if spec.Name == "" {
+			spec.Name = def.name
+		}
+		add(spec, sj.Run)
+	}
+	if sc, ok := def.instance.(ISchedule); ok {
+		for i, sched := range sc.Schedules() {
+			spec := sched.ScheduleSpec
+			if spec.Name == "" {
+				spec.Name = fmt.Sprintf("%s#%d", def.name, i)
+			}
+			if sched.Run == nil {
+				errs = append(errs, fmt.Errorf("[ioc233] 任务 %s 缺少 Run 函数 (bean=%s)", spec.Name, def.name))
+				continue
+			}
+			add(spec, sched.Run)
+		}
+	}
+
+	t := reflect.TypeOf(def.instance)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return jobs, errs
+	}
+	job, isJob := def.instance.(IJob)
+	_, declared := def.instance.(IScheduledJob)
+	for i := 0; i < t.NumField(); i++ {
+		tag := t.Field(i).Tag
+		expr, ok := tag.Lookup("schedule")
+		if !ok {
+			continue
+		}
+		method, hasMethod := tag.Lookup("method")
+		if !hasMethod && (!isJob || declared) {
+			// 不带 method 的标签只调度 IJob.Run，且每个 bean 只取第一个
+			continue
+		}
+		spec, err := ParseSchedule(expr)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%w (bean=%s)", err, def.name))
+			continue
+		}
+		spec.Overlap = OverlapPolicy(tag.Get("overlap"))
+		if !hasMethod {
+			spec.Name = def.name
+			add(spec, job.Run)
+			declared = true
+			continue
+		}
+		run, err := scheduledMethod(def.instance, method)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%w (bean=%s)", err, def.name))
+			continue
+		}
+		spec.Name = def.name + "." + method
+		add(spec, run)
+	}
+	return jobs, errs
+}
+
+// scheduledMethod 将 bean 的导出方法包装为任务函数
+// 支持 func(context.Context) error、func(context.Context)、func() error、func() 四种签名
+func scheduledMethod(instance any, name string) (func(ctx context.Context) error, error) {
+	m := reflect.ValueOf(instance).MethodByName(name)
+	if !m.IsValid() {
+		return nil, fmt.Errorf("[ioc233] schedule 标签引用的方法不存在: %s", name)
+	}
+	switch fn := m.Interface().(type) {
+	case func(context.Context) error:
+		return fn, nil
+	case func(context.Context):
+		return func(ctx context.Context) error { fn(ctx); return nil }, nil
+	case func() error:
+		return func(context.Context) error { return fn() }, nil
+	case func():
+		return func(context.Context) error { fn(); return nil }, nil
+	}
+	return nil, fmt.Errorf("[ioc233] schedule 标签引用的方法签名不支持: %s %v", name, m.Type())
+}
+
+// warnUnscheduledLocked 未启用调度器但有 bean 声明了任务时记录警告，避免任务被静默忽略（调用方需持有锁）
+func (c *Container) warnUnscheduledLocked() {
+	if c.scheduler != nil {
+		return
+	}
+	for _, def := range c.beans {
+		if jobs, errs := jobsOf(def); len(jobs) > 0 || len(errs) > 0 {
+			logWarn("[ioc233] bean 声明了周期任务但未调用 EnableScheduler，任务不会执行: %s", def.name)
+		}
+	}
+}
+
+// startLocked 发现 bean 中的任务并开始调度（容器启动完成钩子，调用方持有容器写锁）
+func (s *Scheduler) startLocked() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ctx != nil {
+		return
+	}
+	for _, def := range s.c.beans {
+		jobs, errs := jobsOf(def)
+		for _, err := range errs {
+			logError("%v", err)
+		}
+		for _, j := range jobs {
+			if s.findLocked(j.name) != nil {
+				logWarn("[ioc233] 任务重复注册，忽略: %s", j.name)
+				continue
+			}
+			s.jobs = append(s.jobs, j)
+		}
+	}
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	for _, j := range s.jobs {
+		s.startJobLocked(j)
+	}
+	logInfo("[ioc233] 调度器已启动，任务数: %d", len(s.jobs))
+}
+
+// startJobLocked 检测错过的执行并启动任务循环（调用方需持有 s.mu）
+func (s *Scheduler) startJobLocked(j *scheduledJob) {
+	if s.Store != nil {
+		s.detectMissed(j, time.Now())
+	}
+	s.loops.Add(1)
+	go s.loop(j)
+}
+
+// detectMissed 根据持久化的最近执行时间计算停机期间错过的执行次数
+func (s *Scheduler) detectMissed(j *scheduledJob, now time.Time) {
+	last, ok, err := s.Store.LastRun(j.name)
+	if err != nil {
+		logError("[ioc233] 读取任务执行记录失败: job=%s err=%v", j.name, err)
+		return
+	}
+	if !ok {
+		return
+	}
+	missed := 0
+	switch {
+	case j.cron != nil:
+		for t := j.cron.Next(last); !t.IsZero() && !t.After(now) && missed < 1000; t = j.cron.Next(t) {
+			missed++
+		}
+	case j.spec.FixedRate > 0:
+		missed = int(now.Sub(last) / j.spec.FixedRate)
+	case now.Sub(last) > j.spec.FixedDelay:
+		missed = 1
+	}
+	if missed == 0 {
+		return
+	}
+	j.mu.Lock()
+	j.status.Missed = missed
+	j.mu.Unlock()
+	logWarn("[ioc233] 任务在停机期间错过执行: job=%s lastRun=%s missed=%d", j.name, last.Format(time.RFC3339), missed)
+	if h, ok := j.target.(IMissedRunHandler); ok {
+		h.OnMissedRuns(last, missed)
+	}
+}
+
+// loop 任务调度循环
+func (s *Scheduler) loop(j *scheduledJob) {
+	defer s.loops.Done()
+	now := time.Now()
+	next := now.Add(j.spec.InitialDelay)
+	if j.cron != nil {
+		next = j.cron.Next(now)
+	}
+	for !next.IsZero() {
+		j.mu.Lock()
+		j.status.NextRun = next
+		j.mu.Unlock()
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-s.ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		switch {
+		case j.spec.FixedDelay > 0:
+			s.execute(j, next, true)
+			next = time.Now().Add(j.spec.FixedDelay)
+		case j.spec.FixedRate > 0:
+			s.fire(j, next)
+			// 执行落后时跳过已过期的触发点，避免集中补跑
+			next = next.Add(j.spec.FixedRate)
+			for !next.After(time.Now()) {
+				next = next.Add(j.spec.FixedRate)
+			}
+		default:
+			s.fire(j, next)
+			next = j.cron.Next(time.Now())
+		}
+	}
+}
+
+// fire 按重叠策略触发一次执行（异步）
+func (s *Scheduler) fire(j *scheduledJob, scheduled time.Time) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.ctx == nil || s.ctx.Err() != nil {
+		return
+	}
+	j.mu.Lock()
+	if j.status.Paused {
+		j.mu.Unlock()
+		return
+	}
+	if j.status.Running > 0 {
+		switch j.spec.Overlap {
+		case OverlapSkip:
+			j.status.Skipped++
+			j.mu.Unlock()
+			logDebug("[ioc233] 任务仍在执行，跳过本次触发: job=%s", j.name)
+			return
+		case OverlapQueue:
+			j.status.Pending++
+			j.mu.Unlock()
+			return
+		}
+	}
+	j.status.Running++
+	j.mu.Unlock()
+	s.running.Add(1)
+	go func() {
+		defer s.running.Done()
+		s.execute(j, scheduled, false)
+	}()
+}
+
+// execute 执行任务并依次处理排队的触发
+// acquire=true 表示调用方尚未占用运行计数（FixedDelay 循环同步执行）
+func (s *Scheduler) execute(j *scheduledJob, scheduled time.Time, acquire bool) {
+	if acquire {
+		j.mu.Lock()
+		if j.status.Paused {
+			j.mu.Unlock()
+			return
+		}
+		j.status.Running++
+		j.mu.Unlock()
+	}
+	for {
+		s.runOnce(j, scheduled)
+		j.mu.Lock()
+		if j.status.Pending > 0 && s.ctx.Err() == nil {
+			j.status.Pending--
+			j.mu.Unlock()
+			scheduled = time.Now()
+			continue
+		}
+		j.status.Running--
+		j.mu.Unlock()
+		return
+	}
+}
+
+// runOnce 执行一次任务并记录结果（任务 panic 视为执行错误）
+func (s *Scheduler) runOnce(j *scheduledJob, scheduled time.Time) {
+	run := JobRun{Job: j.name, ScheduledAt: scheduled, StartedAt: time.Now()}
+	err := func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("panic: %v", r)
+			}
+		}()
+		return j.run(s.ctx)
+	}()
+	run.FinishedAt = time.Now()
+	if err != nil {
+		run.Err = err.Error()
+		logError("[ioc233] 任务执行失败: job=%s err=%v", j.name, err)
+	}
+
+	j.mu.Lock()
+	j.status.Runs++
+	j.status.LastRun = run.StartedAt
+	j.status.LastDuration = run.FinishedAt.Sub(run.StartedAt)
+	j.status.LastError = run.Err
+	j.mu.Unlock()
+
+	if s.Store != nil {
+		if err := s.Store.SaveRun(run); err != nil {
+			logError("[ioc233] 保存任务执行记录失败: job=%s err=%v", j.name, err)
+		}
+	}
+}
+
+// stop 停止调度并等待运行中的任务结束（容器关闭钩子）
+func (s *Scheduler) stop() {
+	s.mu.Lock()
+	if s.cancel == nil {
+		s.mu.Unlock()
+		return
+	}
+	s.cancel()
+	s.mu.Unlock()
+	s.loops.Wait()
+
+	done := make(chan struct{})
+	go func() {
+		s.running.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		logInfo("[ioc233] 调度器已停止")
+	case <-time.After(s.ShutdownTimeout):
+		logWarn("[ioc233] 调度器关闭超时，仍有任务在执行")
+	}
+}
+
+// setPaused 设置任务暂停状态
+func (s *Scheduler) setPaused(name string, paused bool) error {
+	s.mu.RLock()
+	j := s.findLocked(name)
+	s.mu.RUnlock()
+	if j == nil {
+		return fmt.Errorf("[ioc233] 任务不存在: %s", name)
+	}
+	j.mu.Lock()
+	j.status.Paused = paused
+	j.mu.Unlock()
+	logInfo("[ioc233] 任务暂停状态变更: job=%s paused=%v", name, paused)
+	return nil
+}
+
+// findLocked 按名称查找任务（调用方需持有 s.mu）
+func (s *Scheduler) findLocked(name string) *scheduledJob {
+	for _, j := range s.jobs {
+		if j.name == name {
+			return j
+		}
+	}
+	return nil
+}
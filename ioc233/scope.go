@@ -0,0 +1,76 @@
+package ioc233
+
+import (
+	"context"
+	"reflect"
+)
+
+// contextType context.Context 接口类型
+var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
+// NewScope 创建绑定到 ctx 的作用域子容器（例如单个请求/任务）
+// 说明：
+// - 子容器中注册的 bean 只在该作用域内可见，不会污染父容器
+// - 解析时本作用域未命中则回退到父容器查找（单例可与请求级对象一同注入）
+// - 作用域内 context.Context 类型的 autowire 字段注入 ctx
+// - ctx 结束（取消/超时）时自动 Close 作用域，触发作用域内 bean 的 IDestroy 回调
+//
+// 典型用法：
+//
+//	scope := root.NewScope(r.Context())
+//	scope.ProvideByName("requestInfo", &RequestInfo{ID: reqID})
+//	scope.Provide(handler)
+//	_ = scope.StartUp()
+//	defer scope.Close()
+func (c *Container) NewScope(ctx context.Context) *Container {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	scope := c.NewChild()
+	scope.ctx = ctx
+	context.AfterFunc(ctx, func() {
+		_ = scope.Close()
+	})
+	return scope
+}
+
+// NewChild 创建子容器
+// 说明：
+// - 子容器中的注册、Swap、Close 只作用于子容器，不会污染父容器
+// - 解析（注入、GetObjectByTypeFrom、Validate、Adapter）在子容器未命中时回退到父容器，可多级嵌套
+// - 子容器需要自行 StartUp/Close，生命周期与父容器相互独立
+//
+// 典型用法：各模块/各测试共享父容器中的基础设施 bean（数据库、配置），自身 bean 注册在子容器中隔离
+func (c *Container) NewChild() *Container {
+	child := NewContainer()
+	child.parent = c
+	child.ctx = c.ctx
+	return child
+}
+
+// Context 返回作用域绑定的上下文（非作用域容器返回 context.Background()）
+func (c *Container) Context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
+// Parent 返回父容器（根容器返回 nil）
+func (c *Container) Parent() *Container {
+	return c.parent
+}
+
+// withReadLock 在读锁保护下执行 fn（调用方不能已持有本容器的锁；已持有锁的内部流程直接调用 xxxLocked 实现）
+func (c *Container) withReadLock(fn func()) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	fn()
+}
+
+// withWriteLock 在写锁保护下执行 fn（调用方不能已持有本容器的锁）
+func (c *Container) withWriteLock(fn func()) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	fn()
+}
@@ -0,0 +1,80 @@
+package ioc233
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrSecretNotFound 密钥源中不存在该密钥（容器继续查找下一个密钥源）
+var ErrSecretNotFound = errors.New("[ioc233] 密钥不存在")
+
+// SecretSource 密钥源，secret 标签按路径从密钥源读取凭据（Vault、AWS Secrets Manager 实现见 ioc233/vaultsecret、ioc233/awssecret）
+type SecretSource interface {
+	// GetSecret 按路径读取密钥，不存在时返回 ErrSecretNotFound
+	GetSecret(ctx context.Context, path string) (string, error)
+}
+
+// SecretSourceFunc 函数形式的 SecretSource
+type SecretSourceFunc func(ctx context.Context, path string) (string, error)
+
+// GetSecret 实现 SecretSource
+func (fn SecretSourceFunc) GetSecret(ctx context.Context, path string) (string, error) {
+	return fn(ctx, path)
+}
+
+// AddSecretSource 添加密钥源（按添加顺序查找；子容器未命中时回退父容器的密钥源）
+//
+//	container.AddSecretSource(vaultsecret.New(client.KVv2("secret")))
+//
+//	type Repo struct {
+//	    Password string `secret:"db/password"`
+//	}
+func (c *Container) AddSecretSource(src SecretSource) {
+	if src == nil {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.secretSources = append(c.secretSources, src)
+}
+
+// lookupSecret 按密钥源顺序读取密钥（调用方需持有读锁）
+func (c *Container) lookupSecret(ctx context.Context, path string) (string, error) {
+	for _, src := range c.secretSources {
+		v, err := src.GetSecret(ctx, path)
+		if errors.Is(err, ErrSecretNotFound) {
+			continue
+		}
+		return v, err
+	}
+	if c.parent != nil {
+		var (
+			v   string
+			err error
+		)
+		c.parent.withReadLock(func() {
+			v, err = c.parent.lookupSecret(ctx, path)
+		})
+		return v, err
+	}
+	return "", ErrSecretNotFound
+}
+
+// resolveSecretTag 读取 secret 标签对应的密钥并转换为字段类型（string、[]byte 或其他基础类型）
+// 错误信息只包含路径，不包含密钥内容
+func (c *Container) resolveSecretTag(ctx context.Context, structName string, field reflect.StructField, path string) (reflect.Value, error) {
+	raw, err := c.lookupSecret(ctx, path)
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("[ioc233] 密钥注入失败: struct=%s field=%s secret=%s: %w", structName, field.Name, path, err)
+	}
+	if field.Type.Kind() == reflect.Slice && field.Type.Elem().Kind() == reflect.Uint8 {
+		return reflect.ValueOf([]byte(raw)).Convert(field.Type), nil
+	}
+	v, err := parseLiteral(field.Type, raw)
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("[ioc233] 密钥类型不匹配: struct=%s field=%s secret=%s: 不支持的字段类型 %v", structName, field.Name, path, field.Type)
+	}
+	return v, nil
+}
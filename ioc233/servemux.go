@@ -0,0 +1,95 @@
+package ioc233
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// MountServeMux 将 ProvideController 注册的控制器路由挂载到 net/http 的 ServeMux（Go 1.22 路由模式），无需第三方框架
+// 路径参数 :id 转换为 {id}，末尾通配 *path 转换为 {path...}，处理方法中通过 r.PathValue("id") 读取；ANY 路由不限定方法
+// 处理方法支持以下签名：
+//
+//	func(http.ResponseWriter, *http.Request)
+//	func() http.Handler
+//	func() http.HandlerFunc
+//	func(*WebSocketConn)         // WebSocket 端点，完成升级后调用（见 IWebSocketController）
+//
+// 容器中的 IMiddleware bean 按 IOrdered 排序后包裹每一个路由
+// 处理方法签名不支持时不挂载任何路由并返回错误；与 mux 已有路由冲突时返回错误（此前的路由已挂载）
+//
+//	mux := http.NewServeMux()
+//	if err := ioc233.MountServeMux(mux, container); err != nil { ... }
+func MountServeMux(mux *http.ServeMux, c *Container) error {
+	type mount struct {
+		pattern string
+		handler http.Handler
+	}
+	var mounts []mount
+	mws := c.Middlewares()
+	for _, ctrl := range c.GetControllers() {
+		for _, r := range ctrl.Routes {
+			h, err := serveMuxHandler(c, ctrl.Instance, r.Handler)
+			if err != nil {
+				return fmt.Errorf("%w (controller=%s route=%s)", err, ctrl.Name, r.String())
+			}
+			mounts = append(mounts, mount{pattern: serveMuxPattern(r), handler: ChainMiddlewares(h, mws...)})
+		}
+	}
+	for _, m := range mounts {
+		if err := handleSafely(mux, m.pattern, m.handler); err != nil {
+			return err
+		}
+		logInfo("[ioc233] 挂载路由: %s", m.pattern)
+	}
+	return nil
+}
+
+// serveMuxPattern 将路由转换为 ServeMux 模式："GET /users/:id" -> "GET /users/{id}"
+func serveMuxPattern(r Route) string {
+	segments := strings.Split(r.Path, "/")
+	for i, seg := range segments {
+		switch {
+		case strings.HasPrefix(seg, ":") && len(seg) > 1:
+			segments[i] = "{" + seg[1:] + "}"
+		case strings.HasPrefix(seg, "*") && len(seg) > 1 && i == len(segments)-1:
+			segments[i] = "{" + seg[1:] + "...}"
+		}
+	}
+	path := strings.Join(segments, "/")
+	if r.Method == "ANY" {
+		return path
+	}
+	return r.Method + " " + path
+}
+
+// serveMuxHandler 将控制器方法转换为 http.Handler
+func serveMuxHandler(c *Container, instance any, name string) (http.Handler, error) {
+	m := reflect.ValueOf(instance).MethodByName(name)
+	if !m.IsValid() {
+		return nil, fmt.Errorf("[ioc233] 路由处理方法不存在: %s", name)
+	}
+	switch fn := m.Interface().(type) {
+	case func(http.ResponseWriter, *http.Request):
+		return http.HandlerFunc(fn), nil
+	case func() http.Handler:
+		return fn(), nil
+	case func() http.HandlerFunc:
+		return fn(), nil
+	case func(*WebSocketConn):
+		return c.WebSocketHandler(instance, fn), nil
+	}
+	return nil, fmt.Errorf("[ioc233] 路由处理方法签名不支持 net/http: %s %v", name, m.Type())
+}
+
+// handleSafely 调用 mux.Handle，模式非法或冲突导致的 panic 转换为错误
+func handleSafely(mux *http.ServeMux, pattern string, h http.Handler) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("[ioc233] 挂载路由失败: %s: %v", pattern, p)
+		}
+	}()
+	mux.Handle(pattern, h)
+	return nil
+}
@@ -0,0 +1,66 @@
+package ioc233
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Service 通过 ProvideService 注册的业务服务
+type Service struct {
+	// Name bean 名
+	Name string
+	// Instance 服务实例
+	Instance any
+}
+
+// ProvideService 注册业务服务 bean（与 Provide 一样参与注入与生命周期），同时归类为服务，
+// 供 GetServices 查询，与 ProvideController 注册的控制器区分开
+// 容器已冻结时返回 ErrContainerFrozen
+func (c *Container) ProvideService(instance any, opts ...BeanOption) error {
+	if instance == nil {
+		return fmt.Errorf("[ioc233] ProvideService 实例不能为 nil")
+	}
+	prepared := c.prepareBean(instance)
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if err := c.checkFrozenLocked("ProvideService", instance); err != nil {
+		return err
+	}
+	if c.gateByFeatureFlagLocked("", instance, opts) {
+		return nil
+	}
+	from := len(c.beans)
+	if err := c.providePreparedLocked(instance, prepared); err != nil {
+		return err
+	}
+	c.applyBeanOptionsLocked(instance, opts)
+	t := reflect.TypeOf(instance)
+	// 重复类型（已记录警告）或延迟到 StartUp 的 profile 注册，不作为服务记录
+	if registered, ok := c.typeToObjectMap.load(t); ok && sameInstance(registered, instance) {
+		c.serviceMap[t] = instance
+		c.services = append(c.services, Service{Name: c.defaultBeanName(t), Instance: instance})
+	}
+	c.bindLateLocked(from)
+	return nil
+}
+
+// GetServices 返回通过 ProvideService 注册的服务（按注册顺序）
+func (c *Container) GetServices() []Service {
+	var out []Service
+	c.withReadLock(func() {
+		out = append([]Service(nil), c.services...)
+	})
+	return out
+}
+
+// GetServicesAny 获取通过 ProvideService 注册的服务实例
+func (c *Container) GetServicesAny() []any {
+	var out []any
+	c.withReadLock(func() {
+		out = make([]any, len(c.services))
+		for i, s := range c.services {
+			out[i] = s.Instance
+		}
+	})
+	return out
+}
@@ -0,0 +1,158 @@
+package ioc233
+
+import (
+	"hash/maphash"
+	"sync"
+)
+
+// registryShards 注册映射的分片数
+const registryShards = 32
+
+// shardedMap 按键哈希分片的注册映射（typeToObjectMap、nameToObjMap）
+// 每个分片有独立的读写锁：并发的 Provide/ProvideByName 在容器读锁下按分片预留类型与名称（见 provideFast），
+// 不同类型、名称的注册只在各自的分片上竞争；查找与修改仍在容器锁保护下进行，分片锁只保护映射本身
+type shardedMap[K comparable] struct {
+	seed   maphash.Seed
+	shards [registryShards]mapShard[K]
+}
+
+// mapShard 单个分片
+type mapShard[K comparable] struct {
+	mutex sync.RWMutex
+	m     map[K]any
+}
+
+// reservation 并发注册预留的占位值：预留期间查找视为不存在，重复检查视为已占用
+type reservation struct{}
+
+func newShardedMap[K comparable]() *shardedMap[K] {
+	s := &shardedMap[K]{seed: maphash.MakeSeed()}
+	for i := range s.shards {
+		s.shards[i].m = make(map[K]any)
+	}
+	return s
+}
+
+// shard 返回键所在的分片
+func (s *shardedMap[K]) shard(k K) *mapShard[K] {
+	return &s.shards[maphash.Comparable(s.seed, k)%registryShards]
+}
+
+// load 查找键（预留中的键视为不存在）
+func (s *shardedMap[K]) load(k K) (any, bool) {
+	sh := s.shard(k)
+	sh.mutex.RLock()
+	defer sh.mutex.RUnlock()
+	v, ok := sh.m[k]
+	if _, reserved := v.(*reservation); reserved {
+		return nil, false
+	}
+	return v, ok
+}
+
+// get 查找键，不存在时返回 nil
+func (s *shardedMap[K]) get(k K) any {
+	v, _ := s.load(k)
+	return v
+}
+
+// taken 判断键是否已被注册或预留
+func (s *shardedMap[K]) taken(k K) bool {
+	sh := s.shard(k)
+	sh.mutex.RLock()
+	defer sh.mutex.RUnlock()
+	_, ok := sh.m[k]
+	return ok
+}
+
+// store 设置键（覆盖预留）
+func (s *shardedMap[K]) store(k K, v any) {
+	sh := s.shard(k)
+	sh.mutex.Lock()
+	defer sh.mutex.Unlock()
+	sh.m[k] = v
+}
+
+// delete 删除键
+func (s *shardedMap[K]) delete(k K) {
+	sh := s.shard(k)
+	sh.mutex.Lock()
+	defer sh.mutex.Unlock()
+	delete(sh.m, k)
+}
+
+// reserve 键未被注册或预留时以 r 占位并返回 true
+func (s *shardedMap[K]) reserve(k K, r *reservation) bool {
+	sh := s.shard(k)
+	sh.mutex.Lock()
+	defer sh.mutex.Unlock()
+	if _, ok := sh.m[k]; ok {
+		return false
+	}
+	sh.m[k] = r
+	return true
+}
+
+// release 撤销 r 的占位（键已被设置为其他值时不处理）
+func (s *shardedMap[K]) release(k K, r *reservation) {
+	sh := s.shard(k)
+	sh.mutex.Lock()
+	defer sh.mutex.Unlock()
+	if v, ok := sh.m[k].(*reservation); ok && v == r {
+		delete(sh.m, k)
+	}
+}
+
+// holds 判断键当前是否仍由 r 预留
+func (s *shardedMap[K]) holds(k K, r *reservation) bool {
+	sh := s.shard(k)
+	sh.mutex.RLock()
+	defer sh.mutex.RUnlock()
+	v, ok := sh.m[k].(*reservation)
+	return ok && v == r
+}
+
+// rangeAll 遍历已注册的键值（不含预留，顺序不确定），fn 返回 false 时停止；fn 中不能修改本映射
+func (s *shardedMap[K]) rangeAll(fn func(k K, v any) bool) {
+	for i := range s.shards {
+		sh := &s.shards[i]
+		sh.mutex.RLock()
+		for k, v := range sh.m {
+			if _, reserved := v.(*reservation); reserved {
+				continue
+			}
+			if !fn(k, v) {
+				sh.mutex.RUnlock()
+				return
+			}
+		}
+		sh.mutex.RUnlock()
+	}
+}
+
+// clone 返回已注册键值的普通映射副本（不含预留，用于覆盖层与只读快照）
+func (s *shardedMap[K]) clone() map[K]any {
+	out := make(map[K]any)
+	s.rangeAll(func(k K, v any) bool {
+		out[k] = v
+		return true
+	})
+	return out
+}
+
+// restore 用 m 替换已注册的键值（PopOverlay），保留进行中的预留
+func (s *shardedMap[K]) restore(m map[K]any) {
+	for i := range s.shards {
+		sh := &s.shards[i]
+		sh.mutex.Lock()
+		for k, v := range sh.m {
+			if _, reserved := v.(*reservation); !reserved {
+				delete(sh.m, k)
+			}
+		}
+		sh.mutex.Unlock()
+	}
+	for k, v := range m {
+		s.store(k, v)
+	}
+}
@@ -0,0 +1,225 @@
+package ioc233
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"runtime/debug"
+	"sort"
+	"strings"
+)
+
+// SnapshotPath SnapshotHandler 建议挂载的路径（ioc233 diff 命令默认请求该路径）
+const SnapshotPath = "/ioc233/snapshot"
+
+// Snapshot 容器装配快照（可序列化为 JSON，用于跨环境比对）
+type Snapshot struct {
+	// State 容器状态
+	State string `json:"state"`
+	// Profiles 激活的 profile
+	Profiles []string `json:"profiles,omitempty"`
+	// Beans bean 列表（按注册顺序）
+	Beans []SnapshotBean `json:"beans"`
+	// Bindings 显式接口绑定（接口 -> 实现）
+	Bindings map[string]string `json:"bindings,omitempty"`
+	// Edges 依赖关系
+	Edges []DependencyEdge `json:"edges"`
+}
+
+// SnapshotBean 快照中的 bean
+type SnapshotBean struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	// Version bean 类型所在模块的版本（来自构建信息，未知时为空）
+	Version string `json:"version,omitempty"`
+	// Prototype 是否为原型 bean
+	Prototype bool `json:"prototype,omitempty"`
+}
+
+// Snapshot 生成容器当前的装配快照
+func (c *Container) Snapshot() *Snapshot {
+	graph := c.DependencyGraph()
+	profiles := c.ActiveProfiles()
+
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	snap := &Snapshot{State: c.state.String(), Profiles: profiles, Edges: graph.Edges}
+	versions := moduleVersions()
+	for _, def := range c.beans {
+		snap.Beans = append(snap.Beans, SnapshotBean{Name: def.name, Type: def.typ.String(), Version: versionOf(def.typ, versions)})
+	}
+	for _, p := range c.prototypes {
+		snap.Beans = append(snap.Beans, SnapshotBean{Name: p.name, Type: p.out.String(), Version: versionOf(p.out, versions), Prototype: true})
+	}
+	if len(c.bindings) > 0 {
+		snap.Bindings = make(map[string]string, len(c.bindings))
+		for iface, impl := range c.bindings {
+			snap.Bindings[iface.String()] = impl.String()
+		}
+	}
+	return snap
+}
+
+// WriteSnapshot 将容器快照以 JSON 写入 w（用于生成本地 dump 文件）
+func (c *Container) WriteSnapshot(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(c.Snapshot())
+}
+
+// ReadSnapshot 从 JSON 读取快照
+func ReadSnapshot(r io.Reader) (*Snapshot, error) {
+	var snap Snapshot
+	if err := json.NewDecoder(r).Decode(&snap); err != nil {
+		return nil, fmt.Errorf("[ioc233] 读取快照失败: %w", err)
+	}
+	return &snap, nil
+}
+
+// SnapshotHandler 返回输出容器快照 JSON 的 http.Handler（管理端点）
+//
+//	mux.Handle(ioc233.SnapshotPath, ioc233.SnapshotHandler(container))
+func SnapshotHandler(c *Container) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		if err := c.WriteSnapshot(w); err != nil {
+			logError("[ioc233] 输出快照失败: %v", err)
+		}
+	})
+}
+
+// SnapshotDiff 两份快照之间的一处差异
+type SnapshotDiff struct {
+	// Kind "added"（仅 remote 有）、"removed"（仅 local 有）或 "changed"
+	Kind string `json:"kind"`
+	// Subject 差异对象，例如 "bean UserService"、"edge OrderService.Users"、"binding tests.UserService"
+	Subject string `json:"subject"`
+	Local   string `json:"local,omitempty"`
+	Remote  string `json:"remote,omitempty"`
+}
+
+// String 返回 diff 风格的单行描述
+func (d SnapshotDiff) String() string {
+	switch d.Kind {
+	case "added":
+		return "+ " + d.Subject + ": " + d.Remote
+	case "removed":
+		return "- " + d.Subject + ": " + d.Local
+	}
+	return "~ " + d.Subject + ": " + d.Local + " -> " + d.Remote
+}
+
+// DiffSnapshots 比较两份快照的 bean（类型、版本）、接口绑定、依赖关系与 profile
+// 结果按差异对象排序，便于稳定输出
+func DiffSnapshots(local, remote *Snapshot) []SnapshotDiff {
+	var diffs []SnapshotDiff
+	diffs = append(diffs, diffMaps("profiles", map[string]string{"active": strings.Join(local.Profiles, ",")},
+		map[string]string{"active": strings.Join(remote.Profiles, ",")})...)
+	diffs = append(diffs, diffMaps("bean", beanMap(local), beanMap(remote))...)
+	diffs = append(diffs, diffMaps("binding", local.Bindings, remote.Bindings)...)
+	diffs = append(diffs, diffMaps("edge", edgeMap(local), edgeMap(remote))...)
+	return diffs
+}
+
+// beanMap bean 名 -> "类型@版本"
+func beanMap(s *Snapshot) map[string]string {
+	m := make(map[string]string, len(s.Beans))
+	for _, b := range s.Beans {
+		desc := b.Type
+		if b.Version != "" {
+			desc += "@" + b.Version
+		}
+		if b.Prototype {
+			desc += " (prototype)"
+		}
+		m[b.Name] = desc
+	}
+	return m
+}
+
+// edgeMap "消费方.字段" -> 依赖描述
+func edgeMap(s *Snapshot) map[string]string {
+	m := make(map[string]string, len(s.Edges))
+	for _, e := range s.Edges {
+		desc := e.Kind + " -> [" + strings.Join(e.To, ", ") + "]"
+		if e.Error != "" {
+			desc += " (unresolved)"
+		}
+		m[e.From+"."+e.Field] = desc
+	}
+	return m
+}
+
+// diffMaps 比较两个映射
+func diffMaps(kind string, local, remote map[string]string) []SnapshotDiff {
+	keys := make(map[string]bool, len(local)+len(remote))
+	for k := range local {
+		keys[k] = true
+	}
+	for k := range remote {
+		keys[k] = true
+	}
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	var diffs []SnapshotDiff
+	for _, k := range sorted {
+		l, inLocal := local[k]
+		r, inRemote := remote[k]
+		subject := kind + " " + k
+		switch {
+		case !inLocal:
+			diffs = append(diffs, SnapshotDiff{Kind: "added", Subject: subject, Remote: r})
+		case !inRemote:
+			diffs = append(diffs, SnapshotDiff{Kind: "removed", Subject: subject, Local: l})
+		case l != r:
+			diffs = append(diffs, SnapshotDiff{Kind: "changed", Subject: subject, Local: l, Remote: r})
+		}
+	}
+	return diffs
+}
+
+// moduleVersions 从构建信息读取模块版本（模块路径 -> 版本）
+func moduleVersions() map[string]string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return nil
+	}
+	versions := make(map[string]string, len(info.Deps)+1)
+	versions[info.Main.Path] = info.Main.Version
+	for _, dep := range info.Deps {
+		if dep.Replace != nil {
+			versions[dep.Path] = dep.Replace.Version
+		} else {
+			versions[dep.Path] = dep.Version
+		}
+	}
+	return versions
+}
+
+// versionOf 返回类型所在模块的版本（按最长模块路径前缀匹配）
+func versionOf(t reflect.Type, versions map[string]string) string {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	pkg := t.PkgPath()
+	best, version := "", ""
+	for path, v := range versions {
+		if path == "" || len(path) <= len(best) {
+			continue
+		}
+		if pkg == path || strings.HasPrefix(pkg, path+"/") {
+			best, version = path, v
+		}
+	}
+	return version
+}
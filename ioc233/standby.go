@@ -0,0 +1,160 @@
+package ioc233
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// SetPrototypeStandby 为原型 bean 维护 n 个预热的备用实例（n <= 0 表示关闭）
+// 说明：
+// - 适用于构造昂贵的原型（例如每局对战的 AI 上下文），突发创建房间时直接取用备用实例，平滑延迟尖峰
+// - 容器启动后在后台填充备用实例；每取走一个即在后台补充
+// - 备用实例耗尽时退化为同步创建，不会阻塞
+// - Close 时未被取走的备用实例会触发 IDestroy 回调
+func (c *Container) SetPrototypeStandby(prototypeName string, n int) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	p := c.findPrototypeByName(prototypeName)
+	if p == nil {
+		return fmt.Errorf("[ioc233] 未找到原型 bean: %s", prototypeName)
+	}
+	if p.standby != nil {
+		c.drainStandbyLocked(p)
+	}
+	if n <= 0 {
+		p.standby, p.refill = nil, nil
+		return nil
+	}
+	p.standby = make(chan reflect.Value, n)
+	p.refill = make(chan struct{}, 1)
+	if c.state == StateStarted {
+		c.startStandbyLocked(p)
+	}
+	logInfo("[ioc233] 原型 bean 开启预热: name=%s standby=%d", prototypeName, n)
+	return nil
+}
+
+// StandbyCount 返回原型 bean 当前可用的备用实例数
+func (c *Container) StandbyCount(prototypeName string) int {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	if p := c.findPrototypeByName(prototypeName); p != nil && p.standby != nil {
+		return len(p.standby)
+	}
+	return 0
+}
+
+// takeStandby 非阻塞地取出一个备用实例，并通知后台补充
+func (p *prototypeDefinition) takeStandby() (reflect.Value, bool) {
+	if p.standby == nil {
+		return reflect.Value{}, false
+	}
+	select {
+	case v := <-p.standby:
+		p.signalRefill()
+		return v, true
+	default:
+		return reflect.Value{}, false
+	}
+}
+
+// signalRefill 通知后台补充备用实例（已有未处理的信号时忽略）
+func (p *prototypeDefinition) signalRefill() {
+	select {
+	case p.refill <- struct{}{}:
+	default:
+	}
+}
+
+// startStandbyAllLocked 为所有开启预热的原型启动后台补充（调用方需持有写锁）
+func (c *Container) startStandbyAllLocked() {
+	for _, p := range c.prototypes {
+		if p.standby != nil {
+			c.startStandbyLocked(p)
+		}
+	}
+}
+
+// startStandbyLocked 启动单个原型的后台补充协程（调用方需持有写锁）
+func (c *Container) startStandbyLocked(p *prototypeDefinition) {
+	if c.standbyStop == nil {
+		c.standbyStop = make(chan struct{})
+	}
+	stop, standby, refill := c.standbyStop, p.standby, p.refill
+	go func() {
+		for {
+			if !c.fillStandby(p, standby) {
+				return
+			}
+			select {
+			case <-stop:
+				return
+			case <-refill:
+			}
+		}
+	}()
+	p.signalRefill()
+}
+
+// fillStandby 填充备用实例直到填满；容器已不在运行或预热配置已变更时返回 false
+func (c *Container) fillStandby(p *prototypeDefinition, standby chan reflect.Value) bool {
+	for {
+		c.mutex.RLock()
+		if c.state != StateStarted || p.standby != standby {
+			c.mutex.RUnlock()
+			return false
+		}
+		if len(standby) == cap(standby) {
+			c.mutex.RUnlock()
+			return true
+		}
+		v, err := c.buildPrototype(p)
+		if err != nil {
+			c.mutex.RUnlock()
+			logError("[ioc233] 预热原型实例失败: %v", err)
+			return true
+		}
+		// 持有读锁放入，保证 Close 清理时不会遗漏
+		select {
+		case standby <- v:
+			c.mutex.RUnlock()
+		default:
+			// 并发取用/填充导致已填满，丢弃多余实例
+			c.mutex.RUnlock()
+			destroyValue(v)
+			return true
+		}
+	}
+}
+
+// stopStandbyLocked 停止所有后台补充，并销毁未被取走的备用实例（调用方需持有写锁）
+func (c *Container) stopStandbyLocked() {
+	if c.standbyStop != nil {
+		close(c.standbyStop)
+		c.standbyStop = nil
+	}
+	for _, p := range c.prototypes {
+		if p.standby != nil {
+			c.drainStandbyLocked(p)
+		}
+	}
+}
+
+// drainStandbyLocked 取出并销毁原型的全部备用实例（调用方需持有写锁）
+func (c *Container) drainStandbyLocked(p *prototypeDefinition) {
+	for {
+		select {
+		case v := <-p.standby:
+			destroyValue(v)
+		default:
+			return
+		}
+	}
+}
+
+// destroyValue 触发实例的 IDestroy 回调
+func destroyValue(v reflect.Value) {
+	if obj, ok := v.Interface().(IDestroy); ok {
+		obj.OnDestroy()
+	}
+}
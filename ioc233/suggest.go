@@ -0,0 +1,94 @@
+package ioc233
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// maxNameSuggestions 名称注入失败时最多给出的候选数
+const maxNameSuggestions = 3
+
+// suggestNames 返回与 name 编辑距离最近的已注册 bean 名与原型名（包括父容器），按距离、名称排序。
+// 比较忽略大小写；距离超过 len(name)/3+1 的名称不作为候选（调用方需持有本容器的锁）
+func (c *Container) suggestNames(name string) []string {
+	type candidate struct {
+		name     string
+		distance int
+	}
+	limit := len([]rune(name))/3 + 1
+	target := strings.ToLower(name)
+	seen := make(map[string]bool)
+	var candidates []candidate
+	consider := func(other string) {
+		if other == name || seen[other] {
+			return
+		}
+		seen[other] = true
+		if d := editDistance(target, strings.ToLower(other)); d <= limit {
+			candidates = append(candidates, candidate{name: other, distance: d})
+		}
+	}
+	for cur := c; cur != nil; cur = cur.parent {
+		cur.withReadLockIfParent(c, func() {
+			cur.nameToObjMap.rangeAll(func(other string, obj any) bool {
+				if obj != nil {
+					consider(other)
+				}
+				return true
+			})
+			for _, p := range cur.prototypes {
+				consider(p.name)
+			}
+		})
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		return candidates[i].name < candidates[j].name
+	})
+	if len(candidates) > maxNameSuggestions {
+		candidates = candidates[:maxNameSuggestions]
+	}
+	names := make([]string, len(candidates))
+	for i, cand := range candidates {
+		names[i] = cand.name
+	}
+	return names
+}
+
+// didYouMean 返回附加在错误信息后的候选提示，没有候选时返回空串
+func (c *Container) didYouMean(name string) string {
+	names := c.suggestNames(name)
+	if len(names) == 0 {
+		return ""
+	}
+	quoted := make([]string, len(names))
+	for i, n := range names {
+		quoted[i] = fmt.Sprintf("%q", n)
+	}
+	return "，是否想注入: " + strings.Join(quoted, ", ")
+}
+
+// editDistance 计算两个字符串的 Levenshtein 编辑距离（按 rune）
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
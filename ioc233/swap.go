@@ -0,0 +1,197 @@
+package ioc233
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Swap 用新实例替换容器中同类型的 bean（热替换 / 重载）
+// 行为：
+//   - 新实例接管旧实例的类型映射与所有名称映射
+//   - 容器已启动时，新实例会先完成自身的字段注入
+//   - 其他 bean 中指向旧实例的 autowire 字段被重新注入为新实例，并触发 IDependencyChanged 通知；
+//     切片（含 group 分组注入）与 map 字段中的旧实例元素被替换为新实例（写时复制，不修改原切片 / map）
+//   - 以该类型为输入的派生 bean（ProvideDerived）会被重新计算并级联替换
+func (c *Container) Swap(instance any) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.swapLocked(instance)
+}
+
+// swapLocked Swap 的内部实现（调用方需持有写锁）
+func (c *Container) swapLocked(instance any) error {
+	if instance == nil {
+		return errors.New("[ioc233] Swap 参数非法")
+	}
+	t := reflect.TypeOf(instance)
+
+	var target *beanDefinition
+	for _, def := range c.beans {
+		if def.typ == t {
+			target = def
+			break
+		}
+	}
+	if target == nil {
+		return fmt.Errorf("[ioc233] Swap 失败: 未注册类型 %v 的 bean", t)
+	}
+	return c.replaceBeanLocked(target, instance)
+}
+
+// replaceBeanLocked 用 instance 替换 target 的实例（类型可以不同，调用方需持有写锁）
+// 新实例接管旧实例的名称映射；类型变化时移除旧类型映射，使按接口解析命中新实例
+func (c *Container) replaceBeanLocked(target *beanDefinition, instance any) error {
+	old, oldType := target.instance, target.typ
+	if sameInstance(old, instance) {
+		return nil
+	}
+	t := reflect.TypeOf(instance)
+
+	c.initBasicFields(instance)
+	target.instance, target.typ = instance, t
+	c.invalidateReadViewLocked()
+	if oldType != t {
+		c.resetImplIndexLocked()
+	}
+	if sameInstance(c.typeToObjectMap.get(oldType), old) {
+		c.typeToObjectMap.delete(oldType)
+	}
+	if !c.typeToObjectMap.taken(t) {
+		c.typeToObjectMap.store(t, instance)
+	}
+	var names []string
+	c.nameToObjMap.rangeAll(func(name string, obj any) bool {
+		if sameInstance(obj, old) {
+			names = append(names, name)
+		}
+		return true
+	})
+	for _, name := range names {
+		c.nameToObjMap.store(name, instance)
+	}
+	logInfo("[ioc233] 替换 bean: name=%s type=%v", target.name, t)
+
+	if c.state == StateStarted {
+		c.injectInternal(instance, resolveWrite)
+		c.markInjectedLocked(instance)
+	}
+	c.rewireDependentsLocked(old, instance)
+
+	// 级联重新计算以该类型为输入的派生 bean
+	for _, d := range c.derivedList {
+		if d.computed && (d.consumes(t) || d.consumes(oldType)) {
+			if err := c.computeDerivedOneLocked(d); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// rewireDependentsLocked 将所有 bean 中指向旧实例的注入字段改为新实例
+func (c *Container) rewireDependentsLocked(old, replacement any) {
+	newVal := reflect.ValueOf(replacement)
+	for _, def := range c.beans {
+		if sameInstance(def.instance, replacement) {
+			continue
+		}
+		v := reflect.ValueOf(def.instance)
+		if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+			continue
+		}
+		elem := v.Elem()
+		t := elem.Type()
+		for _, field := range injectableFields(t) {
+			if autowireTag(field) == "" {
+				continue
+			}
+			fv := fieldByIndex(elem, field.Index, false)
+			if !fv.IsValid() || !fv.CanSet() {
+				continue
+			}
+			rewired, ok := rewiredValue(fv, old, newVal)
+			if !ok {
+				continue
+			}
+			fv.Set(rewired)
+			c.recordInjection(def.instance, field, injectionMode(field, autowireTag(field)), false, newVal)
+			logInfo("[ioc233] 重新注入依赖: struct=%s field=%s type=%v", displayTypeName(t), field.Name, newVal.Type())
+			if obj, ok := def.instance.(IDependencyChanged); ok {
+				obj.OnDependencyChanged(field.Name)
+			}
+		}
+	}
+}
+
+// rewiredValue 返回字段重新注入后的值：字段本身指向旧实例时返回新实例；
+// 切片 / map 字段中含有旧实例元素时返回替换后的副本；无需重新注入时返回 false
+func rewiredValue(fv reflect.Value, old any, newVal reflect.Value) (reflect.Value, bool) {
+	if isInstance(fv, old) {
+		return newVal, newVal.Type().AssignableTo(fv.Type())
+	}
+	switch fv.Kind() {
+	case reflect.Slice:
+		if !newVal.Type().AssignableTo(fv.Type().Elem()) {
+			return reflect.Value{}, false
+		}
+		var out reflect.Value
+		for i := 0; i < fv.Len(); i++ {
+			if !isInstance(fv.Index(i), old) {
+				continue
+			}
+			if !out.IsValid() {
+				out = reflect.MakeSlice(fv.Type(), fv.Len(), fv.Len())
+				reflect.Copy(out, fv)
+			}
+			out.Index(i).Set(newVal)
+		}
+		return out, out.IsValid()
+	case reflect.Map:
+		if !newVal.Type().AssignableTo(fv.Type().Elem()) {
+			return reflect.Value{}, false
+		}
+		var out reflect.Value
+		for iter := fv.MapRange(); iter.Next(); {
+			if !isInstance(iter.Value(), old) {
+				continue
+			}
+			if !out.IsValid() {
+				out = reflect.MakeMapWithSize(fv.Type(), fv.Len())
+				for it := fv.MapRange(); it.Next(); {
+					out.SetMapIndex(it.Key(), it.Value())
+				}
+			}
+			out.SetMapIndex(iter.Key(), newVal)
+		}
+		return out, out.IsValid()
+	}
+	return reflect.Value{}, false
+}
+
+// isInstance 判断 v（接口值取其动态值）是否为 instance
+func isInstance(v reflect.Value, instance any) bool {
+	if v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return false
+		}
+		v = v.Elem()
+	}
+	return v.CanInterface() && sameInstance(v.Interface(), instance)
+}
+
+// sameInstance 判断两个对象是否为同一实例（引用类型比较地址，可比较的值类型比较值）
+func sameInstance(a, b any) bool {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if !va.IsValid() || !vb.IsValid() || va.Type() != vb.Type() {
+		return false
+	}
+	switch va.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Chan, reflect.Func, reflect.Slice, reflect.UnsafePointer:
+		return va.Pointer() == vb.Pointer()
+	}
+	if va.Comparable() {
+		return va.Equal(vb)
+	}
+	return false
+}
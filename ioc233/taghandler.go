@@ -0,0 +1,126 @@
+package ioc233
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// TagField 标签处理器的调用参数
+type TagField struct {
+	// Container 当前容器（处理器中通过它解析其他 bean；调用方已持有容器锁，只在处理器执行期间有效，不能保存后使用）
+	Container ContainerAdapter
+	// Owner 字段所属的对象（结构体指针）
+	Owner any
+	// Field 字段定义
+	Field reflect.StructField
+	// Value 字段值（可设置）
+	Value reflect.Value
+	// Tag 标签值，例如 `grpcclient:"orders"` 中的 "orders"
+	Tag string
+}
+
+// TagHandler 自定义结构体标签处理器
+// 注入阶段对携带已注册标签的字段调用，在 autowire 注入之前执行；返回错误记为该字段注入失败
+type TagHandler interface {
+	HandleTag(f TagField) error
+}
+
+// TagHandlerFunc 函数形式的 TagHandler
+type TagHandlerFunc func(f TagField) error
+
+// HandleTag 实现 TagHandler
+func (fn TagHandlerFunc) HandleTag(f TagField) error {
+	return fn(f)
+}
+
+// reservedTags 容器自身使用的标签，不允许注册处理器
+var reservedTags = map[string]bool{
+	"autowire": true, "inject": true, "lazy": true, "balance": true, "optional": true,
+	"group": true, "name": true, "module": true, "profile": true, "schedule": true, "overlap": true, "method": true, "route": true, "handler": true,
+	"buffer": true, "default": true, "env": true, "value": true, "secret": true, "config": true,
+	"phase": true, "dependsOn": true,
+}
+
+// tagHandlerEntry 已注册的标签处理器
+type tagHandlerEntry struct {
+	key     string
+	handler TagHandler
+}
+
+// RegisterTagHandler 为结构体标签 key 注册处理器（需在 StartUp 之前注册，子容器继承父容器的处理器）
+//
+//	container.RegisterTagHandler("grpcclient", ioc233.TagHandlerFunc(func(f ioc233.TagField) error {
+//	    conn, err := dial(f.Tag)
+//	    if err != nil {
+//	        return err
+//	    }
+//	    f.Value.Set(reflect.ValueOf(pb.NewOrdersClient(conn)))
+//	    return nil
+//	}))
+func (c *Container) RegisterTagHandler(key string, h TagHandler) error {
+	if key == "" || h == nil {
+		return fmt.Errorf("[ioc233] RegisterTagHandler 参数非法: key=%q", key)
+	}
+	if reservedTags[key] {
+		return fmt.Errorf("[ioc233] 标签 %q 为容器保留标签，不能注册处理器", key)
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for _, e := range c.tagHandlers {
+		if e.key == key {
+			return fmt.Errorf("[ioc233] 标签 %q 已注册处理器: %T", key, e.handler)
+		}
+	}
+	c.tagHandlers = append(c.tagHandlers, tagHandlerEntry{key: key, handler: h})
+	return nil
+}
+
+// tagHandlersFor 返回字段携带的已注册标签（按注册顺序，本容器优先，其次父容器）
+func (c *Container) tagHandlersFor(field reflect.StructField) []tagHandlerEntry {
+	var matched []tagHandlerEntry
+	for _, e := range c.tagHandlers {
+		if _, ok := field.Tag.Lookup(e.key); ok {
+			matched = append(matched, e)
+		}
+	}
+	if c.parent != nil {
+		c.parent.withReadLock(func() {
+			for _, e := range c.parent.tagHandlersFor(field) {
+				if !containsTagKey(matched, e.key) {
+					matched = append(matched, e)
+				}
+			}
+		})
+	}
+	return matched
+}
+
+// applyTagHandlers 依次执行字段的标签处理器，出错时记录注入失败并停止，返回该错误
+func (c *Container) applyTagHandlers(instance any, field reflect.StructField, fv reflect.Value, handlers []tagHandlerEntry) error {
+	for _, e := range handlers {
+		logDebug("[ioc233] 执行标签处理器: field=%s tag=%s handler=%T", field.Name, e.key, e.handler)
+		err := e.handler.HandleTag(TagField{
+			Container: c.lockedAdapter(),
+			Owner:     instance,
+			Field:     field,
+			Value:     fv,
+			Tag:       field.Tag.Get(e.key),
+		})
+		if err != nil {
+			err = fmt.Errorf("[ioc233] 标签处理器执行失败: field=%s tag=%s: %w", field.Name, e.key, err)
+			c.injectionFailed(instance, field, err)
+			return err
+		}
+	}
+	return nil
+}
+
+// containsTagKey 判断处理器列表中是否已包含标签 key
+func containsTagKey(entries []tagHandlerEntry, key string) bool {
+	for _, e := range entries {
+		if e.key == key {
+			return true
+		}
+	}
+	return false
+}
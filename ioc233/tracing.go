@@ -0,0 +1,72 @@
+package ioc233
+
+import (
+	"context"
+	"fmt"
+)
+
+// 启动追踪的 span 名
+const (
+	// SpanStartUp 整个 StartUp 流程
+	SpanStartUp = "ioc233.StartUp"
+	// SpanInject 单个 bean 的字段注入（包含注入前后回调）
+	SpanInject = "ioc233.inject"
+	// SpanCallback 单个生命周期回调（OnInjectBefore / OnInjectAfter / OnInjectComplete）
+	SpanCallback = "ioc233.callback"
+)
+
+// 启动追踪的 span 属性名
+const (
+	SpanAttrBean     = "ioc233.bean"
+	SpanAttrType     = "ioc233.type"
+	SpanAttrCallback = "ioc233.callback"
+)
+
+// SpanAttr span 属性
+type SpanAttr struct {
+	Key   string
+	Value string
+}
+
+// StartupTracer 启动追踪钩子（由 OpenTelemetry 等追踪系统实现，见 ioc233/otelioc）
+// 容器本身不依赖任何追踪库，设置后 StartUp 为整个启动流程、每个 bean 的注入与每个生命周期回调各创建一个 span
+type StartupTracer interface {
+	// StartSpan 以 ctx 中的 span 为父开始一个 span，返回携带新 span 的 ctx 与结束函数
+	// 结束函数的 err 非 nil 表示该步骤失败
+	StartSpan(ctx context.Context, name string, attrs ...SpanAttr) (context.Context, func(err error))
+}
+
+// SetStartupTracer 设置启动追踪钩子（nil 表示不追踪）
+// StartUpCtx 的 ctx 携带 span 时，启动 span 作为其子 span
+func (c *Container) SetStartupTracer(tracer StartupTracer) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.tracer = tracer
+}
+
+// startSpanLocked 开始一个 span；未设置追踪钩子时返回原 ctx 与空的结束函数（调用方需持有锁）
+func (c *Container) startSpanLocked(ctx context.Context, name string, attrs ...SpanAttr) (context.Context, func(err error)) {
+	if c.tracer == nil {
+		return ctx, func(error) {}
+	}
+	return c.tracer.StartSpan(ctx, name, attrs...)
+}
+
+// traceCallbackLocked 在 SpanCallback span 中执行 bean 的生命周期回调（调用方需持有锁）
+// 返回回调超时错误（见 SetCallbackTimeout）
+func (c *Container) traceCallbackLocked(ctx context.Context, def *beanDefinition, callback string, fn func()) error {
+	_, end := c.startSpanLocked(ctx, SpanCallback,
+		SpanAttr{Key: SpanAttrBean, Value: def.name},
+		SpanAttr{Key: SpanAttrCallback, Value: callback})
+	err := c.runCallbackLocked(ctx, def, callback, fn)
+	end(err)
+	return err
+}
+
+// injectionFailuresError 将 bean 的字段注入失败数转换为注入 span 的错误
+func injectionFailuresError(n int) error {
+	if n > 0 {
+		return fmt.Errorf("[ioc233] %d 个字段注入失败", n)
+	}
+	return nil
+}
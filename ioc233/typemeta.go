@@ -0,0 +1,73 @@
+package ioc233
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// structMeta 结构体类型解析后的注入元数据（按 reflect.Type 缓存，所有容器只读共享）
+// Provide、StartUp、原型创建等热路径不再重复展开嵌入字段和解析标签
+type structMeta struct {
+	// fields 参与注入的字段（见 injectableFields）
+	fields []reflect.StructField
+	// plans 与 fields 一一对应的注入计划
+	plans []fieldPlan
+	// phase 结构体 phase 标签（取第一个声明）
+	phase string
+	// dependsOn 结构体 dependsOn 标签声明的 bean 名
+	dependsOn []string
+}
+
+// fieldPlan 单个字段预解析的注入标签
+type fieldPlan struct {
+	field reflect.StructField
+	// autowire autowire 标签（inject 为兼容别名）
+	autowire string
+	// value 标签中的配置表达式
+	value    string
+	hasValue bool
+	// secret 标签中的密钥路径
+	secret    string
+	hasSecret bool
+}
+
+// structMetaCache reflect.Type -> *structMeta
+var structMetaCache sync.Map
+
+// structMetaOf 返回结构体类型 t 的注入元数据（首次访问时解析并缓存）
+func structMetaOf(t reflect.Type) *structMeta {
+	if m, ok := structMetaCache.Load(t); ok {
+		return m.(*structMeta)
+	}
+	m, _ := structMetaCache.LoadOrStore(t, parseStructMeta(t))
+	return m.(*structMeta)
+}
+
+// parseStructMeta 解析结构体类型的注入元数据
+func parseStructMeta(t reflect.Type) *structMeta {
+	m := &structMeta{}
+	collectInjectableFields(t, nil, map[reflect.Type]bool{t: true}, &m.fields)
+	m.plans = make([]fieldPlan, len(m.fields))
+	for i, field := range m.fields {
+		p := fieldPlan{field: field, autowire: autowireTag(field)}
+		p.value, p.hasValue = field.Tag.Lookup("value")
+		p.secret, p.hasSecret = field.Tag.Lookup("secret")
+		m.plans[i] = p
+	}
+	hasPhase := false
+	for i := 0; i < t.NumField(); i++ {
+		tag := t.Field(i).Tag
+		if name, ok := tag.Lookup("phase"); ok && !hasPhase {
+			m.phase, hasPhase = name, true
+		}
+		if list, ok := tag.Lookup("dependsOn"); ok {
+			for _, name := range strings.Split(list, ",") {
+				if name = strings.TrimSpace(name); name != "" {
+					m.dependsOn = append(m.dependsOn, name)
+				}
+			}
+		}
+	}
+	return m
+}
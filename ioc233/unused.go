@@ -0,0 +1,128 @@
+package ioc233
+
+import (
+	"reflect"
+)
+
+// UnusedBean 注册后从未被注入、也从未被获取的 bean（见 Container.UnusedBeans）
+type UnusedBean struct {
+	// Name bean 名
+	Name string
+	// TypeName 实例的具体类型
+	TypeName string
+}
+
+// String 返回 "name (type)" 形式的描述
+func (u UnusedBean) String() string {
+	return u.Name + " (" + u.TypeName + ")"
+}
+
+// instanceKey 引用类型实例的标识（类型 + 地址）
+type instanceKey struct {
+	typ reflect.Type
+	ptr uintptr
+}
+
+// usageKey 返回实例的标识；不可比较的值类型返回 false
+func usageKey(v reflect.Value) (any, bool) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Chan, reflect.Func, reflect.Slice, reflect.UnsafePointer:
+		if v.IsNil() {
+			return nil, false
+		}
+		return instanceKey{typ: v.Type(), ptr: v.Pointer()}, true
+	}
+	if v.Comparable() {
+		return v.Interface(), true
+	}
+	return nil, false
+}
+
+// markUsed 记录实例被注入或获取；切片同时记录每个元素，父容器中的 bean 同样记入父容器
+func (c *Container) markUsed(v reflect.Value) {
+	for v.IsValid() && v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return
+	}
+	if key, ok := usageKey(v); ok {
+		for cur := c; cur != nil; cur = cur.parent {
+			cur.used.Store(key, struct{}{})
+		}
+	}
+	if v.Kind() == reflect.Slice {
+		for i := 0; i < v.Len(); i++ {
+			c.markUsed(v.Index(i))
+		}
+	}
+}
+
+// isUsed 实例是否在运行期被注入或获取过
+func (c *Container) isUsed(instance any) bool {
+	v := reflect.ValueOf(instance)
+	if !v.IsValid() {
+		return false
+	}
+	key, ok := usageKey(v)
+	if !ok {
+		// 无法标识的实例不参与检测
+		return true
+	}
+	_, used := c.used.Load(key)
+	return used
+}
+
+// selfActivated bean 是否由容器或框架主动驱动，不需要被注入也有作用
+func (c *Container) selfActivated(def *beanDefinition) bool {
+	switch def.instance.(type) {
+	case *Container, IRunnable, IHealthCheck, IMiddleware, IWebSocketController, IMigration, BeanPostProcessor, IConfigChanged:
+		return true
+	}
+	t := reflect.TypeOf(def.instance)
+	if _, ok := c.controllerMap[t]; ok {
+		return true
+	}
+	if len(eventMethodsOf(t)) > 0 {
+		return true
+	}
+	jobs, _ := jobsOf(def)
+	return len(jobs) > 0
+}
+
+// UnusedBeans 列出注册后从未被注入、也从未被获取的 bean，用于清理无用注册与发现漏写的注入标签。
+// 以下情况视为已使用：
+//   - 被其他 bean 的 autowire 字段依赖（包括懒加载、切片、负载均衡注入）或被 dependsOn 引用
+//   - 运行期通过 GetObjectByType/GetObjectsByType、Invoke、构造函数参数、Inject、ContainerAdapter 获取
+//   - 由容器主动驱动：控制器、托管运行（IRunnable）、健康检查、中间件、迁移、后置处理器、事件处理器、调度任务、IConfigChanged
+//
+// 结果按注册顺序返回；应在 StartUp 之后、业务流量进入一段时间后调用，启动前调用时运行期获取尚未发生。
+// 仅实现 io.Closer 的 bean 同样会被列出：容器关闭它，但没有任何代码使用它
+func (c *Container) UnusedBeans() []UnusedBean {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	referenced := make(map[string]bool)
+	for _, def := range c.beans {
+		for _, edge := range c.edgesOf(def) {
+			for _, name := range edge.To {
+				referenced[name] = true
+			}
+		}
+		for _, name := range dependsOnOf(def) {
+			referenced[name] = true
+		}
+	}
+
+	var unused []UnusedBean
+	for _, def := range c.beans {
+		if def.instance == nil || referenced[def.name] || c.isUsed(def.instance) || c.selfActivated(def) {
+			continue
+		}
+		unused = append(unused, UnusedBean{Name: def.name, TypeName: def.typ.String()})
+	}
+	return unused
+}
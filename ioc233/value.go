@@ -0,0 +1,67 @@
+package ioc233
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// ProvideValue 注册值 bean（基础类型、结构体值、函数等），让小型配置值与对象走同一张依赖图
+// 说明：
+// - 始终可通过名称注入：autowire:"name"（值类型需可赋值给字段类型）
+// - 具名的自定义类型（例如 type ServiceName string、type Clock func() time.Time）额外按类型注册（autowire:"true"）
+// - int、string、func() 等内置/匿名类型只能按名称注入
+// - 值 bean 不做字段注入，也不触发注册后回调
+// - 名称重复视为致命错误（与 ProvideByName 一致）
+//
+// 示例：
+//
+//	c.ProvideValue("serviceName", "order-service")
+//	c.ProvideValue("bufferSize", 4096)
+//	c.ProvideValue("clock", Clock(time.Now))
+//
+//	type Worker struct {
+//	    Name  string `autowire:"serviceName"`
+//	    Size  int    `autowire:"bufferSize"`
+//	    Clock Clock  `autowire:"true"`
+//	}
+func (c *Container) ProvideValue(name string, v any) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if err := c.checkFrozenLocked("ProvideValue", v); err != nil {
+		return err
+	}
+	return c.provideValueLocked(name, v)
+}
+
+// provideValueLocked ProvideValue 的内部实现（调用方需持有写锁）
+func (c *Container) provideValueLocked(name string, v any) error {
+	if v == nil || strings.TrimSpace(name) == "" {
+		return errors.New("[ioc233] ProvideValue 参数非法")
+	}
+	if c.nameToObjMap.taken(name) {
+		err := errors.New("[ioc233] ProvideValue 重复注册: name=" + name)
+		logError("%s", err.Error())
+		c.fatalErrors = append(c.fatalErrors, err)
+		return err
+	}
+
+	t := reflect.TypeOf(v)
+	c.nameToObjMap.store(name, v)
+	if isDistinctNamedType(t) {
+		if c.typeToObjectMap.taken(t) {
+			logWarn("[ioc233] ProvideValue 重复类型注册，仅按名称注册: name=%s type=%v", name, t)
+		} else {
+			c.typeToObjectMap.store(t, v)
+		}
+	}
+	c.addBeanLocked(&beanDefinition{name: name, typ: t, instance: v})
+	logInfo("[ioc233] 注册值 bean | name = %s (type: %v)", name, t)
+	c.emit(BeanRegistered{Name: name, Type: t})
+	return nil
+}
+
+// isDistinctNamedType 判断是否为可按类型区分的具名自定义类型（排除 int/string 等内置类型与匿名类型）
+func isDistinctNamedType(t reflect.Type) bool {
+	return t.Name() != "" && t.PkgPath() != ""
+}
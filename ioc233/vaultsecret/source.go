@@ -0,0 +1,69 @@
+// Package vaultsecret 基于 HashiCorp Vault KV 的 ioc233 密钥源
+// 独立模块，只有导入本包时才引入 Vault 客户端依赖：
+//
+//	client, _ := api.NewClient(api.DefaultConfig())
+//	container.AddSecretSource(vaultsecret.New(client.KVv2("secret")))
+//
+// secret 标签的路径格式为 "<secret 路径>#<字段>"，省略 # 时最后一段作为字段名：
+// secret:"db/password" 读取 secret db 的 password 字段，secret:"apps/order#token" 读取 apps/order 的 token 字段
+package vaultsecret
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// KV Vault KV 引擎的最小接口（*api.KVv1 与 *api.KVv2 实现了该接口）
+type KV interface {
+	Get(ctx context.Context, secretPath string) (*api.KVSecret, error)
+}
+
+// Source Vault 密钥源（实现 ioc233.SecretSource）
+type Source struct {
+	kv KV
+}
+
+// New 创建 Vault 密钥源
+func New(kv KV) *Source {
+	return &Source{kv: kv}
+}
+
+// GetSecret 实现 ioc233.SecretSource
+func (s *Source) GetSecret(ctx context.Context, path string) (string, error) {
+	secretPath, key := splitPath(path)
+	if secretPath == "" || key == "" {
+		return "", fmt.Errorf("[ioc233] Vault 密钥路径非法: %q", path)
+	}
+	secret, err := s.kv.Get(ctx, secretPath)
+	if errors.Is(err, api.ErrSecretNotFound) || (err == nil && secret == nil) {
+		return "", ioc233.ErrSecretNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("[ioc233] 读取 Vault 密钥失败: path=%s: %w", secretPath, err)
+	}
+	v, ok := secret.Data[key]
+	if !ok || v == nil {
+		return "", fmt.Errorf("%w: path=%s field=%s", ioc233.ErrSecretNotFound, secretPath, key)
+	}
+	if str, ok := v.(string); ok {
+		return str, nil
+	}
+	return fmt.Sprint(v), nil
+}
+
+// splitPath 拆分为 secret 路径与字段名
+func splitPath(path string) (string, string) {
+	if secretPath, key, ok := strings.Cut(path, "#"); ok {
+		return secretPath, key
+	}
+	i := strings.LastIndexByte(path, '/')
+	if i < 0 {
+		return "", ""
+	}
+	return path[:i], path[i+1:]
+}
@@ -0,0 +1,54 @@
+package vaultsecret_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/neko233-com/ioc233-go/ioc233"
+	"github.com/neko233-com/ioc233-go/ioc233/vaultsecret"
+)
+
+// fakeKV 内存中的 Vault KV
+type fakeKV map[string]map[string]any
+
+func (f fakeKV) Get(_ context.Context, path string) (*api.KVSecret, error) {
+	data, ok := f[path]
+	if !ok {
+		return nil, api.ErrSecretNotFound
+	}
+	return &api.KVSecret{Data: data}, nil
+}
+
+type Repo struct {
+	Password string `secret:"db/password"`
+	Token    []byte `secret:"apps/order#token"`
+}
+
+func TestSource_InjectsSecrets(t *testing.T) {
+	kv := fakeKV{
+		"db":         {"password": "s3cret"},
+		"apps/order": {"token": "t0ken"},
+	}
+	c := ioc233.NewContainer()
+	c.AddSecretSource(vaultsecret.New(kv))
+	repo := &Repo{}
+	c.Provide(repo)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	if repo.Password != "s3cret" || string(repo.Token) != "t0ken" {
+		t.Errorf("应该注入 Vault 中的密钥, 实际: %+v", repo)
+	}
+}
+
+func TestSource_NotFound(t *testing.T) {
+	src := vaultsecret.New(fakeKV{"db": {"user": "svc"}})
+	if _, err := src.GetSecret(context.Background(), "missing/password"); !errors.Is(err, ioc233.ErrSecretNotFound) {
+		t.Errorf("secret 不存在时应该返回 ErrSecretNotFound, 实际: %v", err)
+	}
+	if _, err := src.GetSecret(context.Background(), "db/password"); !errors.Is(err, ioc233.ErrSecretNotFound) {
+		t.Errorf("字段不存在时应该返回 ErrSecretNotFound, 实际: %v", err)
+	}
+}
@@ -0,0 +1,136 @@
+package ioc233
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// BeanOption 注册 bean 时的附加选项（Provide、ProvideByName 的可变参数）
+type BeanOption func(*beanOptions)
+
+// beanOptions 附加选项汇总
+type beanOptions struct {
+	visibleTo   []string
+	phase       string
+	noAutoClose bool
+	tags        []string
+	description string
+	groups      []string
+	featureFlag string
+}
+
+// VisibleTo 限制 bean 只能注入到指定模块的消费方（密钥、签名私钥、特权客户端等敏感 bean）：
+//
+//	c.ProvideByName("signingKey", key, ioc233.VisibleTo("billing", "payments"))
+//
+// 消费方所属模块取结构体字段上的 module 标签（例如 _ struct{} `module:"billing"`），未声明时取类型所在包名。
+// 违规的注入在 Validate 中报告，并使 StartUp 失败；切片注入会过滤掉不可见的 bean。
+// 按类型直接 Get 的调用方不受限制
+func VisibleTo(modules ...string) BeanOption {
+	return func(o *beanOptions) {
+		o.visibleTo = append(o.visibleTo, modules...)
+	}
+}
+
+// applyBeanOptionsLocked 将选项应用到已注册（或等待条件求值）的 bean 上（调用方需持有写锁）
+func (c *Container) applyBeanOptionsLocked(instance any, opts []BeanOption) {
+	if len(opts) == 0 || instance == nil {
+		return
+	}
+	for _, def := range c.beans {
+		if sameInstance(def.instance, instance) {
+			var o beanOptions
+			for _, opt := range opts {
+				opt(&o)
+			}
+			def.visibleTo = o.visibleTo
+			def.phase = o.phase
+			def.noAutoClose = o.noAutoClose
+			def.tags = o.tags
+			def.description = o.description
+			def.groups = o.groups
+			return
+		}
+	}
+	// 尚未注册（profile/条件注册），求值通过后再应用
+	for _, def := range append(c.conditionals, c.missingDefaults...) {
+		if sameInstance(def.instance, instance) {
+			def.opts = opts
+			return
+		}
+	}
+}
+
+// consumerModule 返回消费方所属模块：结构体 module 标签，未声明时取包名
+func consumerModule(t reflect.Type) string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Struct {
+		for i := 0; i < t.NumField(); i++ {
+			if m, ok := t.Field(i).Tag.Lookup("module"); ok {
+				return m
+			}
+		}
+	}
+	pkg := t.PkgPath()
+	return pkg[strings.LastIndex(pkg, "/")+1:]
+}
+
+// definitionOf 按实例查找 bean 定义（本容器与父容器，调用方需持有本容器的锁）
+func (c *Container) definitionOf(v reflect.Value) *beanDefinition {
+	for cur := c; cur != nil; cur = cur.parent {
+		var found *beanDefinition
+		cur.withReadLockIfParent(c, func() {
+			for _, def := range cur.beans {
+				if sameInstance(def.instance, v.Interface()) {
+					found = def
+					return
+				}
+			}
+		})
+		if found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// checkVisible 检查 v 对应的 bean 能否注入到 consumer 的 field 字段
+func (c *Container) checkVisible(consumer reflect.Type, field reflect.StructField, v reflect.Value) error {
+	if !v.IsValid() || v.Kind() == reflect.Slice {
+		return nil
+	}
+	if v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	def := c.definitionOf(v)
+	if def == nil || len(def.visibleTo) == 0 {
+		return nil
+	}
+	module := consumerModule(consumer)
+	for _, m := range def.visibleTo {
+		if m == module {
+			return nil
+		}
+	}
+	return fmt.Errorf("[ioc233] bean 可见性违规: %s 仅对模块 %v 可见，不能注入到 %s.%s (module=%s)",
+		def.name, def.visibleTo, displayTypeName(consumer), field.Name, module)
+}
+
+// filterVisible 过滤切片中对 consumer 不可见的元素
+func (c *Container) filterVisible(consumer reflect.Type, field reflect.StructField, v reflect.Value) reflect.Value {
+	if !v.IsValid() || v.Kind() != reflect.Slice {
+		return v
+	}
+	out := reflect.MakeSlice(v.Type(), 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		if err := c.checkVisible(consumer, field, v.Index(i)); err != nil {
+			logDebug("%s", err.Error())
+			continue
+		}
+		out = reflect.Append(out, v.Index(i))
+	}
+	return out
+}
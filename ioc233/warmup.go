@@ -0,0 +1,120 @@
+package ioc233
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// IWarmUp 预热接口
+// 所有 bean 完成注入与 OnInjectComplete 之后、容器进入 StateStarted 之前，实现此接口的 bean 并发执行 WarmUp（缓存预加载、配置表加载等）
+// WarmUp 中应使用注入的字段，不要调用容器的 Get/Provide 方法（启动期间容器持有写锁）
+type IWarmUp interface {
+	// WarmUp 执行预热，ctx 为启动 ctx（StartUpCtx / StartUpWithTimeout 的截止时间同样约束预热）
+	WarmUp(ctx context.Context) error
+}
+
+// WarmUpPolicy 预热失败后的处理策略
+type WarmUpPolicy int
+
+const (
+	// WarmUpContinue 记录失败的 bean 后继续启动（默认），失败记录在 StartupReport.WarmUpFailures
+	WarmUpContinue WarmUpPolicy = iota
+	// WarmUpFail 中止启动，StartUp 返回包装了 *WarmUpError 的错误
+	WarmUpFail
+)
+
+// WarmUpFailure 单个 bean 的预热失败
+type WarmUpFailure struct {
+	// Bean bean 名
+	Bean string
+	// Err 失败原因（panic 转换为错误）
+	Err error
+	// Duration 预热耗时
+	Duration time.Duration
+}
+
+// WarmUpError 预热失败（WarmUpFail 策略下 StartUp 返回的错误原因）
+type WarmUpError struct {
+	Failures []WarmUpFailure
+}
+
+// Error 实现 error 接口
+func (e *WarmUpError) Error() string {
+	parts := make([]string, 0, len(e.Failures))
+	for _, f := range e.Failures {
+		parts = append(parts, fmt.Sprintf("%s: %v", f.Bean, f.Err))
+	}
+	return fmt.Sprintf("[ioc233] 预热失败 (%d): %s", len(e.Failures), strings.Join(parts, "; "))
+}
+
+// Unwrap 返回各 bean 的失败原因，支持 errors.Is / errors.As
+func (e *WarmUpError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failures))
+	for _, f := range e.Failures {
+		errs = append(errs, f.Err)
+	}
+	return errs
+}
+
+// SetWarmUpPolicy 设置预热失败后的处理策略（默认 WarmUpContinue）
+func (c *Container) SetWarmUpPolicy(policy WarmUpPolicy) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.warmUpPolicy = policy
+}
+
+// warmUpLocked 并发执行 beans 中实现 IWarmUp 的 bean 的预热，返回失败列表（按 beans 顺序）（调用方需持有写锁）
+// ctx 结束时不再等待未完成的预热，返回包装了 ctx.Err() 的错误
+func (c *Container) warmUpLocked(ctx context.Context, beans []*beanDefinition) ([]WarmUpFailure, error) {
+	var defs []*beanDefinition
+	for _, def := range beans {
+		if _, ok := def.instance.(IWarmUp); ok {
+			defs = append(defs, def)
+		}
+	}
+	if len(defs) == 0 {
+		return nil, nil
+	}
+	logInfo("[ioc233] 开始预热: count=%d", len(defs))
+	results := make([]WarmUpFailure, len(defs))
+	var wg sync.WaitGroup
+	for i, def := range defs {
+		wg.Add(1)
+		go func(result *WarmUpFailure, def *beanDefinition) {
+			defer wg.Done()
+			began := time.Now()
+			defer func() {
+				if p := recover(); p != nil {
+					result.Err = fmt.Errorf("预热 panic: %v", p)
+				}
+				result.Bean, result.Duration = def.name, time.Since(began)
+			}()
+			result.Err = def.instance.(IWarmUp).WarmUp(ctx)
+		}(&results[i], def)
+	}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return nil, fmt.Errorf("%w: 预热未完成", ctx.Err())
+	}
+
+	var failures []WarmUpFailure
+	for _, r := range results {
+		if r.Err != nil {
+			logError("[ioc233] 预热失败: name=%s: %v", r.Bean, r.Err)
+			failures = append(failures, r)
+		}
+	}
+	if len(failures) > 0 && c.warmUpPolicy == WarmUpFail {
+		return failures, &WarmUpError{Failures: failures}
+	}
+	return failures, nil
+}
@@ -0,0 +1,461 @@
+package ioc233
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// WebSocket 消息类型（与 RFC 6455 的 opcode 一致）
+const (
+	TextMessage   = 1
+	BinaryMessage = 2
+)
+
+// WebSocket 帧 opcode
+const (
+	wsOpContinuation = 0x0
+	wsOpClose        = 0x8
+	wsOpPing         = 0x9
+	wsOpPong         = 0xA
+)
+
+// WebSocket 关闭码
+const (
+	wsCloseNormal        = 1000
+	wsCloseGoingAway     = 1001
+	wsCloseProtocolError = 1002
+	wsCloseTooBig        = 1009
+	wsCloseInternalError = 1011
+)
+
+// defaultWebSocketReadLimit 单条消息的默认最大字节数
+const defaultWebSocketReadLimit = 1 << 20
+
+// defaultWebSocketWriteTimeout 单次写入的默认超时（对端停止读取时写入不会永久阻塞）
+const defaultWebSocketWriteTimeout = 10 * time.Second
+
+// wsCloseTimeout 关闭时等待关闭帧写出的最长时间
+const wsCloseTimeout = time.Second
+
+// wsAcceptGUID 握手时拼接在 Sec-WebSocket-Key 后的固定 GUID
+const wsAcceptGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// ErrWebSocketClosed 连接已在本端关闭（Close 或容器关闭）后继续读写时返回
+var ErrWebSocketClosed = errors.New("[ioc233] WebSocket 连接已关闭")
+
+// IWebSocketController WebSocket 控制器接口
+// 通过 ProvideController 注册的控制器实现此接口时，自动登记路由 GET WebSocketPath() -> ServeWebSocket，
+// 由 HTTP 适配器完成协议升级；控制器与普通控制器一样参与注入与生命周期：
+//
+//	type LobbyController struct {
+//		Rooms *RoomService `autowire:"true"`
+//	}
+//
+//	func (c *LobbyController) WebSocketPath() string { return "/ws/lobby" }
+//
+//	func (c *LobbyController) ServeWebSocket(conn *ioc233.WebSocketConn) {
+//		for {
+//			_, msg, err := conn.ReadMessage()
+//			if err != nil {
+//				return
+//			}
+//			c.Rooms.Broadcast(msg)
+//		}
+//	}
+//
+// 也可以在 route 标签中声明签名为 func(*ioc233.WebSocketConn) 的处理方法，一个控制器可以有多个 WebSocket 端点
+type IWebSocketController interface {
+	// WebSocketPath WebSocket 端点路径，例如 /ws/lobby
+	WebSocketPath() string
+	// ServeWebSocket 处理一个已完成升级的连接，返回后连接关闭
+	ServeWebSocket(conn *WebSocketConn)
+}
+
+// IWebSocketOrigin 自定义 WebSocket 来源校验（控制器实现，作用于其全部 WebSocket 端点）
+// 未实现时只接受没有 Origin 头或 Origin 与 Host 一致的同源请求
+type IWebSocketOrigin interface {
+	CheckOrigin(r *http.Request) bool
+}
+
+// WebSocketConn 已完成升级的 WebSocket 服务端连接
+// ReadMessage 只能在一个协程中调用；WriteMessage 与 Close 可以并发调用
+type WebSocketConn struct {
+	conn      net.Conn
+	reader    *bufio.Reader
+	request   *http.Request
+	ctx       context.Context
+	cancel    context.CancelFunc
+	writeMu   sync.Mutex
+	closed    atomic.Bool
+	closeOnce sync.Once
+	readLimit int64
+	// writeTimeout 单次写入超时（纳秒，SetWriteTimeout）
+	writeTimeout atomic.Int64
+}
+
+// Request 返回发起升级的 HTTP 请求
+func (w *WebSocketConn) Request() *http.Request {
+	return w.request
+}
+
+// Context 返回连接的 ctx：连接关闭（包括容器关闭）时取消
+func (w *WebSocketConn) Context() context.Context {
+	return w.ctx
+}
+
+// RemoteAddr 返回对端地址
+func (w *WebSocketConn) RemoteAddr() net.Addr {
+	return w.conn.RemoteAddr()
+}
+
+// SetReadLimit 设置单条消息的最大字节数，超出时以 1009 关闭连接，<= 0 时使用默认值 1MB
+func (w *WebSocketConn) SetReadLimit(n int64) {
+	if n <= 0 {
+		n = defaultWebSocketReadLimit
+	}
+	w.readLimit = n
+}
+
+// SetWriteTimeout 设置单次写入的超时，对端停止读取时写入在超时后返回错误，<= 0 时使用默认值 10s
+func (w *WebSocketConn) SetWriteTimeout(d time.Duration) {
+	if d <= 0 {
+		d = defaultWebSocketWriteTimeout
+	}
+	w.writeTimeout.Store(int64(d))
+}
+
+// ReadMessage 读取一条完整消息（TextMessage 或 BinaryMessage），自动应答 ping 并合并分片
+// 对端正常关闭时返回 io.EOF，本端已关闭时返回 ErrWebSocketClosed
+func (w *WebSocketConn) ReadMessage() (messageType int, data []byte, err error) {
+	for {
+		fin, op, payload, err := w.readFrame()
+		if err != nil {
+			return 0, nil, w.readError(err)
+		}
+		switch op {
+		case wsOpPing:
+			if err := w.writeFrame(wsOpPong, payload); err != nil {
+				return 0, nil, err
+			}
+			continue
+		case wsOpPong:
+			continue
+		case wsOpClose:
+			code := wsCloseNormal
+			if len(payload) >= 2 {
+				code = int(binary.BigEndian.Uint16(payload))
+			}
+			w.closeWith(code, "")
+			return 0, nil, io.EOF
+		case TextMessage, BinaryMessage:
+			if messageType != 0 {
+				return 0, nil, w.fail(wsCloseProtocolError, "分片消息未结束时收到新消息")
+			}
+			messageType = int(op)
+		case wsOpContinuation:
+			if messageType == 0 {
+				return 0, nil, w.fail(wsCloseProtocolError, "收到没有起始帧的分片")
+			}
+		default:
+			return 0, nil, w.fail(wsCloseProtocolError, fmt.Sprintf("未知 opcode: %d", op))
+		}
+		if int64(len(data)+len(payload)) > w.readLimit {
+			return 0, nil, w.fail(wsCloseTooBig, fmt.Sprintf("消息超过 %d 字节", w.readLimit))
+		}
+		data = append(data, payload...)
+		if fin {
+			return messageType, data, nil
+		}
+	}
+}
+
+// WriteMessage 发送一条消息（TextMessage 或 BinaryMessage）
+func (w *WebSocketConn) WriteMessage(messageType int, data []byte) error {
+	if messageType != TextMessage && messageType != BinaryMessage {
+		return fmt.Errorf("[ioc233] WebSocket 消息类型非法: %d", messageType)
+	}
+	return w.writeFrame(byte(messageType), data)
+}
+
+// Close 发送关闭帧（1000）并关闭连接，可重复调用
+func (w *WebSocketConn) Close() error {
+	w.closeWith(wsCloseNormal, "")
+	return nil
+}
+
+// closeWith 发送带关闭码的关闭帧后关闭底层连接并取消 ctx（只执行一次）
+func (w *WebSocketConn) closeWith(code int, reason string) {
+	w.closeOnce.Do(func() {
+		// 先标记关闭并在加锁前设置写超时：对端不读取时，持有 writeMu 阻塞中的写入在超时后返回，不会阻塞关闭流程
+		w.closed.Store(true)
+		_ = w.conn.SetWriteDeadline(time.Now().Add(wsCloseTimeout))
+		w.writeMu.Lock()
+		payload := binary.BigEndian.AppendUint16(nil, uint16(code))
+		_ = w.writeFrameLocked(wsOpClose, append(payload, reason...))
+		w.writeMu.Unlock()
+		_ = w.conn.Close()
+		w.cancel()
+	})
+}
+
+// fail 以 code 关闭连接并返回协议错误
+func (w *WebSocketConn) fail(code int, reason string) error {
+	w.closeWith(code, "")
+	return fmt.Errorf("[ioc233] WebSocket 协议错误: %s", reason)
+}
+
+// readError 本端已关闭时将底层读错误转换为 ErrWebSocketClosed
+func (w *WebSocketConn) readError(err error) error {
+	if w.closed.Load() {
+		return ErrWebSocketClosed
+	}
+	return err
+}
+
+// readFrame 读取一帧并去掉掩码；客户端帧必须带掩码，控制帧不能分片且不超过 125 字节
+func (w *WebSocketConn) readFrame() (fin bool, op byte, payload []byte, err error) {
+	var head [2]byte
+	if _, err := io.ReadFull(w.reader, head[:]); err != nil {
+		return false, 0, nil, err
+	}
+	fin, op = head[0]&0x80 != 0, head[0]&0x0F
+	if head[0]&0x70 != 0 {
+		return false, 0, nil, w.fail(wsCloseProtocolError, "不支持扩展位")
+	}
+	if head[1]&0x80 == 0 {
+		return false, 0, nil, w.fail(wsCloseProtocolError, "客户端帧未带掩码")
+	}
+	size := uint64(head[1] & 0x7F)
+	switch size {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(w.reader, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		size = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(w.reader, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		size = binary.BigEndian.Uint64(ext[:])
+	}
+	if op >= wsOpClose && (!fin || size > 125) {
+		return false, 0, nil, w.fail(wsCloseProtocolError, "控制帧非法")
+	}
+	if size > uint64(w.readLimit) {
+		return false, 0, nil, w.fail(wsCloseTooBig, fmt.Sprintf("消息超过 %d 字节", w.readLimit))
+	}
+	var mask [4]byte
+	if _, err := io.ReadFull(w.reader, mask[:]); err != nil {
+		return false, 0, nil, err
+	}
+	payload = make([]byte, size)
+	if _, err := io.ReadFull(w.reader, payload); err != nil {
+		return false, 0, nil, err
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+	return fin, op, payload, nil
+}
+
+// writeFrame 写入一个不分片、不带掩码的帧，写入超时见 SetWriteTimeout
+// 先设置写超时再检查关闭标记：与 closeWith 并发时，关闭流程设置的较短超时不会被覆盖
+func (w *WebSocketConn) writeFrame(op byte, payload []byte) error {
+	w.writeMu.Lock()
+	defer w.writeMu.Unlock()
+	_ = w.conn.SetWriteDeadline(time.Now().Add(time.Duration(w.writeTimeout.Load())))
+	if w.closed.Load() {
+		return ErrWebSocketClosed
+	}
+	return w.writeFrameLocked(op, payload)
+}
+
+// writeFrameLocked writeFrame 的内部实现（调用方需持有 writeMu）
+func (w *WebSocketConn) writeFrameLocked(op byte, payload []byte) error {
+	frame := []byte{0x80 | op}
+	switch n := len(payload); {
+	case n <= 125:
+		frame = append(frame, byte(n))
+	case n <= 0xFFFF:
+		frame = binary.BigEndian.AppendUint16(append(frame, 126), uint16(n))
+	default:
+		frame = binary.BigEndian.AppendUint64(append(frame, 127), uint64(n))
+	}
+	_, err := w.conn.Write(append(frame, payload...))
+	return err
+}
+
+// webSocketHub 容器持有的 WebSocket 连接（容器关闭时以 1001 关闭）
+// 使用独立的锁：连接在请求协程中登记，不能等待关闭流程持有的容器写锁
+type webSocketHub struct {
+	mutex  sync.Mutex
+	conns  map[*WebSocketConn]struct{}
+	closed bool
+}
+
+// add 登记连接，容器已关闭时返回 false
+func (h *webSocketHub) add(conn *WebSocketConn) bool {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if h.closed {
+		return false
+	}
+	if h.conns == nil {
+		h.conns = make(map[*WebSocketConn]struct{})
+	}
+	h.conns[conn] = struct{}{}
+	return true
+}
+
+// isClosed 返回容器是否已关闭全部连接
+func (h *webSocketHub) isClosed() bool {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.closed
+}
+
+func (h *webSocketHub) remove(conn *WebSocketConn) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	delete(h.conns, conn)
+}
+
+// closeAll 关闭全部连接，此后的升级请求被拒绝；连接在处理方法返回后才移除（见 active）
+func (h *webSocketHub) closeAll() {
+	h.mutex.Lock()
+	h.closed = true
+	conns := make([]*WebSocketConn, 0, len(h.conns))
+	for conn := range h.conns {
+		conns = append(conns, conn)
+	}
+	h.mutex.Unlock()
+	if len(conns) > 0 {
+		logInfo("[ioc233] 关闭 WebSocket 连接: %d", len(conns))
+	}
+	for _, conn := range conns {
+		conn.closeWith(wsCloseGoingAway, "server shutdown")
+	}
+}
+
+// active 返回处理方法仍未返回的连接的请求路径
+func (h *webSocketHub) active() []string {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	paths := make([]string, 0, len(h.conns))
+	for conn := range h.conns {
+		paths = append(paths, conn.request.URL.Path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// WebSocketHandler 返回完成 WebSocket 升级后调用 serve 的 http.Handler，serve 返回后连接关闭
+// controller 实现 IWebSocketOrigin 时使用其来源校验（可为 nil）；连接由容器持有，容器关闭时以 1001 关闭，此后的升级请求返回 503
+// 通常不需要直接调用：MountServeMux 与 echoioc 对 func(*WebSocketConn) 处理方法自动使用
+func (c *Container) WebSocketHandler(controller any, serve func(conn *WebSocketConn)) http.Handler {
+	checkOrigin := sameOrigin
+	if o, ok := controller.(IWebSocketOrigin); ok {
+		checkOrigin = o.CheckOrigin
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if c.webSockets.isClosed() {
+			http.Error(w, "websocket: server shutting down", http.StatusServiceUnavailable)
+			return
+		}
+		conn, err := upgradeWebSocket(w, r, checkOrigin)
+		if err != nil {
+			logWarn("[ioc233] WebSocket 升级失败: %s %s: %v", r.Method, r.URL.Path, err)
+			return
+		}
+		if !c.webSockets.add(conn) {
+			conn.closeWith(wsCloseGoingAway, "server shutdown")
+			return
+		}
+		defer c.webSockets.remove(conn)
+		defer func() {
+			if p := recover(); p != nil {
+				logError("[ioc233] WebSocket 处理 panic: %s: %v", r.URL.Path, p)
+				conn.closeWith(wsCloseInternalError, "")
+			}
+			conn.Close()
+		}()
+		serve(conn)
+	})
+}
+
+// upgradeWebSocket 校验握手请求并接管连接；失败时已写入 HTTP 错误响应
+func upgradeWebSocket(w http.ResponseWriter, r *http.Request, checkOrigin func(*http.Request) bool) (*WebSocketConn, error) {
+	fail := func(status int, msg string) (*WebSocketConn, error) {
+		http.Error(w, msg, status)
+		return nil, errors.New(msg)
+	}
+	switch {
+	case r.Method != http.MethodGet:
+		return fail(http.StatusMethodNotAllowed, "websocket: method not allowed")
+	case !headerHasToken(r.Header, "Connection", "upgrade") || !headerHasToken(r.Header, "Upgrade", "websocket"):
+		return fail(http.StatusBadRequest, "websocket: not a websocket handshake")
+	case r.Header.Get("Sec-WebSocket-Version") != "13":
+		w.Header().Set("Sec-WebSocket-Version", "13")
+		return fail(http.StatusUpgradeRequired, "websocket: unsupported version")
+	case r.Header.Get("Sec-WebSocket-Key") == "":
+		return fail(http.StatusBadRequest, "websocket: missing Sec-WebSocket-Key")
+	case !checkOrigin(r):
+		return fail(http.StatusForbidden, "websocket: origin not allowed")
+	}
+	netConn, rw, err := http.NewResponseController(w).Hijack()
+	if err != nil {
+		return fail(http.StatusInternalServerError, "websocket: hijack not supported")
+	}
+	accept := sha1.Sum([]byte(r.Header.Get("Sec-WebSocket-Key") + wsAcceptGUID))
+	response := "HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + base64.StdEncoding.EncodeToString(accept[:]) + "\r\n\r\n"
+	if _, err := netConn.Write([]byte(response)); err != nil {
+		netConn.Close()
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(r.Context())
+	conn := &WebSocketConn{
+		conn: netConn, reader: rw.Reader, request: r,
+		ctx: ctx, cancel: cancel, readLimit: defaultWebSocketReadLimit,
+	}
+	conn.writeTimeout.Store(int64(defaultWebSocketWriteTimeout))
+	return conn, nil
+}
+
+// sameOrigin 默认来源校验：没有 Origin 头或 Origin 的主机与请求 Host 一致
+func sameOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	return err == nil && strings.EqualFold(u.Host, r.Host)
+}
+
+// headerHasToken 判断逗号分隔的请求头中是否包含 token（不区分大小写）
+func headerHasToken(h http.Header, key, token string) bool {
+	for _, v := range h.Values(key) {
+		for _, t := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(t), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,316 @@
+package ioc233
+
+import (
+	"errors"
+	"fmt"
+	"go/format"
+	"go/token"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// wireImportPath Google Wire 的导入路径
+const wireImportPath = "github.com/google/wire"
+
+// GenerateWireSet 根据容器的注册信息生成 Google Wire provider set 源码（var ProviderSet = wire.NewSet(...)），
+// 便于性能敏感的项目从反射装配迁移到编译期装配
+// 参数 pkgPath/pkgName 为生成文件所在包的导入路径与包名（同包的类型与函数不加限定）
+// 生成规则：
+//   - ProvideFactory/ProvideDerived/ProvidePrototype 注册的函数按函数名引用为 provider
+//   - Provide/ProvideByName 注册的结构体指针生成 wire.Struct，字段取 autowire 注入字段
+//   - Bind 的显式绑定与接口注入字段/参数用到的实现生成 wire.Bind
+//
+// 无法在 Wire 中表达的注册（匿名函数、值 bean、同类型多个 bean、按名称注入、切片注入、dig 参数/结果对象等）
+// 不会生成代码，而是列在 ProviderSet 的注释中，需要手工迁移。
+// 通常在 go:generate 调用的小程序中，对装配完成的容器调用
+func (c *Container) GenerateWireSet(w io.Writer, pkgPath, pkgName string) error {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	g := &wireGen{
+		proxyGen: proxyGen{selfPath: pkgPath, imports: map[string]string{wireImportPath: "wire"}},
+		provided: make(map[reflect.Type]bool),
+		bound:    make(map[reflect.Type]bool),
+	}
+	g.addFactories(c.factories)
+	for _, d := range c.derivedList {
+		g.addFunc(d.fn, d.name, []reflect.Type{d.out}, d.in, "")
+	}
+	for _, p := range c.prototypes {
+		g.addFunc(p.fn, p.name, []reflect.Type{p.out}, p.in, "原型作用域在 Wire 中每个 injector 只构造一次")
+	}
+	g.addBeans(c.beans)
+	if len(g.entries) == 0 {
+		return errors.New("[ioc233] GenerateWireSet 没有可导出的注册")
+	}
+	if err := g.addBindings(c.bindings); err != nil {
+		return err
+	}
+
+	var out strings.Builder
+	out.WriteString("// Code generated by ioc233.GenerateWireSet. DO NOT EDIT.\n\n")
+	out.WriteString("package " + pkgName + "\n\n")
+	out.WriteString("import (\n")
+	paths := make([]string, 0, len(g.imports))
+	for p := range g.imports {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		fmt.Fprintf(&out, "\t%s %s\n", g.imports[p], strconv.Quote(p))
+	}
+	out.WriteString(")\n\n")
+	out.WriteString("// ProviderSet 由 ioc233 容器注册信息生成的 Wire provider set\n")
+	if len(g.todos) > 0 {
+		out.WriteString("//\n// 以下注册需要手工迁移：\n")
+		for _, todo := range g.todos {
+			out.WriteString("//   - " + todo + "\n")
+		}
+	}
+	out.WriteString("var ProviderSet = wire.NewSet(\n")
+	for _, e := range g.entries {
+		out.WriteString("\t" + e + ",\n")
+	}
+	out.WriteString(")\n")
+
+	src, err := format.Source([]byte(out.String()))
+	if err != nil {
+		return fmt.Errorf("[ioc233] GenerateWireSet 生成代码格式化失败: %w", err)
+	}
+	_, err = w.Write(src)
+	return err
+}
+
+// wireGen Wire provider set 生成上下文
+type wireGen struct {
+	proxyGen
+	// entries wire.NewSet 的参数
+	entries []string
+	// todos 需要手工迁移的注册说明
+	todos []string
+	// provided 已有 provider 的类型
+	provided map[reflect.Type]bool
+	// bound 已生成 wire.Bind 的接口
+	bound map[reflect.Type]bool
+	// needs provider 依赖的类型（用于推导接口绑定）
+	needs []reflect.Type
+}
+
+// todo 记录一条需要手工迁移的注册
+func (g *wireGen) todo(format string, args ...any) {
+	g.todos = append(g.todos, fmt.Sprintf(format, args...))
+}
+
+// addFactories 导出构造函数；结果对象与多返回值无法表达为单个 Wire provider
+func (g *wireGen) addFactories(factories []*factoryDefinition) {
+	for _, f := range factories {
+		digStyle := false
+		for _, out := range f.outputs {
+			digStyle = digStyle || out.field >= 0
+		}
+		for _, in := range f.in {
+			digStyle = digStyle || embedsDigMarker(in, "In")
+		}
+		switch {
+		case digStyle:
+			g.todo("%s: dig 参数对象/结果对象", f.name)
+		case len(f.outs) > 1:
+			g.todo("%s: 返回多个值（Wire provider 只能提供一个类型）", f.name)
+		default:
+			g.addFunc(f.fn, f.name, f.outs, f.in, "")
+		}
+	}
+}
+
+// addFunc 将函数登记为 provider
+func (g *wireGen) addFunc(fn reflect.Value, name string, outs, in []reflect.Type, note string) {
+	ref, ok := g.funcRef(fn)
+	if !ok {
+		g.todo("%s: 匿名函数或方法无法在生成代码中引用", name)
+		return
+	}
+	if note != "" {
+		g.todo("%s: %s", name, note)
+	}
+	g.entries = append(g.entries, ref)
+	for _, out := range outs {
+		g.provided[out] = true
+		if fields := injectedFieldNames(out); len(fields) > 0 {
+			g.todo("%s: 返回的 %v 还依赖字段注入 %v（需在函数中完成）", name, out, fields)
+		}
+	}
+	g.needs = append(g.needs, in...)
+}
+
+// addBeans 将结构体指针 bean 导出为 wire.Struct；已由函数提供的类型跳过
+func (g *wireGen) addBeans(beans []*beanDefinition) {
+	seen := make(map[reflect.Type]bool, len(beans))
+	var structs []*beanDefinition
+	for _, def := range beans {
+		t := def.typ
+		switch {
+		case g.provided[t] && !seen[t]:
+			// 构造函数/派生/原型的产物
+		case seen[t]:
+			g.todo("%s: 与其他 bean 同为 %v 类型（Wire 按类型解析）", def.name, t)
+		case t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Struct || t.Elem().Name() == "":
+			g.todo("%s: 值 bean（%v）需手写 wire.Value 或 provider", def.name, t)
+		default:
+			structs = append(structs, def)
+		}
+		seen[t] = true
+	}
+	for _, def := range structs {
+		g.provided[def.typ] = true
+	}
+	for _, def := range structs {
+		expr, err := g.typeExpr(def.typ.Elem())
+		if err != nil {
+			g.todo("%s: %v", def.name, err)
+			continue
+		}
+		args := []string{"new(" + expr + ")"}
+		for _, field := range g.structFields(def) {
+			args = append(args, strconv.Quote(field))
+		}
+		g.entries = append(g.entries, "wire.Struct("+strings.Join(args, ", ")+")")
+	}
+}
+
+// structFields 返回 wire.Struct 注入的字段：autowire:"true" 的字段，以及有 provider 可满足的 autowire:"false" 字段
+func (g *wireGen) structFields(def *beanDefinition) []string {
+	t := def.typ.Elem()
+	var fields []string
+	for _, field := range injectableFields(t) {
+		tag := autowireTag(field)
+		switch {
+		case tag == "":
+			continue
+		case !field.IsExported():
+			g.todo("%s.%s: 不可导出的注入字段", def.name, field.Name)
+		case len(field.Index) > 1:
+			g.todo("%s.%s: 嵌入结构体中的注入字段（wire.Struct 不支持提升字段）", def.name, field.Name)
+		case field.Type.Kind() == reflect.Slice:
+			g.todo("%s.%s: 切片注入（Wire 不支持多重绑定）", def.name, field.Name)
+		case tag == "true":
+			fields = append(fields, field.Name)
+			g.needs = append(g.needs, field.Type)
+		case tag == "false":
+			if g.satisfiable(field.Type) {
+				fields = append(fields, field.Name)
+				g.needs = append(g.needs, field.Type)
+			}
+		case tag == autowireNew:
+			if g.satisfiable(field.Type) {
+				fields = append(fields, field.Name)
+				g.needs = append(g.needs, field.Type)
+			} else {
+				g.todo("%s.%s: 自动创建 %v（autowire:\"new\"）", def.name, field.Name, field.Type)
+			}
+		default:
+			g.todo("%s.%s: 按名称注入 %q", def.name, field.Name, tag)
+		}
+	}
+	return fields
+}
+
+// addBindings 生成显式绑定与依赖中用到的接口绑定
+func (g *wireGen) addBindings(bindings map[reflect.Type]reflect.Type) error {
+	ifaces := make([]reflect.Type, 0, len(bindings))
+	for iface := range bindings {
+		ifaces = append(ifaces, iface)
+	}
+	sort.Slice(ifaces, func(i, j int) bool { return ifaces[i].String() < ifaces[j].String() })
+	for _, iface := range ifaces {
+		if err := g.bind(iface, bindings[iface]); err != nil {
+			return err
+		}
+	}
+	for _, need := range g.needs {
+		if need.Kind() != reflect.Interface || need == contextType || g.provided[need] || g.bound[need] {
+			continue
+		}
+		if impl := g.implementation(need); impl != nil {
+			if err := g.bind(need, impl); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// bind 生成 wire.Bind(new(iface), new(impl))
+func (g *wireGen) bind(iface, impl reflect.Type) error {
+	ie, err := g.typeExpr(iface)
+	if err != nil {
+		return err
+	}
+	te, err := g.typeExpr(impl)
+	if err != nil {
+		return err
+	}
+	g.bound[iface] = true
+	g.entries = append(g.entries, fmt.Sprintf("wire.Bind(new(%s), new(%s))", ie, te))
+	return nil
+}
+
+// implementation 返回实现接口的第一个已提供类型（按类型名排序，保证输出稳定）
+func (g *wireGen) implementation(iface reflect.Type) reflect.Type {
+	candidates := make([]reflect.Type, 0, len(g.provided))
+	for t := range g.provided {
+		if t.Kind() != reflect.Interface && t.Implements(iface) {
+			candidates = append(candidates, t)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].String() < candidates[j].String() })
+	return candidates[0]
+}
+
+// satisfiable 判断类型能否由已提供的类型满足
+func (g *wireGen) satisfiable(t reflect.Type) bool {
+	if g.provided[t] {
+		return true
+	}
+	return t.Kind() == reflect.Interface && g.implementation(t) != nil
+}
+
+// funcRef 返回顶层函数在生成代码中的引用（匿名函数、方法值、main 包函数返回 false）
+func (g *wireGen) funcRef(fn reflect.Value) (string, bool) {
+	full := funcName(fn)
+	slash := strings.LastIndex(full, "/")
+	dot := strings.Index(full[slash+1:], ".")
+	if dot < 0 {
+		return "", false
+	}
+	path, ident := full[:slash+1+dot], full[slash+1+dot+1:]
+	if !token.IsIdentifier(ident) {
+		return "", false
+	}
+	if path == g.selfPath {
+		return ident, true
+	}
+	if path == "main" || !token.IsExported(ident) {
+		return "", false
+	}
+	return g.aliasFor(path, path[slash+1:]) + "." + ident, true
+}
+
+// injectedFieldNames 返回结构体指针类型中带注入标签的字段名
+func injectedFieldNames(t reflect.Type) []string {
+	if t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	var names []string
+	for i := 0; i < t.Elem().NumField(); i++ {
+		if field := t.Elem().Field(i); autowireTag(field) != "" {
+			names = append(names, field.Name)
+		}
+	}
+	return names
+}
@@ -65,6 +65,22 @@ $newVersion | Out-File $versionFile -Encoding UTF8
 Write-Host "Updated version.txt to $newVersion"
 Write-Host ""
 
+# Submodules (ioc233/prom, ioc233/echoioc, ...) are released together with the root module:
+# each one requires the root module at the same version and is tagged as <dir>/vX.Y.Z
+$rootModule = "github.com/neko233-com/ioc233-go"
+$rootDir = (Get-Location).Path
+$subModules = Get-ChildItem -Path ioc233 -Recurse -Filter go.mod | ForEach-Object {
+    $_.DirectoryName.Substring($rootDir.Length + 1).Replace('\', '/')
+}
+foreach ($dir in $subModules) {
+    Write-Host "Updating $dir/go.mod to require $rootModule $newVersion" -ForegroundColor Yellow
+    go mod edit -require="$rootModule@$newVersion" "$dir/go.mod"
+    if ($LASTEXITCODE -ne 0) {
+        Write-Error "Failed to update $dir/go.mod"
+        exit 1
+    }
+}
+
 # Commit version.txt change
 Write-Host "Committing version.txt..." -ForegroundColor Yellow
 git add $versionFile
@@ -72,6 +88,13 @@ if ($LASTEXITCODE -ne 0) {
     Write-Error "Failed to stage version.txt"
     exit 1
 }
+foreach ($dir in $subModules) {
+    git add "$dir/go.mod"
+    if ($LASTEXITCODE -ne 0) {
+        Write-Error "Failed to stage $dir/go.mod"
+        exit 1
+    }
+}
 
 git commit -m "chore: bump version to $newVersion"
 if ($LASTEXITCODE -ne 0) {
@@ -97,19 +120,32 @@ if ($LASTEXITCODE -ne 0) {
     exit 1
 }
 
-# Push tag
-Write-Host "Pushing tag to remote..." -ForegroundColor Yellow
-git push origin $Version
+# Tag submodules at the same commit (Go resolves ioc233/prom@vX.Y.Z from the tag ioc233/prom/vX.Y.Z)
+$tags = @($Version)
+foreach ($dir in $subModules) {
+    $subTag = "$dir/$Version"
+    Write-Host "Creating git tag $subTag..." -ForegroundColor Yellow
+    git tag -a $subTag -m "Release $subTag"
+    if ($LASTEXITCODE -ne 0) {
+        Write-Error "Failed to create git tag $subTag"
+        exit 1
+    }
+    $tags += $subTag
+}
+
+# Push tags
+Write-Host "Pushing tags to remote..." -ForegroundColor Yellow
+git push origin $tags
 if ($LASTEXITCODE -ne 0) {
-    Write-Error "Failed to push tag"
+    Write-Error "Failed to push tags"
     exit 1
 }
 
 # Check if github remote exists and push to it
 $githubRemote = git remote get-url github 2>$null
 if ($LASTEXITCODE -eq 0) {
-    Write-Host "Pushing tag to github remote..." -ForegroundColor Yellow
-    git push github $Version
+    Write-Host "Pushing tags to github remote..." -ForegroundColor Yellow
+    git push github $tags
     if ($LASTEXITCODE -ne 0) {
         Write-Error "Failed to push tag to github"
         exit 1
@@ -0,0 +1,187 @@
+package tests
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 框架适配器测试 ====================
+
+// frameworkHandler 模拟外部框架自行创建的对象
+type frameworkHandler struct {
+	Users UserService `autowire:"true"`
+}
+
+type adapterDestroyBean struct {
+	events *[]string
+}
+
+func (b *adapterDestroyBean) OnDestroy() { *b.events = append(*b.events, "destroy") }
+
+type missingDependencyHandler struct {
+	Mail *MailSender `autowire:"true"`
+}
+
+func TestAdapter_ResolveAndRange(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&UserServiceImpl{ID: 7})
+	_ = c.ProvideByName("orders", &OrderServiceImpl{})
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	adapter := c.Adapter()
+
+	obj, ok := adapter.Resolve(reflect.TypeOf((*UserService)(nil)).Elem())
+	if !ok || obj.(UserService).GetUser(1) == "" {
+		t.Fatal("Resolve 应该按接口类型解析到实现")
+	}
+	users, ok := ioc233.ResolveAs[UserService](adapter)
+	if !ok || users == nil {
+		t.Fatal("ResolveAs 应该解析到实现")
+	}
+	if orders, ok := ioc233.ResolveByNameAs[*OrderServiceImpl](adapter, "orders"); !ok || orders == nil {
+		t.Fatal("ResolveByNameAs 应该按名称解析")
+	}
+	if _, ok := adapter.ResolveByName("missing"); ok {
+		t.Error("不存在的名称不应该解析成功")
+	}
+
+	var names []string
+	adapter.Range(func(name string, _ any) bool {
+		names = append(names, name)
+		return true
+	})
+	if len(names) != 2 || names[1] != "orders" {
+		t.Errorf("Range 应该按注册顺序遍历, 实际: %v", names)
+	}
+}
+
+func TestAdapter_InjectExternalObject(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&UserServiceImpl{ID: 1})
+	_ = c.StartUp()
+	adapter := c.Adapter()
+
+	h := &frameworkHandler{}
+	if err := adapter.Inject(h); err != nil || h.Users == nil {
+		t.Fatalf("Inject 应该注入外部对象的字段, 错误: %v", err)
+	}
+	if ioc233.GetObjectByTypeFrom[*frameworkHandler](c) != nil {
+		t.Error("Inject 不应该把对象注册到容器")
+	}
+	if err := adapter.Inject(&missingDependencyHandler{}); err == nil {
+		t.Error("必须注入的依赖缺失时 Inject 应该返回错误")
+	}
+	if err := adapter.Inject(frameworkHandler{}); err == nil {
+		t.Error("非指针目标应该返回错误")
+	}
+}
+
+func TestAdapter_LifecycleHooks(t *testing.T) {
+	c := ioc233.NewContainer()
+	var events []string
+	c.Provide(&adapterDestroyBean{events: &events})
+	adapter := c.Adapter()
+
+	adapter.OnStarted(func() { events = append(events, "started") })
+	adapter.OnStopping(func() { events = append(events, "stopping-1") })
+	adapter.OnStopping(func() { events = append(events, "stopping-2") })
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	adapter.OnStarted(func() { events = append(events, "late-started") })
+	_ = c.Close()
+
+	want := []string{"started", "late-started", "stopping-2", "stopping-1", "destroy"}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("钩子执行顺序错误, 期望: %v, 实际: %v", want, events)
+	}
+}
+
+// blockingStartupBean OnInjectComplete 阻塞到 release 关闭
+type blockingStartupBean struct {
+	entered chan struct{}
+	release chan struct{}
+}
+
+func (b *blockingStartupBean) OnInjectComplete() {
+	close(b.entered)
+	<-b.release
+}
+
+func TestAdapter_OtherGoroutinesWaitForStartUp(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.SetQuietStartup(true)
+	bean := &blockingStartupBean{entered: make(chan struct{}), release: make(chan struct{})}
+	c.Provide(bean)
+	startErr := make(chan error, 1)
+	go func() { startErr <- c.StartUp() }()
+	<-bean.entered
+
+	// StartUp 持有写锁期间，其他协程的访问必须等待，而不是跳过锁读写容器
+	ranged := make(chan int, 1)
+	go func() {
+		n := 0
+		c.Adapter().Range(func(string, any) bool { n++; return true })
+		ranged <- n
+	}()
+	hooked := make(chan struct{})
+	go c.Adapter().OnStarted(func() { close(hooked) })
+	select {
+	case <-ranged:
+		t.Fatal("StartUp 回调执行期间其他协程不应该绕过容器锁")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(bean.release)
+	if err := <-startErr; err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	if n := <-ranged; n != 1 {
+		t.Errorf("StartUp 结束后 Range 应该看到 1 个 bean, 实际: %d", n)
+	}
+	select {
+	case <-hooked:
+	case <-time.After(time.Second):
+		t.Error("启动期间注册的 OnStarted 钩子应该被执行")
+	}
+}
+
+func TestAdapter_HooksRegisteredDuringLifecycleRunOnce(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.SetQuietStartup(true)
+	c.Provide(&adapterDestroyBean{events: &[]string{}})
+	adapter := c.Adapter()
+
+	const n = 50
+	var started, stopping atomic.Int32
+	var wg sync.WaitGroup
+	wg.Add(2 * n)
+	for i := 0; i < n; i++ {
+		go func() {
+			defer wg.Done()
+			adapter.OnStarted(func() { started.Add(1) })
+		}()
+		go func() {
+			defer wg.Done()
+			adapter.OnStopping(func() { stopping.Add(1) })
+		}()
+	}
+	// 钩子与 StartUp/Close 并发注册：无论落在取出钩子之前还是之后，都必须恰好执行一次
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	c.Close()
+	wg.Wait()
+	if got := started.Load(); got != n {
+		t.Errorf("OnStarted 钩子应该全部执行一次, 期望: %d, 实际: %d", n, got)
+	}
+	if got := stopping.Load(); got != n {
+		t.Errorf("OnStopping 钩子应该全部执行一次, 期望: %d, 实际: %d", n, got)
+	}
+}
@@ -0,0 +1,156 @@
+package tests
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 注入审计测试 ====================
+
+type PaymentChannel interface {
+	Channel() string
+}
+
+type StripeChannel struct{}
+
+func (s *StripeChannel) Channel() string { return "stripe" }
+
+type AdyenChannel struct{}
+
+func (a *AdyenChannel) Channel() string { return "adyen" }
+
+type PaymentRouter struct {
+	Ledger   *StockLedger               `autowire:"true"`
+	Fallback PaymentChannel             `autowire:"adyen"`
+	All      []PaymentChannel           `autowire:"true"`
+	Cache    ioc233.Lazy[*PricingCache] `autowire:"true"`
+}
+
+type RefundHandler struct {
+	Router *PaymentRouter `autowire:"true"`
+}
+
+type PricingConsumer struct {
+	Ledger *StockLedger `autowire:"true"`
+}
+
+func auditOf(c *ioc233.Container, field string) []ioc233.InjectionRecord {
+	var out []ioc233.InjectionRecord
+	for _, r := range c.InjectionAudit() {
+		if r.Field == field {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+func TestInjectionAudit_RecordsSourceAndMode(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&StockLedger{})
+	c.Provide(&PricingCache{})
+	c.ProvideByName("stripe", &StripeChannel{})
+	c.ProvideByName("adyen", &AdyenChannel{})
+	c.ProvideByName("router", &PaymentRouter{})
+	c.SetInjectionAudit(100)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	defer c.Close()
+
+	records := c.InjectionAudit()
+	if len(records) != 3 {
+		t.Fatalf("懒加载字段解析前不应该记录, 实际: %v", records)
+	}
+	if r := records[0]; r.String() != "PaymentRouter.Ledger <- StockLedger (type)" || r.OwnerBean != "router" || r.At.IsZero() {
+		t.Errorf("按类型注入应该记录来源 bean, 实际: %+v", r)
+	}
+	if r := records[1]; r.Mode != ioc233.EdgeByName || len(r.Sources) != 1 || r.Sources[0] != "adyen" {
+		t.Errorf("按名称注入应该记录来源 bean, 实际: %+v", r)
+	}
+	if r := records[2]; r.Mode != ioc233.EdgeSlice || len(r.Sources) != 2 || r.Sources[0] != "stripe" || r.Sources[1] != "adyen" {
+		t.Errorf("切片注入应该记录全部来源, 实际: %+v", r)
+	}
+
+	router := ioc233.GetObjectByTypeFrom[*PaymentRouter](c)
+	router.Cache.Get()
+	if lazy := auditOf(c, "Cache"); len(lazy) != 1 || lazy[0].String() != "PaymentRouter.Cache <- PricingCache (type, lazy)" {
+		t.Errorf("懒加载字段应该在首次解析时记录, 实际: %v", lazy)
+	}
+}
+
+func TestInjectionAudit_UnmanagedOwnerAndParentSource(t *testing.T) {
+	parent := ioc233.NewContainer()
+	parent.Provide(&StockLedger{})
+	parent.Provide(&PricingCache{})
+	parent.ProvideByName("adyen", &AdyenChannel{})
+	parent.ProvideByName("router", &PaymentRouter{})
+	if err := parent.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	defer parent.Close()
+
+	child := parent.NewChild()
+	child.SetInjectionAudit(100)
+	handler := &RefundHandler{}
+	if err := child.Adapter().Inject(handler); err != nil {
+		t.Fatalf("注入应该成功, 错误: %v", err)
+	}
+	records := child.InjectionAudit()
+	if len(records) != 1 {
+		t.Fatalf("子容器应该只记录自己执行的注入, 实际: %v", records)
+	}
+	if r := records[0]; r.OwnerBean != "" || r.Owner != "RefundHandler" || r.Sources[0] != "router" {
+		t.Errorf("非托管对象的 OwnerBean 应该为空，来自父容器的 bean 应该给出 bean 名, 实际: %+v", r)
+	}
+}
+
+func TestInjectionAudit_OptInAndBounded(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.SetQuietStartup(true)
+	c.Provide(&StockLedger{})
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	defer c.Close()
+
+	if err := c.Adapter().Inject(&PricingConsumer{}); err != nil {
+		t.Fatalf("注入应该成功, 错误: %v", err)
+	}
+	if records := c.InjectionAudit(); len(records) != 0 {
+		t.Fatalf("未开启审计时不应该记录, 实际: %v", records)
+	}
+
+	c.SetInjectionAudit(2)
+	collected := make(chan struct{})
+	for i := 0; i < 5; i++ {
+		owner := &PricingConsumer{}
+		if i == 0 {
+			runtime.SetFinalizer(owner, func(*PricingConsumer) { close(collected) })
+		}
+		if err := c.Adapter().Inject(owner); err != nil {
+			t.Fatalf("注入应该成功, 错误: %v", err)
+		}
+	}
+	records := c.InjectionAudit()
+	if len(records) != 2 || records[1].String() != "PricingConsumer.Ledger <- StockLedger (type)" {
+		t.Fatalf("应该只保留最近 2 条记录, 实际: %v", records)
+	}
+
+	// 记录只保存名称，不应该让被注入的对象无法回收
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		runtime.GC()
+		select {
+		case <-collected:
+			return
+		default:
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("审计记录不应该持有消费方实例的引用")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,81 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== autowire:"new" 自动创建测试 ====================
+
+type NewLeafCache struct {
+	Entries map[string]string
+	Users   UserService `autowire:"true"`
+}
+
+type NewLeafClock struct {
+	Ticks int `default:"1"`
+}
+
+type NewLeafRepo struct {
+	Cache *NewLeafCache `autowire:"new"`
+	Clock *NewLeafClock `autowire:"new"`
+}
+
+type NewLeafService struct {
+	Repo  *NewLeafRepo  `autowire:"new"`
+	Cache *NewLeafCache `autowire:"new"`
+}
+
+func TestAutowireNew_CreatesAndInjectsMissingBeans(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&UserServiceImpl{})
+	svc := &NewLeafService{}
+	c.Provide(svc)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+
+	if svc.Repo == nil || svc.Cache == nil {
+		t.Fatalf("缺失的结构体指针应该被自动创建, 实际: %+v", svc)
+	}
+	if svc.Repo.Cache != svc.Cache {
+		t.Error("自动创建的实例应该注册为单例，供其他字段复用")
+	}
+	if svc.Cache.Entries == nil || svc.Cache.Users == nil {
+		t.Error("自动创建的实例应该完成基础字段初始化与依赖注入")
+	}
+	if svc.Repo.Clock == nil || svc.Repo.Clock.Ticks != 1 {
+		t.Error("自动创建的实例中的 autowire:\"new\" 字段应该被递归创建")
+	}
+	if ioc233.GetObjectByTypeFrom[*NewLeafRepo](c) != svc.Repo {
+		t.Error("自动创建的实例应该可以按类型获取")
+	}
+}
+
+func TestAutowireNew_PrefersExistingBean(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&UserServiceImpl{})
+	cache := &NewLeafCache{}
+	c.Provide(cache)
+	svc := &NewLeafService{}
+	c.Provide(svc)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	if svc.Cache != cache || svc.Repo.Cache != cache {
+		t.Error("已注册的 bean 应该优先于自动创建")
+	}
+}
+
+type NewOnInterface struct {
+	Users UserService `autowire:"new"`
+}
+
+func TestAutowireNew_RejectsNonStructPointer(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&NewOnInterface{})
+	if errs := c.Validate(); len(errs) == 0 {
+		t.Fatal("autowire:\"new\" 用于接口字段时应该报告错误")
+	}
+}
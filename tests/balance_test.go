@@ -0,0 +1,93 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 负载均衡注入测试 ====================
+
+type ShardClient interface {
+	Shard() string
+}
+
+type Shard struct {
+	ID     string
+	Down   bool
+	Weigh  int
+	served int
+}
+
+func (s *Shard) Shard() string   { s.served++; return s.ID }
+func (s *Shard) Available() bool { return !s.Down }
+func (s *Shard) Weight() int     { return s.Weigh }
+
+type shardProxy struct {
+	target func() ShardClient
+}
+
+func (p *shardProxy) Shard() string { return p.target().Shard() }
+
+func init() {
+	ioc233.RegisterProxy[ShardClient](func(target func() ShardClient) ShardClient {
+		return &shardProxy{target: target}
+	})
+}
+
+type RoundRobinUser struct {
+	Client ShardClient `autowire:"true" balance:"round-robin"`
+}
+
+type WeightedUser struct {
+	Client ShardClient `autowire:"true" balance:"weighted"`
+}
+
+func provideShards(t *testing.T, c *ioc233.Container, shards ...*Shard) {
+	for _, s := range shards {
+		if err := c.ProvideByName("shard-"+s.ID, s); err != nil {
+			t.Fatalf("注册应该成功, 错误: %v", err)
+		}
+	}
+}
+
+func TestBalance_RoundRobinSkipsUnavailable(t *testing.T) {
+	c := ioc233.NewContainer()
+	a, b, down := &Shard{ID: "a", Weigh: 1}, &Shard{ID: "b", Weigh: 1}, &Shard{ID: "x", Down: true, Weigh: 1}
+	provideShards(t, c, a, down, b)
+	user := &RoundRobinUser{}
+	c.Provide(user)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+
+	got := []string{user.Client.Shard(), user.Client.Shard(), user.Client.Shard(), user.Client.Shard()}
+	expected := []string{"a", "b", "a", "b"}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Fatalf("轮询顺序不正确: 期望 %v, 得到 %v", expected, got)
+		}
+	}
+	if down.served != 0 {
+		t.Error("不可用的实例不应该被分发")
+	}
+}
+
+func TestBalance_WeightedFromConfig(t *testing.T) {
+	c := ioc233.NewContainer()
+	a, b := &Shard{ID: "a", Weigh: 1}, &Shard{ID: "b", Weigh: 1}
+	provideShards(t, c, a, b)
+	c.SetBalanceWeight("shard-a", 3)
+	user := &WeightedUser{}
+	c.Provide(user)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+
+	for i := 0; i < 8; i++ {
+		user.Client.Shard()
+	}
+	if a.served != 6 || b.served != 2 {
+		t.Errorf("加权分发比例不正确: a=%d b=%d", a.served, b.served)
+	}
+}
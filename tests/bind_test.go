@@ -0,0 +1,63 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 显式接口绑定测试 ====================
+
+type PremiumUserService struct{}
+
+func (s *PremiumUserService) GetUser(int) string { return "Premium" }
+
+type BoundUserConsumer struct {
+	Users UserService `autowire:"true"`
+}
+
+type NotAUserService struct{}
+
+func TestBind_ExplicitImplementationWins(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&UserServiceImpl{ID: 1})
+	c.Provide(&PremiumUserService{})
+	if err := ioc233.BindIn[UserService, *PremiumUserService](c); err != nil {
+		t.Fatalf("Bind 应该成功, 错误: %v", err)
+	}
+	consumer := &BoundUserConsumer{}
+	c.Provide(consumer)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+
+	if consumer.Users == nil || consumer.Users.GetUser(1) != "Premium" {
+		t.Fatal("注入应该使用显式绑定的实现，而不是首个注册的实现")
+	}
+	if got := ioc233.GetObjectByTypeFrom[UserService](c); got == nil || got.GetUser(1) != "Premium" {
+		t.Error("GetObjectByTypeFrom 应该使用显式绑定的实现")
+	}
+	if all := ioc233.GetObjectsByTypeFrom[UserService](c); len(all) != 2 {
+		t.Errorf("GetObjectsByTypeFrom 仍应返回全部实现, 实际: %d", len(all))
+	}
+}
+
+func TestBind_MissingImplementationReported(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&UserServiceImpl{ID: 1})
+	_ = ioc233.BindIn[UserService, *PremiumUserService](c)
+	c.Provide(&BoundUserConsumer{})
+	if errs := c.Validate(); len(errs) != 1 {
+		t.Fatalf("绑定的实现未注册时应该报告错误, 实际: %v", errs)
+	}
+}
+
+func TestBind_Invalid(t *testing.T) {
+	c := ioc233.NewContainer()
+	if err := ioc233.BindIn[*UserServiceImpl, *UserServiceImpl](c); err == nil {
+		t.Error("第一个类型参数不是接口时应该返回错误")
+	}
+	if err := ioc233.BindIn[UserService, *NotAUserService](c); err == nil {
+		t.Error("实现类型未实现接口时应该返回错误")
+	}
+}
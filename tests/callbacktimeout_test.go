@@ -0,0 +1,83 @@
+package tests
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 生命周期回调超时测试 ====================
+
+type StuckWarmer struct {
+	release chan struct{}
+}
+
+func (s *StuckWarmer) OnInjectComplete() { <-s.release }
+
+type AfterStuck struct {
+	_     struct{} `dependsOn:"StuckWarmer"`
+	ready bool
+}
+
+func (a *AfterStuck) OnInjectComplete() { a.ready = true }
+
+func TestSetCallbackTimeout_FailPolicyAbortsStartUp(t *testing.T) {
+	stuck := &StuckWarmer{release: make(chan struct{})}
+	defer close(stuck.release)
+	c := ioc233.NewContainer()
+	c.SetCallbackTimeout(20*time.Millisecond, ioc233.CallbackTimeoutFail)
+	c.Provide(stuck)
+	c.Provide(&AfterStuck{})
+
+	err := c.StartUp()
+	var timeoutErr *ioc233.CallbackTimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("回调超时应该中止启动并返回 CallbackTimeoutError, 实际: %v", err)
+	}
+	if timeoutErr.Bean != "StuckWarmer" || timeoutErr.Callback != "OnInjectComplete" {
+		t.Errorf("错误应该指明卡住的 bean 与回调, 实际: %+v", timeoutErr)
+	}
+	if c.State() != ioc233.StateFailed {
+		t.Errorf("中止后容器应该处于 StateFailed, 实际: %v", c.State())
+	}
+}
+
+func TestSetCallbackTimeout_ContinuePolicyKeepsBooting(t *testing.T) {
+	stuck := &StuckWarmer{release: make(chan struct{})}
+	defer close(stuck.release)
+	after := &AfterStuck{}
+	c := ioc233.NewContainer()
+	c.SetCallbackTimeout(20*time.Millisecond, ioc233.CallbackTimeoutContinue)
+	c.Provide(stuck)
+	c.Provide(after)
+
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("continue 策略下启动应该成功, 错误: %v", err)
+	}
+	if !after.ready {
+		t.Error("卡住的回调之后的 bean 应该继续完成回调")
+	}
+}
+
+type PanickingCallback struct{}
+
+func (*PanickingCallback) OnInjectAfter() { panic("boom") }
+
+func TestSetCallbackTimeout_PropagatesPanics(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.SetCallbackTimeout(time.Second, ioc233.CallbackTimeoutFail)
+	c.Provide(&PanickingCallback{})
+	defer func() {
+		p, ok := recover().(*ioc233.CallbackPanicError)
+		if !ok || p.Value != "boom" || p.Bean != "PanickingCallback" || p.Callback != "OnInjectAfter" {
+			t.Fatalf("回调中的 panic 应该包装后在 StartUp 调用方重新抛出, 实际: %v", p)
+		}
+		if !strings.Contains(string(p.Stack), "OnInjectAfter") {
+			t.Errorf("重新抛出的 panic 应该带有回调协程的调用栈, 实际: %s", p.Stack)
+		}
+	}()
+	_ = c.StartUp()
+}
@@ -0,0 +1,92 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+	"github.com/neko233-com/ioc233-go/ioc233/clients"
+)
+
+// ==================== 客户端提供器模块测试 ====================
+
+type headerMiddleware struct{}
+
+func (headerMiddleware) WrapHTTP(client string, next http.RoundTripper) http.RoundTripper {
+	return roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		r.Header.Set("X-Client", client)
+		return next.RoundTrip(r)
+	})
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+type recordingMailMiddleware struct {
+	sent []*clients.Mail
+}
+
+func (m *recordingMailMiddleware) WrapSMTP(_ string, _ clients.SendFunc) clients.SendFunc {
+	return func(mail *clients.Mail) error {
+		m.sent = append(m.sent, mail)
+		return nil
+	}
+}
+
+type PaymentGateway struct {
+	HTTP *clients.HTTPClient `autowire:"paymentHTTP"`
+	Mail *clients.SMTPSender `autowire:"true"`
+}
+
+func TestClients_HTTPAndSMTPModules(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Header.Get("X-Client")))
+	}))
+	defer server.Close()
+
+	c := ioc233.NewContainer()
+	_ = c.ProvideValue("paymentHTTPConfig", clients.HTTPClientConfig{Timeout: 3 * time.Second})
+	_ = c.ProvideValue("smtpConfig", clients.SMTPConfig{Host: "smtp.example.com", From: "noreply@example.com"})
+	c.Provide(headerMiddleware{})
+	mails := &recordingMailMiddleware{}
+	c.Provide(mails)
+	err := c.Install(
+		clients.HTTPClientModule{Name: "paymentHTTP", ConfigBean: "paymentHTTPConfig"},
+		clients.SMTPSenderModule{ConfigBean: "smtpConfig"},
+	)
+	if err != nil {
+		t.Fatalf("安装模块应该成功, 错误: %v", err)
+	}
+	gateway := &PaymentGateway{}
+	c.Provide(gateway)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+
+	if gateway.HTTP == nil || gateway.HTTP.Client == nil {
+		t.Fatal("HTTP 客户端应该被构造并注入")
+	}
+	if gateway.HTTP.Timeout != 3*time.Second {
+		t.Errorf("应该使用配置 bean 中的超时, 实际: %v", gateway.HTTP.Timeout)
+	}
+	resp, err := gateway.HTTP.Get(server.URL)
+	if err != nil {
+		t.Fatalf("请求应该成功, 错误: %v", err)
+	}
+	defer resp.Body.Close()
+	buf := make([]byte, 64)
+	n, _ := resp.Body.Read(buf)
+	if string(buf[:n]) != "paymentHTTP" {
+		t.Errorf("容器中的 HTTP 中间件应该生效, 实际: %q", string(buf[:n]))
+	}
+
+	if err := gateway.Mail.Send(&clients.Mail{To: []string{"a@example.com"}, Subject: "hi"}); err != nil {
+		t.Fatalf("发送应该成功, 错误: %v", err)
+	}
+	if len(mails.sent) != 1 || mails.sent[0].From != "noreply@example.com" {
+		t.Errorf("SMTP 中间件应该生效并使用配置中的默认发件人, 实际: %+v", mails.sent)
+	}
+}
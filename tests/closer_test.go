@@ -0,0 +1,120 @@
+package tests
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== io.Closer 自动关闭测试 ====================
+
+type closeLog struct {
+	mu    sync.Mutex
+	names []string
+}
+
+func (l *closeLog) add(name string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.names = append(l.names, name)
+}
+
+type ConnPool struct {
+	log *closeLog
+	err error
+}
+
+func (p *ConnPool) Close() error {
+	p.log.add("pool")
+	return p.err
+}
+
+type UserRepository struct {
+	Pool *ConnPool `autowire:"true"`
+	log  *closeLog
+}
+
+func (r *UserRepository) Close() error {
+	r.log.add("repository")
+	return nil
+}
+
+type AuditFile struct {
+	log *closeLog
+}
+
+func (f *AuditFile) Close() error {
+	f.log.add("audit")
+	panic("disk gone")
+}
+
+func TestCloser_ReverseDependencyOrder(t *testing.T) {
+	log := &closeLog{}
+	c := ioc233.NewContainer()
+	// 被依赖方先注册也应该后关闭
+	c.Provide(&UserRepository{log: log})
+	c.Provide(&ConnPool{log: log})
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	if err := c.Close(); err != nil {
+		t.Fatalf("关闭应该成功, 错误: %v", err)
+	}
+	if strings.Join(log.names, ",") != "repository,pool" {
+		t.Errorf("依赖方应该先于被依赖方关闭, 实际: %v", log.names)
+	}
+	c.Close()
+	if len(log.names) != 2 {
+		t.Errorf("重复 Close 不应该重复关闭资源, 实际: %v", log.names)
+	}
+}
+
+func TestCloser_AggregatesErrors(t *testing.T) {
+	log := &closeLog{}
+	poolErr := errors.New("pool busy")
+	c := ioc233.NewContainer()
+	c.Provide(&ConnPool{log: log, err: poolErr})
+	c.Provide(&AuditFile{log: log})
+	c.Provide(&UserRepository{log: log})
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+
+	err := c.Close()
+	if !errors.Is(err, poolErr) || !strings.Contains(err.Error(), "disk gone") {
+		t.Errorf("Close 应该合并返回全部关闭错误（含 panic）, 实际: %v", err)
+	}
+	if len(log.names) != 3 {
+		t.Errorf("单个 bean 关闭失败不应该影响其余 bean, 实际: %v", log.names)
+	}
+}
+
+func TestCloser_WithoutAutoClose(t *testing.T) {
+	log := &closeLog{}
+	c := ioc233.NewContainer()
+	c.Provide(&ConnPool{log: log}, ioc233.WithoutAutoClose())
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	c.Close()
+	if len(log.names) != 0 {
+		t.Errorf("WithoutAutoClose 注册的 bean 不应该被关闭, 实际: %v", log.names)
+	}
+}
+
+func TestCloser_ClosedOnAbortedStartUp(t *testing.T) {
+	log := &closeLog{}
+	c := ioc233.NewContainer()
+	c.SetWarmUpPolicy(ioc233.WarmUpFail)
+	c.Provide(&ConnPool{log: log})
+	c.Provide(&BrokenTable{})
+	if err := c.StartUp(); err == nil {
+		t.Fatal("启动应该失败")
+	}
+	if len(log.names) != 1 || log.names[0] != "pool" {
+		t.Errorf("启动中止时已完成的 bean 应该被关闭, 实际: %v", log.names)
+	}
+}
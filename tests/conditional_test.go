@@ -0,0 +1,190 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 条件注册测试 ====================
+
+type Mailer interface {
+	Send(to string) string
+}
+
+type MockMailer struct{}
+
+func (m *MockMailer) Send(string) string { return "mock" }
+
+type SMTPMailer struct{}
+
+func (m *SMTPMailer) Send(string) string { return "smtp" }
+
+type TaggedDevMailer struct {
+	_ struct{} `profile:"dev,test"`
+}
+
+func (m *TaggedDevMailer) Send(string) string { return "tagged-dev" }
+
+type MailerUser struct {
+	Mailer Mailer `autowire:"true"`
+}
+
+func newProfileContainer(profiles ...string) (*ioc233.Container, *MailerUser) {
+	c := ioc233.NewContainer()
+	if profiles != nil {
+		c.SetActiveProfiles(profiles...)
+	}
+	c.ProvideForProfile("dev", &MockMailer{})
+	c.ProvideForProfile("prod", &SMTPMailer{})
+	user := &MailerUser{}
+	c.Provide(user)
+	return c, user
+}
+
+func TestProfile_SwapImplementations(t *testing.T) {
+	dev, devUser := newProfileContainer("dev")
+	if err := dev.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	if devUser.Mailer == nil || devUser.Mailer.Send("a") != "mock" {
+		t.Fatal("dev profile 应该注入 MockMailer")
+	}
+	if ioc233.GetObjectByTypeFrom[*SMTPMailer](dev) != nil {
+		t.Error("未激活的 profile 对象不应该注册")
+	}
+
+	prod, prodUser := newProfileContainer("prod")
+	_ = prod.StartUp()
+	if prodUser.Mailer == nil || prodUser.Mailer.Send("a") != "smtp" {
+		t.Fatal("prod profile 应该注入 SMTPMailer")
+	}
+}
+
+func TestProfile_TagNegationAndEnv(t *testing.T) {
+	t.Setenv(ioc233.ProfilesEnv, "test")
+	c := ioc233.NewContainer()
+	c.Provide(&TaggedDevMailer{})
+	c.ProvideForProfile("!prod", &SMTPMailer{})
+	user := &MailerUser{}
+	c.Provide(user)
+	_ = c.StartUp()
+
+	if got := c.ActiveProfiles(); len(got) != 1 || got[0] != "test" {
+		t.Errorf("未设置时应该读取环境变量, 实际: %v", got)
+	}
+	if user.Mailer == nil || user.Mailer.Send("a") != "tagged-dev" {
+		t.Error("profile 标签声明的对象应该在匹配时注册")
+	}
+	if ioc233.GetObjectByTypeFrom[*SMTPMailer](c) == nil {
+		t.Error("\"!prod\" 在 prod 未激活时应该匹配")
+	}
+}
+
+func TestProfile_DefaultAndChildInheritance(t *testing.T) {
+	t.Setenv(ioc233.ProfilesEnv, "")
+	c := ioc233.NewContainer()
+	if got := c.ActiveProfiles(); len(got) != 1 || got[0] != ioc233.DefaultProfile {
+		t.Errorf("没有激活 profile 时应该为 default, 实际: %v", got)
+	}
+	c.SetActiveProfiles("prod")
+	child := c.NewChild()
+	if got := child.ActiveProfiles(); len(got) != 1 || got[0] != "prod" {
+		t.Errorf("子容器应该继承父容器的 profile, 实际: %v", got)
+	}
+}
+
+type FeatureFlaggedService struct{}
+
+type OptionalFeatureUser struct {
+	Feature *FeatureFlaggedService `autowire:"false"`
+}
+
+func TestProvideIf_EvaluatedAtStartUp(t *testing.T) {
+	enabled := false
+	cond := func() bool { return enabled }
+	c := ioc233.NewContainer()
+	c.ProvideIf(cond, &FeatureFlaggedService{})
+	c.ProvideIfByName(func() bool { return false }, "namedFeature", &FeatureFlaggedService{})
+	user := &OptionalFeatureUser{}
+	c.Provide(user)
+
+	// 条件在 StartUp 时求值，而不是 ProvideIf 调用时
+	enabled = true
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	if user.Feature == nil {
+		t.Error("条件满足时应该注册并注入")
+	}
+	if _, ok := c.Adapter().ResolveByName("namedFeature"); ok {
+		t.Error("条件不满足时不应该注册")
+	}
+}
+
+func TestProvideIf_SkippedBeanKeepsOptionalFieldNil(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.ProvideIf(func() bool { return false }, &FeatureFlaggedService{})
+	user := &OptionalFeatureUser{}
+	c.Provide(user)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	if user.Feature != nil {
+		t.Error("跳过的对象不应该被注入")
+	}
+	if errs := c.Validate(); len(errs) != 0 {
+		t.Errorf("可选字段不应该产生错误, 实际: %v", errs)
+	}
+}
+
+type FallbackMailer struct{}
+
+func (m *FallbackMailer) Send(string) string { return "fallback" }
+
+func TestProvideIfMissing_FallbackAndOverride(t *testing.T) {
+	// 没有其他实现：兜底实现生效
+	c := ioc233.NewContainer()
+	ioc233.ProvideIfMissingIn[Mailer](c, &FallbackMailer{})
+	user := &MailerUser{}
+	c.Provide(user)
+	_ = c.StartUp()
+	if user.Mailer == nil || user.Mailer.Send("a") != "fallback" {
+		t.Fatal("没有其他实现时应该注册兜底实现")
+	}
+
+	// 应用注册了自己的实现（即使在兜底之后注册、或通过 profile 注册）：兜底不生效
+	c2 := ioc233.NewContainer()
+	c2.SetActiveProfiles("prod")
+	ioc233.ProvideIfMissingIn[Mailer](c2, &FallbackMailer{})
+	c2.ProvideForProfile("prod", &SMTPMailer{})
+	user2 := &MailerUser{}
+	c2.Provide(user2)
+	_ = c2.StartUp()
+	if user2.Mailer == nil || user2.Mailer.Send("a") != "smtp" {
+		t.Fatal("应用的实现应该覆盖兜底实现")
+	}
+	if ioc233.GetObjectByTypeFrom[*FallbackMailer](c2) != nil {
+		t.Error("存在其他实现时兜底实现不应该注册")
+	}
+}
+
+func TestProvideIfMissing_ParentAndDefaultContainer(t *testing.T) {
+	parent := ioc233.NewContainer()
+	parent.Provide(&SMTPMailer{})
+	_ = parent.StartUp()
+	child := parent.NewChild()
+	ioc233.ProvideIfMissingIn[Mailer](child, &FallbackMailer{})
+	_ = child.StartUp()
+	if ioc233.GetObjectByTypeFrom[*FallbackMailer](child) != nil {
+		t.Error("父容器中已存在实现时兜底实现不应该注册")
+	}
+
+	ioc233.Reset()
+	defer ioc233.Reset()
+	ioc233.ProvideIfMissing[Mailer](&FallbackMailer{})
+	_ = ioc233.Default().StartUp()
+	if m := ioc233.GetObjectByType[Mailer](); m == nil || m.Send("a") != "fallback" {
+		t.Error("ProvideIfMissing 应该作用于默认容器")
+	}
+}
@@ -0,0 +1,192 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 配置占位符注入测试 ====================
+
+type DatabaseClient struct {
+	Host    string        `value:"${db.host:localhost}"`
+	Port    int           `value:"${db.port:5432}"`
+	DSN     string        `value:"postgres://${db.user}@${db.host:localhost}:${db.port:5432}/app"`
+	Timeout time.Duration `value:"${db.timeout:3s}"`
+	Users   UserService   `autowire:"true"`
+}
+
+func TestValueTag_ResolvesPlaceholders(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.AddConfigSource(ioc233.MapConfigSource{"db.host": "db.internal", "db.user": "svc"})
+	c.AddConfigSource(ioc233.MapConfigSource{"db.host": "ignored", "db.port": "6543"})
+	c.Provide(&UserServiceImpl{ID: 1})
+	db := &DatabaseClient{}
+	c.Provide(db)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+
+	if db.Host != "db.internal" || db.Port != 6543 || db.Timeout != 3*time.Second {
+		t.Errorf("占位符应该按配置源顺序解析并回退默认值, 实际: %+v", db)
+	}
+	if db.DSN != "postgres://svc@db.internal:6543/app" {
+		t.Errorf("字符串中的多个占位符都应该被替换, 实际: %q", db.DSN)
+	}
+	if db.Users == nil {
+		t.Error("同一对象的 autowire 字段应该照常注入")
+	}
+}
+
+func TestValueTag_MissingKeyFailsValidation(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&UserServiceImpl{ID: 1})
+	c.Provide(&DatabaseClient{})
+
+	errs := c.Validate()
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "db.user") {
+		t.Fatalf("缺少没有默认值的配置项时校验应该失败, 实际: %v", errs)
+	}
+	var failed []ioc233.InjectionFailed
+	c.Subscribe(func(ev ioc233.Event) {
+		if f, ok := ev.(ioc233.InjectionFailed); ok {
+			failed = append(failed, f)
+		}
+	})
+	_ = c.StartUp()
+	if len(failed) != 1 || failed[0].Field != "DSN" {
+		t.Errorf("缺少配置项应该记为字段注入失败, 实际: %+v", failed)
+	}
+}
+
+func TestConfigSource_EnvAndParentFallback(t *testing.T) {
+	t.Setenv("APP_DB_USER", "env-user")
+	parent := ioc233.NewContainer()
+	parent.AddConfigSource(ioc233.EnvConfigSource{Prefix: "APP_"})
+	child := parent.NewChild()
+	child.AddConfigSource(ioc233.MapConfigSource{"db.port": "7000"})
+
+	if v, ok := child.Property("db.user"); !ok || v != "env-user" {
+		t.Errorf("子容器未命中时应该回退父容器的配置源, 实际: %q %v", v, ok)
+	}
+	got, err := child.ResolvePlaceholders("${db.user}:${db.port}")
+	if err != nil || got != "env-user:7000" {
+		t.Errorf("ResolvePlaceholders 结果不正确: %q, 错误: %v", got, err)
+	}
+	if _, err := child.ResolvePlaceholders("${db.missing}"); err == nil {
+		t.Error("未找到且没有默认值的占位符应该返回错误")
+	}
+}
+
+// ==================== 配置文件与配置绑定测试 ====================
+
+type ServerConfig struct {
+	Addr    string        `config:"addr" default:":8080"`
+	Timeout time.Duration `config:"timeout"`
+	Tags    []string
+	TLS     struct {
+		Enabled bool `config:"enabled"`
+	} `config:"tls"`
+}
+
+type ConfigConsumer struct {
+	Server *ServerConfig `autowire:"true"`
+	Token  string        `env:"IOC233_TEST_FILE_TOKEN"`
+}
+
+func writeConfigFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadConfigFile_JSONBindAndProvide(t *testing.T) {
+	src, err := ioc233.LoadConfigFile(writeConfigFile(t, "app.json",
+		`{"server": {"timeout": "5s", "tags": ["a", "b"], "tls": {"enabled": true}}, "db": {"port": 6543}}`))
+	if err != nil {
+		t.Fatalf("加载 JSON 配置应该成功, 错误: %v", err)
+	}
+	if v, ok := src.Lookup("db.port"); !ok || v != "6543" {
+		t.Errorf("嵌套对象应该展开为点分 key, 实际: %q %v", v, ok)
+	}
+
+	c := ioc233.NewContainer()
+	c.AddConfigSource(src)
+	if err := c.ProvideConfig("server", &ServerConfig{}); err != nil {
+		t.Fatalf("ProvideConfig 应该成功, 错误: %v", err)
+	}
+	consumer := &ConfigConsumer{}
+	c.Provide(consumer)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+
+	cfg := consumer.Server
+	if cfg == nil || cfg.Addr != ":8080" || cfg.Timeout != 5*time.Second || !cfg.TLS.Enabled {
+		t.Fatalf("配置结构体应该被绑定并按类型注入, 实际: %+v", cfg)
+	}
+	if len(cfg.Tags) != 2 || cfg.Tags[1] != "b" {
+		t.Errorf("数组应该按下标绑定到切片, 实际: %v", cfg.Tags)
+	}
+}
+
+func TestLoadConfigFile_DotEnvFeedsEnvTag(t *testing.T) {
+	src, err := ioc233.LoadConfigFile(writeConfigFile(t, ".env",
+		"# comment\nexport IOC233_TEST_FILE_TOKEN=\"from-file\"\nOTHER='x'\n"))
+	if err != nil {
+		t.Fatalf("加载 .env 应该成功, 错误: %v", err)
+	}
+	c := ioc233.NewContainer()
+	c.AddConfigSource(src)
+	consumer := &ConfigConsumer{}
+	c.Provide(consumer)
+	if consumer.Token != "from-file" {
+		t.Errorf("环境变量未设置时 env 标签应该回退到配置源, 实际: %q", consumer.Token)
+	}
+}
+
+func TestLoadConfigFile_Errors(t *testing.T) {
+	if _, err := ioc233.LoadConfigFile(writeConfigFile(t, "app.ini", "a=1")); err == nil {
+		t.Error("未注册解码器的格式应该返回错误")
+	}
+	if _, err := ioc233.LoadConfigFile(writeConfigFile(t, "bad.json", "{")); err == nil {
+		t.Error("解析失败应该返回错误")
+	}
+	c := ioc233.NewContainer()
+	c.AddConfigSource(ioc233.MapConfigSource{"server.timeout": "soon"})
+	if err := c.BindConfig("server", &ServerConfig{}); err == nil {
+		t.Error("配置值无法转换为字段类型时应该返回错误")
+	}
+}
+
+type MaintenanceWindow struct {
+	Start    time.Time     `config:"start"`
+	Duration time.Duration `config:"duration"`
+	Holidays []time.Time   `config:"holidays"`
+}
+
+func TestBindConfig_TimeValues(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.AddConfigSource(ioc233.MapConfigSource{
+		"maintenance.start":      "2026-05-01T02:00:00Z",
+		"maintenance.duration":   "45m",
+		"maintenance.holidays.0": "2026-10-01",
+	})
+	w := &MaintenanceWindow{}
+	if err := c.BindConfig("maintenance", w); err != nil {
+		t.Fatalf("绑定应该成功, 错误: %v", err)
+	}
+	if !w.Start.Equal(time.Date(2026, 5, 1, 2, 0, 0, 0, time.UTC)) || w.Duration != 45*time.Minute {
+		t.Errorf("time.Time 与 time.Duration 应该按字面量解析, 实际: %+v", w)
+	}
+	if len(w.Holidays) != 1 || w.Holidays[0].Month() != time.October {
+		t.Errorf("time.Time 切片应该按下标绑定, 实际: %v", w.Holidays)
+	}
+}
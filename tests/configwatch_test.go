@@ -0,0 +1,104 @@
+package tests
+
+import (
+	"os"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 配置热更新测试 ====================
+
+type TunableLimiter struct {
+	Rate  int    `value:"${limiter.rate:10}"`
+	Name  string `value:"${app.name}"`
+	mutex sync.Mutex
+	seen  [][]string
+}
+
+func (l *TunableLimiter) OnConfigChanged(keys []string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.seen = append(l.seen, keys)
+}
+
+func (l *TunableLimiter) notifications() [][]string {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return append([][]string(nil), l.seen...)
+}
+
+type LimiterConfig struct {
+	Burst int `config:"burst"`
+}
+
+func TestNotifyConfigChanged_ReResolvesFieldsAndNotifies(t *testing.T) {
+	cfg := ioc233.MapConfigSource{"limiter.rate": "20", "app.name": "order"}
+	c := ioc233.NewContainer()
+	c.AddConfigSource(cfg)
+	limiter := &TunableLimiter{}
+	c.Provide(limiter)
+	bound := &LimiterConfig{}
+	cfg["limiter.burst"] = "5"
+	if err := c.ProvideConfig("limiter", bound); err != nil {
+		t.Fatalf("ProvideConfig 应该成功, 错误: %v", err)
+	}
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+
+	cfg["limiter.rate"] = "50"
+	cfg["limiter.burst"] = "8"
+	cfg["app.name"] = "changed-but-not-reported"
+	c.NotifyConfigChanged("limiter.rate", "limiter.burst")
+
+	if limiter.Rate != 50 || limiter.Name != "order" {
+		t.Errorf("只有引用了变化 key 的字段应该被重新解析, 实际: rate=%d name=%q", limiter.Rate, limiter.Name)
+	}
+	if bound.Burst != 8 {
+		t.Errorf("ProvideConfig 绑定的结构体应该被重新绑定, 实际: %d", bound.Burst)
+	}
+	if got := limiter.notifications(); len(got) != 1 || !reflect.DeepEqual(got[0], []string{"limiter.rate", "limiter.burst"}) {
+		t.Errorf("IConfigChanged 应该收到变化的 key, 实际: %v", got)
+	}
+}
+
+func TestFileConfigSource_WatchReloadsOnChange(t *testing.T) {
+	path := writeConfigFile(t, "app.json", `{"limiter": {"rate": 20}, "app": {"name": "order"}}`)
+	src, err := ioc233.LoadConfigFile(path)
+	if err != nil {
+		t.Fatalf("加载应该成功, 错误: %v", err)
+	}
+	src.PollInterval = 10 * time.Millisecond
+
+	c := ioc233.NewContainer()
+	defer c.Close()
+	c.AddConfigSource(src)
+	limiter := &TunableLimiter{}
+	c.Provide(limiter)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+
+	// 保证修改时间变化（部分文件系统的时间精度较低）
+	future := time.Now().Add(time.Second)
+	if err := os.WriteFile(path, []byte(`{"limiter": {"rate": 99}, "app": {"name": "order"}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	_ = os.Chtimes(path, future, future)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(limiter.notifications()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	got := limiter.notifications()
+	if len(got) != 1 || !reflect.DeepEqual(got[0], []string{"limiter.rate"}) {
+		t.Fatalf("文件变化后应该通知变化的 key, 实际: %v", got)
+	}
+	if rate, _ := c.Property("limiter.rate"); rate != "99" {
+		t.Errorf("配置源应该重新加载, 实际: %q", rate)
+	}
+}
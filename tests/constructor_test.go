@@ -0,0 +1,115 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 按需构造函数测试 ====================
+
+type OnDemandConfig struct {
+	DSN string
+}
+
+type OnDemandDB struct {
+	Config *OnDemandConfig
+}
+
+type OnDemandRepo struct {
+	DB *OnDemandDB `autowire:"true"`
+}
+
+type OnDemandUnused struct{}
+
+type OnDemandHandler struct {
+	Repo *OnDemandRepo `autowire:"true"`
+}
+
+func newOnDemandContainer(t *testing.T, calls map[string]int) *ioc233.Container {
+	t.Helper()
+	c := ioc233.NewContainer()
+	must := func(err error) {
+		if err != nil {
+			t.Fatalf("注册按需构造函数应该成功, 错误: %v", err)
+		}
+	}
+	must(ioc233.RegisterConstructorIn[*OnDemandConfig](c, func() *OnDemandConfig {
+		calls["config"]++
+		return &OnDemandConfig{DSN: "mem://"}
+	}))
+	must(ioc233.RegisterConstructorIn[*OnDemandDB](c, func(cfg *OnDemandConfig) (*OnDemandDB, error) {
+		calls["db"]++
+		return &OnDemandDB{Config: cfg}, nil
+	}))
+	must(ioc233.RegisterConstructorIn[*OnDemandRepo](c, func() *OnDemandRepo {
+		calls["repo"]++
+		return &OnDemandRepo{}
+	}))
+	must(ioc233.RegisterConstructorIn[*OnDemandUnused](c, func() *OnDemandUnused {
+		calls["unused"]++
+		return &OnDemandUnused{}
+	}))
+	return c
+}
+
+func TestRegisterConstructor_BuildsTransitiveDependenciesOnDemand(t *testing.T) {
+	calls := map[string]int{}
+	c := newOnDemandContainer(t, calls)
+	handler := &OnDemandHandler{}
+	c.Provide(handler)
+	if errs := c.Validate(); len(errs) > 0 {
+		t.Fatalf("存在按需构造函数时校验应该通过, 错误: %v", errs)
+	}
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+
+	if handler.Repo == nil || handler.Repo.DB == nil || handler.Repo.DB.Config.DSN != "mem://" {
+		t.Fatalf("缺失的依赖应该按需构造并完成注入, 实际: %+v", handler.Repo)
+	}
+	if calls["config"] != 1 || calls["db"] != 1 || calls["repo"] != 1 {
+		t.Errorf("每个按需构造函数应该只调用一次, 实际: %v", calls)
+	}
+	if calls["unused"] != 0 {
+		t.Error("未被依赖的按需构造函数不应该被调用")
+	}
+	if ioc233.GetObjectByTypeFrom[*OnDemandDB](c) != handler.Repo.DB {
+		t.Error("按需构造的结果应该注册为单例 bean")
+	}
+}
+
+func TestRegisterConstructor_ExistingBeanWins(t *testing.T) {
+	calls := map[string]int{}
+	c := newOnDemandContainer(t, calls)
+	repo := &OnDemandRepo{DB: &OnDemandDB{}}
+	c.Provide(repo)
+	handler := &OnDemandHandler{}
+	c.Provide(handler)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	if handler.Repo != repo || calls["repo"] != 0 {
+		t.Error("已注册的 bean 应该优先于按需构造函数")
+	}
+}
+
+func TestRegisterConstructor_Errors(t *testing.T) {
+	c := ioc233.NewContainer()
+	if err := ioc233.RegisterConstructorIn[*OnDemandDB](c, func() *OnDemandConfig { return nil }); err == nil {
+		t.Error("返回值类型不匹配时应该返回错误")
+	}
+
+	boom := errors.New("boom")
+	if err := ioc233.RegisterConstructorIn[*OnDemandRepo](c, func() (*OnDemandRepo, error) { return nil, boom }); err != nil {
+		t.Fatalf("注册应该成功, 错误: %v", err)
+	}
+	c.Provide(&OnDemandHandler{})
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("按需构造失败记为注入失败，不应阻止启动, 错误: %v", err)
+	}
+	if c.Metrics().InjectionFailures == 0 {
+		t.Error("按需构造失败应该记为注入失败")
+	}
+}
@@ -0,0 +1,80 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 控制器分组与排序测试 ====================
+
+type V2OrderController struct {
+	_ struct{} `route:"GET /orders" handler:"List"`
+}
+
+func (c *V2OrderController) List()                   {}
+func (c *V2OrderController) ControllerGroup() string { return "/api/v2/" }
+
+type V1OrderController struct {
+	_ struct{} `route:"GET /orders" handler:"List"`
+}
+
+func (c *V1OrderController) List()                   {}
+func (c *V1OrderController) ControllerGroup() string { return "/api/v1" }
+
+type V1UserController struct {
+	_ struct{} `route:"GET /users" handler:"List"`
+}
+
+func (c *V1UserController) List()                   {}
+func (c *V1UserController) ControllerGroup() string { return "/api/v1" }
+func (c *V1UserController) Order() int              { return 1 }
+
+type PingController struct {
+	_ struct{} `route:"GET /ping" handler:"Ping"`
+}
+
+func (c *PingController) Ping() {}
+
+type BadGroupController struct {
+	_ struct{} `route:"GET /x" handler:"X"`
+}
+
+func (c *BadGroupController) X()                      {}
+func (c *BadGroupController) ControllerGroup() string { return "api" }
+
+func TestControllerGroup_PrefixAndOrder(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.ProvideController(&V2OrderController{})
+	c.ProvideController(&V1OrderController{})
+	c.ProvideController(&PingController{})
+	c.ProvideController(&V1UserController{})
+
+	var got []string
+	for _, ctrl := range c.GetControllers() {
+		got = append(got, ctrl.Group+"|"+ctrl.Routes[0].Path)
+	}
+	want := "|/ping,/api/v1|/api/v1/users,/api/v1|/api/v1/orders,/api/v2|/api/v2/orders"
+	if strings.Join(got, ",") != want {
+		t.Errorf("控制器应该按分组聚合、组内按 IOrdered 排序, 期望: %s, 实际: %s", want, strings.Join(got, ","))
+	}
+
+	all := c.GetControllersAny()
+	if len(all) != 4 {
+		t.Fatalf("GetControllersAny 应该返回 4 个控制器, 实际: %d", len(all))
+	}
+	if _, ok := all[1].(*V1UserController); !ok {
+		t.Errorf("GetControllersAny 的顺序应该与 GetControllers 一致, 实际: %T", all[1])
+	}
+}
+
+func TestControllerGroup_InvalidPrefix(t *testing.T) {
+	c := ioc233.NewContainer()
+	if err := c.ProvideController(&BadGroupController{}); err == nil {
+		t.Error("分组前缀不以 / 开头时应该返回错误")
+	}
+	if len(c.GetControllers()) != 0 {
+		t.Error("分组前缀非法时不应该注册控制器")
+	}
+}
@@ -0,0 +1,97 @@
+package tests
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 控制器与路由元数据测试 ====================
+
+type UserController struct {
+	_     struct{}         `route:"GET /users/:id" handler:"Get"`
+	_     struct{}         `route:"post /users" handler:"Create"`
+	Users *UserServiceImpl `autowire:"true"`
+}
+
+func (c *UserController) Get()    {}
+func (c *UserController) Create() {}
+func (c *UserController) Delete() {}
+
+type BadRouteController struct {
+	_ struct{} `route:"GET /orders" handler:"List"`
+}
+
+func TestProvideController_CollectsRoutes(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&UserServiceImpl{ID: 1})
+	ctrl := &UserController{}
+	if err := c.ProvideController(ctrl, ioc233.Route{Method: "delete", Path: "/users/:id", Handler: "Delete"}); err != nil {
+		t.Fatalf("注册控制器应该成功, 错误: %v", err)
+	}
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	if ctrl.Users == nil {
+		t.Error("控制器应该像普通 bean 一样完成注入")
+	}
+
+	controllers := c.GetControllers()
+	if len(controllers) != 1 || controllers[0].Instance != ctrl || controllers[0].Name != "UserController" {
+		t.Fatalf("GetControllers 应该返回注册的控制器, 实际: %+v", controllers)
+	}
+	var got []string
+	for _, r := range controllers[0].Routes {
+		got = append(got, r.String())
+	}
+	want := "GET /users/:id -> Get,POST /users -> Create,DELETE /users/:id -> Delete"
+	if strings.Join(got, ",") != want {
+		t.Errorf("路由应该是 %s, 实际: %s", want, strings.Join(got, ","))
+	}
+	if all := c.GetControllersAny(); len(all) != 1 || all[0] != ctrl {
+		t.Errorf("GetControllersAny 应该返回控制器实例, 实际: %v", all)
+	}
+	if got := ioc233.GetObjectByTypeFrom[*UserController](c); got != ctrl {
+		t.Error("控制器应该可以按类型解析")
+	}
+}
+
+func TestProvideController_RejectsInvalidRoutes(t *testing.T) {
+	c := ioc233.NewContainer()
+	if err := c.ProvideController(&BadRouteController{}); err == nil || !strings.Contains(err.Error(), "List") {
+		t.Errorf("处理方法不存在时应该返回错误, 实际: %v", err)
+	}
+	if err := c.ProvideController(&UserController{}, ioc233.Route{Method: "FETCH", Path: "/x", Handler: "Get"}); err == nil {
+		t.Error("未知的 HTTP 方法应该返回错误")
+	}
+	if err := c.ProvideController(&UserController{}, ioc233.Route{Method: "GET", Path: "users", Handler: "Get"}); err == nil {
+		t.Error("不以 / 开头的路径应该返回错误")
+	}
+	if len(c.GetControllers()) != 0 {
+		t.Error("路由非法的控制器不应该被注册")
+	}
+	if _, ok := c.Adapter().Resolve(reflect.TypeOf(&UserController{})); ok {
+		t.Error("路由非法的控制器不应该注册为 bean")
+	}
+}
+
+func TestProvideController_Frozen(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Freeze()
+	if err := c.ProvideController(&UserController{}); !errors.Is(err, ioc233.ErrContainerFrozen) {
+		t.Errorf("冻结后应该返回 ErrContainerFrozen, 实际: %v", err)
+	}
+}
+
+func TestParseRoute(t *testing.T) {
+	r, err := ioc233.ParseRoute("  get   /users/:id ")
+	if err != nil || r.Method != "GET" || r.Path != "/users/:id" {
+		t.Errorf("解析结果不正确: %+v, 错误: %v", r, err)
+	}
+	if _, err := ioc233.ParseRoute("/users"); err == nil {
+		t.Error("缺少方法的声明应该返回错误")
+	}
+}
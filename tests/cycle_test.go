@@ -0,0 +1,114 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 循环依赖检测测试 ====================
+
+type RingA struct{ B *RingB }
+type RingB struct{ C *RingC }
+type RingC struct{ A *RingA }
+
+func NewRingA(b *RingB) *RingA { return &RingA{B: b} }
+func NewRingB(c *RingC) *RingB { return &RingB{C: c} }
+func NewRingC(a *RingA) *RingC { return &RingC{A: a} }
+
+func TestCycle_ConstructorCycleIsFatal(t *testing.T) {
+	c := ioc233.NewContainer()
+	for _, ctor := range []any{NewRingA, NewRingB, NewRingC} {
+		if err := c.ProvideFactory(ctor); err != nil {
+			t.Fatalf("ProvideFactory 应该成功, 错误: %v", err)
+		}
+	}
+	err := c.StartUp()
+	var cycleErr *ioc233.CycleError
+	if !errors.As(err, &cycleErr) {
+		t.Fatalf("应该返回 *CycleError, 实际: %v", err)
+	}
+	if len(cycleErr.Cycles) != 1 || !cycleErr.Cycles[0].Fatal {
+		t.Fatalf("应该报告 1 个致命环, 实际: %+v", cycleErr.Cycles)
+	}
+	if got := cycleErr.Cycles[0].String(); got != "RingA -> RingB -> RingC -> RingA" {
+		t.Errorf("应该报告完整的依赖链, 实际: %s", got)
+	}
+	if c.State() != ioc233.StateFailed {
+		t.Errorf("致命环应该使容器进入 StateFailed, 实际: %s", c.State())
+	}
+}
+
+type LoopOrders struct {
+	Payments *LoopPayments `autowire:"true"`
+}
+
+type LoopPayments struct {
+	Orders *LoopOrders `autowire:"true"`
+}
+
+func TestCycle_FieldCycleIsSafe(t *testing.T) {
+	c := ioc233.NewContainer()
+	orders, payments := &LoopOrders{}, &LoopPayments{}
+	c.Provide(orders)
+	c.Provide(payments)
+
+	cycles := c.DependencyCycles()
+	if len(cycles) != 1 || cycles[0].Fatal {
+		t.Fatalf("应该检测到 1 个安全环, 实际: %+v", cycles)
+	}
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("字段注入成环时启动应该成功, 错误: %v", err)
+	}
+	if orders.Payments != payments || payments.Orders != orders {
+		t.Error("字段注入成环的 bean 应该互相注入")
+	}
+	report := c.StartupReport()
+	if len(report.FieldCycles) != 1 || report.FieldCycles[0].String() != "LoopOrders -> LoopPayments -> LoopOrders" {
+		t.Errorf("启动报告应该记录安全环, 实际: %+v", report.FieldCycles)
+	}
+}
+
+// MixedRepo 由构造函数创建，依赖的 MixedCache 通过字段回填 MixedRepo：环上有一步字段注入，可以安全启动
+type MixedRepo struct{ Cache *MixedCache }
+
+type MixedCache struct {
+	Repo *MixedRepo `autowire:"true"`
+}
+
+func NewMixedRepo(cache *MixedCache) *MixedRepo { return &MixedRepo{Cache: cache} }
+
+func TestCycle_MixedCycleIsSafe(t *testing.T) {
+	c := ioc233.NewContainer()
+	cache := &MixedCache{}
+	c.Provide(cache)
+	if err := c.ProvideFactory(NewMixedRepo); err != nil {
+		t.Fatalf("ProvideFactory 应该成功, 错误: %v", err)
+	}
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	repo := ioc233.GetObjectByTypeFrom[*MixedRepo](c)
+	if repo == nil || repo.Cache != cache || cache.Repo != repo {
+		t.Error("构造函数与字段注入组成的环应该完成装配")
+	}
+}
+
+type SelfNestingTask struct {
+	Child *SelfNestingTask `autowire:"true"`
+}
+
+func TestCycle_PrototypeFieldCycleIsFatal(t *testing.T) {
+	c := ioc233.NewContainer()
+	if err := c.ProvidePrototype(func() *SelfNestingTask { return &SelfNestingTask{} }); err != nil {
+		t.Fatalf("ProvidePrototype 应该成功, 错误: %v", err)
+	}
+	var cycleErr *ioc233.CycleError
+	if err := c.StartUp(); !errors.As(err, &cycleErr) {
+		t.Fatalf("原型字段自引用应该返回 *CycleError, 实际: %v", err)
+	}
+	if got := cycleErr.Cycles[0].String(); got != "SelfNestingTask -> SelfNestingTask" {
+		t.Errorf("应该报告自引用环, 实际: %s", got)
+	}
+}
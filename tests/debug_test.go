@@ -0,0 +1,94 @@
+package tests
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 调试端点测试 ====================
+
+func debugGet(t *testing.T, server *httptest.Server, endpoint string) []byte {
+	t.Helper()
+	resp, err := http.Get(server.URL + ioc233.DebugPath + endpoint)
+	if err != nil {
+		t.Fatalf("请求调试端点应该成功, 错误: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("%s 应该返回 200, 实际: %s", endpoint, resp.Status)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	return body
+}
+
+func TestDebugHandler_Endpoints(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.SetNilFieldScan(true)
+	c.Provide(&UserServiceImpl{ID: 1})
+	c.Provide(&ForgetfulService{})
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle(ioc233.DebugPath, ioc233.DebugHandler(c))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	var beans []ioc233.DumpBean
+	if err := json.Unmarshal(debugGet(t, server, "beans"), &beans); err != nil || len(beans) != 2 {
+		t.Fatalf("/beans 应该返回 2 个 bean, 实际: %v %v", beans, err)
+	}
+
+	var graph ioc233.DependencyGraph
+	if err := json.Unmarshal(debugGet(t, server, "graph"), &graph); err != nil || len(graph.Edges) == 0 {
+		t.Fatalf("/graph 应该返回依赖边, 实际: %+v %v", graph, err)
+	}
+
+	var errs struct {
+		State      string   `json:"state"`
+		Validation []string `json:"validation"`
+		NilFields  []string `json:"nilFields"`
+	}
+	if err := json.Unmarshal(debugGet(t, server, "errors"), &errs); err != nil {
+		t.Fatalf("/errors 应该返回 JSON, 错误: %v", err)
+	}
+	if errs.State != ioc233.StateStarted.String() || len(errs.NilFields) != 2 {
+		t.Errorf("/errors 应该包含状态与 nil 字段, 实际: %+v", errs)
+	}
+	if len(errs.Validation) == 0 || !strings.Contains(strings.Join(errs.Validation, "\n"), "orders") {
+		t.Errorf("/errors 应该包含装配校验错误, 实际: %v", errs.Validation)
+	}
+
+	var status struct {
+		BeanCount int `json:"beanCount"`
+		Timings   []struct {
+			Bean string `json:"bean"`
+		} `json:"timings"`
+	}
+	if err := json.Unmarshal(debugGet(t, server, "status"), &status); err != nil || status.BeanCount != 2 || len(status.Timings) != 2 {
+		t.Errorf("/status 应该包含 bean 数量与启动耗时, 实际: %+v %v", status, err)
+	}
+
+	if index := string(debugGet(t, server, "")); !strings.Contains(index, "<html") || !strings.Contains(index, `load("status")`) {
+		t.Errorf("根路径应该返回调试面板, 实际: %.200s", index)
+	}
+}
+
+func TestDebugHandler_MethodNotAllowed(t *testing.T) {
+	server := httptest.NewServer(ioc233.DebugHandler(ioc233.NewContainer()))
+	defer server.Close()
+	resp, err := http.Post(server.URL+"/beans", "application/json", nil)
+	if err != nil {
+		t.Fatalf("请求应该成功, 错误: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("POST 应该返回 405, 实际: %s", resp.Status)
+	}
+}
@@ -0,0 +1,68 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 默认容器测试 ====================
+
+func TestDefault_InstanceDelegatesToDefault(t *testing.T) {
+	ioc233.Reset()
+	defer ioc233.Reset()
+	if ioc233.Instance() != ioc233.Default() {
+		t.Fatal("Instance 应该返回默认容器")
+	}
+}
+
+func TestDefault_SetDefault(t *testing.T) {
+	ioc233.Reset()
+	defer ioc233.Reset()
+
+	custom := ioc233.NewContainer()
+	custom.Provide(&UserServiceImpl{ID: 42})
+	_ = custom.StartUp()
+	prev := ioc233.SetDefault(custom)
+	if prev == custom || ioc233.Default() != custom || ioc233.Instance() != custom {
+		t.Fatal("SetDefault 应该替换默认容器")
+	}
+	if ioc233.GetObjectByType[UserService]() == nil {
+		t.Error("包级函数应该委托给新的默认容器")
+	}
+
+	ioc233.SetDefault(nil)
+	if fresh := ioc233.Default(); fresh == nil || fresh == custom {
+		t.Error("SetDefault(nil) 后应该重新创建默认容器")
+	}
+}
+
+func TestResetForTesting_RestoresPrevious(t *testing.T) {
+	ioc233.Reset()
+	defer ioc233.Reset()
+	outer := ioc233.Default()
+
+	var inner *ioc233.Container
+	t.Run("isolated", func(t *testing.T) {
+		inner = ioc233.ResetForTesting(t)
+		if inner == outer || ioc233.Default() != inner {
+			t.Fatal("ResetForTesting 应该安装全新的默认容器")
+		}
+		inner.Provide(&UserServiceImpl{ID: 7})
+		_ = inner.StartUp()
+		ioc233.SetTestMode(true)
+		if ioc233.GetObjectByType[*UserServiceImpl]().ID != 7 {
+			t.Error("包级函数应该使用新的默认容器")
+		}
+	})
+
+	if ioc233.Default() != outer {
+		t.Error("测试结束后应该恢复之前的默认容器")
+	}
+	if inner.State() != ioc233.StateClosed {
+		t.Error("测试结束后应该关闭临时容器")
+	}
+	if ioc233.IsTestMode() {
+		t.Error("测试结束后应该恢复测试模式")
+	}
+}
@@ -0,0 +1,94 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== DependsOn 启动依赖测试 ====================
+
+type DependsLog struct {
+	events []string
+}
+
+type CacheWarmer struct {
+	Log *DependsLog `autowire:"true"`
+}
+
+func (w *CacheWarmer) OnInjectAfter()    { w.Log.events = append(w.Log.events, "inject:warmer") }
+func (w *CacheWarmer) OnInjectComplete() { w.Log.events = append(w.Log.events, "complete:warmer") }
+
+type SchemaMigrator struct {
+	_   struct{}    `dependsOn:"CacheWarmer"`
+	Log *DependsLog `autowire:"true"`
+}
+
+func (m *SchemaMigrator) OnInjectAfter()    { m.Log.events = append(m.Log.events, "inject:migrator") }
+func (m *SchemaMigrator) OnInjectComplete() { m.Log.events = append(m.Log.events, "complete:migrator") }
+
+type TrafficServer struct {
+	Log *DependsLog `autowire:"true"`
+}
+
+func (s *TrafficServer) DependsOn() []string { return []string{"SchemaMigrator", "CacheWarmer"} }
+func (s *TrafficServer) OnInjectAfter()      { s.Log.events = append(s.Log.events, "inject:server") }
+func (s *TrafficServer) OnInjectComplete()   { s.Log.events = append(s.Log.events, "complete:server") }
+
+func TestDependsOn_OrdersLifecycleWithoutFieldWiring(t *testing.T) {
+	log := &DependsLog{}
+	c := ioc233.NewContainer()
+	c.Provide(&TrafficServer{})
+	c.Provide(&SchemaMigrator{})
+	c.Provide(&CacheWarmer{})
+	c.Provide(log)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	want := "inject:warmer,inject:migrator,inject:server,complete:warmer,complete:migrator,complete:server"
+	if got := strings.Join(log.events, ","); got != want {
+		t.Errorf("dependsOn 声明的 bean 应该先完成注入与回调\n期望: %s\n实际: %s", want, got)
+	}
+}
+
+type DependsOnMissing struct {
+	_ struct{} `dependsOn:"NoSuchBean"`
+}
+
+type DependsCycleA struct {
+	_ struct{} `dependsOn:"DependsCycleB"`
+}
+
+type DependsCycleB struct {
+	_ struct{} `dependsOn:"DependsCycleA"`
+}
+
+func TestDependsOn_Errors(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&DependsOnMissing{})
+	if errs := c.Validate(); len(errs) == 0 {
+		t.Error("依赖未注册的 bean 时 Validate 应该报告错误")
+	}
+	if err := c.StartUp(); err == nil || !strings.Contains(err.Error(), "NoSuchBean") {
+		t.Errorf("依赖未注册的 bean 时启动应该失败, 实际: %v", err)
+	}
+
+	c = ioc233.NewContainer()
+	c.Provide(&DependsCycleA{})
+	c.Provide(&DependsCycleB{})
+	if err := c.StartUp(); err == nil || !strings.Contains(err.Error(), "循环") {
+		t.Errorf("dependsOn 循环依赖时启动应该失败, 实际: %v", err)
+	}
+}
+
+func TestDependsOn_LaterPhaseRejected(t *testing.T) {
+	c := ioc233.NewContainer()
+	_ = c.DefinePhases("early")
+	c.Provide(&CacheWarmer{})
+	c.Provide(&SchemaMigrator{}, ioc233.InPhase("early"))
+	c.Provide(&DependsLog{})
+	if err := c.StartUp(); err == nil || !strings.Contains(err.Error(), "更晚") {
+		t.Errorf("依赖更晚阶段的 bean 时启动应该失败, 实际: %v", err)
+	}
+}
@@ -0,0 +1,111 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 派生 bean 测试 ====================
+
+type RouteConfig struct {
+	Prefix string
+}
+
+type RouteHandler struct {
+	Path string
+}
+
+type RoutingTable struct {
+	Routes []string
+}
+
+type RouterUser struct {
+	Table   *RoutingTable `autowire:"true"`
+	changed []string
+}
+
+func (r *RouterUser) OnDependencyChanged(field string) {
+	r.changed = append(r.changed, field)
+}
+
+func buildRoutingTable(cfg *RouteConfig, h *RouteHandler) *RoutingTable {
+	return &RoutingTable{Routes: []string{cfg.Prefix + h.Path}}
+}
+
+func TestProvideDerived_ComputedAtStartUp(t *testing.T) {
+	c := ioc233.NewContainer()
+	user := &RouterUser{}
+	c.Provide(user)
+	if err := c.ProvideDerived(buildRoutingTable); err != nil {
+		t.Fatalf("ProvideDerived 应该成功, 错误: %v", err)
+	}
+	c.Provide(&RouteConfig{Prefix: "/api"})
+	c.Provide(&RouteHandler{Path: "/users"})
+
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	if user.Table == nil || user.Table.Routes[0] != "/api/users" {
+		t.Fatalf("派生 bean 应该被计算并注入, 得到: %+v", user.Table)
+	}
+}
+
+func TestProvideDerived_RecomputeOnSwap(t *testing.T) {
+	c := ioc233.NewContainer()
+	user := &RouterUser{}
+	c.Provide(user)
+	c.Provide(&RouteConfig{Prefix: "/api"})
+	c.Provide(&RouteHandler{Path: "/users"})
+	if err := c.ProvideDerived(buildRoutingTable); err != nil {
+		t.Fatalf("ProvideDerived 应该成功, 错误: %v", err)
+	}
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	first := user.Table
+
+	if err := c.Swap(&RouteConfig{Prefix: "/v2"}); err != nil {
+		t.Fatalf("Swap 应该成功, 错误: %v", err)
+	}
+	if user.Table == first {
+		t.Fatal("输入替换后派生 bean 应该重新计算")
+	}
+	if user.Table.Routes[0] != "/v2/users" {
+		t.Errorf("期望 /v2/users, 得到 %s", user.Table.Routes[0])
+	}
+	if len(user.changed) != 1 || user.changed[0] != "Table" {
+		t.Errorf("依赖方应该收到字段变更通知, 得到: %v", user.changed)
+	}
+	if ioc233.GetObjectByTypeFrom[*RoutingTable](c) != user.Table {
+		t.Error("容器中的派生 bean 应该是重新计算后的实例")
+	}
+}
+
+func TestProvideDerived_MissingInput(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&RouteConfig{Prefix: "/api"})
+	if err := c.ProvideDerived(buildRoutingTable); err != nil {
+		t.Fatalf("ProvideDerived 应该成功, 错误: %v", err)
+	}
+	if err := c.StartUp(); err == nil {
+		t.Fatal("派生 bean 缺少输入时启动应该失败")
+	}
+}
+
+func TestProvideDerived_InvalidFunc(t *testing.T) {
+	c := ioc233.NewContainer()
+	if err := c.ProvideDerived(42); err == nil {
+		t.Fatal("非函数参数应该返回错误")
+	}
+	if err := c.ProvideDerived(func(a *RouteConfig) {}); err == nil {
+		t.Fatal("无返回值的函数应该返回错误")
+	}
+}
+
+func TestSwap_Unregistered(t *testing.T) {
+	c := ioc233.NewContainer()
+	if err := c.Swap(&RouteConfig{}); err == nil {
+		t.Fatal("替换未注册的类型应该返回错误")
+	}
+}
@@ -0,0 +1,65 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 诊断数据测试 ====================
+
+func sectionByName(sections []ioc233.DiagnosticSection, name string) (ioc233.DiagnosticSection, bool) {
+	for _, s := range sections {
+		if s.Name == name {
+			return s, true
+		}
+	}
+	return ioc233.DiagnosticSection{}, false
+}
+
+func TestDiagnostics_DefaultSections(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.SetQuietStartup(true)
+	c.SetNilFieldScan(true)
+	c.Provide(&ForgetfulService{})
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+
+	sections := c.Diagnostics()
+	summary, ok := sectionByName(sections, "summary")
+	if !ok || summary.Items[0].Key != "state" || summary.Items[0].Value != "Started" {
+		t.Fatalf("应该包含启动摘要: %+v", sections)
+	}
+	report, ok := sectionByName(sections, "report")
+	if !ok || report.Level() != ioc233.DiagnosticWarn {
+		t.Errorf("应该以警告级别列出 nil 字段: %+v", report)
+	}
+	if _, ok := sectionByName(sections, "scheduler"); ok {
+		t.Error("未启用调度器时不应该输出调度分组")
+	}
+
+	text := ioc233.FormatDiagnostics(sections)
+	if !strings.Contains(text, "== ioc233 容器 ==") || !strings.Contains(text, "! ForgetfulService.") {
+		t.Errorf("文本格式化结果不符合预期:\n%s", text)
+	}
+}
+
+func TestDiagnostics_CustomRenderers(t *testing.T) {
+	c := ioc233.NewContainer()
+	_ = c.ProvideByName("dup", &UserServiceImpl{})
+	_ = c.ProvideByName("dup", &UserServiceImpl{})
+
+	hostSection := func(*ioc233.Container) ioc233.DiagnosticSection {
+		return ioc233.DiagnosticSection{Name: "host", Title: "宿主框架",
+			Items: []ioc233.DiagnosticItem{{Level: ioc233.DiagnosticInfo, Key: "routes", Value: "12"}}}
+	}
+	sections := c.Diagnostics(ioc233.ValidationDiagnostics, hostSection)
+	if len(sections) != 2 || sections[0].Name != "validation" || sections[1].Name != "host" {
+		t.Fatalf("应该按渲染器顺序返回分组: %+v", sections)
+	}
+	if sections[0].Level() != ioc233.DiagnosticError {
+		t.Error("装配错误应该为 error 级别")
+	}
+}
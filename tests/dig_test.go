@@ -0,0 +1,141 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== dig/fx 兼容测试 ====================
+
+type CacheClient struct {
+	Addr string
+}
+
+type DigRoute interface {
+	Pattern() string
+}
+
+type healthRoute struct{}
+
+func (healthRoute) Pattern() string { return "/health" }
+
+type usersRoute struct{}
+
+func (usersRoute) Pattern() string { return "/users" }
+
+// CacheClients 结果对象：两个同类型的命名 bean
+type CacheClients struct {
+	ioc233.Out
+
+	Primary *CacheClient `name:"primary"`
+	Replica *CacheClient `name:"replica"`
+}
+
+func NewCacheClients() CacheClients {
+	return CacheClients{Primary: &CacheClient{Addr: "cache-1"}, Replica: &CacheClient{Addr: "cache-2"}}
+}
+
+type healthRouteResult struct {
+	ioc233.Out
+
+	Route DigRoute `group:"routes"`
+}
+
+func NewHealthRoute() healthRouteResult { return healthRouteResult{Route: healthRoute{}} }
+
+type usersRouteResult struct {
+	ioc233.Out
+
+	Route DigRoute `group:"routes"`
+}
+
+func NewUsersRoute() usersRouteResult { return usersRouteResult{Route: usersRoute{}} }
+
+type RouterParams struct {
+	ioc233.In
+
+	Primary *CacheClient `name:"primary"`
+	Replica *CacheClient `name:"replica"`
+	Routes  []DigRoute   `group:"routes"`
+	Mailer  Mailer       `optional:"true"`
+}
+
+type Router struct {
+	Params RouterParams
+}
+
+func NewRouter(p RouterParams) *Router { return &Router{Params: p} }
+
+func TestProvideDig_ParamAndResultObjects(t *testing.T) {
+	c := ioc233.NewContainer()
+	if err := c.ProvideDig(NewRouter, NewCacheClients, NewHealthRoute, NewUsersRoute); err != nil {
+		t.Fatalf("ProvideDig 应该成功, 错误: %v", err)
+	}
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+
+	router := ioc233.GetObjectByTypeFrom[*Router](c)
+	if router == nil {
+		t.Fatal("Router 应该被构造")
+	}
+	p := router.Params
+	if p.Primary == nil || p.Primary.Addr != "cache-1" || p.Replica == nil || p.Replica.Addr != "cache-2" {
+		t.Errorf("命名字段应该按名称注入: primary=%+v replica=%+v", p.Primary, p.Replica)
+	}
+	if len(p.Routes) != 2 {
+		t.Fatalf("值分组应该包含 2 个路由, 实际: %d", len(p.Routes))
+	}
+	patterns := []string{p.Routes[0].Pattern(), p.Routes[1].Pattern()}
+	if strings.Join(patterns, ",") != "/health,/users" {
+		t.Errorf("值分组应该按注册顺序排列, 实际: %v", patterns)
+	}
+	if p.Mailer != nil {
+		t.Error("缺失的可选字段应该保持零值")
+	}
+	if obj, ok := c.Adapter().ResolveByName("replica"); !ok || obj != p.Replica {
+		t.Error("结果对象的命名字段应该注册为命名 bean")
+	}
+}
+
+type StrictRouterParams struct {
+	ioc233.In
+
+	Mailer Mailer
+}
+
+func NewStrictRouter(p StrictRouterParams) *Router { return &Router{} }
+
+func TestProvideDig_MissingRequiredField(t *testing.T) {
+	c := ioc233.NewContainer()
+	if err := c.ProvideDig(NewStrictRouter); err != nil {
+		t.Fatalf("ProvideDig 应该成功, 错误: %v", err)
+	}
+	err := c.StartUp()
+	if err == nil {
+		t.Fatal("缺少必需字段时启动应该失败")
+	}
+	if !strings.Contains(err.Error(), "Mailer") {
+		t.Errorf("错误信息应该包含缺失的字段, 实际: %v", err)
+	}
+}
+
+func TestDigConstructors(t *testing.T) {
+	c := ioc233.NewContainer()
+	svc := &UserServiceImpl{ID: 9}
+	c.Provide(svc)
+
+	ctors := c.DigConstructors()
+	if len(ctors) != 1 {
+		t.Fatalf("应该导出 1 个构造函数, 实际: %d", len(ctors))
+	}
+	ctor, ok := ctors[0].(func() *UserServiceImpl)
+	if !ok {
+		t.Fatalf("导出的构造函数类型错误: %T", ctors[0])
+	}
+	if ctor() != svc {
+		t.Error("导出的构造函数应该返回容器中的单例")
+	}
+}
@@ -0,0 +1,110 @@
+package tests
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 优雅排空测试 ====================
+
+type shutdownLog struct {
+	mu    sync.Mutex
+	steps []string
+}
+
+func (l *shutdownLog) add(step string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.steps = append(l.steps, step)
+}
+
+type DrainingServer struct {
+	Log         *shutdownLog
+	name        string
+	hasDeadline bool
+}
+
+func (s *DrainingServer) OnDrain(ctx context.Context) {
+	_, s.hasDeadline = ctx.Deadline()
+	s.Log.add("drain:" + s.name)
+}
+
+func (s *DrainingServer) OnDestroy() { s.Log.add("destroy:" + s.name) }
+
+type DrainingWorker struct {
+	Log *shutdownLog
+}
+
+func (w *DrainingWorker) OnDrain(ctx context.Context) { w.Log.add("drain:worker") }
+
+func (w *DrainingWorker) Start(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+func (w *DrainingWorker) Stop(ctx context.Context) error {
+	w.Log.add("stop:worker")
+	return nil
+}
+
+type PanickingDrain struct{}
+
+func (PanickingDrain) OnDrain(ctx context.Context) { panic("drain failed") }
+
+func TestDrain_BeforeStopAndDestroy(t *testing.T) {
+	log := &shutdownLog{}
+	c := ioc233.NewContainer()
+	api := &DrainingServer{Log: log, name: "api"}
+	c.ProvideByName("api", api)
+	c.ProvideByName("worker", &DrainingWorker{Log: log})
+	c.ProvideByName("admin", &DrainingServer{Log: log, name: "admin"})
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	c.Close()
+
+	want := []string{"drain:admin", "drain:worker", "drain:api", "stop:worker", "destroy:admin", "destroy:api"}
+	if len(log.steps) != len(want) {
+		t.Fatalf("关闭步骤应该是 %v, 实际: %v", want, log.steps)
+	}
+	for i := range want {
+		if log.steps[i] != want[i] {
+			t.Fatalf("关闭步骤应该是 %v, 实际: %v", want, log.steps)
+		}
+	}
+	if !api.hasDeadline {
+		t.Error("OnDrain 的 ctx 应该携带关闭时限")
+	}
+}
+
+func TestDrain_PanicDoesNotAbortClose(t *testing.T) {
+	log := &shutdownLog{}
+	c := ioc233.NewContainer()
+	c.ProvideByName("api", &DrainingServer{Log: log, name: "api"})
+	c.Provide(PanickingDrain{})
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	if err := c.Close(); err != nil {
+		t.Errorf("排空 panic 不应该导致 Close 失败, 错误: %v", err)
+	}
+	if len(log.steps) != 2 || log.steps[0] != "drain:api" {
+		t.Errorf("其他 bean 仍应该完成排空与停止, 实际: %v", log.steps)
+	}
+	if c.State() != ioc233.StateClosed {
+		t.Errorf("容器应该已关闭, 实际状态: %v", c.State())
+	}
+}
+
+func TestDrain_SkippedWhenNotStarted(t *testing.T) {
+	log := &shutdownLog{}
+	c := ioc233.NewContainer()
+	c.Provide(&DrainingServer{Log: log, name: "api"})
+	c.Close()
+	if len(log.steps) != 0 {
+		t.Errorf("未启动的容器关闭时不应该排空, 实际: %v", log.steps)
+	}
+}
@@ -0,0 +1,82 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 容器内容转储测试 ====================
+
+type DumpedCheckout struct {
+	Users   UserService  `autowire:"true"`
+	Mailers []Mailer     `autowire:"true"`
+	Orders  OrderService `autowire:"false"`
+	Plain   string
+}
+
+func TestDumpJSON_InjectionResults(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&UserServiceImpl{ID: 1})
+	c.Provide(&SMTPMailer{})
+	c.Provide(&DumpedCheckout{})
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := c.DumpJSON(&buf); err != nil {
+		t.Fatalf("转储应该成功, 错误: %v", err)
+	}
+	var dump ioc233.ContainerDump
+	if err := json.Unmarshal(buf.Bytes(), &dump); err != nil {
+		t.Fatalf("转储应该是合法 JSON, 错误: %v", err)
+	}
+	if dump.State != ioc233.StateStarted.String() || len(dump.Beans) != 3 || len(dump.Errors) != 0 {
+		t.Fatalf("转储内容不符合预期: %+v", dump)
+	}
+
+	checkout := dump.Beans[2]
+	if checkout.Name != "DumpedCheckout" || len(checkout.Fields) != 3 {
+		t.Fatalf("应该记录 3 个 autowire 字段, 实际: %+v", checkout)
+	}
+	fields := map[string]ioc233.DumpField{}
+	for _, f := range checkout.Fields {
+		fields[f.Name] = f
+	}
+	if f := fields["Users"]; !f.Injected || len(f.Targets) != 1 || f.Targets[0] != "UserServiceImpl" {
+		t.Errorf("Users 应该注入 UserServiceImpl, 实际: %+v", f)
+	}
+	if f := fields["Mailers"]; len(f.Targets) != 1 || f.Targets[0] != "SMTPMailer" {
+		t.Errorf("切片字段应该列出每个元素, 实际: %+v", f)
+	}
+	if f := fields["Orders"]; f.Injected || f.Error != "" {
+		t.Errorf("未命中的可选字段应该记录为未注入且无错误, 实际: %+v", f)
+	}
+}
+
+type DumpedBroken struct {
+	Orders OrderService `autowire:"true"`
+}
+
+func TestDumpJSON_RecordsStartupError(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&DumpedBroken{})
+	_ = c.ProvideByName("mailer", &SMTPMailer{})
+	_ = c.ProvideByName("mailer", &MockMailer{})
+	if err := c.StartUp(); err == nil {
+		t.Fatal("存在致命错误时启动应该失败")
+	}
+
+	dump := c.Dump()
+	if dump.State != ioc233.StateFailed.String() || len(dump.Errors) != 2 || !strings.Contains(dump.Errors[0], "mailer") {
+		t.Fatalf("转储应该记录启动错误, 实际: %+v", dump)
+	}
+	field := dump.Beans[0].Fields[0]
+	if field.Injected || !strings.Contains(field.Error, "OrderService") {
+		t.Errorf("未注入的必须字段应该记录原因, 实际: %+v", field)
+	}
+}
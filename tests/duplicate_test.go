@@ -0,0 +1,98 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 重复注册策略测试 ====================
+
+type RegionSetting struct {
+	Region string
+}
+
+type DuplicateHolder struct {
+	Users  *UserServiceImpl `autowire:"true"`
+	Region *RegionSetting   `autowire:"region"`
+}
+
+func TestDuplicatePolicy_Default(t *testing.T) {
+	c := ioc233.NewContainer()
+	if err := c.Provide(&UserServiceImpl{ID: 1}); err != nil {
+		t.Fatalf("注册应该成功, 错误: %v", err)
+	}
+	if err := c.Provide(&UserServiceImpl{ID: 2}); err != nil {
+		t.Errorf("默认策略下 Provide 重复类型应该只记录警告, 实际: %v", err)
+	}
+	c.ProvideByName("region", &RegionSetting{Region: "eu"})
+	if err := c.ProvideByName("region", &RegionSetting{Region: "us"}); !errors.Is(err, ioc233.ErrDuplicateBean) {
+		t.Errorf("默认策略下 ProvideByName 重复名称应该返回 ErrDuplicateBean, 实际: %v", err)
+	}
+	if err := c.StartUp(); err == nil {
+		t.Error("默认策略下 ProvideByName 重复名称应该阻止启动")
+	}
+}
+
+func TestDuplicatePolicy_Error(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.SetDuplicatePolicy(ioc233.DuplicateError)
+	c.Provide(&UserServiceImpl{ID: 1})
+	if err := c.Provide(&UserServiceImpl{ID: 2}); !errors.Is(err, ioc233.ErrDuplicateBean) {
+		t.Errorf("DuplicateError 策略下 Provide 重复类型应该返回 ErrDuplicateBean, 实际: %v", err)
+	}
+	if err := c.StartUp(); !errors.Is(err, ioc233.ErrDuplicateBean) {
+		t.Errorf("DuplicateError 策略下重复注册应该阻止启动, 实际: %v", err)
+	}
+}
+
+func TestDuplicatePolicy_Replace(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.SetDuplicatePolicy(ioc233.DuplicateReplace)
+	c.Provide(&UserServiceImpl{ID: 1})
+	c.ProvideByName("region", &RegionSetting{Region: "eu"})
+	holder := &DuplicateHolder{}
+	c.Provide(holder)
+
+	if err := c.Provide(&UserServiceImpl{ID: 2}); err != nil {
+		t.Fatalf("DuplicateReplace 策略下 Provide 应该成功, 错误: %v", err)
+	}
+	if err := c.ProvideByName("region", &RegionSetting{Region: "us"}); err != nil {
+		t.Fatalf("DuplicateReplace 策略下 ProvideByName 应该成功, 错误: %v", err)
+	}
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	defer c.Close()
+	if holder.Users.ID != 2 || holder.Region.Region != "us" {
+		t.Errorf("后注册的实例应该替换已有 bean, 实际: type=%d name=%s", holder.Users.ID, holder.Region.Region)
+	}
+
+	c.ProvideByName("region", &RegionSetting{Region: "ap"})
+	if holder.Region.Region != "ap" {
+		t.Errorf("启动后替换应该重新注入依赖方, 实际: %s", holder.Region.Region)
+	}
+}
+
+func TestDuplicatePolicy_KeepFirst(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.SetDuplicatePolicy(ioc233.DuplicateKeepFirst)
+	c.Provide(&UserServiceImpl{ID: 1})
+	c.ProvideByName("region", &RegionSetting{Region: "eu"})
+	if err := c.Provide(&UserServiceImpl{ID: 2}); err != nil {
+		t.Errorf("DuplicateKeepFirst 策略下 Provide 不应该返回错误, 实际: %v", err)
+	}
+	if err := c.ProvideByName("region", &RegionSetting{Region: "us"}); err != nil {
+		t.Errorf("DuplicateKeepFirst 策略下 ProvideByName 不应该返回错误, 实际: %v", err)
+	}
+	holder := &DuplicateHolder{}
+	c.Provide(holder)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	defer c.Close()
+	if holder.Users.ID != 1 || holder.Region.Region != "eu" {
+		t.Errorf("应该保留首个实例, 实际: type=%d name=%s", holder.Users.ID, holder.Region.Region)
+	}
+}
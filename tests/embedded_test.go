@@ -0,0 +1,91 @@
+package tests
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 嵌入结构体注入测试 ====================
+
+// BaseController 被多个控制器嵌入的基础结构体
+type BaseController struct {
+	Logger *slog.Logger `autowire:"true"`
+	Users  UserService  `autowire:"true"`
+	Prefix string       `default:"/api"`
+}
+
+type AccountController struct {
+	BaseController
+	Orders OrderService `autowire:"true"`
+}
+
+type ProfileController struct {
+	*BaseController
+}
+
+// LoggingBase 嵌入字段本身带 autowire 标签时作为普通依赖注入，不展开
+type LoggingBase struct {
+	Logger *slog.Logger `autowire:"true"`
+}
+
+type SharedBaseController struct {
+	*LoggingBase `autowire:"true"`
+}
+
+func TestEmbedded_InjectsPromotedFields(t *testing.T) {
+	c := ioc233.NewContainer()
+	logger := slog.Default()
+	c.ProvideByName("logger", logger)
+	c.Provide(&UserServiceImpl{})
+	c.Provide(&OrderServiceImpl{})
+	account := &AccountController{}
+	profile := &ProfileController{}
+	c.Provide(account)
+	c.Provide(profile)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+
+	if account.Logger != logger || account.Users == nil || account.Orders == nil {
+		t.Errorf("值嵌入的基础结构体字段应该被注入, 实际: %+v", account)
+	}
+	if account.Prefix != "/api" {
+		t.Errorf("嵌入结构体的 default 标签应该生效, 实际: %q", account.Prefix)
+	}
+	if profile.BaseController == nil {
+		t.Fatal("nil 嵌入指针应该在注入时分配")
+	}
+	if profile.Logger != logger || profile.Users == nil {
+		t.Errorf("指针嵌入的基础结构体字段应该被注入, 实际: %+v", profile.BaseController)
+	}
+}
+
+func TestEmbedded_MissingPromotedDependencyFailsValidation(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&UserServiceImpl{})
+	c.Provide(&AccountController{})
+	if errs := c.Validate(); len(errs) == 0 {
+		t.Fatal("嵌入结构体中缺失的依赖应该被 Validate 报告")
+	}
+}
+
+func TestEmbedded_TaggedEmbeddedFieldIsInjectedAsBean(t *testing.T) {
+	c := ioc233.NewContainer()
+	logger := slog.Default()
+	c.ProvideByName("logger", logger)
+	base := &LoggingBase{}
+	c.Provide(base)
+	ctrl := &SharedBaseController{}
+	c.Provide(ctrl)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	if ctrl.LoggingBase != base {
+		t.Error("带 autowire 标签的嵌入指针应该注入容器中的 bean")
+	}
+	if base.Logger != logger {
+		t.Error("被注入的 bean 应该由容器完成自身的注入")
+	}
+}
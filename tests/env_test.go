@@ -0,0 +1,64 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== env 标签测试 ====================
+
+type EnvSettings struct {
+	Port    int           `env:"IOC233_TEST_PORT" default:"8080"`
+	Host    string        `env:"IOC233_TEST_HOST" default:"localhost"`
+	Debug   bool          `env:"IOC233_TEST_DEBUG"`
+	Timeout time.Duration `env:"IOC233_TEST_TIMEOUT"`
+}
+
+func TestEnvTag_PopulatesFromEnvironment(t *testing.T) {
+	t.Setenv("IOC233_TEST_PORT", "9090")
+	t.Setenv("IOC233_TEST_DEBUG", "true")
+	t.Setenv("IOC233_TEST_TIMEOUT", "5s")
+
+	c := ioc233.NewContainer()
+	s := &EnvSettings{Port: 1}
+	c.Provide(s)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+
+	want := EnvSettings{Port: 9090, Host: "localhost", Debug: true, Timeout: 5 * time.Second}
+	if *s != want {
+		t.Errorf("环境变量应该覆盖字段值, 未设置时回退 default 标签, 期望 %+v, 实际 %+v", want, *s)
+	}
+}
+
+type RequiredEnvSettings struct {
+	Token string `env:"IOC233_TEST_TOKEN,required"`
+}
+
+func TestEnvTag_RequiredMissingFailsStartUp(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&RequiredEnvSettings{})
+	if err := c.StartUp(); err == nil {
+		t.Fatal("必需的环境变量未设置时启动应该失败")
+	}
+
+	t.Setenv("IOC233_TEST_TOKEN", "secret")
+	c = ioc233.NewContainer()
+	s := &RequiredEnvSettings{}
+	c.Provide(s)
+	if err := c.StartUp(); err != nil || s.Token != "secret" {
+		t.Fatalf("必需的环境变量已设置时应该注入, 错误: %v, 实际: %q", err, s.Token)
+	}
+}
+
+func TestEnvTag_InvalidValueFailsStartUp(t *testing.T) {
+	t.Setenv("IOC233_TEST_PORT", "not-a-number")
+	c := ioc233.NewContainer()
+	c.Provide(&EnvSettings{})
+	if err := c.StartUp(); err == nil {
+		t.Fatal("环境变量值无法转换为字段类型时启动应该失败")
+	}
+}
@@ -0,0 +1,118 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 类型化事件总线测试 ====================
+
+type OrderPlaced struct {
+	OrderID int
+}
+
+type OrderCancelled struct {
+	OrderID int
+}
+
+type OrderDesk struct {
+	Bus *ioc233.EventBus `autowire:"true"`
+}
+
+func (s *OrderDesk) Place(id int) {
+	ioc233.PublishOn(s.Bus, OrderPlaced{OrderID: id})
+}
+
+type InventoryListener struct {
+	Bus      *ioc233.EventBus `autowire:"true"`
+	reserved []int
+}
+
+func (l *InventoryListener) OnInjectComplete() {
+	ioc233.SubscribeOn(l.Bus, func(e OrderPlaced) { l.reserved = append(l.reserved, e.OrderID) })
+}
+
+func TestEventBus_InjectedBusDecouplesBeans(t *testing.T) {
+	c := ioc233.NewContainer()
+	orders := &OrderDesk{}
+	inventory := &InventoryListener{}
+	c.Provide(orders)
+	c.Provide(inventory)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	defer c.Close()
+
+	if orders.Bus == nil || orders.Bus != c.EventBus() {
+		t.Fatal("*EventBus 字段应该注入容器的事件总线")
+	}
+	orders.Place(7)
+	orders.Place(8)
+	if len(inventory.reserved) != 2 || inventory.reserved[0] != 7 || inventory.reserved[1] != 8 {
+		t.Errorf("订阅者应该按发布顺序收到事件, 实际: %v", inventory.reserved)
+	}
+}
+
+func TestEventBus_DispatchByTypeAndUnsubscribe(t *testing.T) {
+	bus := ioc233.NewContainer().EventBus()
+	var placed, cancelled []int
+	unsubscribe := ioc233.SubscribeOn(bus, func(e OrderPlaced) { placed = append(placed, e.OrderID) })
+	ioc233.SubscribeOn(bus, func(e OrderCancelled) { cancelled = append(cancelled, e.OrderID) })
+
+	ioc233.PublishOn(bus, OrderPlaced{OrderID: 1})
+	ioc233.PublishOn(bus, OrderCancelled{OrderID: 2})
+	if len(placed) != 1 || len(cancelled) != 1 {
+		t.Fatalf("事件应该只分发给对应类型的订阅者, placed=%v cancelled=%v", placed, cancelled)
+	}
+
+	unsubscribe()
+	unsubscribe()
+	ioc233.PublishOn(bus, OrderPlaced{OrderID: 3})
+	if len(placed) != 1 {
+		t.Errorf("取消订阅后不应该再收到事件, 实际: %v", placed)
+	}
+	if n := ioc233.Subscribers[OrderPlaced](bus); n != 0 {
+		t.Errorf("取消订阅后订阅者数量应该为 0, 实际: %d", n)
+	}
+}
+
+func TestEventBus_PanicIsolated(t *testing.T) {
+	bus := ioc233.NewContainer().EventBus()
+	delivered := 0
+	ioc233.SubscribeOn(bus, func(OrderPlaced) { panic("listener failed") })
+	ioc233.SubscribeOn(bus, func(OrderPlaced) { delivered++ })
+
+	ioc233.PublishOn(bus, OrderPlaced{OrderID: 1})
+	if delivered != 1 {
+		t.Error("单个订阅者 panic 不应该影响其他订阅者")
+	}
+}
+
+func TestEventBus_SharedWithChildAndDefault(t *testing.T) {
+	parent := ioc233.NewContainer()
+	child := parent.NewChild()
+	if child.EventBus() != parent.EventBus() {
+		t.Error("子容器应该共享根容器的事件总线")
+	}
+
+	ioc233.ResetForTesting(t)
+	got := 0
+	unsubscribe := ioc233.Subscribe(func(e OrderPlaced) { got = e.OrderID })
+	defer unsubscribe()
+	ioc233.Publish(OrderPlaced{OrderID: 42})
+	if got != 42 {
+		t.Errorf("默认容器的 Publish/Subscribe 应该互通, 实际: %d", got)
+	}
+}
+
+func TestEventBus_InvokeParameter(t *testing.T) {
+	c := ioc233.NewContainer()
+	var bus *ioc233.EventBus
+	if err := c.Invoke(func(b *ioc233.EventBus) { bus = b }); err != nil {
+		t.Fatalf("Invoke 应该成功, 错误: %v", err)
+	}
+	if bus != c.EventBus() {
+		t.Error("Invoke 的 *EventBus 参数应该解析为容器的事件总线")
+	}
+}
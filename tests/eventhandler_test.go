@@ -0,0 +1,102 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 事件处理 bean 自动订阅测试 ====================
+
+type ShippingHandler struct {
+	shipped []int
+}
+
+func (h *ShippingHandler) HandleEvent(e OrderPlaced) { h.shipped = append(h.shipped, e.OrderID) }
+
+var _ ioc233.IEventHandler[OrderPlaced] = (*ShippingHandler)(nil)
+
+type AuditHandler struct {
+	placed    []int
+	cancelled []int
+	// OnDependencyChanged 等生命周期方法不应该被当作事件处理方法
+	changed []string
+}
+
+func (h *AuditHandler) OnOrderPlaced(e OrderPlaced) { h.placed = append(h.placed, e.OrderID) }
+func (h *AuditHandler) OnOrderCancelled(e OrderCancelled) {
+	h.cancelled = append(h.cancelled, e.OrderID)
+}
+func (h *AuditHandler) OnDependencyChanged(field string) { h.changed = append(h.changed, field) }
+
+type WelcomePublisher struct {
+	Bus *ioc233.EventBus `autowire:"true"`
+}
+
+func (p *WelcomePublisher) OnInjectComplete() {
+	ioc233.PublishOn(p.Bus, OrderPlaced{OrderID: 1})
+}
+
+func TestEventHandler_AutoSubscribedOnStartUp(t *testing.T) {
+	c := ioc233.NewContainer()
+	shipping := &ShippingHandler{}
+	audit := &AuditHandler{}
+	c.Provide(&WelcomePublisher{})
+	c.Provide(shipping)
+	c.Provide(audit)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+
+	ioc233.PublishOn(c.EventBus(), OrderPlaced{OrderID: 2})
+	ioc233.PublishOn(c.EventBus(), OrderCancelled{OrderID: 3})
+	if len(shipping.shipped) != 2 || shipping.shipped[0] != 1 {
+		t.Errorf("IEventHandler bean 应该自动订阅, 且能收到 OnInjectComplete 中发布的事件, 实际: %v", shipping.shipped)
+	}
+	if len(audit.placed) != 2 || len(audit.cancelled) != 1 {
+		t.Errorf("On<事件类型名> 方法应该自动订阅, placed=%v cancelled=%v", audit.placed, audit.cancelled)
+	}
+	if n := ioc233.Subscribers[string](c.EventBus()); n != 0 {
+		t.Errorf("参数类型与方法名不匹配的方法不应该被订阅, 实际订阅者: %d", n)
+	}
+
+	c.Close()
+	ioc233.PublishOn(c.EventBus(), OrderPlaced{OrderID: 4})
+	if len(shipping.shipped) != 2 {
+		t.Errorf("容器关闭后应该取消自动订阅, 实际: %v", shipping.shipped)
+	}
+}
+
+func TestEventHandler_LateRegistration(t *testing.T) {
+	c := ioc233.NewContainer()
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	defer c.Close()
+
+	shipping := &ShippingHandler{}
+	if err := c.Provide(shipping); err != nil {
+		t.Fatalf("启动后注册应该成功, 错误: %v", err)
+	}
+	ioc233.PublishOn(c.EventBus(), OrderPlaced{OrderID: 5})
+	if len(shipping.shipped) != 1 {
+		t.Errorf("启动后注册的事件处理 bean 应该立即订阅, 实际: %v", shipping.shipped)
+	}
+}
+
+func TestEventHandler_ChildUnsubscribesOnClose(t *testing.T) {
+	parent := ioc233.NewContainer()
+	child := parent.NewChild()
+	shipping := &ShippingHandler{}
+	child.Provide(shipping)
+	if err := child.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+
+	ioc233.PublishOn(parent.EventBus(), OrderPlaced{OrderID: 6})
+	child.Close()
+	ioc233.PublishOn(parent.EventBus(), OrderPlaced{OrderID: 7})
+	if len(shipping.shipped) != 1 {
+		t.Errorf("子容器的事件处理 bean 应该订阅共享总线, 并在子容器关闭时取消, 实际: %v", shipping.shipped)
+	}
+}
@@ -0,0 +1,87 @@
+package tests
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 容器事件订阅测试 ====================
+
+type EventOrphan struct {
+	Orders OrderService `autowire:"true"`
+}
+
+func TestSubscribe_EmitsTypedEvents(t *testing.T) {
+	c := ioc233.NewContainer()
+	var events []ioc233.Event
+	c.Subscribe(func(ev ioc233.Event) { events = append(events, ev) })
+
+	c.Provide(&UserServiceImpl{ID: 1})
+	_ = c.ProvideByName("orphan", &EventOrphan{})
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+
+	var kinds []string
+	for _, ev := range events {
+		kinds = append(kinds, reflect.TypeOf(ev).Name())
+	}
+	want := []string{"BeanRegistered", "BeanRegistered", "InjectionStarted", "InjectionStarted", "InjectionFailed", "StartupCompleted"}
+	if !reflect.DeepEqual(kinds, want) {
+		t.Fatalf("事件顺序不正确, 期望 %v, 实际 %v", want, kinds)
+	}
+	if reg := events[1].(ioc233.BeanRegistered); reg.Name != "orphan" || reg.Type != reflect.TypeOf(&EventOrphan{}) {
+		t.Errorf("BeanRegistered 应该包含 bean 名与类型, 实际: %+v", reg)
+	}
+	if failed := events[4].(ioc233.InjectionFailed); failed.Bean != "orphan" || failed.Field != "Orders" || failed.Err == nil {
+		t.Errorf("InjectionFailed 应该包含 bean、字段与错误, 实际: %+v", failed)
+	}
+	if done := events[5].(ioc233.StartupCompleted); done.Err != nil || done.BeanCount != 2 || done.Duration <= 0 {
+		t.Errorf("StartupCompleted 不正确: %+v", done)
+	}
+}
+
+func TestSubscribe_StartupFailureAndUnsubscribe(t *testing.T) {
+	c := ioc233.NewContainer()
+	var completed []ioc233.StartupCompleted
+	var registered int
+	unsubscribe := c.Subscribe(func(ev ioc233.Event) {
+		switch ev := ev.(type) {
+		case ioc233.StartupCompleted:
+			completed = append(completed, ev)
+		case ioc233.BeanRegistered:
+			registered++
+		}
+	})
+	_ = c.ProvideByName("users", &UserServiceImpl{ID: 1})
+	_ = c.ProvideByName("users", &UserServiceImpl{ID: 2})
+	err := c.StartUp()
+	if err == nil || len(completed) != 1 || !errors.Is(completed[0].Err, err) {
+		t.Fatalf("启动失败时 StartupCompleted 应该携带错误, 实际: %+v", completed)
+	}
+
+	unsubscribe()
+	c.Provide(&MailSender{})
+	if registered != 1 {
+		t.Errorf("取消订阅后不应该再收到事件, 实际收到 %d 个注册事件", registered)
+	}
+}
+
+func TestSubscribe_PrototypeRegistered(t *testing.T) {
+	c := ioc233.NewContainer()
+	var reg ioc233.BeanRegistered
+	c.Subscribe(func(ev ioc233.Event) {
+		if r, ok := ev.(ioc233.BeanRegistered); ok {
+			reg = r
+		}
+	})
+	if err := c.ProvidePrototype(func() *MailSender { return &MailSender{} }); err != nil {
+		t.Fatalf("注册原型应该成功, 错误: %v", err)
+	}
+	if !reg.Prototype || reg.Name != "MailSender" {
+		t.Errorf("原型注册应该发出 Prototype 事件, 实际: %+v", reg)
+	}
+}
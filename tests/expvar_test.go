@@ -0,0 +1,105 @@
+package tests
+
+import (
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== expvar 发布测试 ====================
+
+// expvarSeq 保证同一进程内多次运行（go test -count=N）时变量名不重复
+var expvarSeq atomic.Int64
+
+// uniqueExpvarName 返回本次测试专用的 expvar 变量名（expvar 变量是进程全局的，不能注销）
+func uniqueExpvarName(t *testing.T) string {
+	return fmt.Sprintf("ioc233_%s_%d", t.Name(), expvarSeq.Add(1))
+}
+
+func readExpvar(t *testing.T, name string) map[string]any {
+	t.Helper()
+	v := expvar.Get(name)
+	if v == nil {
+		t.Fatalf("expvar 变量 %s 应该已发布", name)
+	}
+	var stats map[string]any
+	if err := json.Unmarshal([]byte(v.String()), &stats); err != nil {
+		t.Fatalf("expvar 输出应该是 JSON, 错误: %v", err)
+	}
+	return stats
+}
+
+func TestPublishExpvar(t *testing.T) {
+	c := ioc233.NewContainer()
+	name := uniqueExpvarName(t)
+	if err := c.PublishExpvar(name); err != nil {
+		t.Fatalf("发布应该成功, 错误: %v", err)
+	}
+	if err := c.PublishExpvar(name); err == nil {
+		t.Error("重复发布同名变量应该返回错误")
+	}
+
+	stats := readExpvar(t, name)
+	if stats["state"] != ioc233.StateCreated.String() || stats["lastStartup"] != nil {
+		t.Errorf("启动前的统计不正确: %v", stats)
+	}
+
+	// 变量在读取时重新计算
+	c.Provide(&UserServiceImpl{ID: 1})
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	stats = readExpvar(t, name)
+	if stats["state"] != ioc233.StateStarted.String() || stats["beanCount"] != float64(1) || stats["lastStartup"] == nil {
+		t.Errorf("启动后的统计不正确: %v", stats)
+	}
+	if errs, _ := stats["fatalErrors"].([]any); len(errs) != 0 {
+		t.Errorf("启动成功时不应该有致命错误: %v", errs)
+	}
+}
+
+func TestPublishExpvar_FatalErrors(t *testing.T) {
+	c := ioc233.NewContainer()
+	_ = c.ProvideByName("users", &UserServiceImpl{ID: 1})
+	_ = c.ProvideByName("users", &UserServiceImpl{ID: 2})
+	name := uniqueExpvarName(t)
+	if err := c.PublishExpvar(name); err != nil {
+		t.Fatalf("发布应该成功, 错误: %v", err)
+	}
+	_ = c.StartUp()
+	errs, _ := readExpvar(t, name)["fatalErrors"].([]any)
+	if len(errs) == 0 {
+		t.Error("重复注册应该出现在致命错误中")
+	}
+}
+
+func TestPublishExpvar_ConcurrentSameName(t *testing.T) {
+	name := uniqueExpvarName(t)
+	var (
+		wg        sync.WaitGroup
+		succeeded atomic.Int32
+	)
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := ioc233.NewContainer().PublishExpvar(name); err == nil {
+				succeeded.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	if n := succeeded.Load(); n != 1 {
+		t.Errorf("并发发布同名变量应该只有一次成功且不 panic, 实际成功: %d", n)
+	}
+
+	expvar.Publish(name+"_external", expvar.Func(func() any { return nil }))
+	if err := ioc233.NewContainer().PublishExpvar(name + "_external"); err == nil {
+		t.Error("其他代码已发布的同名变量应该返回错误")
+	}
+}
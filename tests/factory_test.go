@@ -0,0 +1,159 @@
+package tests
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 构造函数注入测试 ====================
+
+type InventoryDB struct {
+	DSN string
+}
+
+type InventoryRepo struct {
+	DB *InventoryDB
+}
+
+type InventoryService struct {
+	Repo   *InventoryRepo
+	Users  UserService
+	Mailer Mailer `autowire:"true"`
+}
+
+func NewInventoryDB() *InventoryDB { return &InventoryDB{DSN: "memory"} }
+
+func NewInventoryRepo(db *InventoryDB) *InventoryRepo { return &InventoryRepo{DB: db} }
+
+func NewInventoryService(repo *InventoryRepo, users UserService) *InventoryService {
+	return &InventoryService{Repo: repo, Users: users}
+}
+
+func TestProvideFactory_TopologicalConstruction(t *testing.T) {
+	c := ioc233.NewContainer()
+	// 注册顺序与依赖顺序相反，容器按拓扑顺序构造
+	for _, ctor := range []any{NewInventoryService, NewInventoryRepo, NewInventoryDB} {
+		if err := c.ProvideFactory(ctor); err != nil {
+			t.Fatalf("ProvideFactory 应该成功, 错误: %v", err)
+		}
+	}
+	c.Provide(&UserServiceImpl{ID: 5})
+	c.Provide(&SMTPMailer{})
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+
+	svc := ioc233.GetObjectByTypeFrom[*InventoryService](c)
+	if svc == nil || svc.Repo == nil || svc.Repo.DB == nil || svc.Users == nil {
+		t.Fatalf("应该构造完整的依赖图: %+v", svc)
+	}
+	if svc.Repo != ioc233.GetObjectByTypeFrom[*InventoryRepo](c) {
+		t.Error("构造函数的结果应该注册为单例")
+	}
+	if svc.Mailer == nil {
+		t.Error("构造出的 bean 也应该完成字段注入")
+	}
+}
+
+type cycleA struct{}
+type cycleB struct{}
+
+func TestProvideFactory_CycleAndMissing(t *testing.T) {
+	c := ioc233.NewContainer()
+	_ = c.ProvideFactory(func(*cycleB) *cycleA { return &cycleA{} })
+	_ = c.ProvideFactory(func(*cycleA) *cycleB { return &cycleB{} })
+	err := c.StartUp()
+	if err == nil || !strings.Contains(err.Error(), "循环依赖") {
+		t.Fatalf("循环依赖应该导致启动失败, 实际: %v", err)
+	}
+
+	c = ioc233.NewContainer()
+	_ = c.ProvideFactory(NewInventoryRepo)
+	err = c.StartUp()
+	if err == nil || !strings.Contains(err.Error(), "*tests.InventoryDB") {
+		t.Fatalf("缺少参数依赖时应该指出缺失的类型, 实际: %v", err)
+	}
+
+	if err := ioc233.NewContainer().ProvideFactory(42); err == nil {
+		t.Error("非函数参数应该返回错误")
+	}
+}
+
+// ==================== 多返回值构造函数测试 ====================
+
+type EventReader struct{ Topic string }
+type EventWriter struct{ Topic string }
+
+type EventConsumer struct {
+	Reader *EventReader `autowire:"true"`
+	Writer *EventWriter `autowire:"true"`
+}
+
+func NewEventPipe(db *InventoryDB) (*EventReader, *EventWriter, error) {
+	return &EventReader{Topic: db.DSN}, &EventWriter{Topic: db.DSN}, nil
+}
+
+func TestProvideFactory_MultipleReturns(t *testing.T) {
+	c := ioc233.NewContainer()
+	_ = c.ProvideFactory(func(r *EventReader) *InventoryRepo { return &InventoryRepo{} })
+	if err := c.ProvideFactory(NewEventPipe); err != nil {
+		t.Fatalf("多返回值构造函数应该注册成功, 错误: %v", err)
+	}
+	_ = c.ProvideFactory(NewInventoryDB)
+	consumer := &EventConsumer{}
+	c.Provide(consumer)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	if consumer.Reader == nil || consumer.Writer == nil || consumer.Reader.Topic != "memory" {
+		t.Fatalf("每个非 error 返回值都应该注册为 bean: %+v", consumer)
+	}
+	if ioc233.GetObjectByTypeFrom[*InventoryRepo](c) == nil {
+		t.Error("依赖多返回值构造函数任一输出的构造函数应该被构造")
+	}
+}
+
+func TestProvideFactory_InvalidSignatures(t *testing.T) {
+	c := ioc233.NewContainer()
+	if err := c.ProvideFactory(func() error { return nil }); err == nil {
+		t.Error("只返回 error 的构造函数应该返回错误")
+	}
+	if err := c.ProvideFactory(func() (error, *EventReader) { return nil, nil }); err == nil {
+		t.Error("error 不在最后的构造函数应该返回错误")
+	}
+	if err := c.ProvideFactory(func() (*EventReader, *EventReader) { return nil, nil }); err == nil {
+		t.Error("返回重复类型的构造函数应该返回错误")
+	}
+}
+
+// ==================== 构造函数错误传播测试 ====================
+
+var errDialFailed = errors.New("dial tcp: connection refused")
+
+func NewFailingDB() (*InventoryDB, error) { return &InventoryDB{DSN: "half-built"}, errDialFailed }
+
+func TestProvideFactory_ErrorAbortsStartUp(t *testing.T) {
+	c := ioc233.NewContainer()
+	_ = c.ProvideFactory(NewInventoryRepo)
+	_ = c.ProvideFactory(NewFailingDB)
+	err := c.StartUp()
+	if err == nil {
+		t.Fatal("构造函数返回错误时启动应该失败")
+	}
+	var factoryErr *ioc233.FactoryError
+	if !errors.As(err, &factoryErr) || !strings.HasSuffix(factoryErr.Func, "NewFailingDB") {
+		t.Fatalf("错误应该指出出错的构造函数, 实际: %v", err)
+	}
+	if !errors.Is(err, errDialFailed) {
+		t.Error("应该包装构造函数的原始错误")
+	}
+	if c.State() != ioc233.StateFailed {
+		t.Errorf("容器应该处于 Failed 状态, 实际: %v", c.State())
+	}
+	if ioc233.GetObjectByTypeFrom[*InventoryDB](c) != nil || ioc233.GetObjectByTypeFrom[*InventoryRepo](c) != nil {
+		t.Error("不应该注册构造失败的 bean 及依赖它的 bean")
+	}
+}
@@ -0,0 +1,109 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 特性开关测试 ====================
+
+type Matchmaker interface {
+	Algorithm() string
+}
+
+type EloMatchmaker struct{}
+
+func (m *EloMatchmaker) Algorithm() string { return "elo" }
+
+type SkillGraphMatchmaker struct {
+	started bool
+}
+
+func (m *SkillGraphMatchmaker) Algorithm() string { return "skill-graph" }
+func (m *SkillGraphMatchmaker) OnInjectAfter()    { m.started = true }
+
+type LobbyService struct {
+	Matchmaker Matchmaker `autowire:"true"`
+}
+
+type BetaLeaderboard struct{}
+
+type LeaderboardPage struct {
+	Beta *BetaLeaderboard `autowire:"false"`
+}
+
+func TestFeatureFlag_ActivatesBeanWhenFlagOn(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.SetFeatureFlagSource(ioc233.StaticFeatureFlags{"new-matchmaker": true})
+	next := &SkillGraphMatchmaker{}
+	c.Provide(next, ioc233.WithFeatureFlag("new-matchmaker"), ioc233.WithTags("matchmaking"))
+	lobby := &LobbyService{}
+	c.Provide(lobby)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	defer c.Close()
+
+	if lobby.Matchmaker != next || !next.started {
+		t.Errorf("开关开启时 bean 应该注册、注入并执行生命周期, 实际: %v %v", lobby.Matchmaker, next.started)
+	}
+	if beans := c.FindBeansByTag("matchmaking"); len(beans) != 1 {
+		t.Errorf("其他选项应该在激活后生效, 实际: %+v", beans)
+	}
+}
+
+func TestFeatureFlag_FlagOffFallsBackToDefault(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.SetFeatureFlagSource(ioc233.FeatureFlagFunc(func(flag string) bool { return flag == "other" }))
+	next := &SkillGraphMatchmaker{}
+	c.Provide(next, ioc233.WithFeatureFlag("new-matchmaker"))
+	ioc233.ProvideIfMissingIn[Matchmaker](c, &EloMatchmaker{})
+	lobby := &LobbyService{}
+	c.Provide(lobby)
+	c.ProvideByName("betaBoard", &BetaLeaderboard{}, ioc233.WithFeatureFlag("beta-leaderboard"))
+	page := &LeaderboardPage{}
+	c.Provide(page)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	defer c.Close()
+
+	if lobby.Matchmaker == nil || lobby.Matchmaker.Algorithm() != "elo" {
+		t.Errorf("开关关闭时应该注入兜底实现, 实际: %v", lobby.Matchmaker)
+	}
+	if next.started {
+		t.Error("开关关闭时 bean 不应该执行生命周期")
+	}
+	if page.Beta != nil {
+		t.Errorf("开关关闭时可选字段应该保持 nil, 实际: %v", page.Beta)
+	}
+	if _, ok := c.LookupBean("betaBoard"); ok {
+		t.Error("开关关闭时 bean 不应该注册")
+	}
+}
+
+func TestFeatureFlag_ParentSourceAndLateRegistration(t *testing.T) {
+	parent := ioc233.NewContainer()
+	parent.SetFeatureFlagSource(ioc233.StaticFeatureFlags{"beta-leaderboard": true})
+	child := parent.NewChild()
+	if !child.FeatureEnabled("beta-leaderboard") || child.FeatureEnabled("new-matchmaker") {
+		t.Error("子容器应该使用父容器的特性开关源")
+	}
+	if ioc233.NewContainer().FeatureEnabled("beta-leaderboard") {
+		t.Error("未设置特性开关源时开关应该视为关闭")
+	}
+
+	if err := child.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	defer child.Close()
+	child.Provide(&BetaLeaderboard{}, ioc233.WithFeatureFlag("beta-leaderboard"))
+	child.Provide(&SkillGraphMatchmaker{}, ioc233.WithFeatureFlag("new-matchmaker"))
+	if _, ok := child.LookupBean("BetaLeaderboard"); !ok {
+		t.Error("启动后注册时开关开启的 bean 应该立即注册")
+	}
+	if _, ok := child.LookupBean("SkillGraphMatchmaker"); ok {
+		t.Error("启动后注册时开关关闭的 bean 不应该注册")
+	}
+}
@@ -0,0 +1,179 @@
+package tests
+
+import (
+	"bytes"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 字段默认值提供器测试 ====================
+
+// RequestIDGenerator 用户自定义的需要自动初始化的类型
+type RequestIDGenerator struct {
+	Prefix string
+}
+
+// PrefixedIDs 需要带初始数据的 map 类型
+type PrefixedIDs map[string]int
+
+func init() {
+	ioc233.RegisterDefaultProvider(
+		func(f reflect.StructField) bool { return f.Type == reflect.TypeOf((*RequestIDGenerator)(nil)) },
+		func(fv reflect.Value) { fv.Set(reflect.ValueOf(&RequestIDGenerator{Prefix: "req-"})) },
+	)
+	ioc233.RegisterDefaultProvider(
+		func(f reflect.StructField) bool { return f.Type == reflect.TypeOf((*bytes.Buffer)(nil)) },
+		func(fv reflect.Value) { fv.Set(reflect.ValueOf(bytes.NewBufferString("init"))) },
+	)
+	// 先于内置 map 初始化匹配
+	ioc233.RegisterDefaultProvider(
+		func(f reflect.StructField) bool { return f.Type == reflect.TypeOf(PrefixedIDs(nil)) },
+		func(fv reflect.Value) { fv.Set(reflect.ValueOf(PrefixedIDs{"seed": 1})) },
+	)
+}
+
+type ServiceWithCustomDefaults struct {
+	IDs    *RequestIDGenerator
+	Buffer *bytes.Buffer
+	Seeded PrefixedIDs
+	Users  *UserServiceImpl `autowire:"false"`
+}
+
+func TestRegisterDefaultProvider_InitializesCustomTypes(t *testing.T) {
+	c := ioc233.NewContainer()
+	svc := &ServiceWithCustomDefaults{}
+	c.Provide(svc)
+
+	if svc.IDs == nil || svc.IDs.Prefix != "req-" {
+		t.Errorf("自定义类型字段应该被提供器初始化, 实际: %+v", svc.IDs)
+	}
+	if svc.Buffer == nil || svc.Buffer.String() != "init" {
+		t.Error("*bytes.Buffer 字段应该被提供器初始化")
+	}
+	if svc.Seeded["seed"] != 1 {
+		t.Errorf("用户提供器应该先于内置 map 初始化, 实际: %v", svc.Seeded)
+	}
+	if svc.Users != nil {
+		t.Error("autowire 字段不应该被默认值提供器初始化")
+	}
+}
+
+func TestRegisterDefaultProvider_KeepsNonZeroFields(t *testing.T) {
+	c := ioc233.NewContainer()
+	own := &RequestIDGenerator{Prefix: "own-"}
+	svc := &ServiceWithCustomDefaults{IDs: own}
+	c.Provide(svc)
+
+	if svc.IDs != own {
+		t.Error("已赋值的字段不应该被覆盖")
+	}
+}
+
+type ServiceWithSyncFields struct {
+	Events   chan string `buffer:"8"`
+	Done     chan struct{}
+	Inbox    <-chan int
+	Sessions *sync.Map
+	Workers  *sync.WaitGroup
+	Count    *atomic.Int64
+	Ready    *atomic.Bool
+	Latest   *atomic.Pointer[UserServiceImpl]
+	Config   *atomic.Value
+}
+
+func TestApplyDefaultProviders_ChannelsAndSyncTypes(t *testing.T) {
+	c := ioc233.NewContainer()
+	svc := &ServiceWithSyncFields{}
+	c.Provide(svc)
+
+	if svc.Events == nil || cap(svc.Events) != 8 {
+		t.Errorf("chan 字段应该按 buffer 标签初始化, 实际 cap=%d", cap(svc.Events))
+	}
+	if svc.Done == nil || cap(svc.Done) != 0 {
+		t.Error("未指定 buffer 的 chan 字段应该初始化为无缓冲")
+	}
+	if svc.Inbox != nil {
+		t.Error("单向 chan 字段不应该被初始化")
+	}
+	if svc.Sessions == nil || svc.Workers == nil || svc.Count == nil || svc.Ready == nil || svc.Latest == nil || svc.Config == nil {
+		t.Fatalf("sync 与 atomic 类型指针应该被初始化, 实际: %+v", svc)
+	}
+	svc.Count.Add(2)
+	svc.Sessions.Store("k", 1)
+	if svc.Count.Load() != 2 {
+		t.Error("初始化后的 atomic 字段应该可以直接使用")
+	}
+}
+
+// ==================== default 标签测试 ====================
+
+type LogLevel string
+
+type ServerSettings struct {
+	Port    int           `default:"8080"`
+	Host    string        `default:"localhost"`
+	Debug   bool          `default:"true"`
+	Ratio   float64       `default:"0.75"`
+	Timeout time.Duration `default:"1m30s"`
+	MaxConn uint16        `default:"512"`
+	Level   LogLevel      `default:"info"`
+	Workers int           `default:"4"`
+}
+
+func TestDefaultTag_SetsZeroFields(t *testing.T) {
+	c := ioc233.NewContainer()
+	s := &ServerSettings{Workers: 16}
+	c.Provide(s)
+
+	want := ServerSettings{
+		Port: 8080, Host: "localhost", Debug: true, Ratio: 0.75,
+		Timeout: 90 * time.Second, MaxConn: 512, Level: "info", Workers: 16,
+	}
+	if *s != want {
+		t.Errorf("default 标签应该设置零值字段且保留已赋值字段, 期望 %+v, 实际 %+v", want, *s)
+	}
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+}
+
+type BadDefaults struct {
+	Port int `default:"http"`
+}
+
+func TestDefaultTag_InvalidLiteralFailsStartUp(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&BadDefaults{})
+	if err := c.StartUp(); err == nil {
+		t.Fatal("default 字面量非法时启动应该失败")
+	}
+}
+
+type ScheduleDefaults struct {
+	Interval time.Duration `default:"1h30m"`
+	Legacy   time.Duration `default:"1000"`
+	Launch   time.Time     `default:"2026-01-02T15:04:05+08:00"`
+	Day      time.Time     `default:"2026-03-01"`
+}
+
+func TestDefaultTag_DurationAndTime(t *testing.T) {
+	c := ioc233.NewContainer()
+	s := &ScheduleDefaults{}
+	c.Provide(s)
+
+	if s.Interval != 90*time.Minute || s.Legacy != time.Microsecond {
+		t.Errorf("Duration 应该解析 \"1h30m\" 并兼容纳秒整数, 实际: %v %v", s.Interval, s.Legacy)
+	}
+	launch := time.Date(2026, 1, 2, 7, 4, 5, 0, time.UTC)
+	if !s.Launch.Equal(launch) {
+		t.Errorf("Time 应该按 RFC3339 解析, 实际: %v", s.Launch)
+	}
+	if !s.Day.Equal(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Time 应该支持日期格式, 实际: %v", s.Day)
+	}
+}
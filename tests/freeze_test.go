@@ -0,0 +1,68 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 容器冻结测试 ====================
+
+type FrozenPlugin struct {
+	Users *UserServiceImpl `autowire:"true"`
+}
+
+func TestFreeze_RejectsNewRegistrations(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&UserServiceImpl{ID: 1})
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	c.Freeze()
+	if !c.IsFrozen() {
+		t.Fatal("Freeze 之后 IsFrozen 应该返回 true")
+	}
+
+	plugin := &FrozenPlugin{}
+	if err := c.Provide(plugin); !errors.Is(err, ioc233.ErrContainerFrozen) {
+		t.Errorf("冻结后 Provide 应该返回 ErrContainerFrozen, 实际: %v", err)
+	}
+	if plugin.Users != nil {
+		t.Error("被拒绝的 bean 不应该被注入")
+	}
+	if err := c.ProvideByName("plugin", &FrozenPlugin{}); !errors.Is(err, ioc233.ErrContainerFrozen) {
+		t.Errorf("冻结后 ProvideByName 应该返回 ErrContainerFrozen, 实际: %v", err)
+	}
+	if err := c.ProvideValue("limit", 10); !errors.Is(err, ioc233.ErrContainerFrozen) {
+		t.Errorf("冻结后 ProvideValue 应该返回 ErrContainerFrozen, 实际: %v", err)
+	}
+	if err := c.ProvideFactory(func() *MailSender { return &MailSender{} }); !errors.Is(err, ioc233.ErrContainerFrozen) {
+		t.Errorf("冻结后 ProvideFactory 应该返回 ErrContainerFrozen, 实际: %v", err)
+	}
+	if _, ok := c.Adapter().ResolveByName("plugin"); ok {
+		t.Error("冻结后注册的 bean 不应该出现在容器中")
+	}
+
+	// 替换已有 bean 不受冻结影响
+	if err := c.Swap(&UserServiceImpl{ID: 2}); err != nil {
+		t.Errorf("冻结后 Swap 应该仍然可用, 错误: %v", err)
+	}
+}
+
+func TestSetFreezeAfterStartUp(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.SetFreezeAfterStartUp(true)
+	if err := c.Provide(&UserServiceImpl{ID: 1}); err != nil {
+		t.Fatalf("启动前注册应该成功, 错误: %v", err)
+	}
+	if c.IsFrozen() {
+		t.Fatal("启动前容器不应该被冻结")
+	}
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	if err := c.Provide(&FrozenPlugin{}); !errors.Is(err, ioc233.ErrContainerFrozen) {
+		t.Errorf("StartUp 之后应该自动冻结, 实际: %v", err)
+	}
+}
@@ -0,0 +1,88 @@
+package tests
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 依赖图与契约测试生成测试 ====================
+
+type GraphConsumer struct {
+	Users    UserService              `autowire:"true"`
+	Orders   *OrderServiceImpl        `autowire:"orders"`
+	All      []UserService            `autowire:"true"`
+	Missing  *MailSender              `autowire:"true"`
+	LazyUser ioc233.Lazy[UserService] `autowire:"true"`
+	Task     *TaskContext             `autowire:"false"`
+	plain    *UserServiceImpl
+}
+
+func newGraphContainer() *ioc233.Container {
+	c := ioc233.NewContainer()
+	c.Provide(&UserServiceImpl{ID: 1})
+	c.Provide(&PremiumUserService{})
+	_ = c.ProvideByName("orders", &OrderServiceImpl{})
+	_ = c.ProvidePrototype(func() *TaskContext { return &TaskContext{} })
+	_ = c.ProvideByName("consumer", &GraphConsumer{})
+	return c
+}
+
+func TestDependencyGraph_Edges(t *testing.T) {
+	graph := newGraphContainer().DependencyGraph()
+	if len(graph.Beans) != 4 {
+		t.Fatalf("应该有 4 个节点, 实际: %d", len(graph.Beans))
+	}
+	edges := map[string]ioc233.DependencyEdge{}
+	for _, e := range graph.Edges {
+		if e.From == "consumer" {
+			edges[e.Field] = e
+		}
+	}
+	if e := edges["Users"]; e.Kind != ioc233.EdgeByInterface || len(e.To) != 1 || e.To[0] != "UserServiceImpl" {
+		t.Errorf("接口边错误: %+v", e)
+	}
+	if e := edges["Orders"]; e.Kind != ioc233.EdgeByName || e.To[0] != "orders" {
+		t.Errorf("名称边错误: %+v", e)
+	}
+	if e := edges["All"]; e.Kind != ioc233.EdgeSlice || len(e.To) != 2 {
+		t.Errorf("切片边应该包含全部实现: %+v", e)
+	}
+	if e := edges["Missing"]; e.Error == "" {
+		t.Errorf("不可解析的边应该记录错误: %+v", e)
+	}
+	if e := edges["LazyUser"]; !e.Lazy || e.FieldTypeName != "tests.UserService" {
+		t.Errorf("懒加载边应该按目标类型记录: %+v", e)
+	}
+	if e := edges["Task"]; !e.Prototype || e.To[0] != "TaskContext" {
+		t.Errorf("原型边错误: %+v", e)
+	}
+	if _, ok := edges["plain"]; ok {
+		t.Error("没有 autowire 标签的字段不应该成为边")
+	}
+}
+
+func TestGenerateContractTests(t *testing.T) {
+	graph := newGraphContainer().DependencyGraph()
+	var buf bytes.Buffer
+	if err := ioc233.GenerateContractTests(&buf, "github.com/neko233-com/ioc233-go/tests", "tests", graph); err != nil {
+		t.Fatalf("生成应该成功, 错误: %v", err)
+	}
+	src := buf.String()
+	for _, want := range []string{
+		"func TestContract_consumer_Users(t *testing.T)",
+		"var _ UserService = *new(*UserServiceImpl)",
+		"func TestContract_consumer_All_UserServiceImpl(t *testing.T)",
+		"func TestContract_consumer_All_PremiumUserService(t *testing.T)",
+		"func TestContract_consumer_LazyUser(t *testing.T)",
+	} {
+		if !strings.Contains(src, want) {
+			t.Errorf("生成代码缺少 %q:\n%s", want, src)
+		}
+	}
+	if strings.Contains(src, "TestContract_consumer_Orders") {
+		t.Error("具体类型依赖不应该生成接口契约测试")
+	}
+}
@@ -0,0 +1,127 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 分组注入测试 ====================
+
+type PacketHandler interface {
+	Opcode() string
+}
+
+type LoginPacketHandler struct{}
+
+func (h *LoginPacketHandler) Opcode() string { return "login" }
+func (h *LoginPacketHandler) Order() int     { return 2 }
+
+type ChatPacketHandler struct{}
+
+func (h *ChatPacketHandler) Opcode() string { return "chat" }
+func (h *ChatPacketHandler) Order() int     { return 1 }
+
+type PingPacketHandler struct{}
+
+func (h *PingPacketHandler) Opcode() string { return "ping" }
+
+type PacketDispatcher struct {
+	Handlers []PacketHandler `autowire:"group:handlers"`
+	Admin    []PacketHandler `autowire:"group:admin"`
+}
+
+type BadGroupHolder struct {
+	Handler PacketHandler `autowire:"group:handlers"`
+}
+
+func opcodes(handlers []PacketHandler) string {
+	names := make([]string, 0, len(handlers))
+	for _, h := range handlers {
+		names = append(names, h.Opcode())
+	}
+	return strings.Join(names, ",")
+}
+
+func TestGroupInjection_CollectsMembersInOrder(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&LoginPacketHandler{}, ioc233.InGroup("handlers"))
+	c.Provide(&ChatPacketHandler{}, ioc233.InGroup("handlers", "admin"))
+	c.Provide(&PingPacketHandler{})
+	dispatcher := &PacketDispatcher{}
+	c.Provide(dispatcher)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	defer c.Close()
+
+	if got := opcodes(dispatcher.Handlers); got != "chat,login" {
+		t.Errorf("应该只注入分组成员并按 Order 排序, 实际: %s", got)
+	}
+	if got := opcodes(dispatcher.Admin); got != "chat" {
+		t.Errorf("bean 可以加入多个分组, 实际: %s", got)
+	}
+	if _, ok := c.LookupBean("LoginPacketHandler"); !ok {
+		t.Error("分组成员仍应该可以按名称获取")
+	}
+}
+
+func TestGroupInjection_EmptyGroupAndNonSliceField(t *testing.T) {
+	c := ioc233.NewContainer()
+	dispatcher := &PacketDispatcher{}
+	c.Provide(dispatcher)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	defer c.Close()
+	if dispatcher.Handlers == nil || len(dispatcher.Handlers) != 0 {
+		t.Errorf("分组没有成员时应该注入空切片, 实际: %#v", dispatcher.Handlers)
+	}
+
+	bad := ioc233.NewContainer()
+	bad.Provide(&LoginPacketHandler{}, ioc233.InGroup("handlers"))
+	bad.Provide(&BadGroupHolder{})
+	if errs := bad.Validate(); len(errs) != 1 || !strings.Contains(errs[0].Error(), "必须是切片") {
+		t.Errorf("非切片字段使用分组注入应该返回错误, 实际: %v", errs)
+	}
+}
+
+func TestGroupInjection_MergesParentAndDigGroups(t *testing.T) {
+	parent := ioc233.NewContainer()
+	parent.Provide(&LoginPacketHandler{}, ioc233.InGroup("handlers"))
+	if err := parent.StartUp(); err != nil {
+		t.Fatalf("父容器启动应该成功, 错误: %v", err)
+	}
+	defer parent.Close()
+
+	child := parent.NewChild()
+	child.Provide(&PingPacketHandler{}, ioc233.InGroup("handlers"))
+	dispatcher := &PacketDispatcher{}
+	child.Provide(dispatcher)
+	if err := child.StartUp(); err != nil {
+		t.Fatalf("子容器启动应该成功, 错误: %v", err)
+	}
+	defer child.Close()
+	if got := opcodes(dispatcher.Handlers); got != "login,ping" {
+		t.Errorf("应该合并父容器的分组成员, 实际: %s", got)
+	}
+
+	c := ioc233.NewContainer()
+	if err := c.ProvideDig(NewHealthRoute, NewUsersRoute); err != nil {
+		t.Fatalf("注册构造函数应该成功, 错误: %v", err)
+	}
+	holder := &RouteGroupHolder{}
+	c.Provide(holder)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	defer c.Close()
+	if len(holder.Routes) != 2 {
+		t.Errorf("结构体字段也应该能注入 dig 结果对象的 group 值, 实际: %v", holder.Routes)
+	}
+}
+
+type RouteGroupHolder struct {
+	Routes []DigRoute `autowire:"group:routes"`
+}
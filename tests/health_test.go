@@ -0,0 +1,107 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 健康检查测试 ====================
+
+type HealthyDB struct {
+	delay time.Duration
+}
+
+func (d *HealthyDB) Health(ctx context.Context) error {
+	time.Sleep(d.delay)
+	return nil
+}
+
+type BrokenCache struct{}
+
+func (BrokenCache) Health(ctx context.Context) error { return errors.New("redis: connection refused") }
+
+type PanickingProbe struct{}
+
+func (*PanickingProbe) Health(ctx context.Context) error { panic("probe crashed") }
+
+type HangingUpstream struct{}
+
+func (*HangingUpstream) Health(ctx context.Context) error {
+	select {}
+}
+
+func TestHealth_AggregatesPerBean(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.ProvideByName("db", &HealthyDB{})
+	c.ProvideByName("cache", BrokenCache{})
+	c.Provide(&UserServiceImpl{ID: 1})
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+
+	report := c.Health(context.Background())
+	if len(report.Checks) != 2 {
+		t.Fatalf("只有实现 IHealthCheck 的 bean 参与检查, 实际: %+v", report.Checks)
+	}
+	if report.Healthy() {
+		t.Error("有检查失败时报告不应该健康")
+	}
+	if report.Checks[0].Bean != "db" || !report.Checks[0].Healthy() {
+		t.Errorf("db 应该健康, 实际: %+v", report.Checks[0])
+	}
+	failed := report.Failed()
+	if len(failed) != 1 || failed[0].Bean != "cache" || !strings.Contains(failed[0].Err.Error(), "connection refused") {
+		t.Errorf("cache 应该失败并带有原因, 实际: %+v", failed)
+	}
+	if !strings.Contains(report.String(), "DOWN") {
+		t.Errorf("摘要应该标记 DOWN, 实际: %s", report.String())
+	}
+}
+
+func TestHealth_RunsConcurrently(t *testing.T) {
+	c := ioc233.NewContainer()
+	for _, name := range []string{"a", "b", "c", "d"} {
+		c.ProvideByName(name, &HealthyDB{delay: 50 * time.Millisecond})
+	}
+
+	report := c.Health(context.Background())
+	if !report.Healthy() {
+		t.Errorf("所有检查都应该通过, 实际: %s", report.String())
+	}
+	if report.Duration >= 150*time.Millisecond {
+		t.Errorf("检查应该并发执行, 实际耗时: %v", report.Duration)
+	}
+}
+
+func TestHealth_PanicAndTimeoutReportedAsFailures(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.ProvideByName("probe", &PanickingProbe{})
+	c.ProvideByName("upstream", &HangingUpstream{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	report := c.Health(ctx)
+	failed := report.Failed()
+	if len(failed) != 2 {
+		t.Fatalf("panic 与超时都应该记为失败, 实际: %+v", report.Checks)
+	}
+	if !strings.Contains(failed[0].Err.Error(), "panic") {
+		t.Errorf("panic 应该转换为错误, 实际: %v", failed[0].Err)
+	}
+	if !errors.Is(failed[1].Err, context.DeadlineExceeded) {
+		t.Errorf("超时的检查应该包装 ctx 错误, 实际: %v", failed[1].Err)
+	}
+}
+
+func TestHealth_NoChecksIsHealthy(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&UserServiceImpl{ID: 1})
+	if report := c.Health(context.Background()); !report.Healthy() || len(report.Checks) != 0 {
+		t.Errorf("没有健康检查时应该视为健康, 实际: %s", report.String())
+	}
+}
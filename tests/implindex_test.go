@@ -0,0 +1,64 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 接口实现索引测试 ====================
+
+type IndexedNotifier interface {
+	Channel() string
+}
+
+type EmailNotifier struct{}
+
+func (*EmailNotifier) Channel() string { return "email" }
+
+type SMSNotifier struct{}
+
+func (*SMSNotifier) Channel() string { return "sms" }
+
+func channelsOf(c *ioc233.Container) []string {
+	var channels []string
+	for _, n := range ioc233.GetObjectsByTypeFrom[IndexedNotifier](c) {
+		channels = append(channels, n.Channel())
+	}
+	return channels
+}
+
+func TestImplIndex_UpdatedOnProvide(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&EmailNotifier{})
+	if got := channelsOf(c); len(got) != 1 || got[0] != "email" {
+		t.Fatalf("应该找到唯一实现, 实际: %v", got)
+	}
+	// 索引已建立后注册的实现应该被追加
+	c.Provide(&SMSNotifier{})
+	c.Provide(&UserServiceImpl{})
+	if got := channelsOf(c); len(got) != 2 || got[1] != "sms" {
+		t.Fatalf("之后注册的实现应该按注册顺序加入索引, 实际: %v", got)
+	}
+	if n := ioc233.GetObjectByTypeFrom[IndexedNotifier](c); n == nil || n.Channel() != "email" {
+		t.Errorf("按接口获取应该返回第一个实现, 实际: %v", n)
+	}
+}
+
+func TestImplIndex_FollowsOverlays(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&EmailNotifier{})
+	_ = channelsOf(c)
+
+	c.PushOverlay()
+	c.Provide(&SMSNotifier{})
+	if got := channelsOf(c); len(got) != 2 || got[0] != "sms" {
+		t.Fatalf("覆盖层 bean 应该排在最前, 实际: %v", got)
+	}
+	if err := c.PopOverlay(); err != nil {
+		t.Fatalf("PopOverlay 应该成功, 错误: %v", err)
+	}
+	if got := channelsOf(c); len(got) != 1 || got[0] != "email" {
+		t.Errorf("弹出覆盖层后索引应该恢复, 实际: %v", got)
+	}
+}
@@ -0,0 +1,53 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 函数注入测试 ====================
+
+func TestInvoke_ResolvesParameters(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&UserServiceImpl{ID: 3})
+	c.Provide(&SMTPMailer{})
+	c.Provide(&MockMailer{})
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+
+	called := false
+	err := c.Invoke(func(ctx context.Context, users UserService, impl *UserServiceImpl, mailers []Mailer) {
+		called = true
+		if ctx == nil || users == nil || impl.ID != 3 || len(mailers) != 2 {
+			t.Errorf("参数应该从容器解析: users=%v impl=%+v mailers=%d", users, impl, len(mailers))
+		}
+	})
+	if err != nil || !called {
+		t.Fatalf("Invoke 应该调用函数, 错误: %v", err)
+	}
+
+	want := errors.New("warmup failed")
+	if err := c.Invoke(func(UserService) (int, error) { return 0, want }); err != want {
+		t.Errorf("应该返回函数的 error 返回值, 实际: %v", err)
+	}
+}
+
+func TestInvoke_MissingDependency(t *testing.T) {
+	c := ioc233.NewContainer()
+	called := false
+	err := c.Invoke(func(OrderService, *UserServiceImpl) { called = true })
+	if err == nil || called {
+		t.Fatal("缺少依赖时应该返回错误且不调用函数")
+	}
+	if !strings.Contains(err.Error(), "tests.OrderService") || !strings.Contains(err.Error(), "*tests.UserServiceImpl") {
+		t.Errorf("错误信息应该列出全部缺失参数: %v", err)
+	}
+	if err := c.Invoke("not a func"); err == nil {
+		t.Error("非函数参数应该返回错误")
+	}
+}
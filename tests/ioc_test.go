@@ -72,8 +72,6 @@ func (l *LifecycleTracker) OnInjectComplete() {
 // ==================== 测试辅助函数 ====================
 
 func resetContainer() {
-	// 使用 build tag 提供的 Reset 函数
-	// 需要在测试时使用 -tags test 标志
 	ioc233.Reset()
 }
 
@@ -0,0 +1,154 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 启动后注册（延迟绑定）测试 ====================
+
+type LatePlugin struct {
+	Users  UserService  `autowire:"true"`
+	Helper *LateHelper  `autowire:"new"`
+	Orders OrderService `autowire:"false"`
+
+	Tracker LifecycleTracker
+}
+
+type LateHelper struct {
+	Users UserService `autowire:"true"`
+}
+
+func (p *LatePlugin) OnInjectBefore()   { p.Tracker.OnInjectBefore() }
+func (p *LatePlugin) OnInjectAfter()    { p.Tracker.OnInjectAfter() }
+func (p *LatePlugin) OnInjectComplete() { p.Tracker.OnInjectComplete() }
+
+func TestLateBinding_ProvideAfterStartUpInjectsImmediately(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&UserServiceImpl{})
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+
+	plugin := &LatePlugin{}
+	c.Provide(plugin)
+	if plugin.Users == nil {
+		t.Fatal("启动后注册的 bean 应该立即完成注入")
+	}
+	if plugin.Helper == nil || plugin.Helper.Users == nil {
+		t.Error("启动后注册时自动创建的依赖也应该完成注入")
+	}
+	if !plugin.Tracker.InjectBeforeCalled || !plugin.Tracker.InjectAfterCalled || !plugin.Tracker.InjectCompleteCalled {
+		t.Errorf("启动后注册的 bean 应该触发生命周期回调, 实际: %+v", &plugin.Tracker)
+	}
+}
+
+func TestLateBinding_ProvideByNameAfterStartUp(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&UserServiceImpl{})
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	orders := &OrderServiceImpl{}
+	if err := c.ProvideByName("orders", orders); err != nil {
+		t.Fatalf("ProvideByName 应该成功, 错误: %v", err)
+	}
+	if orders.UserService == nil {
+		t.Error("启动后按名称注册的 bean 应该立即完成注入")
+	}
+}
+
+func TestLateBinding_BeforeStartUpDefersInjection(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&UserServiceImpl{})
+	orders := &OrderServiceImpl{}
+	c.Provide(orders)
+	if orders.UserService != nil {
+		t.Error("StartUp 之前注册的 bean 不应该立即注入")
+	}
+}
+
+// LateWorker 启动后由构造函数或派生函数创建的 bean，记录完整生命周期
+type LateWorker struct {
+	Users UserService `autowire:"true"`
+
+	Tracker  LifecycleTracker
+	warmed   bool
+	received []int
+	started  chan struct{}
+}
+
+func newLateWorker() *LateWorker { return &LateWorker{started: make(chan struct{})} }
+
+func (w *LateWorker) OnInjectBefore()                  { w.Tracker.OnInjectBefore() }
+func (w *LateWorker) OnInjectAfter()                   { w.Tracker.OnInjectAfter() }
+func (w *LateWorker) OnInjectComplete()                { w.Tracker.OnInjectComplete() }
+func (w *LateWorker) WarmUp(ctx context.Context) error { w.warmed = true; return nil }
+func (w *LateWorker) HandleEvent(e OrderPlaced)        { w.received = append(w.received, e.OrderID) }
+func (w *LateWorker) Stop(ctx context.Context) error   { return nil }
+func (w *LateWorker) Start(ctx context.Context) error {
+	close(w.started)
+	<-ctx.Done()
+	return nil
+}
+
+type LateWorkerConfig struct {
+	Name string
+}
+
+// assertLateLifecycle 检查启动后创建的 bean 与 Provide 一样执行了完整生命周期
+func assertLateLifecycle(t *testing.T, c *ioc233.Container, w *LateWorker) {
+	t.Helper()
+	if w.Users == nil {
+		t.Error("启动后创建的 bean 应该完成字段注入")
+	}
+	if !w.Tracker.InjectBeforeCalled || !w.Tracker.InjectAfterCalled || !w.Tracker.InjectCompleteCalled {
+		t.Errorf("启动后创建的 bean 应该触发注入回调, 实际: %+v", &w.Tracker)
+	}
+	if !w.warmed {
+		t.Error("启动后创建的 bean 应该执行预热")
+	}
+	ioc233.PublishOn(c.EventBus(), OrderPlaced{OrderID: 7})
+	if len(w.received) != 1 || w.received[0] != 7 {
+		t.Errorf("启动后创建的事件处理 bean 应该自动订阅, 实际: %v", w.received)
+	}
+	select {
+	case <-w.started:
+	case <-time.After(2 * time.Second):
+		t.Error("启动后创建的 IRunnable 应该被启动")
+	}
+}
+
+func TestLateBinding_FactoryAfterStartUpRunsLifecycle(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&UserServiceImpl{})
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	defer c.Close()
+
+	worker := newLateWorker()
+	if err := c.ProvideFactory(func() *LateWorker { return worker }); err != nil {
+		t.Fatalf("ProvideFactory 应该成功, 错误: %v", err)
+	}
+	assertLateLifecycle(t, c, worker)
+}
+
+func TestLateBinding_DerivedAfterStartUpRunsLifecycle(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&UserServiceImpl{})
+	c.Provide(&LateWorkerConfig{Name: "late"})
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	defer c.Close()
+
+	worker := newLateWorker()
+	if err := c.ProvideDerived(func(cfg *LateWorkerConfig) *LateWorker { return worker }); err != nil {
+		t.Fatalf("ProvideDerived 应该成功, 错误: %v", err)
+	}
+	assertLateLifecycle(t, c, worker)
+}
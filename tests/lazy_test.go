@@ -0,0 +1,142 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 懒加载注入测试 ====================
+
+type Greeter interface {
+	Greet(name string) string
+}
+
+type EnglishGreeter struct{}
+
+func (g *EnglishGreeter) Greet(name string) string { return "hello " + name }
+
+// greeterProxy 手写的转发代理（等价于 GenerateProxy 的生成结果）
+type greeterProxy struct {
+	target func() Greeter
+}
+
+func (p *greeterProxy) Greet(name string) string { return p.target().Greet(name) }
+
+func init() {
+	ioc233.RegisterProxy[Greeter](func(target func() Greeter) Greeter {
+		return &greeterProxy{target: target}
+	})
+}
+
+type LazyConsumer struct {
+	Greeter Greeter                  `autowire:"true" lazy:"true"`
+	Users   ioc233.Lazy[UserService] `autowire:"true"`
+}
+
+func TestLazy_ProxyResolvesOnFirstCall(t *testing.T) {
+	c := ioc233.NewContainer()
+	consumer := &LazyConsumer{}
+	c.Provide(consumer)
+	c.Provide(&UserServiceImpl{ID: 7})
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	if consumer.Greeter == nil {
+		t.Fatal("懒加载字段应该注入代理")
+	}
+	if _, isReal := consumer.Greeter.(*EnglishGreeter); isReal {
+		t.Fatal("懒加载字段不应该直接注入真实对象")
+	}
+
+	// 启动之后才注册真实实现，首次调用时解析
+	c.Provide(&EnglishGreeter{})
+	if got := consumer.Greeter.Greet("neko"); got != "hello neko" {
+		t.Errorf("期望 'hello neko', 得到 %q", got)
+	}
+	if consumer.Users.Get().GetUser(1) != "User" {
+		t.Error("Lazy[T] 应该解析到 UserService 实现")
+	}
+}
+
+func TestLazy_MissingReturnsError(t *testing.T) {
+	c := ioc233.NewContainer()
+	consumer := &LazyConsumer{}
+	c.Provide(consumer)
+	if err := c.StartUpCtx(context.Background()); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	if _, err := consumer.Users.TryGet(); err == nil {
+		t.Fatal("未注册的懒加载依赖应该返回错误")
+	}
+	if errs := c.Validate(); len(errs) != 2 {
+		t.Errorf("Validate 应该报告 2 个缺失依赖, 得到 %d 个: %v", len(errs), errs)
+	}
+}
+
+type PanickyLazyConsumer struct {
+	Greeter Greeter              `autowire:"true" lazy:"true"`
+	Lazy    ioc233.Lazy[Greeter] `autowire:"true"`
+}
+
+func TestLazy_ProviderPanicIsRecordedAndReraised(t *testing.T) {
+	c := ioc233.NewContainer()
+	consumer := &PanickyLazyConsumer{}
+	c.Provide(consumer)
+	calls := 0
+	c.ProvidePrototype(func() Greeter {
+		calls++
+		panic("boom")
+	})
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := consumer.Lazy.TryGet(); err == nil || !strings.Contains(err.Error(), "boom") {
+			t.Fatalf("第 %d 次 TryGet 应该返回工厂 panic 的错误, 得到: %v", i+1, err)
+		}
+		func() {
+			defer func() {
+				if p := recover(); p == nil || !strings.Contains(fmt.Sprint(p), "boom") {
+					t.Errorf("第 %d 次 Get 应该重新抛出工厂 panic, 得到: %v", i+1, p)
+				}
+			}()
+			consumer.Lazy.Get()
+		}()
+		func() {
+			defer func() {
+				if p := recover(); p == nil || !strings.Contains(fmt.Sprint(p), "boom") {
+					t.Errorf("第 %d 次代理调用应该重新抛出工厂 panic, 得到: %v", i+1, p)
+				}
+			}()
+			consumer.Greeter.Greet("neko")
+		}()
+	}
+	if calls != 2 {
+		t.Errorf("失败结果应该被记录, 工厂只应该各调用一次, 实际: %d", calls)
+	}
+	// panic 不应该遗留容器锁
+	if err := c.Provide(&EnglishGreeter{}); err != nil {
+		t.Errorf("panic 之后容器应该仍然可用, 错误: %v", err)
+	}
+}
+
+func TestGenerateProxy(t *testing.T) {
+	var buf bytes.Buffer
+	iface := reflect.TypeOf((*Greeter)(nil)).Elem()
+	if err := ioc233.GenerateProxy(&buf, iface.PkgPath(), "tests", iface); err != nil {
+		t.Fatalf("生成应该成功, 错误: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"type greeterProxy struct", "func (p *greeterProxy) Greet(a0 string) string", "ioc233.RegisterProxy[Greeter]"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("生成代码缺少 %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package tests
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 资源泄漏报告测试 ====================
+
+func TestLeakReport_CleanShutdown(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&UserServiceImpl{ID: 1})
+	loop := &GameLoop{started: make(chan struct{})}
+	c.Provide(loop)
+	c.Provide(&ConnPool{log: &closeLog{}})
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	<-loop.started
+	if report := c.LeakReport(); len(report.Leaks) != 1 || report.Leaks[0].Kind != ioc233.LeakRunnable {
+		t.Errorf("运行中的容器应该列出存活的托管运行 bean, 实际: %v", report)
+	}
+
+	c.Close()
+	if report := c.LeakReport(); report.HasLeaks() || report.State != ioc233.StateClosed {
+		t.Errorf("正常关闭后不应该有泄漏, 实际: %v", report)
+	}
+}
+
+func TestLeakReport_StuckRunnableAndFailedCloser(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.SetRunnableStopTimeout(20 * time.Millisecond)
+	runner := &StubbornRunner{stop: make(chan struct{}), started: make(chan struct{})}
+	c.ProvideByName("stubborn", runner)
+	c.ProvideByName("pool", &ConnPool{log: &closeLog{}, err: errors.New("pool busy")})
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	<-runner.started
+	c.Close()
+
+	report := c.LeakReport()
+	var kinds []string
+	for _, l := range report.Leaks {
+		kinds = append(kinds, l.Kind+":"+l.Bean)
+	}
+	if strings.Join(kinds, ",") != "runnable:stubborn,closer:pool" {
+		t.Errorf("应该报告未退出的托管运行 bean 与关闭失败的资源, 实际: %v", kinds)
+	}
+	if !strings.Contains(report.String(), "pool busy") {
+		t.Errorf("报告应该包含关闭失败的原因, 实际: %s", report)
+	}
+
+	close(runner.stop)
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) && len(c.LeakReport().Leaks) != 1 {
+		time.Sleep(time.Millisecond)
+	}
+	if leaks := c.LeakReport().Leaks; len(leaks) != 1 || leaks[0].Kind != ioc233.LeakCloser {
+		t.Errorf("托管运行 bean 退出后不应该再报告, 实际: %v", leaks)
+	}
+}
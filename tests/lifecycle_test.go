@@ -0,0 +1,118 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 可取消启动测试 ====================
+
+type stopRecorder struct {
+	stopped []string
+}
+
+type CancelTrigger struct {
+	cancel   context.CancelFunc
+	recorder *stopRecorder
+}
+
+func (b *CancelTrigger) OnInjectAfter() { b.cancel() }
+func (b *CancelTrigger) OnDestroy() {
+	b.recorder.stopped = append(b.recorder.stopped, "CancelTrigger")
+}
+
+type EarlyBean struct {
+	recorder *stopRecorder
+}
+
+func (b *EarlyBean) OnDestroy() {
+	b.recorder.stopped = append(b.recorder.stopped, "EarlyBean")
+}
+
+type LateBean struct {
+	recorder *stopRecorder
+	Complete bool
+}
+
+func (b *LateBean) OnInjectComplete() { b.Complete = true }
+func (b *LateBean) OnDestroy() {
+	b.recorder.stopped = append(b.recorder.stopped, "LateBean")
+}
+
+func TestStartUpCtx_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	recorder := &stopRecorder{}
+	late := &LateBean{recorder: recorder}
+
+	c := ioc233.NewContainer()
+	c.Provide(&EarlyBean{recorder: recorder})
+	c.Provide(&CancelTrigger{cancel: cancel, recorder: recorder})
+	c.Provide(late)
+
+	err := c.StartUpCtx(ctx)
+	if err == nil {
+		t.Fatal("ctx 取消后启动应该失败")
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("错误应该包装 context.Canceled, 得到: %v", err)
+	}
+	var aborted *ioc233.StartupAbortedError
+	if !errors.As(err, &aborted) {
+		t.Fatalf("错误类型应该为 *StartupAbortedError, 得到: %T", err)
+	}
+	if len(aborted.Completed) != 2 || len(aborted.Pending) != 1 || aborted.Pending[0] != "LateBean" {
+		t.Errorf("中止报告不正确: %+v", aborted)
+	}
+
+	expected := []string{"CancelTrigger", "EarlyBean"}
+	if len(recorder.stopped) != len(expected) {
+		t.Fatalf("期望逆序停止 %v, 得到 %v", expected, recorder.stopped)
+	}
+	for i := range expected {
+		if recorder.stopped[i] != expected[i] {
+			t.Errorf("位置 %d: 期望 %s, 得到 %s", i, expected[i], recorder.stopped[i])
+		}
+	}
+	if late.Complete {
+		t.Error("被中止的启动不应该触发 OnInjectComplete")
+	}
+	if c.State() != ioc233.StateFailed {
+		t.Errorf("容器应该进入 Failed 状态, 得到 %s", c.State())
+	}
+	if c.StartUp() == nil {
+		t.Error("Failed 状态的容器不应该能再次启动")
+	}
+}
+
+func TestContainer_StateAndClose(t *testing.T) {
+	recorder := &stopRecorder{}
+	c := ioc233.NewContainer()
+	c.Provide(&EarlyBean{recorder: recorder})
+	c.Provide(&LateBean{recorder: recorder})
+	if c.State() != ioc233.StateCreated {
+		t.Fatalf("新容器应该处于 Created 状态, 得到 %s", c.State())
+	}
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	if c.State() != ioc233.StateStarted {
+		t.Fatalf("启动后应该处于 Started 状态, 得到 %s", c.State())
+	}
+	if err := c.Close(); err != nil {
+		t.Fatalf("关闭应该成功, 错误: %v", err)
+	}
+	if c.State() != ioc233.StateClosed {
+		t.Errorf("关闭后应该处于 Closed 状态, 得到 %s", c.State())
+	}
+	if len(recorder.stopped) != 2 || recorder.stopped[0] != "LateBean" {
+		t.Errorf("Close 应该按逆序停止, 得到 %v", recorder.stopped)
+	}
+	_ = c.Close()
+	if len(recorder.stopped) != 2 {
+		t.Error("重复 Close 不应该再次触发停止回调")
+	}
+}
@@ -0,0 +1,72 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== bean 元数据与标签查询测试 ====================
+
+type SessionCache struct{}
+
+type QuoteCache struct{}
+
+type ProdAuditTrail struct {
+	_ struct{} `profile:"prod"`
+}
+
+func TestBeanMetadata_FindBeansByTag(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&SessionCache{}, ioc233.WithTags("cache", "critical", " ", "cache"), ioc233.WithDescription("登录会话缓存"))
+	c.ProvideByName("quotes", &QuoteCache{}, ioc233.WithTags("cache"))
+	c.Provide(&UserServiceImpl{ID: 1})
+
+	caches := c.FindBeansByTag("cache")
+	if len(caches) != 2 || caches[0].Name != "SessionCache" || caches[1].Name != "quotes" {
+		t.Fatalf("应该按注册顺序返回带标签的 bean, 实际: %+v", caches)
+	}
+	if session := caches[0]; len(session.Tags) != 2 || !session.HasTag("critical") || session.Description != "登录会话缓存" {
+		t.Errorf("标签应该去重并忽略空白，描述应该保留, 实际: %+v", session)
+	}
+	if critical := c.FindBeansByTag("critical"); len(critical) != 1 || critical[0].TypeName != "*tests.SessionCache" {
+		t.Errorf("应该只返回带 critical 标签的 bean, 实际: %+v", critical)
+	}
+	if none := c.FindBeansByTag("missing"); len(none) != 0 {
+		t.Errorf("没有 bean 带该标签时应该返回空, 实际: %+v", none)
+	}
+
+	info, ok := c.LookupBean("quotes")
+	if !ok || !info.HasTag("cache") || info.Instance == nil {
+		t.Errorf("LookupBean 应该返回 bean 及元数据, 实际: %+v %v", info, ok)
+	}
+	info.Tags[0] = "mutated"
+	if again, _ := c.LookupBean("quotes"); again.Tags[0] != "cache" {
+		t.Error("修改返回的标签不应该影响容器")
+	}
+}
+
+func TestBeanMetadata_ProfileRegistrationAndDump(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.SetActiveProfiles("prod")
+	c.Provide(&ProdAuditTrail{}, ioc233.WithTags("audit"), ioc233.WithDescription("审计日志"))
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	defer c.Close()
+	if audit := c.FindBeansByTag("audit"); len(audit) != 1 || audit[0].Name != "ProdAuditTrail" {
+		t.Errorf("按 profile 注册的 bean 也应该应用元数据, 实际: %+v", audit)
+	}
+
+	var buf bytes.Buffer
+	if err := c.DumpJSON(&buf); err != nil {
+		t.Fatalf("转储应该成功, 错误: %v", err)
+	}
+	var dump ioc233.ContainerDump
+	json.Unmarshal(buf.Bytes(), &dump)
+	if len(dump.Beans) != 1 || dump.Beans[0].Description != "审计日志" || dump.Beans[0].Tags[0] != "audit" {
+		t.Errorf("转储应该包含标签与描述, 实际: %+v", dump.Beans)
+	}
+}
@@ -0,0 +1,63 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 容器运行指标测试 ====================
+
+type MetricsOrphan struct {
+	Orders OrderService `autowire:"true"`
+}
+
+func TestMetrics_CountsFailuresAndLazyResolutions(t *testing.T) {
+	c := ioc233.NewContainer()
+	if m := c.Metrics(); m.State != ioc233.StateCreated || m.StartupDuration != 0 {
+		t.Fatalf("启动前不应该有启动耗时, 实际: %+v", m)
+	}
+	consumer := &LazyConsumer{}
+	c.Provide(consumer)
+	c.Provide(&UserServiceImpl{ID: 1})
+	c.Provide(&EnglishGreeter{})
+	c.Provide(&MetricsOrphan{})
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+
+	m := c.Metrics()
+	if m.State != ioc233.StateStarted || m.Beans != 4 || m.StartupDuration <= 0 {
+		t.Fatalf("启动后的指标不正确: %+v", m)
+	}
+	if m.InjectionFailures != 1 {
+		t.Errorf("缺失依赖应该计入 1 次注入失败, 实际: %d", m.InjectionFailures)
+	}
+	if m.LazyResolutions != 0 {
+		t.Errorf("懒加载依赖在首次使用前不应该解析, 实际: %d", m.LazyResolutions)
+	}
+
+	// 解析结果会被复用，重复调用只计一次
+	consumer.Users.Get()
+	consumer.Users.Get()
+	consumer.Greeter.Greet("neko")
+	consumer.Greeter.Greet("neko")
+	if m := c.Metrics(); m.LazyResolutions != 2 || m.LazyFailures != 0 {
+		t.Errorf("应该记录 2 次懒加载解析, 实际: %+v", m)
+	}
+}
+
+func TestMetrics_LazyFailure(t *testing.T) {
+	c := ioc233.NewContainer()
+	consumer := &LazyConsumer{}
+	c.Provide(consumer)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	if _, err := consumer.Users.TryGet(); err == nil {
+		t.Fatal("未注册的懒加载依赖应该返回错误")
+	}
+	if m := c.Metrics(); m.LazyResolutions != 1 || m.LazyFailures != 1 {
+		t.Errorf("解析失败应该计入懒加载失败, 实际: %+v", m)
+	}
+}
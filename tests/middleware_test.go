@@ -0,0 +1,116 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 路由中间件链测试 ====================
+
+type TraceHeaderMiddleware struct {
+	name  string
+	order int
+}
+
+func (m *TraceHeaderMiddleware) Order() int { return m.order }
+
+func (m *TraceHeaderMiddleware) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("X-Chain", m.name)
+		next.ServeHTTP(w, r)
+	})
+}
+
+type TokenGuard struct {
+	Users *UserServiceImpl `autowire:"true"`
+}
+
+func (g *TokenGuard) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func serveWithToken(mux *http.ServeMux, path string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.Header.Set("Authorization", "token")
+	mux.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestMiddleware_OrderedByPriority(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&TraceHeaderMiddleware{name: "inner", order: 20})
+	c.ProvideByName("outer", &TraceHeaderMiddleware{name: "outer", order: 10})
+	c.ProvideMiddleware(&TokenGuard{})
+	c.Provide(&UserServiceImpl{ID: 1})
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+
+	mws := c.Middlewares()
+	if len(mws) != 3 {
+		t.Fatalf("应该发现 3 个中间件, 实际: %d", len(mws))
+	}
+	if first, ok := mws[0].(*TraceHeaderMiddleware); !ok || first.name != "outer" {
+		t.Errorf("Order 最小的中间件应该排在最前, 实际: %#v", mws[0])
+	}
+	if guard, ok := mws[2].(*TokenGuard); !ok || guard.Users == nil {
+		t.Errorf("未实现 IOrdered 的中间件应该排在最后且完成注入, 实际: %#v", mws[2])
+	}
+}
+
+func TestMiddleware_WrapsServeMuxRoutes(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&UserServiceImpl{ID: 9})
+	c.ProvideController(&MemberController{})
+	c.Provide(&TraceHeaderMiddleware{name: "inner", order: 20})
+	c.ProvideByName("outer", &TraceHeaderMiddleware{name: "outer", order: 10})
+	c.ProvideMiddleware(&TokenGuard{})
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	mux := http.NewServeMux()
+	if err := ioc233.MountServeMux(mux, c); err != nil {
+		t.Fatalf("挂载应该成功, 错误: %v", err)
+	}
+
+	rec := serve(mux, http.MethodGet, "/profiles/42")
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("中间件应该能中断处理链, 实际: %d", rec.Code)
+	}
+	if chain := strings.Join(rec.Header().Values("X-Chain"), ","); chain != "outer,inner" {
+		t.Errorf("中间件应该按 Order 由外向内执行, 实际: %q", chain)
+	}
+
+	rec = serveWithToken(mux, "/profiles/42")
+	if rec.Code != http.StatusOK || rec.Body.String() != "profile 42 of user 9" {
+		t.Errorf("通过中间件后应该调用控制器, 实际: %d %q", rec.Code, rec.Body.String())
+	}
+}
+
+func TestMiddleware_ProvideNil(t *testing.T) {
+	c := ioc233.NewContainer()
+	if err := c.ProvideMiddleware(nil); err == nil {
+		t.Error("注册 nil 中间件应该返回错误")
+	}
+}
+
+func TestChainMiddlewares(t *testing.T) {
+	h := ioc233.ChainMiddlewares(http.NotFoundHandler(),
+		&TraceHeaderMiddleware{name: "a"}, &TraceHeaderMiddleware{name: "b"})
+	mux := http.NewServeMux()
+	mux.Handle("/", h)
+	if chain := strings.Join(serve(mux, http.MethodGet, "/").Header().Values("X-Chain"), ","); chain != "a,b" {
+		t.Errorf("第一个中间件应该位于最外层, 实际: %q", chain)
+	}
+}
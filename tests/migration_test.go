@@ -0,0 +1,142 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 数据库迁移测试 ====================
+
+// noopConnector 不会真正建立连接的 driver.Connector（迁移测试使用自定义记录与锁，不访问数据库）
+type noopConnector struct{}
+
+func (noopConnector) Connect(context.Context) (driver.Conn, error) {
+	return nil, errors.New("noop connector")
+}
+func (noopConnector) Driver() driver.Driver { return nil }
+
+type memoryMigrationStore struct {
+	applied map[int]bool
+}
+
+func (s *memoryMigrationStore) AppliedVersions(context.Context, *sql.DB) (map[int]bool, error) {
+	result := make(map[int]bool, len(s.applied))
+	for v := range s.applied {
+		result[v] = true
+	}
+	return result, nil
+}
+
+func (s *memoryMigrationStore) MarkApplied(_ context.Context, _ *sql.DB, version int) error {
+	s.applied[version] = true
+	return nil
+}
+
+type countingMigrationLock struct {
+	locked, unlocked int
+}
+
+func (l *countingMigrationLock) Lock(context.Context, *sql.DB) (func() error, error) {
+	l.locked++
+	return func() error {
+		l.unlocked++
+		return nil
+	}, nil
+}
+
+type MigrationLog struct {
+	Versions []int
+}
+
+type CreateUsersMigration struct {
+	Log *MigrationLog `autowire:"true"`
+}
+
+func (m *CreateUsersMigration) Version() int { return 1 }
+func (m *CreateUsersMigration) Up(context.Context, *sql.DB) error {
+	m.Log.Versions = append(m.Log.Versions, 1)
+	return nil
+}
+
+type AddEmailMigration struct {
+	Log  *MigrationLog `autowire:"true"`
+	Fail bool
+}
+
+func (m *AddEmailMigration) Version() int { return 2 }
+func (m *AddEmailMigration) Up(context.Context, *sql.DB) error {
+	if m.Fail {
+		return errors.New("boom")
+	}
+	m.Log.Versions = append(m.Log.Versions, 2)
+	return nil
+}
+
+func newMigrationContainer(store *memoryMigrationStore, lock *countingMigrationLock, addEmail *AddEmailMigration) (*ioc233.Container, *MigrationLog) {
+	c := ioc233.NewContainer()
+	log := &MigrationLog{}
+	c.Provide(log)
+	c.Provide(sql.OpenDB(noopConnector{}))
+	c.Provide(store)
+	c.Provide(lock)
+	// 故意倒序注册，验证按 Version 排序执行
+	c.Provide(addEmail)
+	c.Provide(&CreateUsersMigration{})
+	return c, log
+}
+
+func TestMigration_RunsInVersionOrderAndSkipsApplied(t *testing.T) {
+	store := &memoryMigrationStore{applied: map[int]bool{}}
+	lock := &countingMigrationLock{}
+	c, log := newMigrationContainer(store, lock, &AddEmailMigration{})
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	if len(log.Versions) != 2 || log.Versions[0] != 1 || log.Versions[1] != 2 {
+		t.Fatalf("迁移应该按版本升序执行, 实际: %v", log.Versions)
+	}
+	if lock.locked != 1 || lock.unlocked != 1 {
+		t.Errorf("迁移锁应该获取并释放一次, 实际: lock=%d unlock=%d", lock.locked, lock.unlocked)
+	}
+
+	// 第二个副本：版本已记录，不应重复执行
+	c2, log2 := newMigrationContainer(store, &countingMigrationLock{}, &AddEmailMigration{})
+	if err := c2.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	if len(log2.Versions) != 0 {
+		t.Errorf("已执行的迁移应该跳过, 实际: %v", log2.Versions)
+	}
+}
+
+func TestMigration_FailureAbortsStartUp(t *testing.T) {
+	store := &memoryMigrationStore{applied: map[int]bool{}}
+	lock := &countingMigrationLock{}
+	c, _ := newMigrationContainer(store, lock, &AddEmailMigration{Fail: true})
+	if err := c.StartUp(); err == nil {
+		t.Fatal("迁移失败时启动应该失败")
+	}
+	if c.State() != ioc233.StateFailed {
+		t.Errorf("迁移失败后容器状态应该为 Failed, 实际: %v", c.State())
+	}
+	if !store.applied[1] || store.applied[2] {
+		t.Errorf("只有成功的迁移应该被记录, 实际: %v", store.applied)
+	}
+	if lock.unlocked != 1 {
+		t.Error("迁移失败时也应该释放迁移锁")
+	}
+}
+
+func TestMigration_RequiresDatabase(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&MigrationLog{})
+	c.Provide(&CreateUsersMigration{})
+	if err := c.StartUp(); err == nil {
+		t.Fatal("存在迁移但没有 *sql.DB bean 时启动应该失败")
+	}
+}
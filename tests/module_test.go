@@ -0,0 +1,73 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 模块测试 ====================
+
+type storageModule struct {
+	configured int
+}
+
+func (m *storageModule) Configure(c *ioc233.Container) error {
+	m.configured++
+	c.Provide(&SharedDatabase{DSN: "module"})
+	return nil
+}
+
+type repositoryModule struct {
+	storage *storageModule
+}
+
+func (m *repositoryModule) Configure(c *ioc233.Container) error {
+	if err := c.Install(m.storage); err != nil {
+		return err
+	}
+	c.Provide(&ModuleRepository{})
+	return nil
+}
+
+type brokenModule struct{}
+
+func (brokenModule) Configure(*ioc233.Container) error { return errors.New("broken") }
+
+func TestInstall_ModulesAndDependencies(t *testing.T) {
+	c := ioc233.NewContainer()
+	storage := &storageModule{}
+	funcModule := ioc233.RegistryModuleFunc(func(c *ioc233.Container) error {
+		c.Provide(&UserServiceImpl{ID: 1})
+		return nil
+	})
+	if err := c.Install(storage, &repositoryModule{storage: storage}, funcModule); err != nil {
+		t.Fatalf("安装模块应该成功, 错误: %v", err)
+	}
+	if storage.configured != 1 {
+		t.Errorf("共同依赖的模块应该只安装一次, 实际: %d", storage.configured)
+	}
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	repo := ioc233.GetObjectByTypeFrom[*ModuleRepository](c)
+	if repo == nil || repo.DB == nil || repo.DB.DSN != "module" {
+		t.Fatal("模块注册的 bean 应该完成注入")
+	}
+	if ioc233.GetObjectByTypeFrom[UserService](c) == nil {
+		t.Error("RegistryModuleFunc 应该可以作为模块安装")
+	}
+}
+
+func TestInstall_StopsOnError(t *testing.T) {
+	c := ioc233.NewContainer()
+	storage := &storageModule{}
+	err := c.Install(brokenModule{}, storage)
+	if err == nil {
+		t.Fatal("模块返回错误时 Install 应该失败")
+	}
+	if storage.configured != 0 {
+		t.Error("出错后不应该继续安装后续模块")
+	}
+}
@@ -0,0 +1,74 @@
+package tests
+
+import (
+	htmltemplate "html/template"
+	"testing"
+	texttemplate "text/template"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 带命名空间的 bean 名测试 ====================
+
+type MailTemplates struct {
+	Text *texttemplate.Template `autowire:"text/template/Template"`
+	HTML *htmltemplate.Template `autowire:"html/template/Template"`
+}
+
+type ShardConfig struct {
+	Shards int
+}
+
+type ShardRouter struct {
+	Primary *ShardConfig `autowire:"primary/ShardConfig"`
+	Replica *ShardConfig `autowire:"replica/ShardConfig"`
+}
+
+func TestNamespacedNames_SameStructNameInDifferentPackages(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.SetNamespacedNames(true)
+	text := texttemplate.New("text")
+	html := htmltemplate.New("html")
+	c.Provide(text)
+	c.Provide(html)
+	mail := &MailTemplates{}
+	c.Provide(mail)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	defer c.Close()
+
+	if mail.Text != text || mail.HTML != html {
+		t.Errorf("同名结构体应该按包路径区分, 实际: %v %v", mail.Text, mail.HTML)
+	}
+	if name := ioc233.NamespacedName(mail); name != "github.com/neko233-com/ioc233-go/tests/MailTemplates" {
+		t.Errorf("NamespacedName 应该返回 包路径/类型名, 实际: %s", name)
+	}
+	if obj, ok := c.Adapter().ResolveByName(ioc233.NamespacedName(mail)); !ok || obj != mail {
+		t.Errorf("开启后默认 bean 名应该带包路径, 实际: %v %v", obj, ok)
+	}
+}
+
+func TestNamespacedNames_ProvideInNamespace(t *testing.T) {
+	c := ioc233.NewContainer()
+	primary, replica := &ShardConfig{Shards: 8}, &ShardConfig{Shards: 2}
+	if err := c.ProvideInNamespace("primary", primary); err != nil {
+		t.Fatalf("注册应该成功, 错误: %v", err)
+	}
+	if err := c.ProvideInNamespace("replica/", replica); err != nil {
+		t.Fatalf("注册应该成功, 错误: %v", err)
+	}
+	router := &ShardRouter{}
+	c.Provide(router)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	defer c.Close()
+
+	if router.Primary != primary || router.Replica != replica {
+		t.Errorf("应该按 namespace/类型名 注入, 实际: %+v %+v", router.Primary, router.Replica)
+	}
+	if err := c.ProvideInNamespace(" ", &ShardConfig{}); err == nil {
+		t.Error("空命名空间应该返回错误")
+	}
+}
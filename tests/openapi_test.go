@@ -0,0 +1,116 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== OpenAPI 文档生成测试 ====================
+
+type ProductQuery struct {
+	Keyword string `json:"keyword"`
+	Page    int    `json:"page,omitempty"`
+}
+
+type CreateProductRequest struct {
+	Name  string   `json:"name"`
+	Price float64  `json:"price"`
+	Tags  []string `json:"tags,omitempty"`
+}
+
+type Product struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+	Parent    *Product  `json:"parent,omitempty"`
+	secret    string
+}
+
+type CatalogController struct {
+	_ func(ProductQuery) []Product        `route:"GET /products" handler:"List" summary:"查询商品"`
+	_ func(CreateProductRequest) *Product `route:"POST /products" handler:"Create"`
+	_ struct{}                            `route:"ANY /files/*path" handler:"Files"`
+}
+
+func (c *CatalogController) List()   {}
+func (c *CatalogController) Create() {}
+func (c *CatalogController) Files()  {}
+func (c *CatalogController) Get()    {}
+
+func TestOpenAPISpec_FromControllerRoutes(t *testing.T) {
+	c := ioc233.NewContainer()
+	err := c.ProvideController(&CatalogController{},
+		ioc233.Route{Method: "GET", Path: "/products/:id", Handler: "Get", Response: Product{}})
+	if err != nil {
+		t.Fatalf("注册控制器应该成功, 错误: %v", err)
+	}
+	doc := c.OpenAPISpec()
+	if doc.OpenAPI != ioc233.OpenAPIVersion {
+		t.Errorf("文档版本应该是 %s, 实际: %s", ioc233.OpenAPIVersion, doc.OpenAPI)
+	}
+
+	list := doc.Paths["/products"]["get"]
+	if list == nil || list.Summary != "查询商品" || list.OperationID != "CatalogController.List" {
+		t.Fatalf("GET /products 应该生成操作, 实际: %+v", list)
+	}
+	if len(list.Parameters) != 2 || list.Parameters[0].Name != "keyword" || list.Parameters[0].In != "query" ||
+		!list.Parameters[0].Required || list.Parameters[1].Required {
+		t.Errorf("GET 的请求结构体应该展开为查询参数, 实际: %+v", list.Parameters)
+	}
+	resp := list.Responses["200"].Content["application/json"].Schema
+	if resp.Type != "array" || resp.Items.Ref != "#/components/schemas/Product" {
+		t.Errorf("响应类型应该是 Product 数组, 实际: %+v", resp)
+	}
+
+	create := doc.Paths["/products"]["post"]
+	if create == nil || create.RequestBody == nil ||
+		create.RequestBody.Content["application/json"].Schema.Ref != "#/components/schemas/CreateProductRequest" {
+		t.Fatalf("POST 的请求类型应该作为请求体, 实际: %+v", create)
+	}
+
+	get := doc.Paths["/products/{id}"]["get"]
+	if get == nil || len(get.Parameters) != 1 || get.Parameters[0].In != "path" || get.Parameters[0].Name != "id" {
+		t.Errorf("路径参数 :id 应该转换为 {id}, 实际: %+v", get)
+	}
+	if files := doc.Paths["/files/{path}"]; len(files) != 7 {
+		t.Errorf("ANY 路由应该展开为所有方法, 实际: %d", len(files))
+	}
+
+	product := doc.Components.Schemas["Product"]
+	if product == nil || len(product.Properties) != 4 {
+		t.Fatalf("Product 应该登记到 components 且只包含导出字段, 实际: %+v", product)
+	}
+	if product.Properties["createdAt"].Format != "date-time" || product.Properties["parent"].Ref != "#/components/schemas/Product" {
+		t.Errorf("time.Time 与自引用字段应该正确生成, 实际: %+v", product.Properties)
+	}
+	if !reflect.DeepEqual(product.Required, []string{"id", "name", "createdAt"}) {
+		t.Errorf("无 omitempty 的字段应该为必填, 实际: %v", product.Required)
+	}
+	if _, err := json.Marshal(doc); err != nil {
+		t.Errorf("文档应该可以序列化为 JSON, 错误: %v", err)
+	}
+}
+
+func TestOpenAPIHandler(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.ProvideController(&CatalogController{})
+	rec := httptest.NewRecorder()
+	ioc233.OpenAPIHandler(c).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+
+	var doc struct {
+		OpenAPI string                    `json:"openapi"`
+		Paths   map[string]map[string]any `json:"paths"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("应该输出合法 JSON, 错误: %v", err)
+	}
+	if rec.Header().Get("Content-Type") != "application/json" || doc.Paths["/products"]["post"] == nil {
+		t.Errorf("应该输出 OpenAPI 文档, 实际: %s", rec.Body.String())
+	}
+}
@@ -0,0 +1,85 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 有序切片注入测试 ====================
+
+type Middleware interface {
+	Name() string
+}
+
+type AuthMiddleware struct{}
+
+func (m *AuthMiddleware) Name() string { return "auth" }
+func (m *AuthMiddleware) Order() int   { return 10 }
+
+type LogMiddleware struct{}
+
+func (m *LogMiddleware) Name() string { return "log" }
+func (m *LogMiddleware) Order() int   { return 1 }
+
+type MetricsMiddleware struct{}
+
+func (m *MetricsMiddleware) Name() string { return "metrics" }
+
+type Pipeline struct {
+	Chain    []Middleware `autowire:"true"`
+	Optional []Middleware `autowire:"false"`
+}
+
+func TestSliceInjection_Ordered(t *testing.T) {
+	c := ioc233.NewContainer()
+	pipeline := &Pipeline{}
+	c.Provide(&MetricsMiddleware{})
+	c.Provide(&AuthMiddleware{})
+	c.Provide(&LogMiddleware{})
+	c.Provide(pipeline)
+
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+
+	expected := []string{"log", "auth", "metrics"}
+	if len(pipeline.Chain) != len(expected) {
+		t.Fatalf("期望 %d 个中间件, 得到 %d 个", len(expected), len(pipeline.Chain))
+	}
+	for i, name := range expected {
+		if pipeline.Chain[i].Name() != name {
+			t.Errorf("位置 %d: 期望 %s, 得到 %s", i, name, pipeline.Chain[i].Name())
+		}
+	}
+}
+
+func TestSliceInjection_Empty(t *testing.T) {
+	c := ioc233.NewContainer()
+	pipeline := &Pipeline{}
+	c.Provide(pipeline)
+
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	if pipeline.Chain != nil {
+		t.Error("必须注入的切片找不到实现时应该保持原值")
+	}
+	if pipeline.Optional == nil || len(pipeline.Optional) != 0 {
+		t.Error("可选注入的切片找不到实现时应该为空切片")
+	}
+	if errs := c.Validate(); len(errs) != 1 {
+		t.Errorf("Validate 应该报告 1 个错误, 得到 %d 个", len(errs))
+	}
+}
+
+func TestGetObjectsByType_Ordered(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&AuthMiddleware{})
+	c.Provide(&LogMiddleware{})
+
+	all := ioc233.GetObjectsByTypeFrom[Middleware](c)
+	if len(all) != 2 || all[0].Name() != "log" || all[1].Name() != "auth" {
+		t.Fatalf("应该按 Order 返回全部实现, 得到: %v", all)
+	}
+}
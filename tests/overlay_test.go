@@ -0,0 +1,73 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 覆盖层测试 ====================
+
+func TestOverlay_ShadowAndRestore(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&SMTPMailer{})
+	user := &MailerUser{}
+	c.Provide(user)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+
+	c.PushOverlay()
+	fake := &FakeMailer{}
+	c.Provide(fake)
+	if got := ioc233.GetObjectByTypeFrom[Mailer](c); got != fake {
+		t.Fatalf("覆盖层 bean 应该优先解析, 实际: %T", got)
+	}
+	if user.Mailer.Send("a") != "smtp" {
+		t.Error("不同类型且不同名的覆盖层 bean 不应该替换已注入的字段")
+	}
+
+	c.PushOverlay()
+	_ = c.ProvideByName("SMTPMailer", &MockMailer{})
+	if user.Mailer.Send("a") != "mock" {
+		t.Fatal("同名覆盖层 bean 应该重新注入依赖方")
+	}
+	if ioc233.GetObjectByTypeFrom[*SMTPMailer](c) != nil {
+		t.Error("被遮蔽的 bean 不应该再按类型解析到")
+	}
+
+	if err := c.PopOverlay(); err != nil {
+		t.Fatalf("弹出覆盖层应该成功, 错误: %v", err)
+	}
+	if user.Mailer.Send("a") != "smtp" {
+		t.Error("弹出后应该恢复原来的注入")
+	}
+	if ioc233.GetObjectByTypeFrom[Mailer](c) != fake {
+		t.Error("外层覆盖层仍应生效")
+	}
+
+	_ = c.PopOverlay()
+	if _, ok := ioc233.GetObjectByTypeFrom[Mailer](c).(*SMTPMailer); !ok {
+		t.Error("全部弹出后应该恢复原有注册")
+	}
+	if len(ioc233.GetObjectsByTypeFrom[Mailer](c)) != 1 {
+		t.Error("全部弹出后不应该残留覆盖层 bean")
+	}
+	if err := c.PopOverlay(); err == nil {
+		t.Error("没有覆盖层时弹出应该返回错误")
+	}
+}
+
+func TestOverlay_SameTypeBeforeStartUp(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&UserServiceImpl{ID: 1})
+	c.PushOverlay()
+	c.Provide(&UserServiceImpl{ID: 2})
+	if us := ioc233.GetObjectByTypeFrom[*UserServiceImpl](c); us == nil || us.ID != 2 {
+		t.Fatalf("覆盖层中的同类型 bean 应该遮蔽底层 bean, 实际: %+v", us)
+	}
+	_ = c.PopOverlay()
+	if us := ioc233.GetObjectByTypeFrom[*UserServiceImpl](c); us == nil || us.ID != 1 {
+		t.Errorf("弹出后应该恢复底层 bean, 实际: %+v", us)
+	}
+}
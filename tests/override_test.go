@@ -0,0 +1,84 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 测试覆盖测试 ====================
+
+type FakeMailer struct {
+	Sent []string
+}
+
+func (m *FakeMailer) Send(to string) string {
+	m.Sent = append(m.Sent, to)
+	return "fake"
+}
+
+func TestOverrideByName_ReplaceWithFakeBeforeStartUp(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&SMTPMailer{})
+	user := &MailerUser{}
+	c.Provide(user)
+
+	fake := &FakeMailer{}
+	if err := c.OverrideByName("SMTPMailer", fake); err != nil {
+		t.Fatalf("启动前覆盖应该成功, 错误: %v", err)
+	}
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	if user.Mailer.Send("a@b.c") != "fake" || len(fake.Sent) != 1 {
+		t.Fatal("应该注入覆盖后的假实现")
+	}
+	if ioc233.GetObjectByTypeFrom[*SMTPMailer](c) != nil {
+		t.Error("被覆盖的真实实现不应该再按类型解析到")
+	}
+	if len(ioc233.GetObjectsByTypeFrom[Mailer](c)) != 1 {
+		t.Error("覆盖不应该留下重复的实现")
+	}
+}
+
+func TestOverride_SameTypeOrRegister(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&UserServiceImpl{ID: 1})
+	if err := c.Override(&UserServiceImpl{ID: 2}); err != nil {
+		t.Fatalf("覆盖同类型 bean 应该成功, 错误: %v", err)
+	}
+	if err := c.Override(&FakeMailer{}); err != nil {
+		t.Fatalf("覆盖未注册的类型应该直接注册, 错误: %v", err)
+	}
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	if us := ioc233.GetObjectByTypeFrom[*UserServiceImpl](c); us == nil || us.ID != 2 {
+		t.Errorf("应该解析到覆盖后的实例, 实际: %+v", us)
+	}
+	if obj, ok := c.Adapter().ResolveByName("FakeMailer"); !ok || obj == nil {
+		t.Error("未注册的类型应该按默认名注册")
+	}
+}
+
+func TestOverride_RequiresTestModeAfterStartUp(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&SMTPMailer{})
+	user := &MailerUser{}
+	c.Provide(user)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	if err := c.OverrideByName("SMTPMailer", &FakeMailer{}); err == nil {
+		t.Fatal("非测试模式下启动后覆盖应该返回错误")
+	}
+
+	ioc233.SetTestMode(true)
+	defer ioc233.SetTestMode(false)
+	if err := c.OverrideByName("SMTPMailer", &FakeMailer{}); err != nil {
+		t.Fatalf("测试模式下覆盖应该成功, 错误: %v", err)
+	}
+	if user.Mailer.Send("x") != "fake" {
+		t.Error("启动后覆盖应该重新注入依赖方")
+	}
+}
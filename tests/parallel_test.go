@@ -0,0 +1,113 @@
+package tests
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 并行注入测试 ====================
+
+type ParallelShared struct {
+	events []string
+}
+
+type ParallelLeaf struct {
+	Shared *ParallelShared    `autowire:"true"`
+	Port   int                `value:"${parallel.port:8080}"`
+	Peers  []*ParallelScratch `autowire:"false"`
+}
+
+type ParallelScratch struct{}
+
+// ParallelBuilder 含 autowire:"new" 字段，在并发注入之后顺序注入
+type ParallelBuilder struct {
+	Shared *ParallelShared  `autowire:"true"`
+	Fresh  *ParallelScratch `autowire:"new"`
+}
+
+// ParallelRoot 与 ParallelEdge 的 Log 在构造时赋值（注入前回调中字段尚未注入）
+type ParallelRoot struct {
+	Log    *ParallelShared
+	Shared *ParallelShared `autowire:"true"`
+}
+
+func (r *ParallelRoot) OnInjectBefore() { r.Log.events = append(r.Log.events, "before:root") }
+func (r *ParallelRoot) OnInjectAfter()  { r.Log.events = append(r.Log.events, "after:root") }
+
+type ParallelEdge struct {
+	_      struct{} `dependsOn:"ParallelRoot"`
+	Log    *ParallelShared
+	Shared *ParallelShared `autowire:"true"`
+}
+
+func (e *ParallelEdge) OnInjectBefore() { e.Log.events = append(e.Log.events, "before:edge") }
+func (e *ParallelEdge) OnInjectAfter()  { e.Log.events = append(e.Log.events, "after:edge") }
+
+func TestSetInjectionWorkers_InjectsManyBeans(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.SetInjectionWorkers(8)
+	shared := &ParallelShared{}
+	c.Provide(shared)
+	leaves := make([]*ParallelLeaf, 200)
+	for i := range leaves {
+		leaves[i] = &ParallelLeaf{}
+		c.ProvideByName(fmt.Sprintf("leaf-%d", i), leaves[i])
+	}
+	builder := &ParallelBuilder{}
+	c.Provide(builder)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	for i, leaf := range leaves {
+		if leaf.Shared != shared || leaf.Port != 8080 {
+			t.Fatalf("第 %d 个 bean 应该完成注入, 实际: %+v", i, leaf)
+		}
+	}
+	if builder.Shared != shared || builder.Fresh == nil {
+		t.Errorf("含 autowire:\"new\" 字段的 bean 应该顺序注入成功, 实际: %+v", builder)
+	}
+	if n := c.Metrics().InjectionFailures; n != 0 {
+		t.Errorf("并行注入不应该产生注入失败, 实际: %d", n)
+	}
+}
+
+func TestSetInjectionWorkers_CallbacksKeepDependencyOrder(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.SetInjectionWorkers(4)
+	shared := &ParallelShared{}
+	edge, root := &ParallelEdge{Log: shared}, &ParallelRoot{Log: shared}
+	c.Provide(edge)
+	c.Provide(root)
+	c.Provide(shared)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	if edge.Shared != shared || root.Shared != shared {
+		t.Fatal("带回调的 bean 应该完成注入")
+	}
+	want := "before:root,before:edge,after:root,after:edge"
+	if got := strings.Join(shared.events, ","); got != want {
+		t.Errorf("并行注入时回调应该保持依赖顺序\n期望: %s\n实际: %s", want, got)
+	}
+}
+
+type ParallelMissing struct {
+	Gateway *PluginGateway `autowire:"true"`
+}
+
+func TestSetInjectionWorkers_ReportsFailuresPerBean(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.SetInjectionWorkers(4)
+	for i := 0; i < 20; i++ {
+		c.ProvideByName(fmt.Sprintf("missing-%d", i), &ParallelMissing{})
+	}
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("缺失依赖不应该导致启动失败, 错误: %v", err)
+	}
+	if n := c.Metrics().InjectionFailures; n != 20 {
+		t.Errorf("每个 bean 的注入失败都应该被计数, 期望 20, 实际: %d", n)
+	}
+}
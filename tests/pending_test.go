@@ -0,0 +1,76 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 待定依赖补齐测试 ====================
+
+type PluginGateway interface {
+	Charge(amount int) error
+}
+
+type StripePluginGateway struct{}
+
+func (g *StripePluginGateway) Charge(amount int) error { return nil }
+
+type PluginCheckout struct {
+	Gateway PluginGateway `autowire:"true"`
+	Refunds PluginGateway `autowire:"refundGateway"`
+	Audit   PluginGateway `autowire:"false"`
+
+	resolved []string
+}
+
+func (s *PluginCheckout) OnDependencyResolved(field string) {
+	s.resolved = append(s.resolved, field)
+}
+
+func TestPendingDependencies_BackfilledWhenProvidedLater(t *testing.T) {
+	c := ioc233.NewContainer()
+	checkout := &PluginCheckout{}
+	c.Provide(checkout)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("缺失依赖记为注入失败，不应阻止启动, 错误: %v", err)
+	}
+
+	pending := c.PendingDependencies()
+	if len(pending) != 2 || pending[0].Field != "Gateway" || pending[1].Field != "Refunds" {
+		t.Fatalf("必须字段与名称注入字段应该登记为待定依赖（可选字段除外）, 实际: %+v", pending)
+	}
+
+	stripe := &StripePluginGateway{}
+	c.Provide(stripe)
+	if checkout.Gateway != stripe {
+		t.Fatal("匹配的 bean 注册后应该补齐待定字段")
+	}
+	if checkout.Audit != nil {
+		t.Error("可选字段不应该被补齐")
+	}
+	if len(checkout.resolved) != 1 || checkout.resolved[0] != "Gateway" {
+		t.Errorf("补齐后应该触发 IDependencyResolved, 实际: %v", checkout.resolved)
+	}
+	if got := c.PendingDependencies(); len(got) != 1 || got[0].Field != "Refunds" {
+		t.Errorf("已补齐的依赖应该从待定列表移除, 实际: %+v", got)
+	}
+
+	refunds := &StripePluginGateway{}
+	_ = c.ProvideByName("refundGateway", refunds)
+	if checkout.Refunds != refunds || len(c.PendingDependencies()) != 0 {
+		t.Error("名称注入的待定字段应该在同名 bean 注册后补齐")
+	}
+}
+
+func TestPendingDependencies_EmptyWhenAllResolved(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&UserServiceImpl{})
+	c.Provide(&OrderServiceImpl{})
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	if got := c.PendingDependencies(); len(got) != 0 {
+		t.Errorf("所有依赖满足时不应该有待定依赖, 实际: %+v", got)
+	}
+}
@@ -0,0 +1,85 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 分阶段启动测试 ====================
+
+// phaseLog 记录回调顺序
+type phaseLog struct {
+	events []string
+}
+
+func (l *phaseLog) add(e string) { l.events = append(l.events, e) }
+
+type PhaseDB struct {
+	log *phaseLog
+}
+
+func (d *PhaseDB) OnInjectBefore()   { d.log.add("inject:db") }
+func (d *PhaseDB) OnInjectComplete() { d.log.add("complete:db") }
+
+type PhaseRepo struct {
+	_  struct{} `phase:"domain"`
+	DB *PhaseDB `autowire:"true"`
+
+	log *phaseLog
+}
+
+func (r *PhaseRepo) OnInjectBefore()   { r.log.add("inject:repo") }
+func (r *PhaseRepo) OnInjectComplete() { r.log.add("complete:repo") }
+
+type PhaseController struct {
+	Repo *PhaseRepo `autowire:"true"`
+
+	log *phaseLog
+}
+
+func (h *PhaseController) OnInjectBefore()   { h.log.add("inject:controller") }
+func (h *PhaseController) OnInjectComplete() { h.log.add("complete:controller") }
+
+func TestPhases_EachPhaseCompletesBeforeNext(t *testing.T) {
+	log := &phaseLog{}
+	c := ioc233.NewContainer()
+	if err := c.DefinePhases("infrastructure", "domain"); err != nil {
+		t.Fatalf("DefinePhases 应该成功, 错误: %v", err)
+	}
+	// 注册顺序与阶段顺序相反
+	c.Provide(&PhaseController{log: log})
+	c.Provide(&PhaseRepo{log: log})
+	c.Provide(&PhaseDB{log: log}, ioc233.InPhase("infrastructure"))
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+
+	want := "inject:db,complete:db,inject:repo,complete:repo,inject:controller,complete:controller"
+	if got := strings.Join(log.events, ","); got != want {
+		t.Errorf("每个阶段应该完成注入与回调后再进入下一阶段\n期望: %s\n实际: %s", want, got)
+	}
+}
+
+func TestPhases_UndefinedPhaseFailsStartUp(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&PhaseRepo{log: &phaseLog{}})
+	if err := c.StartUp(); err == nil || !strings.Contains(err.Error(), "domain") {
+		t.Fatalf("声明未定义的阶段时启动应该失败并指出阶段名, 实际: %v", err)
+	}
+}
+
+func TestPhases_DefineValidation(t *testing.T) {
+	c := ioc233.NewContainer()
+	if err := c.DefinePhases("a", "a"); err == nil {
+		t.Error("重复的阶段名应该返回错误")
+	}
+	if err := c.DefinePhases(""); err == nil {
+		t.Error("空阶段名应该返回错误")
+	}
+	_ = c.StartUp()
+	if err := c.DefinePhases("a"); err == nil {
+		t.Error("StartUp 之后定义阶段应该返回错误")
+	}
+}
@@ -0,0 +1,127 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== bean 后置处理器测试 ====================
+
+// CountingMailer 包装 Mailer 的计数代理
+type CountingMailer struct {
+	Target Mailer
+	Calls  int
+}
+
+func (m *CountingMailer) Send(to string) string {
+	m.Calls++
+	return m.Target.Send(to)
+}
+
+// mailerProxyProcessor 注入后把 Mailer 实现包装为计数代理，并记录调用顺序
+type mailerProxyProcessor struct {
+	calls []string
+}
+
+func (p *mailerProxyProcessor) BeforeInject(name string, bean any) (any, error) {
+	p.calls = append(p.calls, "before:"+name)
+	return nil, nil
+}
+
+func (p *mailerProxyProcessor) AfterInject(name string, bean any) (any, error) {
+	p.calls = append(p.calls, "after:"+name)
+	if m, ok := bean.(*SMTPMailer); ok {
+		return &CountingMailer{Target: m}, nil
+	}
+	return bean, nil
+}
+
+func TestPostProcessor_WrapsBeanInProxy(t *testing.T) {
+	c := ioc233.NewContainer()
+	user := &MailerUser{}
+	_ = c.ProvideByName("user", user)
+	_ = c.ProvideByName("mailer", &SMTPMailer{})
+	p := &mailerProxyProcessor{}
+	c.RegisterPostProcessor(p)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+
+	want := []string{"before:user", "after:user", "before:mailer", "after:mailer"}
+	if len(p.calls) != len(want) {
+		t.Fatalf("钩子调用顺序不正确, 期望 %v, 实际 %v", want, p.calls)
+	}
+	for i := range want {
+		if p.calls[i] != want[i] {
+			t.Fatalf("钩子调用顺序不正确, 期望 %v, 实际 %v", want, p.calls)
+		}
+	}
+
+	proxy, ok := user.Mailer.(*CountingMailer)
+	if !ok {
+		t.Fatalf("已注入的字段应该被改为代理, 实际: %T", user.Mailer)
+	}
+	if user.Mailer.Send("a@b.c") != "smtp" || proxy.Calls != 1 {
+		t.Error("代理应该转发调用到原实例")
+	}
+	if obj, ok := c.Adapter().ResolveByName("mailer"); !ok || obj != proxy {
+		t.Errorf("按名称应该解析到代理, 实际: %T", obj)
+	}
+	if ioc233.GetObjectByTypeFrom[*SMTPMailer](c) != nil {
+		t.Error("被替换的原实例不应该再按类型解析到")
+	}
+}
+
+// replacingProcessor 注入前把 UserServiceImpl 替换为新实例
+type replacingProcessor struct{}
+
+func (replacingProcessor) BeforeInject(name string, bean any) (any, error) {
+	if _, ok := bean.(*UserServiceImpl); ok {
+		return &UserServiceImpl{ID: 42}, nil
+	}
+	return nil, nil
+}
+
+func (replacingProcessor) AfterInject(string, any) (any, error) { return nil, nil }
+
+func TestPostProcessor_ReplaceBeforeInject(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&UserServiceImpl{ID: 1})
+	c.RegisterPostProcessor(replacingProcessor{})
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	if us := ioc233.GetObjectByTypeFrom[UserService](c); us == nil || us.(*UserServiceImpl).ID != 42 {
+		t.Errorf("应该解析到替换后的实例, 实际: %+v", us)
+	}
+}
+
+// failingProcessor 处理指定 bean 时返回错误
+type failingProcessor struct {
+	err error
+}
+
+func (p failingProcessor) BeforeInject(string, any) (any, error) { return nil, nil }
+
+func (p failingProcessor) AfterInject(name string, bean any) (any, error) {
+	if name == "mailer" {
+		return nil, p.err
+	}
+	return nil, nil
+}
+
+func TestPostProcessor_ErrorAbortsStartUp(t *testing.T) {
+	c := ioc233.NewContainer()
+	_ = c.ProvideByName("mailer", &SMTPMailer{})
+	boom := errors.New("boom")
+	c.RegisterPostProcessor(failingProcessor{err: boom})
+	err := c.StartUp()
+	if !errors.Is(err, boom) {
+		t.Fatalf("后置处理器出错时启动应该失败, 实际: %v", err)
+	}
+	if c.State() != ioc233.StateFailed {
+		t.Errorf("容器状态应该为失败, 实际: %v", c.State())
+	}
+}
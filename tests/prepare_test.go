@@ -0,0 +1,148 @@
+package tests
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 并发注册测试 ====================
+
+type PreparedWorker struct {
+	Queue   string `default:"jobs"`
+	Retries int    `value:"${worker.retries:3}" default:"5"`
+	Tags    map[string]string
+	Users   *UserServiceImpl `autowire:"false"`
+}
+
+func TestProvideByName_ConcurrentRegistrationInitializesFields(t *testing.T) {
+	c := ioc233.NewContainer()
+	workers := make([]*PreparedWorker, 200)
+	var wg sync.WaitGroup
+	for i := range workers {
+		workers[i] = &PreparedWorker{}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := c.ProvideByName(fmt.Sprintf("worker-%d", i), workers[i]); err != nil {
+				t.Errorf("并发注册应该成功, 错误: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	for i, w := range workers {
+		if w.Queue != "jobs" || w.Retries != 3 || w.Tags == nil {
+			t.Fatalf("第 %d 个 bean 的基础字段应该被初始化, 实际: %+v", i, w)
+		}
+		if obj, ok := c.Adapter().ResolveByName(fmt.Sprintf("worker-%d", i)); !ok || obj != w {
+			t.Fatalf("第 %d 个 bean 应该按名称注册", i)
+		}
+	}
+}
+
+func TestProvideByName_RejectedDuplicateIsNotMutated(t *testing.T) {
+	c := ioc233.NewContainer()
+	first := &PreparedWorker{}
+	if err := c.ProvideByName("worker", first); err != nil {
+		t.Fatalf("首次注册应该成功, 错误: %v", err)
+	}
+	dup := &PreparedWorker{}
+	if err := c.ProvideByName("worker", dup); err == nil {
+		t.Fatal("重复名称注册应该返回错误")
+	}
+	if dup.Queue != "" || dup.Retries != 0 || dup.Tags != nil {
+		t.Errorf("被拒绝的重复注册不应该初始化字段, 实际: %+v", dup)
+	}
+	if first.Queue != "jobs" || first.Tags == nil {
+		t.Errorf("通过检查的注册应该初始化字段, 实际: %+v", first)
+	}
+
+	c.SetDuplicatePolicy(ioc233.DuplicateKeepFirst)
+	kept := &PreparedWorker{}
+	c.Provide(&PreparedWorker{})
+	c.Provide(kept)
+	if kept.Queue != "" || kept.Tags != nil {
+		t.Errorf("保留首个实例时被忽略的注册不应该初始化字段, 实际: %+v", kept)
+	}
+}
+
+func TestProvide_ConcurrentSameNameAndTypeRegisteredOnce(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.SetQuietStartup(true)
+	const n = 64
+	var wg sync.WaitGroup
+	var accepted atomic.Int32
+	for i := 0; i < n; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if err := c.ProvideByName("shared-worker", &PreparedWorker{}); err == nil {
+				accepted.Add(1)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			c.Provide(&UserServiceImpl{})
+		}()
+	}
+	wg.Wait()
+
+	// 同名注册只能有一个通过分片预留，其余按重复策略处理
+	if got := accepted.Load(); got != 1 {
+		t.Fatalf("并发的同名 ProvideByName 应该只有一个成功, 实际: %d", got)
+	}
+	names := 0
+	types := 0
+	c.Adapter().Range(func(name string, obj any) bool {
+		switch {
+		case name == "shared-worker":
+			names++
+		case name == "UserServiceImpl":
+			types++
+		}
+		return true
+	})
+	if names != 1 || types != 1 {
+		t.Errorf("同名、同类型的 bean 应该各注册一次, 实际: name=%d type=%d", names, types)
+	}
+}
+
+// BenchmarkProvideByName_Concurrent 并发注册的吞吐（多核机器上用 -cpu 1,4,8 对比写锁与分片竞争）
+func BenchmarkProvideByName_Concurrent(b *testing.B) {
+	prev := ioc233.GetLogger()
+	ioc233.SetLogger(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	defer ioc233.SetLogger(prev)
+	c := ioc233.NewContainer()
+	var seq atomic.Int64
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			name := "worker-" + strconv.FormatInt(seq.Add(1), 10)
+			if err := c.ProvideByName(name, &PreparedWorker{}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+type PreparedBadDefault struct {
+	Port int `default:"http"`
+}
+
+func TestProvide_PreparedFieldErrorsStillFailStartUp(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&PreparedBadDefault{})
+	if err := c.StartUp(); err == nil {
+		t.Fatal("读锁下初始化产生的 default 错误仍应该导致启动失败")
+	}
+}
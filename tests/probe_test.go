@@ -0,0 +1,92 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 存活与就绪探针测试 ====================
+
+type ToggleHealth struct {
+	down atomic.Bool
+}
+
+func (h *ToggleHealth) Health(ctx context.Context) error {
+	if h.down.Load() {
+		return errors.New("downstream unavailable")
+	}
+	return nil
+}
+
+type probeBody struct {
+	Status string `json:"status"`
+	State  string `json:"state"`
+	Checks []struct {
+		Bean   string `json:"bean"`
+		Status string `json:"status"`
+		Error  string `json:"error"`
+	} `json:"checks"`
+}
+
+func probe(t *testing.T, h http.Handler, path string) (int, probeBody) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	var body probeBody
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("探针应该返回 JSON, 错误: %v, 内容: %s", err, rec.Body.String())
+	}
+	return rec.Code, body
+}
+
+func TestProbes_FollowStartupAndHealth(t *testing.T) {
+	c := ioc233.NewContainer()
+	dep := &ToggleHealth{}
+	c.ProvideByName("downstream", dep)
+	health, ready := ioc233.HealthHandler(c), ioc233.ReadyHandler(c)
+
+	if code, _ := probe(t, health, ioc233.HealthPath); code != http.StatusOK {
+		t.Errorf("启动前存活探针应该返回 200, 实际: %d", code)
+	}
+	if code, body := probe(t, ready, ioc233.ReadyPath); code != http.StatusServiceUnavailable || body.State != "Created" {
+		t.Errorf("启动前就绪探针应该返回 503, 实际: %d %+v", code, body)
+	}
+
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	code, body := probe(t, ready, ioc233.ReadyPath)
+	if code != http.StatusOK || body.Status != "UP" || len(body.Checks) != 1 || body.Checks[0].Bean != "downstream" {
+		t.Errorf("启动完成且健康时就绪探针应该返回 200, 实际: %d %+v", code, body)
+	}
+
+	dep.down.Store(true)
+	for _, h := range []http.Handler{health, ready} {
+		code, body := probe(t, h, ioc233.ReadyPath)
+		if code != http.StatusServiceUnavailable || body.Status != "DOWN" || body.Checks[0].Error != "downstream unavailable" {
+			t.Errorf("健康检查失败时探针应该返回 503 与失败原因, 实际: %d %+v", code, body)
+		}
+	}
+
+	dep.down.Store(false)
+	c.Close()
+	if code, _ := probe(t, health, ioc233.HealthPath); code != http.StatusServiceUnavailable {
+		t.Errorf("关闭后存活探针应该返回 503, 实际: %d", code)
+	}
+}
+
+func TestProbes_RejectNonGet(t *testing.T) {
+	c := ioc233.NewContainer()
+	rec := httptest.NewRecorder()
+	ioc233.HealthHandler(c).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, ioc233.HealthPath, nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST 应该返回 405, 实际: %d", rec.Code)
+	}
+}
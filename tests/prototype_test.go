@@ -0,0 +1,168 @@
+package tests
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 原型作用域测试 ====================
+
+type TaskContext struct {
+	Users   UserService `autowire:"true"`
+	Visited map[string]bool
+	Ready   bool
+}
+
+func (t *TaskContext) OnInjectComplete() { t.Ready = true }
+
+type TaskRunner struct {
+	First  *TaskContext `autowire:"true"`
+	Second *TaskContext `autowire:"TaskContext"`
+}
+
+func TestPrototype_FreshInstancePerResolve(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&UserServiceImpl{ID: 1})
+	if err := c.ProvidePrototype(func() *TaskContext { return &TaskContext{} }); err != nil {
+		t.Fatalf("ProvidePrototype 应该成功, 错误: %v", err)
+	}
+	runner := &TaskRunner{}
+	c.Provide(runner)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+
+	if runner.First == nil || runner.Second == nil {
+		t.Fatal("原型 bean 应该被注入")
+	}
+	if runner.First == runner.Second {
+		t.Fatal("每次注入都应该创建新实例")
+	}
+
+	a := ioc233.GetObjectByTypeFrom[*TaskContext](c)
+	b := ioc233.GetObjectByTypeFrom[*TaskContext](c)
+	if a == nil || a == b {
+		t.Fatal("每次 Get 都应该创建新实例")
+	}
+	if a.Users == nil || a.Visited == nil || !a.Ready {
+		t.Errorf("原型实例应该完成注入、基础初始化与回调: %+v", a)
+	}
+}
+
+func TestPrototype_FactoryParams(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&UserServiceImpl{ID: 9})
+	err := c.ProvidePrototype(func(us UserService) *OrderServiceImpl {
+		return &OrderServiceImpl{UserService: us}
+	})
+	if err != nil {
+		t.Fatalf("ProvidePrototype 应该成功, 错误: %v", err)
+	}
+	order := ioc233.GetObjectByTypeFrom[OrderService](c)
+	if order == nil || order.(*OrderServiceImpl).UserService == nil {
+		t.Fatal("原型工厂的参数应该从容器解析")
+	}
+}
+
+func TestPrototype_Invalid(t *testing.T) {
+	c := ioc233.NewContainer()
+	if err := c.ProvidePrototype("not a func"); err == nil {
+		t.Fatal("非函数参数应该返回错误")
+	}
+	factory := func() *TaskContext { return &TaskContext{} }
+	_ = c.ProvidePrototype(factory)
+	if err := c.ProvidePrototype(factory); err == nil {
+		t.Fatal("重复注册原型类型应该返回错误")
+	}
+}
+
+// BrokenTaskContext 基础字段与必须依赖都无法满足的原型
+type BrokenTaskContext struct {
+	Retries int         `default:"not-a-number"`
+	Mail    *MailSender `autowire:"true"`
+	Ready   bool
+}
+
+func (b *BrokenTaskContext) OnInjectComplete() { b.Ready = true }
+
+func TestPrototype_InitErrorsReturnedToCaller(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.SetQuietStartup(true)
+	if err := c.ProvidePrototype(func() *BrokenTaskContext { return &BrokenTaskContext{} }); err != nil {
+		t.Fatalf("ProvidePrototype 应该成功, 错误: %v", err)
+	}
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	defer c.Close()
+
+	if obj := ioc233.GetObjectByTypeFrom[*BrokenTaskContext](c); obj != nil {
+		t.Fatalf("初始化失败的原型实例不应该返回给调用方: %+v", obj)
+	}
+	// 读路径上的原型构造失败只返回给调用方，不应该记为容器的致命错误
+	if errs := c.Validate(); len(errs) != 0 {
+		t.Errorf("原型构造失败不应该写入容器的致命错误: %v", errs)
+	}
+}
+
+// ==================== 原型预热测试 ====================
+
+type MatchAIContext struct {
+	Serial    int
+	Destroyed bool
+}
+
+func (m *MatchAIContext) OnDestroy() { m.Destroyed = true }
+
+func waitStandby(c *ioc233.Container, name string, want int) bool {
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if c.StandbyCount(name) == want {
+			return true
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return false
+}
+
+func TestPrototypeStandby_PreloadAndReplenish(t *testing.T) {
+	var created atomic.Int32
+	c := ioc233.NewContainer()
+	_ = c.ProvidePrototype(func() *MatchAIContext {
+		return &MatchAIContext{Serial: int(created.Add(1))}
+	})
+	if err := c.SetPrototypeStandby("MatchAIContext", 3); err != nil {
+		t.Fatalf("开启预热应该成功, 错误: %v", err)
+	}
+	if err := c.SetPrototypeStandby("Missing", 1); err == nil {
+		t.Error("未注册的原型应该返回错误")
+	}
+	if c.StandbyCount("MatchAIContext") != 0 {
+		t.Error("启动前不应该预热")
+	}
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	if !waitStandby(c, "MatchAIContext", 3) {
+		t.Fatalf("启动后应该预热 3 个实例, 实际: %d", c.StandbyCount("MatchAIContext"))
+	}
+
+	first := ioc233.GetObjectByTypeFrom[*MatchAIContext](c)
+	if first == nil || first.Serial > 3 {
+		t.Fatalf("应该取到预热的实例, 实际: %+v", first)
+	}
+	if !waitStandby(c, "MatchAIContext", 3) {
+		t.Fatal("取走后应该在后台补充")
+	}
+
+	_ = c.Close()
+	if c.StandbyCount("MatchAIContext") != 0 {
+		t.Error("关闭后应该清空备用实例")
+	}
+	if first.Destroyed {
+		t.Error("已取走的原型实例不应该由容器销毁")
+	}
+}
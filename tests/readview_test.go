@@ -0,0 +1,68 @@
+package tests
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 启动后无锁读取测试 ====================
+
+type ViewCache struct {
+	Version int
+}
+
+func TestReadView_ReflectsChangesAfterStartUp(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&EmailNotifier{})
+	c.Provide(&ViewCache{Version: 1})
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	if got := ioc233.GetObjectByTypeFrom[*ViewCache](c); got == nil || got.Version != 1 {
+		t.Fatalf("启动后应该能按类型获取, 实际: %v", got)
+	}
+	if n := ioc233.GetObjectByTypeFrom[IndexedNotifier](c); n == nil || n.Channel() != "email" {
+		t.Fatalf("启动后应该能按接口获取, 实际: %v", n)
+	}
+
+	// 替换与启动后注册应该立即可见
+	if err := c.Swap(&ViewCache{Version: 2}); err != nil {
+		t.Fatalf("Swap 应该成功, 错误: %v", err)
+	}
+	if got := ioc233.GetObjectByTypeFrom[*ViewCache](c); got.Version != 2 {
+		t.Errorf("Swap 之后应该获取到新实例, 实际版本: %d", got.Version)
+	}
+	c.ProvideByName("sms", &SMSNotifier{})
+	if obj, ok := c.Adapter().ResolveByName("sms"); !ok || obj.(IndexedNotifier).Channel() != "sms" {
+		t.Errorf("启动后注册的 bean 应该可以按名称获取, 实际: %v %v", obj, ok)
+	}
+}
+
+func TestReadView_ConcurrentReadsDuringRegistration(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&ViewCache{Version: 1})
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 500; j++ {
+				if got := ioc233.GetObjectByTypeFrom[*ViewCache](c); got == nil {
+					t.Error("并发读取不应该返回 nil")
+					return
+				}
+			}
+		}()
+	}
+	for i := 0; i < 50; i++ {
+		c.ProvideByName(fmt.Sprintf("user-%d", i), &UserServiceImpl{ID: i})
+	}
+	wg.Wait()
+}
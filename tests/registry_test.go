@@ -0,0 +1,101 @@
+package tests
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 共享注册表测试 ====================
+
+type MailSender struct{}
+
+type JobWorker struct {
+	Mail *MailSender `autowire:"true"`
+}
+
+func newTestRegistry() *ioc233.Registry {
+	return ioc233.NewRegistry().
+		Module("user", func(c *ioc233.Container) error {
+			c.Provide(&UserServiceImpl{ID: 1})
+			return nil
+		}).
+		Module("order", func(c *ioc233.Container) error {
+			c.Provide(&OrderServiceImpl{})
+			return nil
+		}).
+		Module("mail", func(c *ioc233.Container) error {
+			c.Provide(&MailSender{})
+			return nil
+		}).
+		Module("jobs", func(c *ioc233.Container) error {
+			c.Provide(&JobWorker{})
+			return nil
+		})
+}
+
+func TestRegistry_CheckPass(t *testing.T) {
+	r := newTestRegistry().Binary("server", "user", "order")
+	if err := r.Check("server"); err != nil {
+		t.Fatalf("server 装配应该通过校验, 错误: %v", err)
+	}
+}
+
+func TestRegistry_CheckDrift(t *testing.T) {
+	r := newTestRegistry().
+		Binary("server", "user", "order", "mail").
+		Binary("worker", "order", "jobs")
+
+	err := r.CheckAll()
+	if err == nil {
+		t.Fatal("worker 缺少 user/mail 模块，校验应该失败")
+	}
+	if !strings.Contains(err.Error(), "worker") {
+		t.Errorf("错误信息应该指出出问题的二进制, 得到: %v", err)
+	}
+	if r.Check("server") != nil {
+		t.Error("server 装配完整，不应报错")
+	}
+}
+
+func TestRegistry_ApplyUnknown(t *testing.T) {
+	r := newTestRegistry().Binary("server", "user", "missing")
+	c := ioc233.NewContainer()
+	if err := r.Apply(c, "server"); err == nil {
+		t.Fatal("引用未声明模块应该返回错误")
+	}
+	if err := r.Apply(c, "nobody"); err == nil {
+		t.Fatal("未声明的二进制应该返回错误")
+	}
+}
+
+func TestRegistry_ApplyAndStartUp(t *testing.T) {
+	r := newTestRegistry().Binary("server", "order", "user")
+	c := ioc233.NewContainer()
+	if err := r.Apply(c, "server"); err != nil {
+		t.Fatalf("Apply 应该成功, 错误: %v", err)
+	}
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	order := ioc233.GetObjectByTypeFrom[*OrderServiceImpl](c)
+	if order == nil || order.UserService == nil {
+		t.Fatal("独立容器中的依赖应该被注入")
+	}
+}
+
+func TestGenerateRegistryCheck(t *testing.T) {
+	var buf bytes.Buffer
+	err := ioc233.GenerateRegistryCheck(&buf, "wiring", "Registry", nil, "server", "game-worker")
+	if err != nil {
+		t.Fatalf("生成应该成功, 错误: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"package wiring", "func TestWiring_server", "func TestWiring_game_worker", `Registry.Check("game-worker")`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("生成代码缺少 %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 启动报告与 nil 字段扫描测试 ====================
+
+type ForgetfulService struct {
+	Users    UserService  `autowire:"true"`
+	Mail     *MailSender  `autowire:"false"`
+	Orders   OrderService `autowire:"orders"`
+	Optional *MailSender
+}
+
+func TestStartupReport_NilFieldScan(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.SetNilFieldScan(true)
+	c.Provide(&UserServiceImpl{ID: 1})
+	c.Provide(&ForgetfulService{})
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+
+	report := c.StartupReport()
+	if report == nil || report.BeanCount != 2 {
+		t.Fatalf("启动后应该生成报告, 实际: %+v", report)
+	}
+	if len(report.NilFields) != 2 {
+		t.Fatalf("应该报告 2 个 nil 字段, 实际: %v", report.NilFields)
+	}
+	fields := map[string]string{}
+	for _, issue := range report.NilFields {
+		fields[issue.Field] = issue.Reason
+	}
+	if !strings.Contains(fields["Mail"], "autowire:\"false\"") {
+		t.Errorf("可选字段的原因应该说明可选注入未找到, 实际: %q", fields["Mail"])
+	}
+	if !strings.Contains(fields["Orders"], "orders") {
+		t.Errorf("名称注入字段的原因应该包含 bean 名, 实际: %q", fields["Orders"])
+	}
+	if !strings.Contains(report.String(), "ForgetfulService.Orders") {
+		t.Errorf("汇总报告应该包含 struct.field, 实际: %s", report.String())
+	}
+}
+
+func TestStartupReport_ScanDisabledByDefault(t *testing.T) {
+	c := ioc233.NewContainer()
+	if c.StartupReport() != nil {
+		t.Error("启动前不应该有报告")
+	}
+	c.Provide(&ForgetfulService{})
+	_ = c.StartUp()
+	if report := c.StartupReport(); report == nil || len(report.NilFields) != 0 {
+		t.Errorf("未开启扫描时不应该报告 nil 字段, 实际: %+v", report)
+	}
+}
+
+type SlowWarmupCache struct {
+	Users UserService `autowire:"true"`
+}
+
+func (s *SlowWarmupCache) OnInjectComplete() { time.Sleep(20 * time.Millisecond) }
+
+type SlowDialer struct{}
+
+func NewSlowDialer() *SlowDialer {
+	time.Sleep(10 * time.Millisecond)
+	return &SlowDialer{}
+}
+
+func TestStartupReport_BeanTimings(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&UserServiceImpl{ID: 1})
+	c.Provide(&SlowWarmupCache{})
+	if err := c.ProvideFactory(NewSlowDialer); err != nil {
+		t.Fatalf("ProvideFactory 应该成功, 错误: %v", err)
+	}
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+
+	report := c.StartupReport()
+	if len(report.BeanTimings) != 3 {
+		t.Fatalf("应该记录 3 个 bean 的耗时, 实际: %+v", report.BeanTimings)
+	}
+	slowest := report.SlowestBeans(2)
+	if len(slowest) != 2 || slowest[0].Bean != "SlowWarmupCache" || slowest[1].Bean != "SlowDialer" {
+		t.Fatalf("最慢的 bean 应该按耗时降序排列, 实际: %+v", slowest)
+	}
+	if slowest[0].Callbacks < 20*time.Millisecond {
+		t.Errorf("回调耗时应该计入 Callbacks, 实际: %v", slowest[0].Callbacks)
+	}
+	if slowest[1].Construct < 10*time.Millisecond {
+		t.Errorf("构造函数耗时应该计入 Construct, 实际: %v", slowest[1].Construct)
+	}
+	if !strings.Contains(report.String(), "SlowWarmupCache") {
+		t.Errorf("汇总报告应该列出最慢的 bean, 实际: %s", report.String())
+	}
+	if all := report.SlowestBeans(0); len(all) != 3 {
+		t.Errorf("n <= 0 时应该返回全部, 实际: %d", len(all))
+	}
+}
@@ -0,0 +1,156 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 托管运行重启策略测试 ====================
+
+// FlakyWorker 前 failures 次运行失败（奇数次返回错误、偶数次 panic），之后阻塞到 ctx 取消
+type FlakyWorker struct {
+	failures int32
+	runs     atomic.Int32
+	healthy  chan struct{}
+}
+
+func (w *FlakyWorker) Start(ctx context.Context) error {
+	n := w.runs.Add(1)
+	if n <= w.failures {
+		if n%2 == 0 {
+			panic("worker crashed")
+		}
+		return errors.New("connection lost")
+	}
+	close(w.healthy)
+	<-ctx.Done()
+	return nil
+}
+
+func (w *FlakyWorker) Stop(ctx context.Context) error { return nil }
+
+// OneShotWorker 每次运行立即正常返回
+type OneShotWorker struct {
+	runs   atomic.Int32
+	policy ioc233.RestartPolicy
+}
+
+func (w *OneShotWorker) Start(ctx context.Context) error {
+	w.runs.Add(1)
+	return nil
+}
+
+func (w *OneShotWorker) Stop(ctx context.Context) error { return nil }
+
+func (w *OneShotWorker) RestartPolicy() ioc233.RestartPolicy { return w.policy }
+
+func fastRestart(mode ioc233.RestartMode) ioc233.RestartPolicy {
+	return ioc233.RestartPolicy{Mode: mode, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+}
+
+func TestRestartPolicy_OnFailureRestartsUntilHealthy(t *testing.T) {
+	c := ioc233.NewContainer()
+	worker := &FlakyWorker{failures: 3, healthy: make(chan struct{})}
+	c.ProvideByName("worker", worker)
+	c.SetRestartPolicy("worker", fastRestart(ioc233.RestartOnFailure))
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	defer c.Close()
+
+	select {
+	case <-worker.healthy:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("on-failure 策略应该在错误与 panic 后重启, 运行次数: %d", worker.runs.Load())
+	}
+	if n := worker.runs.Load(); n != 4 {
+		t.Errorf("应该运行 4 次（3 次失败 + 1 次恢复）, 实际: %d", n)
+	}
+}
+
+func TestRestartPolicy_NeverByDefault(t *testing.T) {
+	c := ioc233.NewContainer()
+	worker := &FlakyWorker{failures: 1, healthy: make(chan struct{})}
+	c.Provide(worker)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	c.Close()
+
+	if n := worker.runs.Load(); n != 1 {
+		t.Errorf("默认策略不应该重启, 运行次数: %d", n)
+	}
+}
+
+func TestRestartPolicy_OnFailureIgnoresCleanExit(t *testing.T) {
+	c := ioc233.NewContainer()
+	worker := &OneShotWorker{policy: fastRestart(ioc233.RestartOnFailure)}
+	c.Provide(worker)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	c.Close()
+
+	if n := worker.runs.Load(); n != 1 {
+		t.Errorf("on-failure 策略下正常返回不应该重启, 运行次数: %d", n)
+	}
+}
+
+func TestRestartPolicy_AlwaysWithMaxRestarts(t *testing.T) {
+	c := ioc233.NewContainer()
+	policy := fastRestart(ioc233.RestartAlways)
+	policy.MaxRestarts = 3
+	worker := &OneShotWorker{policy: policy}
+	c.Provide(worker)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for worker.runs.Load() < 4 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(30 * time.Millisecond)
+	c.Close()
+	if n := worker.runs.Load(); n != 4 {
+		t.Errorf("always 策略应该重启到 MaxRestarts 为止（共 4 次运行）, 实际: %d", n)
+	}
+}
+
+func TestRestartPolicy_SetterOverridesInterface(t *testing.T) {
+	c := ioc233.NewContainer()
+	worker := &OneShotWorker{policy: fastRestart(ioc233.RestartAlways)}
+	c.ProvideByName("oneshot", worker)
+	c.SetRestartPolicy("oneshot", ioc233.RestartPolicy{Mode: ioc233.RestartNever})
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	c.Close()
+
+	if n := worker.runs.Load(); n != 1 {
+		t.Errorf("SetRestartPolicy 应该优先于 IRestartable, 运行次数: %d", n)
+	}
+}
+
+func TestRestartPolicy_NoRestartAfterClose(t *testing.T) {
+	c := ioc233.NewContainer()
+	loop := &GameLoop{started: make(chan struct{})}
+	c.ProvideByName("loop", loop)
+	c.SetRestartPolicy("loop", fastRestart(ioc233.RestartAlways))
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	<-loop.started
+	// GameLoop 第二次启动会重复 close(started) 而 panic，关闭后不应该重启
+	if err := c.Close(); err != nil {
+		t.Errorf("关闭应该成功, 错误: %v", err)
+	}
+}
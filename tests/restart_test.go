@@ -0,0 +1,63 @@
+package tests
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 重复 StartUp 测试 ====================
+
+type StartCounter struct {
+	Users UserService `autowire:"true"`
+
+	completes atomic.Int32
+}
+
+func (s *StartCounter) OnInjectComplete() { s.completes.Add(1) }
+
+func TestRepeatedStartUp_OnlyInjectsNewBeans(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&UserServiceImpl{})
+	first := &StartCounter{}
+	c.Provide(first)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("重复调用 StartUp 应该成功, 错误: %v", err)
+	}
+	if n := first.completes.Load(); n != 1 {
+		t.Errorf("已注入的 bean 不应该重复触发回调, 实际调用 %d 次", n)
+	}
+
+	second := &StartCounter{}
+	_ = c.ProvideByName("second", second)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("重复调用 StartUp 应该成功, 错误: %v", err)
+	}
+	if first.completes.Load() != 1 || second.completes.Load() != 1 || second.Users == nil {
+		t.Errorf("每个 bean 只应该注入并回调一次, 实际: first=%d second=%d", first.completes.Load(), second.completes.Load())
+	}
+}
+
+type ReentrantStarter struct {
+	c   *ioc233.Container
+	err error
+}
+
+func (r *ReentrantStarter) OnInjectComplete() { r.err = r.c.StartUp() }
+
+func TestRepeatedStartUp_ReentrantCallReturnsError(t *testing.T) {
+	c := ioc233.NewContainer()
+	r := &ReentrantStarter{c: c}
+	c.Provide(r)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	if !errors.Is(r.err, ioc233.ErrStartUpInProgress) {
+		t.Errorf("回调中重复调用 StartUp 应该返回 ErrStartUpInProgress, 实际: %v", r.err)
+	}
+}
@@ -0,0 +1,114 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 应用入口测试 ====================
+
+func runInBackground(ctx context.Context, c *ioc233.Container, opts ...ioc233.RunOption) <-chan error {
+	done := make(chan error, 1)
+	go func() { done <- ioc233.RunContext(ctx, c, opts...) }()
+	return done
+}
+
+func waitRunResult(t *testing.T, done <-chan error) error {
+	t.Helper()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run 应该在关闭后返回")
+		return nil
+	}
+}
+
+func TestRun_ClosesWhenContextCancelled(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&UserServiceImpl{ID: 1})
+	loop := &GameLoop{started: make(chan struct{})}
+	c.Provide(loop)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := runInBackground(ctx, c)
+	select {
+	case <-loop.started:
+	case <-time.After(time.Second):
+		t.Fatal("Run 应该启动容器中的托管运行 bean")
+	}
+	cancel()
+
+	if err := waitRunResult(t, done); err != nil {
+		t.Errorf("正常关闭应该返回 nil, 实际: %v", err)
+	}
+	if !loop.stopped.Load() || !loop.exited.Load() {
+		t.Error("Run 返回前托管运行 bean 应该已停止")
+	}
+	if c.State() != ioc233.StateClosed {
+		t.Errorf("Run 返回后容器应该已关闭, 实际状态: %v", c.State())
+	}
+}
+
+func TestRun_ClosesOnSignal(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&UserServiceImpl{ID: 1})
+	loop := &GameLoop{started: make(chan struct{})}
+	c.Provide(loop)
+
+	done := runInBackground(context.Background(), c)
+	select {
+	case <-loop.started:
+	case <-time.After(time.Second):
+		t.Fatal("Run 应该启动容器中的托管运行 bean")
+	}
+	proc, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		t.Fatalf("获取当前进程失败: %v", err)
+	}
+	if err := proc.Signal(syscall.SIGTERM); err != nil {
+		t.Skipf("当前平台不支持发送信号: %v", err)
+	}
+
+	if err := waitRunResult(t, done); err != nil {
+		t.Errorf("收到 SIGTERM 后应该正常关闭, 实际: %v", err)
+	}
+	if !loop.exited.Load() {
+		t.Error("收到 SIGTERM 后托管运行 bean 应该已停止")
+	}
+}
+
+func TestRun_GracePeriodExceeded(t *testing.T) {
+	c := ioc233.NewContainer()
+	runner := &StubbornRunner{stop: make(chan struct{}), started: make(chan struct{})}
+	defer close(runner.stop)
+	c.Provide(runner)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := runInBackground(ctx, c, ioc233.WithGracePeriod(20*time.Millisecond))
+	<-runner.started
+	cancel()
+
+	if err := waitRunResult(t, done); !errors.Is(err, ioc233.ErrShutdownTimeout) {
+		t.Errorf("宽限期内未停止应该返回 ErrShutdownTimeout, 实际: %v", err)
+	}
+}
+
+func TestRun_ReturnsStartUpError(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&PreparedBadDefault{})
+
+	err := waitRunResult(t, runInBackground(context.Background(), c))
+	if err == nil {
+		t.Fatal("启动失败时 Run 应该返回错误")
+	}
+	if c.State() != ioc233.StateClosed {
+		t.Errorf("启动失败后容器应该已关闭, 实际状态: %v", c.State())
+	}
+}
@@ -0,0 +1,163 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 托管运行测试 ====================
+
+type GameLoop struct {
+	Users   *UserServiceImpl `autowire:"true"`
+	started chan struct{}
+	ticks   atomic.Int32
+	exited  atomic.Bool
+	stopped atomic.Bool
+	order   *[]string
+	mu      *sync.Mutex
+	name    string
+}
+
+func (g *GameLoop) Start(ctx context.Context) error {
+	close(g.started)
+	ticker := time.NewTicker(time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			g.exited.Store(true)
+			return ctx.Err()
+		case <-ticker.C:
+			g.ticks.Add(1)
+		}
+	}
+}
+
+func (g *GameLoop) Stop(ctx context.Context) error {
+	g.stopped.Store(true)
+	if g.order != nil {
+		g.mu.Lock()
+		*g.order = append(*g.order, g.name)
+		g.mu.Unlock()
+	}
+	return nil
+}
+
+type StubbornRunner struct {
+	stop    chan struct{}
+	started chan struct{}
+}
+
+func (s *StubbornRunner) Start(ctx context.Context) error {
+	if s.started != nil {
+		close(s.started)
+	}
+	<-s.stop
+	return nil
+}
+
+func (s *StubbornRunner) Stop(ctx context.Context) error { return nil }
+
+func TestRunnable_StartedAfterStartUpAndStoppedOnClose(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&UserServiceImpl{ID: 1})
+	loop := &GameLoop{started: make(chan struct{})}
+	c.Provide(loop)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+
+	select {
+	case <-loop.started:
+	case <-time.After(time.Second):
+		t.Fatal("StartUp 之后托管运行 bean 应该被启动")
+	}
+	if loop.Users == nil {
+		t.Error("托管运行 bean 启动时依赖应该已注入")
+	}
+
+	c.Close()
+	if !loop.stopped.Load() {
+		t.Error("Close 应该调用 Stop")
+	}
+	if !loop.exited.Load() {
+		t.Error("Close 返回前 Start 应该已随 ctx 取消退出")
+	}
+}
+
+func TestRunnable_StopInReverseOrder(t *testing.T) {
+	var order []string
+	var mu sync.Mutex
+	c := ioc233.NewContainer()
+	c.ProvideByName("first", &GameLoop{started: make(chan struct{}), order: &order, mu: &mu, name: "first"})
+	c.ProvideByName("second", &GameLoop{started: make(chan struct{}), order: &order, mu: &mu, name: "second"})
+	c.Provide(&UserServiceImpl{ID: 1})
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	c.Close()
+
+	if len(order) != 2 || order[0] != "second" || order[1] != "first" {
+		t.Errorf("Stop 应该按注册逆序调用, 实际: %v", order)
+	}
+}
+
+func TestRunnable_LateRegistrationStarted(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&UserServiceImpl{ID: 1})
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	loop := &GameLoop{started: make(chan struct{})}
+	if err := c.Provide(loop); err != nil {
+		t.Fatalf("启动后注册应该成功, 错误: %v", err)
+	}
+
+	select {
+	case <-loop.started:
+	case <-time.After(time.Second):
+		t.Fatal("启动后注册的托管运行 bean 应该立即启动")
+	}
+	c.Close()
+	if !loop.exited.Load() {
+		t.Error("Close 应该停止启动后注册的托管运行 bean")
+	}
+}
+
+func TestRunnable_NotStartedWithoutStartUp(t *testing.T) {
+	c := ioc233.NewContainer()
+	loop := &GameLoop{started: make(chan struct{})}
+	c.Provide(loop)
+	c.Close()
+
+	select {
+	case <-loop.started:
+		t.Error("未启动的容器不应该运行托管运行 bean")
+	default:
+	}
+}
+
+func TestRunnable_StopTimeout(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.SetRunnableStopTimeout(20 * time.Millisecond)
+	runner := &StubbornRunner{stop: make(chan struct{})}
+	defer close(runner.stop)
+	c.Provide(runner)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+
+	begin := time.Now()
+	if err := c.Close(); !errors.Is(err, ioc233.ErrShutdownTimeout) {
+		t.Errorf("bean 未按时退出时 Close 应该返回 ErrShutdownTimeout, 实际: %v", err)
+	}
+	if elapsed := time.Since(begin); elapsed > time.Second {
+		t.Errorf("不响应停止的 bean 不应该阻塞 Close 超过超时时间, 实际耗时: %v", elapsed)
+	}
+}
@@ -0,0 +1,295 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 任务调度测试 ====================
+
+func TestParseCron_Next(t *testing.T) {
+	cases := []struct {
+		expr string
+		from time.Time
+		want time.Time
+	}{
+		{"*/15 * * * *", time.Date(2026, 3, 2, 10, 7, 30, 0, time.UTC), time.Date(2026, 3, 2, 10, 15, 0, 0, time.UTC)},
+		{"0 0 9 * * MON-FRI", time.Date(2026, 3, 7, 12, 0, 0, 0, time.UTC), time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2026, 12, 31, 23, 59, 59, 0, time.UTC), time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"30 2 1,15 * *", time.Date(2026, 3, 1, 2, 30, 0, 0, time.UTC), time.Date(2026, 3, 15, 2, 30, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tc := range cases {
+		s, err := ioc233.ParseCron(tc.expr)
+		if err != nil {
+			t.Fatalf("解析 %q 应该成功, 错误: %v", tc.expr, err)
+		}
+		if got := s.Next(tc.from); !got.Equal(tc.want) {
+			t.Errorf("%q 从 %v 起的下次触发应为 %v, 实际: %v", tc.expr, tc.from, tc.want, got)
+		}
+	}
+	for _, bad := range []string{"* * *", "61 * * * *", "*/0 * * * *", "* * * FOO *"} {
+		if _, err := ioc233.ParseCron(bad); err == nil {
+			t.Errorf("非法表达式 %q 应该返回错误", bad)
+		}
+	}
+}
+
+type HeartbeatJob struct {
+	Users UserService `autowire:"true"`
+	Count atomic.Int32
+}
+
+func (j *HeartbeatJob) Schedule() ioc233.ScheduleSpec {
+	return ioc233.ScheduleSpec{FixedRate: 5 * time.Millisecond}
+}
+
+func (j *HeartbeatJob) Run(ctx context.Context) error {
+	j.Count.Add(1)
+	return nil
+}
+
+// concurrencyProbe 记录任务的最大并发数
+type concurrencyProbe struct {
+	mu      sync.Mutex
+	current int
+	max     int
+}
+
+func (p *concurrencyProbe) run(d time.Duration) {
+	p.mu.Lock()
+	p.current++
+	if p.current > p.max {
+		p.max = p.current
+	}
+	p.mu.Unlock()
+	time.Sleep(d)
+	p.mu.Lock()
+	p.current--
+	p.mu.Unlock()
+}
+
+func (p *concurrencyProbe) peak() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.max
+}
+
+type SlowReportJob struct {
+	_     struct{} `schedule:"fixedRate:5ms" overlap:"skip"`
+	probe concurrencyProbe
+}
+
+func (j *SlowReportJob) Run(ctx context.Context) error {
+	j.probe.run(30 * time.Millisecond)
+	return nil
+}
+
+func waitFor(cond func() bool) bool {
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return true
+		}
+		time.Sleep(2 * time.Millisecond)
+	}
+	return false
+}
+
+func jobStatus(s *ioc233.Scheduler, name string) ioc233.JobStatus {
+	for _, st := range s.Jobs() {
+		if st.Name == name {
+			return st
+		}
+	}
+	return ioc233.JobStatus{}
+}
+
+func TestScheduler_InterfaceJobAndClose(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&UserServiceImpl{ID: 1})
+	job := &HeartbeatJob{}
+	c.Provide(job)
+	c.EnableScheduler()
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	if job.Users == nil {
+		t.Fatal("任务 bean 应该完成注入")
+	}
+	if !waitFor(func() bool { return job.Count.Load() >= 3 }) {
+		t.Fatalf("固定频率任务应该被多次执行, 实际: %d", job.Count.Load())
+	}
+	_ = c.Close()
+	stopped := job.Count.Load()
+	time.Sleep(20 * time.Millisecond)
+	if job.Count.Load() != stopped {
+		t.Error("容器关闭后任务不应该继续执行")
+	}
+}
+
+func TestScheduler_TagJobOverlapSkip(t *testing.T) {
+	c := ioc233.NewContainer()
+	job := &SlowReportJob{}
+	c.Provide(job)
+	s := c.EnableScheduler()
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	defer c.Close()
+	if !waitFor(func() bool { return jobStatus(s, "SlowReportJob").Skipped > 0 }) {
+		t.Fatal("重叠的触发应该被跳过")
+	}
+	if job.probe.peak() != 1 {
+		t.Errorf("skip 策略下不应该并发执行, 最大并发: %d", job.probe.peak())
+	}
+}
+
+func TestScheduler_OverlapConcurrentAndQueue(t *testing.T) {
+	c := ioc233.NewContainer()
+	s := c.EnableScheduler()
+	var concurrent, queued concurrencyProbe
+	var queuedRuns atomic.Int32
+	_ = s.Schedule("concurrent", ioc233.ScheduleSpec{FixedRate: 5 * time.Millisecond, Overlap: ioc233.OverlapConcurrent},
+		func(ctx context.Context) error { concurrent.run(30 * time.Millisecond); return nil })
+	_ = s.Schedule("queued", ioc233.ScheduleSpec{FixedRate: time.Hour, Overlap: ioc233.OverlapQueue},
+		func(ctx context.Context) error { queued.run(20 * time.Millisecond); queuedRuns.Add(1); return nil })
+	if err := s.Schedule("queued", ioc233.ScheduleSpec{FixedRate: time.Second}, func(context.Context) error { return nil }); err == nil {
+		t.Error("重复的任务名应该返回错误")
+	}
+	if err := s.Schedule("bad", ioc233.ScheduleSpec{}, func(context.Context) error { return nil }); err == nil {
+		t.Error("未声明调度方式应该返回错误")
+	}
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	defer c.Close()
+
+	if !waitFor(func() bool { return concurrent.peak() > 1 }) {
+		t.Error("concurrent 策略应该允许并发执行")
+	}
+	_ = s.Trigger("queued")
+	_ = s.Trigger("queued")
+	if !waitFor(func() bool { return queuedRuns.Load() == 3 }) {
+		t.Fatalf("queue 策略应该依次执行排队的触发, 实际执行: %d", queuedRuns.Load())
+	}
+	if queued.peak() != 1 {
+		t.Errorf("queue 策略下不应该并发执行, 最大并发: %d", queued.peak())
+	}
+}
+
+func TestScheduler_AdminPauseResume(t *testing.T) {
+	c := ioc233.NewContainer()
+	job := &HeartbeatJob{}
+	c.Provide(&UserServiceImpl{ID: 1})
+	c.Provide(job)
+	s := c.EnableScheduler()
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	defer c.Close()
+
+	mux := http.NewServeMux()
+	mux.Handle(ioc233.SchedulerPath+"/", ioc233.SchedulerHandler(s))
+	post := func(path string) int {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, ioc233.SchedulerPath+path, nil))
+		return rec.Code
+	}
+	if code := post("/HeartbeatJob/pause"); code != http.StatusNoContent {
+		t.Fatalf("暂停应该成功, 状态码: %d", code)
+	}
+	if !jobStatus(s, "HeartbeatJob").Paused {
+		t.Fatal("任务应该处于暂停状态")
+	}
+	time.Sleep(10 * time.Millisecond)
+	paused := job.Count.Load()
+	time.Sleep(20 * time.Millisecond)
+	if job.Count.Load() != paused {
+		t.Error("暂停期间任务不应该执行")
+	}
+	if code := post("/HeartbeatJob/resume"); code != http.StatusNoContent {
+		t.Fatalf("恢复应该成功, 状态码: %d", code)
+	}
+	if !waitFor(func() bool { return job.Count.Load() > paused }) {
+		t.Error("恢复后任务应该继续执行")
+	}
+	if code := post("/Missing/pause"); code != http.StatusNotFound {
+		t.Errorf("不存在的任务应该返回 404, 实际: %d", code)
+	}
+}
+
+type memoryJobStore struct {
+	mu   sync.Mutex
+	last map[string]time.Time
+	runs []ioc233.JobRun
+}
+
+func (m *memoryJobStore) LastRun(job string) (time.Time, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	t, ok := m.last[job]
+	return t, ok, nil
+}
+
+func (m *memoryJobStore) SaveRun(run ioc233.JobRun) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.runs = append(m.runs, run)
+	m.last[run.Job] = run.ScheduledAt
+	return nil
+}
+
+func (m *memoryJobStore) runCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.runs)
+}
+
+type SettlementJob struct {
+	_         struct{} `schedule:"cron:0 * * * *"`
+	MissedArg int
+}
+
+func (j *SettlementJob) Run(ctx context.Context) error { return nil }
+
+func (j *SettlementJob) OnMissedRuns(last time.Time, missed int) { j.MissedArg = missed }
+
+func TestScheduler_PersistenceDetectsMissedRuns(t *testing.T) {
+	now := time.Now()
+	store := &memoryJobStore{last: map[string]time.Time{
+		// 以整点为基准，避免测试恰好跨过整点时多算一次
+		"SettlementJob": now.Truncate(time.Hour).Add(-3*time.Hour + time.Minute),
+		"sync":          now.Add(-time.Hour),
+	}}
+	c := ioc233.NewContainer()
+	c.Provide(store)
+	settlement := &SettlementJob{}
+	c.Provide(settlement)
+	s := c.EnableScheduler()
+	_ = s.Schedule("sync", ioc233.ScheduleSpec{FixedRate: 10 * time.Minute}, func(context.Context) error { return nil })
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	defer c.Close()
+
+	if s.Store == nil {
+		t.Fatal("JobStore 应该注入调度器")
+	}
+	if settlement.MissedArg != 3 || jobStatus(s, "SettlementJob").Missed != 3 {
+		t.Errorf("cron 任务应该检测到 3 次错过的执行, 实际: %d", settlement.MissedArg)
+	}
+	if jobStatus(s, "sync").Missed != 6 {
+		t.Errorf("固定频率任务应该检测到 6 次错过的执行, 实际: %d", jobStatus(s, "sync").Missed)
+	}
+	if !waitFor(func() bool { return store.runCount() > 0 }) {
+		t.Error("执行记录应该保存到 JobStore")
+	}
+}
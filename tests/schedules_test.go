@@ -0,0 +1,97 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 多任务声明测试 ====================
+
+type SessionReaper struct {
+	Users   *UserServiceImpl `autowire:"true"`
+	reaped  atomic.Int32
+	flushed atomic.Int32
+}
+
+func (r *SessionReaper) Schedules() []ioc233.Schedule {
+	return []ioc233.Schedule{
+		{ScheduleSpec: ioc233.ScheduleSpec{Name: "reap", FixedRate: 5 * time.Millisecond}, Run: func(ctx context.Context) error {
+			r.reaped.Add(1)
+			return nil
+		}},
+		{ScheduleSpec: ioc233.ScheduleSpec{FixedDelay: 5 * time.Millisecond}, Run: func(ctx context.Context) error {
+			r.flushed.Add(1)
+			return errors.New("flush failed")
+		}},
+	}
+}
+
+type MetricsFlusher struct {
+	_ struct{} `schedule:"fixedRate:5ms" method:"Flush"`
+	_ struct{} `schedule:"fixedDelay:5ms" method:"Compact" overlap:"queue"`
+	_ struct{} `schedule:"fixedRate:5ms" method:"Missing"`
+
+	flushes  atomic.Int32
+	compacts atomic.Int32
+}
+
+func (m *MetricsFlusher) Flush(ctx context.Context) error {
+	m.flushes.Add(1)
+	return nil
+}
+
+func (m *MetricsFlusher) Compact() { m.compacts.Add(1) }
+
+func TestSchedules_InterfaceDeclaresMultipleJobs(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&UserServiceImpl{ID: 1})
+	reaper := &SessionReaper{}
+	c.ProvideByName("reaper", reaper)
+	s := c.EnableScheduler()
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+
+	if !waitFor(func() bool { return reaper.reaped.Load() >= 3 && reaper.flushed.Load() >= 3 }) {
+		t.Fatalf("ISchedule 声明的每个任务都应该被调度, reap=%d flush=%d", reaper.reaped.Load(), reaper.flushed.Load())
+	}
+	if st := jobStatus(s, "reaper#1"); st.LastError != "flush failed" {
+		t.Errorf("未命名的任务应该以 bean名#序号 命名并记录错误, 实际: %+v", st)
+	}
+	if jobStatus(s, "reap").Runs == 0 {
+		t.Error("命名的任务应该使用声明的名称")
+	}
+
+	_ = c.Close()
+	stopped := reaper.reaped.Load()
+	time.Sleep(20 * time.Millisecond)
+	if reaper.reaped.Load() != stopped {
+		t.Error("容器关闭后任务不应该继续执行")
+	}
+}
+
+func TestSchedules_MethodTags(t *testing.T) {
+	c := ioc233.NewContainer()
+	flusher := &MetricsFlusher{}
+	c.ProvideByName("metrics", flusher)
+	s := c.EnableScheduler()
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("方法不存在只应该记录错误, 不应该导致启动失败: %v", err)
+	}
+	defer c.Close()
+
+	if !waitFor(func() bool { return flusher.flushes.Load() >= 3 && flusher.compacts.Load() >= 3 }) {
+		t.Fatalf("标签声明的方法都应该被调度, flush=%d compact=%d", flusher.flushes.Load(), flusher.compacts.Load())
+	}
+	if st := jobStatus(s, "metrics.Compact"); st.Overlap != ioc233.OverlapQueue {
+		t.Errorf("方法任务应该以 bean名.方法名 命名并读取 overlap 标签, 实际: %+v", st)
+	}
+	if len(s.Jobs()) != 2 {
+		t.Errorf("引用不存在方法的标签应该被忽略, 实际任务: %+v", s.Jobs())
+	}
+}
@@ -0,0 +1,134 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 请求作用域测试 ====================
+
+type requestKey struct{}
+
+type RequestInfo struct {
+	ID string
+}
+
+type RequestHandler struct {
+	Ctx       context.Context `autowire:"true"`
+	Request   *RequestInfo    `autowire:"requestInfo"`
+	Users     UserService     `autowire:"true"`
+	Destroyed bool
+}
+
+func (h *RequestHandler) OnDestroy() { h.Destroyed = true }
+
+func TestScope_FallbackToParent(t *testing.T) {
+	root := ioc233.NewContainer()
+	root.Provide(&UserServiceImpl{ID: 1})
+	if err := root.StartUp(); err != nil {
+		t.Fatalf("根容器启动应该成功, 错误: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), requestKey{}, "v"))
+	defer cancel()
+	scope := root.NewScope(ctx)
+	handler := &RequestHandler{}
+	if err := scope.ProvideByName("requestInfo", &RequestInfo{ID: "req-1"}); err != nil {
+		t.Fatalf("注册应该成功, 错误: %v", err)
+	}
+	scope.Provide(handler)
+	if err := scope.StartUp(); err != nil {
+		t.Fatalf("作用域启动应该成功, 错误: %v", err)
+	}
+
+	if handler.Users == nil {
+		t.Fatal("作用域内应该能注入父容器的单例")
+	}
+	if handler.Request == nil || handler.Request.ID != "req-1" {
+		t.Fatal("作用域内应该能注入请求级对象")
+	}
+	if handler.Ctx == nil || handler.Ctx.Value(requestKey{}) != "v" {
+		t.Fatal("context.Context 字段应该注入作用域的 ctx")
+	}
+	if scope.Parent() != root || scope.Context() != ctx {
+		t.Error("作用域应该记录父容器与上下文")
+	}
+	if ioc233.GetObjectByTypeFrom[*RequestInfo](root) != nil {
+		t.Error("作用域内注册的 bean 不应该出现在父容器")
+	}
+	if ioc233.GetObjectByTypeFrom[UserService](scope) == nil {
+		t.Error("作用域 Get 应该回退到父容器")
+	}
+}
+
+func TestScope_ClosedWhenContextDone(t *testing.T) {
+	root := ioc233.NewContainer()
+	ctx, cancel := context.WithCancel(context.Background())
+	scope := root.NewScope(ctx)
+	handler := &RequestHandler{}
+	scope.Provide(handler)
+	_ = scope.StartUp()
+
+	cancel()
+	deadline := time.Now().Add(time.Second)
+	for scope.State() != ioc233.StateClosed && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if scope.State() != ioc233.StateClosed || !handler.Destroyed {
+		t.Fatal("ctx 结束后作用域应该自动关闭并触发停止回调")
+	}
+}
+
+// ==================== 父子容器测试 ====================
+
+type SharedDatabase struct {
+	DSN string
+}
+
+type ModuleRepository struct {
+	DB *SharedDatabase `autowire:"true"`
+}
+
+func TestChild_IsolatedRegistrationsWithParentFallback(t *testing.T) {
+	parent := ioc233.NewContainer()
+	parent.Provide(&SharedDatabase{DSN: "shared"})
+	_ = parent.StartUp()
+
+	childA := parent.NewChild()
+	repoA := &ModuleRepository{}
+	childA.Provide(repoA)
+	childA.Provide(&UserServiceImpl{ID: 1})
+	if err := childA.StartUp(); err != nil {
+		t.Fatalf("子容器启动应该成功, 错误: %v", err)
+	}
+	if repoA.DB == nil || repoA.DB.DSN != "shared" {
+		t.Fatal("子容器应该能注入父容器中的 bean")
+	}
+	if ioc233.GetObjectByTypeFrom[*ModuleRepository](parent) != nil {
+		t.Error("子容器的注册不应该出现在父容器")
+	}
+
+	// 兄弟子容器之间相互隔离，并可多级嵌套
+	childB := parent.NewChild()
+	grandChild := childB.NewChild()
+	repoB := &ModuleRepository{}
+	grandChild.Provide(repoB)
+	if errs := grandChild.Validate(); len(errs) != 0 {
+		t.Fatalf("孙容器应该可以通过祖先容器校验通过, 错误: %v", errs)
+	}
+	_ = grandChild.StartUp()
+	if repoB.DB != repoA.DB {
+		t.Error("多级子容器应该回退到同一个祖先 bean")
+	}
+	if ioc233.GetObjectByTypeFrom[UserService](childB) != nil {
+		t.Error("兄弟子容器的注册不应该可见")
+	}
+
+	_ = childA.Close()
+	if parent.State() != ioc233.StateStarted {
+		t.Error("关闭子容器不应该影响父容器")
+	}
+}
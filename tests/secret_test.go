@@ -0,0 +1,77 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 密钥注入测试 ====================
+
+type SecretRepo struct {
+	Password string      `secret:"db/password"`
+	TLSKey   []byte      `secret:"tls/key"`
+	Users    UserService `autowire:"true"`
+}
+
+// mapSecrets 内存密钥源
+func mapSecrets(m map[string]string) ioc233.SecretSource {
+	return ioc233.SecretSourceFunc(func(_ context.Context, path string) (string, error) {
+		v, ok := m[path]
+		if !ok {
+			return "", ioc233.ErrSecretNotFound
+		}
+		return v, nil
+	})
+}
+
+func TestSecretTag_InjectsFromSources(t *testing.T) {
+	parent := ioc233.NewContainer()
+	parent.AddSecretSource(mapSecrets(map[string]string{"tls/key": "pem"}))
+	c := parent.NewChild()
+	c.AddSecretSource(mapSecrets(map[string]string{"db/password": "s3cret"}))
+	c.Provide(&UserServiceImpl{ID: 1})
+	repo := &SecretRepo{}
+	c.Provide(repo)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	if repo.Password != "s3cret" || string(repo.TLSKey) != "pem" || repo.Users == nil {
+		t.Errorf("密钥应该按密钥源顺序解析并回退父容器, 实际: %+v", repo)
+	}
+}
+
+// startWithSecrets 启动容器并收集字段注入失败事件
+func startWithSecrets(src ioc233.SecretSource) []ioc233.InjectionFailed {
+	c := ioc233.NewContainer()
+	c.AddSecretSource(src)
+	var failed []ioc233.InjectionFailed
+	c.Subscribe(func(ev ioc233.Event) {
+		if f, ok := ev.(ioc233.InjectionFailed); ok {
+			failed = append(failed, f)
+		}
+	})
+	c.Provide(&UserServiceImpl{ID: 1})
+	c.Provide(&SecretRepo{})
+	_ = c.StartUp()
+	return failed
+}
+
+func TestSecretTag_Failures(t *testing.T) {
+	failed := startWithSecrets(mapSecrets(map[string]string{"db/password": "s3cret"}))
+	if len(failed) != 1 || failed[0].Field != "TLSKey" || !errors.Is(failed[0].Err, ioc233.ErrSecretNotFound) {
+		t.Fatalf("密钥不存在应该记为字段注入失败, 实际: %+v", failed)
+	}
+	if strings.Contains(failed[0].Err.Error(), "s3cret") {
+		t.Error("错误信息不应该包含密钥内容")
+	}
+
+	boom := errors.New("permission denied")
+	failed = startWithSecrets(ioc233.SecretSourceFunc(func(context.Context, string) (string, error) { return "", boom }))
+	if len(failed) != 2 || !errors.Is(failed[0].Err, boom) {
+		t.Errorf("密钥源出错应该记为字段注入失败, 实际: %+v", failed)
+	}
+}
@@ -0,0 +1,93 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== net/http ServeMux 挂载测试 ====================
+
+type MemberController struct {
+	_     struct{}         `route:"GET /profiles/:id" handler:"Get"`
+	_     struct{}         `route:"ANY /files/*path" handler:"Files"`
+	_     struct{}         `route:"POST /profiles" handler:"Create"`
+	Users *UserServiceImpl `autowire:"true"`
+}
+
+func (c *MemberController) Get(w http.ResponseWriter, r *http.Request) {
+	fmt.Fprintf(w, "profile %s of user %d", r.PathValue("id"), c.Users.ID)
+}
+
+func (c *MemberController) Files(w http.ResponseWriter, r *http.Request) {
+	fmt.Fprintf(w, "%s %s", r.Method, r.PathValue("path"))
+}
+
+func (c *MemberController) Create() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusCreated) })
+}
+
+type LegacyController struct {
+	_ struct{} `route:"GET /plain" handler:"Show"`
+}
+
+func (c *LegacyController) Show(id int) string { return "" }
+
+func serve(mux *http.ServeMux, method, path string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+	return rec
+}
+
+func TestMountServeMux_RoutesToControllers(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&UserServiceImpl{ID: 9})
+	if err := c.ProvideController(&MemberController{}); err != nil {
+		t.Fatalf("注册控制器应该成功, 错误: %v", err)
+	}
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	if err := ioc233.MountServeMux(mux, c); err != nil {
+		t.Fatalf("挂载应该成功, 错误: %v", err)
+	}
+	if rec := serve(mux, http.MethodGet, "/profiles/42"); rec.Body.String() != "profile 42 of user 9" {
+		t.Errorf(":id 应该转换为路径参数, 实际: %d %q", rec.Code, rec.Body.String())
+	}
+	if rec := serve(mux, http.MethodDelete, "/files/a/b.txt"); rec.Body.String() != "DELETE a/b.txt" {
+		t.Errorf("ANY 与 *path 通配应该生效, 实际: %d %q", rec.Code, rec.Body.String())
+	}
+	if rec := serve(mux, http.MethodPost, "/profiles"); rec.Code != http.StatusCreated {
+		t.Errorf("返回 http.Handler 的处理方法应该被挂载, 实际: %d", rec.Code)
+	}
+	if rec := serve(mux, http.MethodPost, "/profiles/42"); rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("路由应该限定 HTTP 方法, 实际: %d", rec.Code)
+	}
+}
+
+func TestMountServeMux_Errors(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.ProvideController(&LegacyController{})
+	mux := http.NewServeMux()
+	if err := ioc233.MountServeMux(mux, c); err == nil || !strings.Contains(err.Error(), "Show") {
+		t.Errorf("签名不支持时应该返回错误, 实际: %v", err)
+	}
+	if rec := serve(mux, http.MethodGet, "/plain"); rec.Code != http.StatusNotFound {
+		t.Errorf("签名不支持时不应该挂载任何路由, 实际: %d", rec.Code)
+	}
+
+	c = ioc233.NewContainer()
+	c.Provide(&UserServiceImpl{ID: 1})
+	c.ProvideController(&MemberController{})
+	mux = http.NewServeMux()
+	mux.HandleFunc("GET /profiles/{id}", func(http.ResponseWriter, *http.Request) {})
+	if err := ioc233.MountServeMux(mux, c); err == nil {
+		t.Error("与已有路由冲突时应该返回错误")
+	}
+}
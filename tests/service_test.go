@@ -0,0 +1,68 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 服务/控制器分类测试 ====================
+
+type LedgerService struct {
+	Users *UserServiceImpl `autowire:"true"`
+}
+
+type LedgerController struct {
+	_      struct{}       `route:"GET /ledger" handler:"List"`
+	Ledger *LedgerService `autowire:"true"`
+}
+
+func (c *LedgerController) List() {}
+
+func TestProvideService_ClassifiedSeparately(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&UserServiceImpl{ID: 1})
+	ledger := &LedgerService{}
+	if err := c.ProvideService(ledger); err != nil {
+		t.Fatalf("注册服务应该成功, 错误: %v", err)
+	}
+	ctrl := &LedgerController{}
+	c.ProvideController(ctrl)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+
+	services := c.GetServices()
+	if len(services) != 1 || services[0].Name != "LedgerService" || services[0].Instance != ledger {
+		t.Errorf("GetServices 应该只返回 ProvideService 注册的服务, 实际: %+v", services)
+	}
+	if all := c.GetServicesAny(); len(all) != 1 || all[0] != ledger {
+		t.Errorf("GetServicesAny 应该返回服务实例, 实际: %v", all)
+	}
+	if controllers := c.GetControllers(); len(controllers) != 1 || controllers[0].Instance != ctrl {
+		t.Errorf("GetControllers 不应该包含服务, 实际: %+v", controllers)
+	}
+	if ledger.Users == nil || ctrl.Ledger != ledger {
+		t.Error("服务应该像普通 bean 一样注入与被注入")
+	}
+	if got := ioc233.GetObjectByTypeFrom[*LedgerService](c); got != ledger {
+		t.Error("服务应该可以按类型解析")
+	}
+}
+
+func TestProvideService_DuplicateAndNil(t *testing.T) {
+	c := ioc233.NewContainer()
+	if err := c.ProvideService(nil); err == nil {
+		t.Error("注册 nil 服务应该返回错误")
+	}
+	c.ProvideService(&LedgerService{})
+	c.ProvideService(&LedgerService{})
+	if services := c.GetServices(); len(services) != 1 {
+		t.Errorf("重复类型不应该重复记录为服务, 实际: %d", len(services))
+	}
+
+	c.Freeze()
+	if err := c.ProvideService(&UserServiceImpl{}); err == nil {
+		t.Error("容器冻结后注册服务应该返回错误")
+	}
+}
@@ -0,0 +1,74 @@
+package tests
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 装配快照与比对测试 ====================
+
+func TestSnapshot_RoundTripAndHandler(t *testing.T) {
+	c := newGraphContainer()
+	_ = ioc233.BindIn[UserService, *PremiumUserService](c)
+	var buf bytes.Buffer
+	if err := c.WriteSnapshot(&buf); err != nil {
+		t.Fatalf("写入快照应该成功, 错误: %v", err)
+	}
+	local, err := ioc233.ReadSnapshot(&buf)
+	if err != nil {
+		t.Fatalf("读取快照应该成功, 错误: %v", err)
+	}
+	if len(local.Beans) != 5 || local.Bindings["tests.UserService"] != "*tests.PremiumUserService" {
+		t.Errorf("快照内容错误: beans=%v bindings=%v", local.Beans, local.Bindings)
+	}
+
+	server := httptest.NewServer(ioc233.SnapshotHandler(c))
+	defer server.Close()
+	resp, err := http.Get(server.URL + ioc233.SnapshotPath)
+	if err != nil {
+		t.Fatalf("请求管理端点应该成功, 错误: %v", err)
+	}
+	defer resp.Body.Close()
+	remote, err := ioc233.ReadSnapshot(resp.Body)
+	if err != nil {
+		t.Fatalf("解析远端快照应该成功, 错误: %v", err)
+	}
+	if diffs := ioc233.DiffSnapshots(local, remote); len(diffs) != 0 {
+		t.Errorf("同一容器的快照不应该有差异, 实际: %v", diffs)
+	}
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	local := newGraphContainer()
+	local.SetActiveProfiles("dev")
+
+	remote := ioc233.NewContainer()
+	remote.SetActiveProfiles("prod")
+	remote.Provide(&PremiumUserService{})
+	_ = remote.ProvideByName("orders", &OrderServiceImpl{})
+	_ = remote.ProvidePrototype(func() *TaskContext { return &TaskContext{} })
+	_ = remote.ProvideByName("consumer", &GraphConsumer{})
+	_ = ioc233.BindIn[UserService, *PremiumUserService](remote)
+
+	diffs := ioc233.DiffSnapshots(local.Snapshot(), remote.Snapshot())
+	var lines []string
+	for _, d := range diffs {
+		lines = append(lines, d.String())
+	}
+	out := strings.Join(lines, "\n")
+	for _, want := range []string{
+		"~ profiles active: dev -> prod",
+		"- bean UserServiceImpl",
+		"+ binding tests.UserService: *tests.PremiumUserService",
+		"~ edge consumer.Users: interface -> [UserServiceImpl] -> interface -> [PremiumUserService]",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("差异中缺少 %q, 实际:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 启动截止时间测试 ====================
+
+type HangingBefore struct {
+	release chan struct{}
+}
+
+func (h *HangingBefore) OnInjectBefore() { <-h.release }
+
+type DeadlineTail struct {
+	_ struct{} `dependsOn:"HangingBefore"`
+}
+
+func TestStartUpWithTimeout_ReportsUnfinishedBeans(t *testing.T) {
+	hang := &HangingBefore{release: make(chan struct{})}
+	defer close(hang.release)
+	c := ioc233.NewContainer()
+	c.Provide(&UserServiceImpl{})
+	c.Provide(hang)
+	c.Provide(&DeadlineTail{})
+
+	begin := time.Now()
+	err := c.StartUpWithTimeout(50 * time.Millisecond)
+	if elapsed := time.Since(begin); elapsed > time.Second {
+		t.Fatalf("超时后应该及时返回, 实际耗时: %v", elapsed)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("超时应该返回 DeadlineExceeded, 实际: %v", err)
+	}
+	var aborted *ioc233.StartupAbortedError
+	if !errors.As(err, &aborted) {
+		t.Fatalf("超时应该返回 StartupAbortedError, 实际: %T", err)
+	}
+	if aborted.Partial != "HangingBefore" {
+		t.Errorf("卡住的 bean 应该报告为注入到一半, 实际: %q", aborted.Partial)
+	}
+	if strings.Join(aborted.Pending, ",") != "DeadlineTail" {
+		t.Errorf("之后的 bean 应该报告为未完成注入, 实际: %v", aborted.Pending)
+	}
+	if strings.Join(aborted.Completed, ",") != "UserServiceImpl" {
+		t.Errorf("已完成的 bean 应该被列出, 实际: %v", aborted.Completed)
+	}
+}
+
+func TestStartUpWithTimeout_SucceedsWithinDeadline(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&UserServiceImpl{})
+	if err := c.StartUpWithTimeout(time.Second); err != nil {
+		t.Fatalf("在截止时间内完成时启动应该成功, 错误: %v", err)
+	}
+	if c.State() != ioc233.StateStarted {
+		t.Errorf("启动后容器应该处于 StateStarted, 实际: %v", c.State())
+	}
+}
@@ -0,0 +1,55 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 名称注入候选提示测试 ====================
+
+type TypoConsumer struct {
+	Users UserService `autowire:"UserServcie"`
+}
+
+type FarOffConsumer struct {
+	Users UserService `autowire:"inventory"`
+}
+
+func TestNameSuggestion_ClosestMatches(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.ProvideByName("UserService", &UserServiceImpl{ID: 1})
+	c.ProvideByName("userServiceV2", &UserServiceImpl{ID: 2})
+	c.ProvideByName("OrderService", &UserServiceImpl{ID: 3})
+	c.Provide(&TypoConsumer{})
+
+	errs := c.Validate()
+	if len(errs) != 1 {
+		t.Fatalf("应该有一个名称注入错误, 实际: %v", errs)
+	}
+	msg := errs[0].Error()
+	if !strings.Contains(msg, `是否想注入: "UserService", "userServiceV2"`) {
+		t.Errorf("错误信息应该按编辑距离给出最近的候选, 实际: %s", msg)
+	}
+	if strings.Contains(msg, "OrderService") {
+		t.Errorf("差异过大的名称不应该作为候选, 实际: %s", msg)
+	}
+}
+
+func TestNameSuggestion_ParentNamesAndNoMatch(t *testing.T) {
+	parent := ioc233.NewContainer()
+	parent.ProvideByName("UserService", &UserServiceImpl{ID: 1})
+	child := parent.NewChild()
+	child.Provide(&TypoConsumer{})
+	if errs := child.Validate(); len(errs) != 1 || !strings.Contains(errs[0].Error(), `是否想注入: "UserService"`) {
+		t.Errorf("候选应该包括父容器中的 bean 名, 实际: %v", errs)
+	}
+
+	c := ioc233.NewContainer()
+	c.ProvideByName("UserService", &UserServiceImpl{ID: 1})
+	c.Provide(&FarOffConsumer{})
+	if errs := c.Validate(); len(errs) != 1 || strings.Contains(errs[0].Error(), "是否想注入") {
+		t.Errorf("没有相近的名称时不应该给出候选, 实际: %v", errs)
+	}
+}
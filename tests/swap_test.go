@@ -0,0 +1,96 @@
+package tests
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== Swap 重新注入测试 ====================
+
+type SwapHandler interface {
+	Handle() string
+}
+
+type SwapMainHandler struct{ Version string }
+
+func (h *SwapMainHandler) Handle() string { return "main-" + h.Version }
+
+type SwapOtherHandler struct{}
+
+func (h *SwapOtherHandler) Handle() string { return "other" }
+
+type SwapConsumer struct {
+	Single  *SwapMainHandler       `autowire:"true"`
+	All     []SwapHandler          `autowire:"true"`
+	Group   []SwapHandler          `autowire:"group:swap-handlers"`
+	ByName  map[string]SwapHandler `autowire:"swapHandlerMap"`
+	changed []string
+}
+
+type SwapGroupConsumer struct {
+	Group []SwapHandler `autowire:"group:swap-handlers"`
+}
+
+func (s *SwapConsumer) OnDependencyChanged(field string) {
+	s.changed = append(s.changed, field)
+}
+
+func handled(handlers []SwapHandler) []string {
+	out := make([]string, 0, len(handlers))
+	for _, h := range handlers {
+		out = append(out, h.Handle())
+	}
+	return out
+}
+
+func TestSwap_RewiresCollectionFields(t *testing.T) {
+	c := ioc233.NewContainer()
+	main, other := &SwapMainHandler{Version: "v1"}, &SwapOtherHandler{}
+	consumer := &SwapConsumer{}
+	c.Provide(consumer)
+	c.Provide(main, ioc233.InGroup("swap-handlers"))
+	c.Provide(other, ioc233.InGroup("swap-handlers"))
+	routes := map[string]SwapHandler{"main": main, "other": other}
+	c.ProvideValue("swapHandlerMap", routes)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	all, group := consumer.All, consumer.Group
+
+	replacement := &SwapMainHandler{Version: "v2"}
+	if err := c.Swap(replacement); err != nil {
+		t.Fatalf("Swap 应该成功, 错误: %v", err)
+	}
+	if consumer.Single != replacement {
+		t.Error("指针字段应该被重新注入")
+	}
+	if got := handled(consumer.All); !slices.Equal(got, []string{"main-v2", "other"}) {
+		t.Errorf("切片字段中的旧实例应该被替换, 得到: %v", got)
+	}
+	if got := handled(consumer.Group); !slices.Equal(got, []string{"main-v2", "other"}) {
+		t.Errorf("分组字段中的旧实例应该被替换, 得到: %v", got)
+	}
+	if consumer.ByName["main"] != replacement || consumer.ByName["other"] != other {
+		t.Errorf("map 字段中的旧实例应该被替换, 得到: %v", consumer.ByName)
+	}
+	if all[0] != main || group[0] != main || routes["main"] != main {
+		t.Error("重新注入应该写时复制, 不应该修改原切片 / map")
+	}
+	for _, field := range []string{"Single", "All", "Group", "ByName"} {
+		if !slices.Contains(consumer.changed, field) {
+			t.Errorf("字段 %s 应该收到变更通知, 得到: %v", field, consumer.changed)
+		}
+	}
+	if len(consumer.changed) != 4 {
+		t.Errorf("每个字段只应该通知一次, 得到: %v", consumer.changed)
+	}
+
+	// 新注入的分组字段解析到替换后的实例
+	late := &SwapGroupConsumer{}
+	c.Provide(late)
+	if got := handled(late.Group); !slices.Equal(got, []string{"main-v2", "other"}) {
+		t.Errorf("替换后的实例应该接管分组成员身份, 得到: %v", got)
+	}
+}
@@ -0,0 +1,118 @@
+package tests
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 自定义标签处理器测试 ====================
+
+// OrdersClient 模拟按地址创建的 gRPC 客户端
+type OrdersClient struct {
+	Target string
+	Users  UserService
+}
+
+type CheckoutService struct {
+	Orders *OrdersClient `grpcclient:"orders"`
+	Users  UserService   `autowire:"true"`
+	Cache  string        `cache:"redis" autowire:"false"`
+}
+
+// grpcClientHandler 按标签值创建客户端，并从容器解析客户端自身的依赖
+func grpcClientHandler(f ioc233.TagField) error {
+	users, _ := ioc233.ResolveAs[UserService](f.Container)
+	f.Value.Set(reflect.ValueOf(&OrdersClient{Target: f.Tag, Users: users}))
+	return nil
+}
+
+func TestTagHandler_CustomTagsRunDuringInjection(t *testing.T) {
+	c := ioc233.NewContainer()
+	if err := c.RegisterTagHandler("grpcclient", ioc233.TagHandlerFunc(grpcClientHandler)); err != nil {
+		t.Fatalf("注册标签处理器应该成功, 错误: %v", err)
+	}
+	var owners []any
+	_ = c.RegisterTagHandler("cache", ioc233.TagHandlerFunc(func(f ioc233.TagField) error {
+		owners = append(owners, f.Owner)
+		f.Value.SetString(f.Tag)
+		return nil
+	}))
+
+	c.Provide(&UserServiceImpl{ID: 1})
+	svc := &CheckoutService{}
+	c.Provide(svc)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+
+	if svc.Orders == nil || svc.Orders.Target != "orders" || svc.Orders.Users == nil {
+		t.Fatalf("标签处理器应该设置字段并能解析容器中的 bean, 实际: %+v", svc.Orders)
+	}
+	if svc.Users == nil {
+		t.Error("同一对象的 autowire 字段应该照常注入")
+	}
+	if svc.Cache != "redis" || len(owners) != 1 || owners[0] != svc {
+		t.Errorf("同时带 autowire 的字段也应该执行处理器, 实际: cache=%q owners=%v", svc.Cache, owners)
+	}
+}
+
+func TestTagHandler_ErrorCountsAsInjectionFailure(t *testing.T) {
+	c := ioc233.NewContainer()
+	boom := errors.New("dial failed")
+	_ = c.RegisterTagHandler("grpcclient", ioc233.TagHandlerFunc(func(ioc233.TagField) error { return boom }))
+	var failed []ioc233.InjectionFailed
+	c.Subscribe(func(ev ioc233.Event) {
+		if f, ok := ev.(ioc233.InjectionFailed); ok {
+			failed = append(failed, f)
+		}
+	})
+	c.Provide(&UserServiceImpl{ID: 1})
+	c.Provide(&CheckoutService{})
+	_ = c.StartUp()
+
+	if len(failed) != 1 || failed[0].Field != "Orders" || !errors.Is(failed[0].Err, boom) {
+		t.Fatalf("处理器出错应该记为字段注入失败, 实际: %+v", failed)
+	}
+	if c.Metrics().InjectionFailures != 1 {
+		t.Errorf("注入失败计数应该为 1, 实际: %d", c.Metrics().InjectionFailures)
+	}
+}
+
+func TestTagHandler_RegistrationRules(t *testing.T) {
+	c := ioc233.NewContainer()
+	noop := ioc233.TagHandlerFunc(func(ioc233.TagField) error { return nil })
+	if err := c.RegisterTagHandler("autowire", noop); err == nil {
+		t.Error("保留标签不允许注册处理器")
+	}
+	if err := c.RegisterTagHandler("", noop); err == nil {
+		t.Error("空标签应该返回错误")
+	}
+	if err := c.RegisterTagHandler("cache", noop); err != nil {
+		t.Fatalf("注册应该成功, 错误: %v", err)
+	}
+	if err := c.RegisterTagHandler("cache", noop); err == nil {
+		t.Error("重复注册同一标签应该返回错误")
+	}
+}
+
+func TestTagHandler_ChildInheritsParentHandlers(t *testing.T) {
+	parent := ioc233.NewContainer()
+	_ = parent.RegisterTagHandler("grpcclient", ioc233.TagHandlerFunc(grpcClientHandler))
+	parent.Provide(&UserServiceImpl{ID: 1})
+	if err := parent.StartUp(); err != nil {
+		t.Fatalf("父容器启动应该成功, 错误: %v", err)
+	}
+
+	child := parent.NewChild()
+	svc := &CheckoutService{}
+	child.Provide(svc)
+	if err := child.StartUp(); err != nil {
+		t.Fatalf("子容器启动应该成功, 错误: %v", err)
+	}
+	if svc.Orders == nil || svc.Orders.Target != "orders" {
+		t.Errorf("子容器应该继承父容器的标签处理器, 实际: %+v", svc.Orders)
+	}
+}
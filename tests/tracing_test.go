@@ -0,0 +1,115 @@
+package tests
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 启动追踪测试 ====================
+
+// recordedSpan 记录的 span
+type recordedSpan struct {
+	name   string
+	parent string
+	attrs  map[string]string
+	err    error
+	ended  bool
+}
+
+type spanKey struct{}
+
+// recordingTracer 记录 span 与父子关系的追踪钩子
+type recordingTracer struct {
+	spans []*recordedSpan
+}
+
+func (r *recordingTracer) StartSpan(ctx context.Context, name string, attrs ...ioc233.SpanAttr) (context.Context, func(error)) {
+	span := &recordedSpan{name: name, attrs: map[string]string{}}
+	if parent, ok := ctx.Value(spanKey{}).(*recordedSpan); ok {
+		span.parent = parent.name + "/" + parent.attrs[ioc233.SpanAttrBean]
+	}
+	for _, a := range attrs {
+		span.attrs[a.Key] = a.Value
+	}
+	r.spans = append(r.spans, span)
+	return context.WithValue(ctx, spanKey{}, span), func(err error) {
+		span.err, span.ended = err, true
+	}
+}
+
+func (r *recordingTracer) find(name, bean string) *recordedSpan {
+	for _, s := range r.spans {
+		if s.name == name && s.attrs[ioc233.SpanAttrBean] == bean {
+			return s
+		}
+	}
+	return nil
+}
+
+type TracedService struct {
+	Users UserService `autowire:"true"`
+}
+
+func (s *TracedService) OnInjectBefore()   {}
+func (s *TracedService) OnInjectComplete() {}
+
+type TracedOrphan struct {
+	Orders OrderService `autowire:"true"`
+}
+
+func TestStartupTracer_SpansPerBeanAndCallback(t *testing.T) {
+	c := ioc233.NewContainer()
+	tracer := &recordingTracer{}
+	c.SetStartupTracer(tracer)
+	c.Provide(&UserServiceImpl{ID: 1})
+	c.Provide(&TracedService{})
+	c.Provide(&TracedOrphan{})
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+
+	root := tracer.find(ioc233.SpanStartUp, "")
+	if root == nil || !root.ended || root.err != nil {
+		t.Fatalf("应该记录成功结束的启动 span, 实际: %+v", root)
+	}
+	inject := tracer.find(ioc233.SpanInject, "TracedService")
+	if inject == nil || inject.parent != ioc233.SpanStartUp+"/" || inject.attrs[ioc233.SpanAttrType] != "*tests.TracedService" {
+		t.Fatalf("注入 span 应该是启动 span 的子 span, 实际: %+v", inject)
+	}
+	var callbacks []string
+	for _, s := range tracer.spans {
+		if s.name == ioc233.SpanCallback && s.attrs[ioc233.SpanAttrBean] == "TracedService" {
+			callbacks = append(callbacks, s.attrs[ioc233.SpanAttrCallback]+"<"+s.parent)
+		}
+	}
+	want := "OnInjectBefore<ioc233.inject/TracedService,OnInjectComplete<ioc233.StartUp/"
+	if strings.Join(callbacks, ",") != want {
+		t.Errorf("回调 span 不正确, 期望 %s, 实际 %v", want, callbacks)
+	}
+	if orphan := tracer.find(ioc233.SpanInject, "TracedOrphan"); orphan == nil || orphan.err == nil {
+		t.Errorf("字段注入失败时注入 span 应该记录错误, 实际: %+v", orphan)
+	}
+	for _, s := range tracer.spans {
+		if !s.ended {
+			t.Errorf("span 应该全部结束: %+v", s)
+		}
+	}
+}
+
+func TestStartupTracer_RecordsStartupError(t *testing.T) {
+	c := ioc233.NewContainer()
+	tracer := &recordingTracer{}
+	c.SetStartupTracer(tracer)
+	c.Provide(&UserServiceImpl{ID: 1})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := c.StartUpCtx(ctx); err == nil {
+		t.Fatal("取消的启动应该失败")
+	}
+	if root := tracer.find(ioc233.SpanStartUp, ""); root == nil || root.err == nil {
+		t.Errorf("启动失败时启动 span 应该记录错误, 实际: %+v", root)
+	}
+}
@@ -0,0 +1,64 @@
+package tests
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 反射元数据缓存测试 ====================
+
+type MetaBase struct {
+	Users UserService `autowire:"true"`
+}
+
+type MetaRequest struct {
+	MetaBase
+	Port   int `value:"${meta.port:9000}"`
+	Labels map[string]string
+}
+
+func TestStructMetaCache_ConcurrentPrototypes(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&UserServiceImpl{ID: 1})
+	if err := c.ProvidePrototype(func() *MetaRequest { return &MetaRequest{} }); err != nil {
+		t.Fatalf("ProvidePrototype 应该成功, 错误: %v", err)
+	}
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	results := make([]*MetaRequest, 64)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = ioc233.GetObjectByTypeFrom[*MetaRequest](c)
+		}(i)
+	}
+	wg.Wait()
+	for i, r := range results {
+		if r == nil || r.Users == nil || r.Port != 9000 || r.Labels == nil {
+			t.Fatalf("第 %d 个原型实例应该使用缓存的元数据完成注入, 实际: %+v", i, r)
+		}
+	}
+}
+
+func TestStructMetaCache_SharedAcrossContainers(t *testing.T) {
+	for i := 0; i < 3; i++ {
+		c := ioc233.NewContainer()
+		c.AddConfigSource(ioc233.MapConfigSource{"meta.port": "7000"})
+		c.Provide(&UserServiceImpl{ID: i})
+		req := &MetaRequest{}
+		c.Provide(req)
+		if err := c.StartUp(); err != nil {
+			t.Fatalf("启动应该成功, 错误: %v", err)
+		}
+		// 元数据只缓存标签，配置值与依赖仍按各容器解析
+		if req.Users.(*UserServiceImpl).ID != i || req.Port != 7000 {
+			t.Errorf("第 %d 个容器应该注入自身的依赖与配置, 实际: %+v", i, req)
+		}
+	}
+}
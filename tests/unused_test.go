@@ -0,0 +1,95 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 未使用 bean 检测测试 ====================
+
+type StockLedger struct{}
+
+type PricingCache struct{}
+
+type LegacyMailer struct{}
+
+type ReportExporter struct{}
+
+type OrderCheckout struct {
+	Ledger *StockLedger               `autowire:"true"`
+	Cache  ioc233.Lazy[*PricingCache] `autowire:"true"`
+}
+
+type CheckoutEndpoint struct {
+	Checkout *OrderCheckout `autowire:"true"`
+	_        struct{}       `route:"POST /checkout" handler:"Submit"`
+}
+
+func (c *CheckoutEndpoint) Submit() {}
+
+func unusedNames(c *ioc233.Container) []string {
+	var names []string
+	for _, u := range c.UnusedBeans() {
+		names = append(names, u.Name)
+	}
+	return names
+}
+
+func TestUnusedBeans_InjectedAndFetched(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&StockLedger{})
+	c.Provide(&PricingCache{})
+	c.Provide(&LegacyMailer{})
+	c.Provide(&ReportExporter{})
+	c.Provide(&OrderCheckout{})
+	c.ProvideController(&CheckoutEndpoint{})
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	defer c.Close()
+
+	unused := c.UnusedBeans()
+	if len(unused) != 2 || unused[0].String() != "LegacyMailer (*tests.LegacyMailer)" || unused[1].Name != "ReportExporter" {
+		t.Fatalf("未被注入的 bean 应该按注册顺序列出（懒加载依赖与控制器不计入）, 实际: %v", unused)
+	}
+
+	if ioc233.GetObjectByTypeFrom[*ReportExporter](c) == nil {
+		t.Fatal("应该可以获取 ReportExporter")
+	}
+	if names := unusedNames(c); len(names) != 1 || names[0] != "LegacyMailer" {
+		t.Errorf("运行期获取过的 bean 不应该再被列出, 实际: %v", names)
+	}
+	c.Invoke(func(*LegacyMailer) {})
+	if names := unusedNames(c); len(names) != 0 {
+		t.Errorf("Invoke 参数使用过的 bean 不应该被列出, 实际: %v", names)
+	}
+}
+
+func TestUnusedBeans_SelfActivatedAndChildContainer(t *testing.T) {
+	parent := ioc233.NewContainer()
+	parent.Provide(&StockLedger{})
+	parent.Provide(&UserServiceImpl{ID: 1})
+	parent.Provide(&GameLoop{started: make(chan struct{})})
+	if err := parent.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	defer parent.Close()
+	if names := unusedNames(parent); len(names) != 1 || names[0] != "StockLedger" {
+		t.Fatalf("托管运行 bean 由容器驱动，不应该被列出, 实际: %v", names)
+	}
+
+	child := parent.NewChild()
+	child.Provide(&OrderCheckout{})
+	child.Provide(&PricingCache{})
+	if err := child.StartUp(); err != nil {
+		t.Fatalf("子容器启动应该成功, 错误: %v", err)
+	}
+	defer child.Close()
+	if names := unusedNames(parent); len(names) != 0 {
+		t.Errorf("被子容器注入的父容器 bean 不应该被列出, 实际: %v", names)
+	}
+	if names := unusedNames(child); len(names) != 1 || names[0] != "OrderCheckout" {
+		t.Errorf("子容器应该只列出自己未使用的 bean, 实际: %v", names)
+	}
+}
@@ -0,0 +1,85 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 值 bean 测试 ====================
+
+type ServiceName string
+
+type Clock func() time.Time
+
+type BufferOptions struct {
+	Size int
+}
+
+type ValueConsumer struct {
+	Name    ServiceName   `autowire:"true"`
+	RawName string        `autowire:"rawName"`
+	Size    int           `autowire:"bufferSize"`
+	Options BufferOptions `autowire:"true"`
+	Clock   Clock         `autowire:"true"`
+	Hook    func() string `autowire:"hook"`
+}
+
+func TestProvideValue_InjectByNameAndType(t *testing.T) {
+	fixed := time.Unix(100, 0)
+	c := ioc233.NewContainer()
+	_ = c.ProvideValue("serviceName", ServiceName("order-service"))
+	_ = c.ProvideValue("rawName", "raw")
+	_ = c.ProvideValue("bufferSize", 4096)
+	_ = c.ProvideValue("bufferOptions", BufferOptions{Size: 8})
+	_ = c.ProvideValue("clock", Clock(func() time.Time { return fixed }))
+	_ = c.ProvideValue("hook", func() string { return "hooked" })
+	consumer := &ValueConsumer{}
+	c.Provide(consumer)
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+
+	if consumer.Name != "order-service" || consumer.RawName != "raw" || consumer.Size != 4096 {
+		t.Errorf("基础值注入错误: %+v", consumer)
+	}
+	if consumer.Options.Size != 8 {
+		t.Error("结构体值应该按类型注入")
+	}
+	if consumer.Clock == nil || !consumer.Clock().Equal(fixed) {
+		t.Error("具名函数类型应该按类型注入")
+	}
+	if consumer.Hook == nil || consumer.Hook() != "hooked" {
+		t.Error("匿名函数应该按名称注入")
+	}
+}
+
+type BuiltinTypeConsumer struct {
+	Size int `autowire:"true"`
+}
+
+func TestProvideValue_BuiltinTypesOnlyByName(t *testing.T) {
+	c := ioc233.NewContainer()
+	_ = c.ProvideValue("bufferSize", 4096)
+	c.Provide(&BuiltinTypeConsumer{})
+	if errs := c.Validate(); len(errs) == 0 {
+		t.Error("内置类型的值不应该按类型注入")
+	}
+}
+
+func TestProvideValue_DuplicateNameIsFatal(t *testing.T) {
+	c := ioc233.NewContainer()
+	if err := c.ProvideValue("bufferSize", 1); err != nil {
+		t.Fatalf("首次注册应该成功, 错误: %v", err)
+	}
+	if err := c.ProvideValue("bufferSize", 2); err == nil {
+		t.Error("重复名称应该返回错误")
+	}
+	if err := c.ProvideValue("nilValue", nil); err == nil {
+		t.Error("nil 值应该返回错误")
+	}
+	if err := c.StartUp(); err == nil {
+		t.Error("存在重复名称时启动应该失败")
+	}
+}
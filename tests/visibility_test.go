@@ -0,0 +1,107 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== bean 可见性测试 ====================
+
+type SigningKey struct {
+	Secret string
+}
+
+type ChargeGateway interface {
+	Charge(amount int) string
+}
+
+type PrivilegedGateway struct{}
+
+func (g *PrivilegedGateway) Charge(int) string { return "privileged" }
+
+type PublicGateway struct{}
+
+func (g *PublicGateway) Charge(int) string { return "public" }
+
+type BillingService struct {
+	_        struct{}        `module:"billing"`
+	Key      *SigningKey     `autowire:"true"`
+	Gateways []ChargeGateway `autowire:"true"`
+}
+
+type ReportingService struct {
+	Key *SigningKey `autowire:"false"`
+}
+
+type GatewayAuditor struct {
+	Gateways []ChargeGateway `autowire:"true"`
+}
+
+func TestVisibleTo_AllowedModuleInjects(t *testing.T) {
+	c := ioc233.NewContainer()
+	_ = c.ProvideByName("signingKey", &SigningKey{Secret: "s3cr3t"}, ioc233.VisibleTo("billing", "payments"))
+	c.Provide(&PrivilegedGateway{}, ioc233.VisibleTo("billing"))
+	c.Provide(&PublicGateway{})
+	billing := &BillingService{}
+	auditor := &GatewayAuditor{}
+	c.Provide(billing)
+	c.Provide(auditor)
+
+	if errs := c.Validate(); len(errs) > 0 {
+		t.Fatalf("授权模块的注入不应该报错: %v", errs)
+	}
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	if billing.Key == nil || len(billing.Gateways) != 2 {
+		t.Fatalf("billing 模块应该注入受限 bean: %+v", billing)
+	}
+	if len(auditor.Gateways) != 1 || auditor.Gateways[0].Charge(1) != "public" {
+		t.Errorf("切片注入应该过滤掉不可见的 bean, 实际: %d 个", len(auditor.Gateways))
+	}
+}
+
+func TestVisibleTo_ViolationReported(t *testing.T) {
+	c := ioc233.NewContainer()
+	_ = c.ProvideByName("signingKey", &SigningKey{}, ioc233.VisibleTo("billing"))
+	reporting := &ReportingService{}
+	c.Provide(reporting)
+
+	errs := c.Validate()
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "ReportingService.Key") {
+		t.Fatalf("Validate 应该报告可见性违规, 实际: %v", errs)
+	}
+	if err := c.StartUp(); err == nil {
+		t.Fatal("存在可见性违规时启动应该失败")
+	}
+	if reporting.Key != nil {
+		t.Error("不应该注入不可见的 bean")
+	}
+	if c.State() != ioc233.StateFailed {
+		t.Errorf("容器应该处于 Failed 状态, 实际: %v", c.State())
+	}
+}
+
+type ProdSigningKey struct {
+	_ struct{} `profile:"prod"`
+}
+
+type ProdKeyConsumer struct {
+	Key *ProdSigningKey `autowire:"false"`
+}
+
+func TestVisibleTo_AppliesToProfileRegistration(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.SetActiveProfiles("prod")
+	c.Provide(&ProdSigningKey{}, ioc233.VisibleTo("billing"))
+	consumer := &ProdKeyConsumer{}
+	c.Provide(consumer)
+	if err := c.StartUp(); err == nil {
+		t.Fatal("按 profile 注册的 bean 也应该应用可见性限制")
+	}
+	if consumer.Key != nil {
+		t.Error("不应该注入不可见的 bean")
+	}
+}
@@ -0,0 +1,147 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== 预热测试 ====================
+
+type PriceCache struct {
+	Users     *UserServiceImpl `autowire:"true"`
+	delay     time.Duration
+	usersSeen atomic.Bool
+	completed atomic.Bool
+	warmed    atomic.Bool
+}
+
+func (p *PriceCache) OnInjectComplete() { p.completed.Store(true) }
+
+func (p *PriceCache) WarmUp(ctx context.Context) error {
+	time.Sleep(p.delay)
+	p.usersSeen.Store(p.Users != nil && p.completed.Load())
+	p.warmed.Store(true)
+	return nil
+}
+
+var errTableMissing = errors.New("item table missing")
+
+type BrokenTable struct {
+	destroyed atomic.Bool
+}
+
+func (b *BrokenTable) WarmUp(ctx context.Context) error { return errTableMissing }
+
+func (b *BrokenTable) OnDestroy() { b.destroyed.Store(true) }
+
+type PanickingWarmUp struct{}
+
+func (*PanickingWarmUp) WarmUp(ctx context.Context) error { panic("table corrupted") }
+
+type SlowWarmUp struct{}
+
+func (*SlowWarmUp) WarmUp(ctx context.Context) error {
+	time.Sleep(time.Second)
+	return nil
+}
+
+func TestWarmUp_RunsConcurrentlyAfterInjection(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&UserServiceImpl{ID: 1})
+	var caches []*PriceCache
+	for _, name := range []string{"items", "prices", "shops"} {
+		cache := &PriceCache{delay: 60 * time.Millisecond}
+		c.ProvideByName(name, cache)
+		caches = append(caches, cache)
+	}
+
+	begin := time.Now()
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	defer c.Close()
+	if elapsed := time.Since(begin); elapsed >= 170*time.Millisecond {
+		t.Errorf("预热应该并发执行, 启动耗时: %v", elapsed)
+	}
+	for _, cache := range caches {
+		if !cache.warmed.Load() {
+			t.Fatal("StartUp 返回前预热应该已完成")
+		}
+		if !cache.usersSeen.Load() {
+			t.Error("预热时依赖应该已注入且 OnInjectComplete 已执行")
+		}
+	}
+}
+
+func TestWarmUp_NonFatalByDefault(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.ProvideByName("table", &BrokenTable{})
+	c.ProvideByName("panicky", &PanickingWarmUp{})
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("默认策略下预热失败不应该中止启动, 错误: %v", err)
+	}
+	defer c.Close()
+
+	failures := c.StartupReport().WarmUpFailures
+	if len(failures) != 2 || failures[0].Bean != "table" || failures[1].Bean != "panicky" {
+		t.Fatalf("启动报告应该按注册顺序记录预热失败, 实际: %+v", failures)
+	}
+	if !errors.Is(failures[0].Err, errTableMissing) {
+		t.Errorf("应该保留预热返回的错误, 实际: %v", failures[0].Err)
+	}
+}
+
+func TestWarmUp_FatalPolicyAbortsStartUp(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.SetWarmUpPolicy(ioc233.WarmUpFail)
+	table := &BrokenTable{}
+	c.ProvideByName("table", table)
+	c.Provide(&UserServiceImpl{ID: 1})
+
+	err := c.StartUp()
+	var warmUpErr *ioc233.WarmUpError
+	if !errors.As(err, &warmUpErr) || len(warmUpErr.Failures) != 1 {
+		t.Fatalf("WarmUpFail 策略下应该返回 *WarmUpError, 实际: %v", err)
+	}
+	if !errors.Is(err, errTableMissing) {
+		t.Errorf("错误应该包装预热失败原因, 实际: %v", err)
+	}
+	if c.State() != ioc233.StateFailed {
+		t.Errorf("预热失败后容器应该进入 StateFailed, 实际: %v", c.State())
+	}
+	if !table.destroyed.Load() {
+		t.Error("中止启动时已完成注入的 bean 应该触发 OnDestroy")
+	}
+}
+
+func TestWarmUp_BoundedByStartUpDeadline(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&SlowWarmUp{})
+
+	err := c.StartUpWithTimeout(30 * time.Millisecond)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("预热超过启动截止时间应该中止启动, 实际: %v", err)
+	}
+}
+
+func TestWarmUp_LateRegistration(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&UserServiceImpl{ID: 1})
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	defer c.Close()
+
+	cache := &PriceCache{}
+	if err := c.Provide(cache); err != nil {
+		t.Fatalf("启动后注册应该成功, 错误: %v", err)
+	}
+	if !cache.warmed.Load() || !cache.usersSeen.Load() {
+		t.Error("启动后注册的 bean 应该在注入完成后立即预热")
+	}
+}
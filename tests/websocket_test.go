@@ -0,0 +1,284 @@
+package tests
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== WebSocket 控制器测试 ====================
+
+type LobbyController struct {
+	Users  *UserServiceImpl `autowire:"true"`
+	joined chan struct{}
+	left   chan error
+}
+
+func (c *LobbyController) WebSocketPath() string { return "/ws/lobby" }
+
+func (c *LobbyController) ServeWebSocket(conn *ioc233.WebSocketConn) {
+	close(c.joined)
+	for {
+		kind, msg, err := conn.ReadMessage()
+		if err != nil {
+			c.left <- err
+			return
+		}
+		conn.WriteMessage(kind, append([]byte("echo: "), msg...))
+	}
+}
+
+type ChatController struct {
+	_ struct{} `route:"GET /ws/chat/:room" handler:"Chat"`
+}
+
+func (c *ChatController) Chat(conn *ioc233.WebSocketConn) {
+	conn.WriteMessage(ioc233.TextMessage, []byte("room "+conn.Request().PathValue("room")))
+}
+
+func (c *ChatController) CheckOrigin(r *http.Request) bool {
+	return r.Header.Get("Origin") != "https://evil.example"
+}
+
+// wsClient 测试用的最小 WebSocket 客户端（客户端帧带掩码）
+type wsClient struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+func dialWebSocket(t *testing.T, server *httptest.Server, path string, header http.Header) (*wsClient, *http.Response) {
+	t.Helper()
+	conn, err := net.Dial("tcp", strings.TrimPrefix(server.URL, "http://"))
+	if err != nil {
+		t.Fatalf("连接失败: %v", err)
+	}
+	req, _ := http.NewRequest(http.MethodGet, server.URL+path, nil)
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	req.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	if err := req.Write(conn); err != nil {
+		t.Fatalf("发送握手失败: %v", err)
+	}
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, req)
+	if err != nil {
+		t.Fatalf("读取握手响应失败: %v", err)
+	}
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+	return &wsClient{conn: conn, reader: reader}, resp
+}
+
+func (c *wsClient) send(op byte, payload []byte) {
+	mask := [4]byte{1, 2, 3, 4}
+	frame := []byte{0x80 | op, 0x80 | byte(len(payload))}
+	frame = append(frame, mask[:]...)
+	for i, b := range payload {
+		frame = append(frame, b^mask[i%4])
+	}
+	c.conn.Write(frame)
+}
+
+func (c *wsClient) read() (op byte, payload []byte, err error) {
+	var head [2]byte
+	if _, err := io.ReadFull(c.reader, head[:]); err != nil {
+		return 0, nil, err
+	}
+	size := int(head[1] & 0x7F)
+	if size == 126 {
+		var ext [2]byte
+		io.ReadFull(c.reader, ext[:])
+		size = int(binary.BigEndian.Uint16(ext[:]))
+	}
+	payload = make([]byte, size)
+	_, err = io.ReadFull(c.reader, payload)
+	return head[0] & 0x0F, payload, err
+}
+
+func newWebSocketServer(t *testing.T, c *ioc233.Container) *httptest.Server {
+	t.Helper()
+	if err := c.StartUp(); err != nil {
+		t.Fatalf("启动应该成功, 错误: %v", err)
+	}
+	mux := http.NewServeMux()
+	if err := ioc233.MountServeMux(mux, c); err != nil {
+		t.Fatalf("挂载应该成功, 错误: %v", err)
+	}
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestWebSocketController_Echo(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&UserServiceImpl{ID: 1})
+	lobby := &LobbyController{joined: make(chan struct{}), left: make(chan error, 1)}
+	if err := c.ProvideController(lobby); err != nil {
+		t.Fatalf("注册 WebSocket 控制器应该成功, 错误: %v", err)
+	}
+	if routes := c.GetControllers()[0].Routes; len(routes) != 1 || routes[0].String() != "GET /ws/lobby -> ServeWebSocket" {
+		t.Errorf("IWebSocketController 应该登记 WebSocket 路由, 实际: %v", routes)
+	}
+	server := newWebSocketServer(t, c)
+	defer c.Close()
+	if lobby.Users == nil {
+		t.Error("WebSocket 控制器应该完成注入")
+	}
+
+	client, resp := dialWebSocket(t, server, "/ws/lobby", nil)
+	defer client.conn.Close()
+	if resp.StatusCode != http.StatusSwitchingProtocols || resp.Header.Get("Sec-WebSocket-Accept") != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+		t.Fatalf("握手应该成功, 实际: %d %v", resp.StatusCode, resp.Header)
+	}
+	client.send(0x1, []byte("hi"))
+	if op, payload, err := client.read(); err != nil || op != 0x1 || string(payload) != "echo: hi" {
+		t.Errorf("应该收到回显消息, 实际: %d %q %v", op, payload, err)
+	}
+	client.send(0x9, []byte("ping"))
+	if op, payload, _ := client.read(); op != 0xA || string(payload) != "ping" {
+		t.Errorf("ping 应该自动应答 pong, 实际: %d %q", op, payload)
+	}
+	client.send(0x8, binary.BigEndian.AppendUint16(nil, 1000))
+	if err := <-lobby.left; !errors.Is(err, io.EOF) {
+		t.Errorf("对端关闭时 ReadMessage 应该返回 io.EOF, 实际: %v", err)
+	}
+}
+
+func TestWebSocketController_RouteHandlerAndOrigin(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.ProvideController(&ChatController{})
+	server := newWebSocketServer(t, c)
+	defer c.Close()
+
+	client, resp := dialWebSocket(t, server, "/ws/chat/42", nil)
+	defer client.conn.Close()
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("握手应该成功, 实际: %d", resp.StatusCode)
+	}
+	if _, payload, _ := client.read(); string(payload) != "room 42" {
+		t.Errorf("route 标签声明的 WebSocket 端点应该可以读取路径参数, 实际: %q", payload)
+	}
+	if op, _, _ := client.read(); op != 0x8 {
+		t.Errorf("处理方法返回后应该关闭连接, 实际 opcode: %d", op)
+	}
+
+	rejected, resp := dialWebSocket(t, server, "/ws/chat/42", http.Header{"Origin": {"https://evil.example"}})
+	defer rejected.conn.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("IWebSocketOrigin 拒绝的来源应该返回 403, 实际: %d", resp.StatusCode)
+	}
+	if resp, err := http.Get(server.URL + "/ws/chat/42"); err != nil || resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("非 WebSocket 请求应该返回 400, 实际: %v %v", resp, err)
+	}
+}
+
+func TestWebSocketController_ClosedWithContainer(t *testing.T) {
+	c := ioc233.NewContainer()
+	c.Provide(&UserServiceImpl{ID: 1})
+	lobby := &LobbyController{joined: make(chan struct{}), left: make(chan error, 1)}
+	c.ProvideController(lobby)
+	server := newWebSocketServer(t, c)
+
+	client, _ := dialWebSocket(t, server, "/ws/lobby", nil)
+	defer client.conn.Close()
+	<-lobby.joined
+	c.Close()
+
+	op, payload, _ := client.read()
+	if op != 0x8 || len(payload) < 2 || binary.BigEndian.Uint16(payload) != 1001 {
+		t.Errorf("容器关闭时应该以 1001 关闭连接, 实际: %d %v", op, payload)
+	}
+	if err := <-lobby.left; !errors.Is(err, ioc233.ErrWebSocketClosed) {
+		t.Errorf("本端关闭后 ReadMessage 应该返回 ErrWebSocketClosed, 实际: %v", err)
+	}
+
+	late, resp := dialWebSocket(t, server, "/ws/lobby", nil)
+	defer late.conn.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("容器关闭后的升级请求应该返回 503, 实际: %d", resp.StatusCode)
+	}
+}
+
+// FloodController 持续向不读取的对端写入，直到写入失败
+type FloodController struct {
+	_            struct{} `route:"GET /ws/flood" handler:"Flood"`
+	writeTimeout time.Duration
+	writing      chan struct{}
+	done         chan error
+}
+
+func (c *FloodController) Flood(conn *ioc233.WebSocketConn) {
+	conn.SetWriteTimeout(c.writeTimeout)
+	chunk := make([]byte, 1<<20)
+	close(c.writing)
+	for {
+		if err := conn.WriteMessage(ioc233.BinaryMessage, chunk); err != nil {
+			c.done <- err
+			return
+		}
+	}
+}
+
+func TestWebSocketController_CloseWithStalledPeer(t *testing.T) {
+	c := ioc233.NewContainer()
+	flood := &FloodController{writeTimeout: time.Minute, writing: make(chan struct{}), done: make(chan error, 1)}
+	c.ProvideController(flood)
+	server := newWebSocketServer(t, c)
+
+	// 对端从不读取：服务端写入最终阻塞在持有写锁的 WriteMessage 中
+	client, _ := dialWebSocket(t, server, "/ws/flood", nil)
+	defer client.conn.Close()
+	<-flood.writing
+	time.Sleep(200 * time.Millisecond)
+
+	closed := make(chan struct{})
+	go func() {
+		c.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("对端不读取时容器关闭不应该被阻塞的写入卡住")
+	}
+	select {
+	case err := <-flood.done:
+		if err == nil {
+			t.Error("关闭后阻塞的写入应该返回错误")
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("关闭后阻塞的写入应该返回")
+	}
+}
+
+func TestWebSocketController_WriteTimeout(t *testing.T) {
+	c := ioc233.NewContainer()
+	flood := &FloodController{writeTimeout: 100 * time.Millisecond, writing: make(chan struct{}), done: make(chan error, 1)}
+	c.ProvideController(flood)
+	server := newWebSocketServer(t, c)
+	defer c.Close()
+
+	client, _ := dialWebSocket(t, server, "/ws/flood", nil)
+	defer client.conn.Close()
+	select {
+	case err := <-flood.done:
+		var netErr net.Error
+		if !errors.As(err, &netErr) || !netErr.Timeout() {
+			t.Errorf("对端不读取时写入应该超时, 实际: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("对端不读取时写入应该在超时后返回")
+	}
+}
@@ -0,0 +1,90 @@
+package tests
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/neko233-com/ioc233-go/ioc233"
+)
+
+// ==================== Wire provider set 生成测试 ====================
+
+type WireConsumer struct {
+	Users  UserService  `autowire:"true"`
+	Mailer Mailer       `autowire:"false"`
+	DB     *InventoryDB `autowire:"InventoryDB"`
+}
+
+func newWireContainer(t *testing.T) *ioc233.Container {
+	c := ioc233.NewContainer()
+	for _, ctor := range []any{NewInventoryDB, NewInventoryRepo, NewInventoryService, func() *CacheClient { return &CacheClient{} }} {
+		if err := c.ProvideFactory(ctor); err != nil {
+			t.Fatalf("ProvideFactory 应该成功, 错误: %v", err)
+		}
+	}
+	c.Provide(&UserServiceImpl{})
+	c.Provide(&WireConsumer{})
+	if err := c.ProvideValue("bufferSize", 4096); err != nil {
+		t.Fatalf("ProvideValue 应该成功, 错误: %v", err)
+	}
+	return c
+}
+
+func TestGenerateWireSet_SamePackage(t *testing.T) {
+	c := newWireContainer(t)
+	var buf bytes.Buffer
+	pkgPath := reflect.TypeOf(WireConsumer{}).PkgPath()
+	if err := c.GenerateWireSet(&buf, pkgPath, "tests"); err != nil {
+		t.Fatalf("生成应该成功, 错误: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		`wire "github.com/google/wire"`,
+		"var ProviderSet = wire.NewSet(",
+		"NewInventoryDB,",
+		"NewInventoryRepo,",
+		"NewInventoryService,",
+		"wire.Struct(new(UserServiceImpl)),",
+		`wire.Struct(new(WireConsumer), "Users"),`,
+		"wire.Bind(new(UserService), new(*UserServiceImpl)),",
+		"匿名函数",
+		"bufferSize: 值 bean",
+		`WireConsumer.DB: 按名称注入 "InventoryDB"`,
+		"依赖字段注入 [Mailer]",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("生成代码缺少 %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "func1,") {
+		t.Errorf("匿名构造函数不应该被引用:\n%s", out)
+	}
+}
+
+func TestGenerateWireSet_OtherPackage(t *testing.T) {
+	c := newWireContainer(t)
+	var buf bytes.Buffer
+	if err := c.GenerateWireSet(&buf, "example.com/app/wiring", "wiring"); err != nil {
+		t.Fatalf("生成应该成功, 错误: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		`tests "github.com/neko233-com/ioc233-go/tests"`,
+		"tests.NewInventoryDB,",
+		"wire.Struct(new(tests.WireConsumer)",
+		"wire.Bind(new(tests.UserService), new(*tests.UserServiceImpl)),",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("生成代码缺少 %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestGenerateWireSet_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ioc233.NewContainer().GenerateWireSet(&buf, "example.com/app", "app"); err == nil {
+		t.Error("没有注册时应该返回错误")
+	}
+}